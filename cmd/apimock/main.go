@@ -0,0 +1,277 @@
+// Package main contains the main goroutine for the apimock command-line
+// application.  This application generates a mock implementation of the
+// ForemanClient interface of the foreman/api package, to be used by
+// programs testing their use of the Foreman API without a live Foreman.
+//
+// The application is run through go generate from the foreman/api package
+// directory:
+//
+//	go generate ./foreman/api
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/printer"
+	"go/token"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	// Name of the interface the mock is generated for
+	interfaceName = "ForemanClient"
+	// Name the mocked package is imported as in the generated file
+	packageAlias = "api"
+)
+
+func main() {
+	output := flag.String("o", "apimock/client.go", "file the mock is written to")
+	flag.Parse()
+
+	if err := run(".", *output); err != nil {
+		fmt.Fprintf(os.Stderr, "apimock: %s\n", err)
+		os.Exit(1)
+	}
+}
+
+// run generates the mock of the interface declared in the package in the
+// supplied directory and writes it to the supplied output file.
+func run(dir string, output string) error {
+	importPath, listErr := exec.Command("go", "list", "-f", "{{.ImportPath}}", dir).Output()
+	if listErr != nil {
+		return fmt.Errorf("could not resolve the import path of [%s]: %s", dir, listErr)
+	}
+
+	fset := token.NewFileSet()
+	pkgs, parseErr := parser.ParseDir(fset, dir, func(fi os.FileInfo) bool {
+		return !strings.HasSuffix(fi.Name(), "_test.go")
+	}, 0)
+	if parseErr != nil {
+		return parseErr
+	}
+
+	for _, pkg := range pkgs {
+		for _, file := range pkg.Files {
+			iface := findInterface(file, interfaceName)
+			if iface == nil {
+				continue
+			}
+			src, genErr := generate(file, iface, strings.TrimSpace(string(importPath)))
+			if genErr != nil {
+				return genErr
+			}
+			if mkdirErr := os.MkdirAll(filepath.Dir(output), 0755); mkdirErr != nil {
+				return mkdirErr
+			}
+			return os.WriteFile(output, src, 0644)
+		}
+	}
+	return fmt.Errorf("interface [%s] not found in [%s]", interfaceName, dir)
+}
+
+// findInterface returns the interface type declared with the supplied name in
+// the supplied file, nil if the file does not declare it.
+func findInterface(file *ast.File, name string) *ast.InterfaceType {
+	for _, decl := range file.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.TYPE {
+			continue
+		}
+		for _, spec := range gen.Specs {
+			ts := spec.(*ast.TypeSpec)
+			if ts.Name.Name != name {
+				continue
+			}
+			if iface, ok := ts.Type.(*ast.InterfaceType); ok {
+				return iface
+			}
+		}
+	}
+	return nil
+}
+
+// method describes a method of the mocked interface with its types qualified
+// for use outside of the mocked package
+type method struct {
+	Name     string
+	Params   []string
+	Types    []string
+	Results  string
+	Variadic bool
+}
+
+// signature returns the parameters of the method with their types
+func (m method) signature() string {
+	params := make([]string, len(m.Params))
+	for i := range m.Params {
+		params[i] = m.Params[i] + " " + m.Types[i]
+	}
+	return strings.Join(params, ", ")
+}
+
+// generate renders the source of the mock of the supplied interface.
+// Exported identifiers of the mocked package are qualified with the package
+// alias and the imports of the declaring file used by the methods are kept.
+func generate(file *ast.File, iface *ast.InterfaceType, importPath string) ([]byte, error) {
+	var methods []method
+	used := map[string]bool{}
+
+	for _, field := range iface.Methods.List {
+		fn, ok := field.Type.(*ast.FuncType)
+		if !ok || len(field.Names) == 0 {
+			return nil, fmt.Errorf("embedded interfaces are not supported")
+		}
+		m := method{Name: field.Names[0].Name}
+		if fn.Params != nil {
+			for _, param := range fn.Params.List {
+				if _, ok := param.Type.(*ast.Ellipsis); ok {
+					m.Variadic = true
+				}
+				typ := render(qualify(param.Type, used))
+				if len(param.Names) == 0 {
+					m.Params = append(m.Params, "p"+strconv.Itoa(len(m.Params)))
+					m.Types = append(m.Types, typ)
+				}
+				for _, name := range param.Names {
+					m.Params = append(m.Params, name.Name)
+					m.Types = append(m.Types, typ)
+				}
+			}
+		}
+		if fn.Results != nil {
+			results := &ast.FuncType{Params: &ast.FieldList{}, Results: fn.Results}
+			m.Results = strings.TrimPrefix(render(qualify(results, used)), "func()")
+		}
+		methods = append(methods, m)
+	}
+
+	// Keep the imports of the declaring file referenced by the methods
+	imports := []string{strconv.Quote("sync")}
+	for _, spec := range file.Imports {
+		path, _ := strconv.Unquote(spec.Path.Value)
+		name := path[strings.LastIndex(path, "/")+1:]
+		if spec.Name != nil {
+			name = spec.Name.Name
+		}
+		if used[name] {
+			imports = append(imports, spec.Path.Value)
+		}
+	}
+	sort.Strings(imports)
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "// Code generated by apimock. DO NOT EDIT.\n\n")
+	fmt.Fprintf(&buf, "// Package apimock provides a mock implementation of the %s.%s\n", packageAlias, interfaceName)
+	fmt.Fprintf(&buf, "// interface.\n")
+	fmt.Fprintf(&buf, "package apimock\n\nimport (\n")
+	for _, imp := range imports {
+		fmt.Fprintf(&buf, "\t%s\n", imp)
+	}
+	fmt.Fprintf(&buf, "\n\t%s %s\n)\n\n", packageAlias, strconv.Quote(importPath))
+
+	fmt.Fprintf(&buf, "// Call records a call to a method of the mock with its arguments\n")
+	fmt.Fprintf(&buf, "type Call struct {\n\tMethod string\n\tArgs []interface{}\n}\n\n")
+
+	fmt.Fprintf(&buf, "// Client is a mock implementation of %s.%s.  Each method calls the\n", packageAlias, interfaceName)
+	fmt.Fprintf(&buf, "// function of the same name suffixed with Func and panics if the function\n")
+	fmt.Fprintf(&buf, "// is not set.  Calls are recorded in the order they are made.\n")
+	fmt.Fprintf(&buf, "type Client struct {\n\tmu sync.Mutex\n\tcalls []Call\n\n")
+	for _, m := range methods {
+		fmt.Fprintf(&buf, "\t%sFunc func(%s)%s\n", m.Name, m.signature(), m.Results)
+	}
+	fmt.Fprintf(&buf, "}\n\n")
+
+	fmt.Fprintf(&buf, "// Client must implement %s.%s\n", packageAlias, interfaceName)
+	fmt.Fprintf(&buf, "var _ %s.%s = (*Client)(nil)\n\n", packageAlias, interfaceName)
+
+	fmt.Fprintf(&buf, "// Calls returns the calls made to the mock\n")
+	fmt.Fprintf(&buf, "func (mock *Client) Calls() []Call {\n\tmock.mu.Lock()\n\tdefer mock.mu.Unlock()\n")
+	fmt.Fprintf(&buf, "\treturn append([]Call(nil), mock.calls...)\n}\n\n")
+
+	fmt.Fprintf(&buf, "// record records a call to the method with the supplied name\n")
+	fmt.Fprintf(&buf, "func (mock *Client) record(method string, args ...interface{}) {\n\tmock.mu.Lock()\n\tdefer mock.mu.Unlock()\n")
+	fmt.Fprintf(&buf, "\tmock.calls = append(mock.calls, Call{Method: method, Args: args})\n}\n\n")
+
+	for _, m := range methods {
+		args := strings.Join(m.Params, ", ")
+		if m.Variadic {
+			args += "..."
+		}
+		fmt.Fprintf(&buf, "// %s calls %sFunc\n", m.Name, m.Name)
+		fmt.Fprintf(&buf, "func (mock *Client) %s(%s)%s {\n", m.Name, m.signature(), m.Results)
+		fmt.Fprintf(&buf, "\tmock.record(%s)\n", strings.Join(append([]string{strconv.Quote(m.Name)}, m.Params...), ", "))
+		fmt.Fprintf(&buf, "\tif mock.%sFunc == nil {\n\t\tpanic(\"apimock: %sFunc is not set\")\n\t}\n", m.Name, m.Name)
+		if m.Results == "" {
+			fmt.Fprintf(&buf, "\tmock.%sFunc(%s)\n}\n\n", m.Name, args)
+		} else {
+			fmt.Fprintf(&buf, "\treturn mock.%sFunc(%s)\n}\n\n", m.Name, args)
+		}
+	}
+
+	return format.Source(buf.Bytes())
+}
+
+// qualify returns the supplied type expression with the exported
+// identifiers of the mocked package qualified with the package alias.  The
+// names of the packages referenced by the expression are added to the
+// supplied set.
+func qualify(expr ast.Expr, used map[string]bool) ast.Expr {
+	switch e := expr.(type) {
+	case *ast.Ident:
+		if e.IsExported() {
+			return &ast.SelectorExpr{X: ast.NewIdent(packageAlias), Sel: e}
+		}
+		return e
+	case *ast.SelectorExpr:
+		if x, ok := e.X.(*ast.Ident); ok {
+			used[x.Name] = true
+		}
+		return e
+	case *ast.StarExpr:
+		return &ast.StarExpr{X: qualify(e.X, used)}
+	case *ast.ArrayType:
+		return &ast.ArrayType{Len: e.Len, Elt: qualify(e.Elt, used)}
+	case *ast.MapType:
+		return &ast.MapType{Key: qualify(e.Key, used), Value: qualify(e.Value, used)}
+	case *ast.Ellipsis:
+		return &ast.Ellipsis{Elt: qualify(e.Elt, used)}
+	case *ast.ChanType:
+		return &ast.ChanType{Dir: e.Dir, Value: qualify(e.Value, used)}
+	case *ast.FuncType:
+		return &ast.FuncType{Params: qualifyFields(e.Params, used), Results: qualifyFields(e.Results, used)}
+	}
+	return expr
+}
+
+// qualifyFields qualifies the types of the supplied parameters or results
+func qualifyFields(fields *ast.FieldList, used map[string]bool) *ast.FieldList {
+	if fields == nil {
+		return nil
+	}
+	qualified := &ast.FieldList{}
+	for _, field := range fields.List {
+		qualified.List = append(qualified.List, &ast.Field{
+			Names: field.Names,
+			Type:  qualify(field.Type, used),
+		})
+	}
+	return qualified
+}
+
+// render prints the supplied expression as Go source.  The expression is
+// printed without the positions of the parsed source, so that the qualified
+// identifiers do not break lines.
+func render(expr ast.Expr) string {
+	var buf bytes.Buffer
+	printer.Fprint(&buf, token.NewFileSet(), expr)
+	return buf.String()
+}
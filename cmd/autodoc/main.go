@@ -13,16 +13,15 @@ import (
 	"github.com/HanseMerkur/terraform-provider-foreman/foreman"
 	"github.com/wayfair/terraform-provider-utils/autodoc"
 
-	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	legacyschema "github.com/hashicorp/terraform/helper/schema"
 )
 
 func main() {
-	// Use the provider function to get information on the provider's schema,
-	// resources, and data sources. The Provider() function returns a
-	// *terraform.ResourceProvider (interface) which will need to be type asserted
-	// to a *schema.Provider (struct)
-	resourceProvider := foreman.Provider()
-	provider := resourceProvider.(*schema.Provider)
+	// The autodoc engine is built against the legacy Terraform helper/schema
+	// package.  Convert the provider's schema to the legacy types before
+	// starting the engine.
+	provider := legacyProvider(foreman.Provider())
 	// Start the autodoc engine
 	errors := autodoc.Document(provider)
 	if len(errors) != 0 {
@@ -33,3 +32,57 @@ func main() {
 	}
 	os.Exit(autodoc.ExitSuccess)
 }
+
+// legacyProvider converts the schema of the provider, its resources and data
+// sources to the legacy helper/schema types.  Only the properties read by the
+// autodoc engine are converted.
+func legacyProvider(p *schema.Provider) *legacyschema.Provider {
+	provider := &legacyschema.Provider{
+		Schema:         legacySchemaMap(p.Schema),
+		ResourcesMap:   make(map[string]*legacyschema.Resource, len(p.ResourcesMap)),
+		DataSourcesMap: make(map[string]*legacyschema.Resource, len(p.DataSourcesMap)),
+	}
+	for name, r := range p.ResourcesMap {
+		provider.ResourcesMap[name] = legacyResource(r)
+	}
+	for name, r := range p.DataSourcesMap {
+		provider.DataSourcesMap[name] = legacyResource(r)
+	}
+	return provider
+}
+
+// legacyResource converts the schema of a resource to the legacy
+// helper/schema types.
+func legacyResource(r *schema.Resource) *legacyschema.Resource {
+	return &legacyschema.Resource{
+		Schema: legacySchemaMap(r.Schema),
+	}
+}
+
+// legacySchemaMap converts a schema map to the legacy helper/schema types.
+// Nested resources and element schemas are converted recursively.
+func legacySchemaMap(m map[string]*schema.Schema) map[string]*legacyschema.Schema {
+	legacy := make(map[string]*legacyschema.Schema, len(m))
+	for key, s := range m {
+		ls := &legacyschema.Schema{
+			// NOTE(ALL): both packages declare the value types in the same
+			//   order
+			Type:          legacyschema.ValueType(s.Type),
+			Description:   s.Description,
+			Optional:      s.Optional,
+			Required:      s.Required,
+			Computed:      s.Computed,
+			ForceNew:      s.ForceNew,
+			Sensitive:     s.Sensitive,
+			ConflictsWith: s.ConflictsWith,
+		}
+		switch elem := s.Elem.(type) {
+		case *schema.Resource:
+			ls.Elem = legacyResource(elem)
+		case *schema.Schema:
+			ls.Elem = legacySchemaMap(map[string]*schema.Schema{"": elem})[""]
+		}
+		legacy[key] = ls
+	}
+	return legacy
+}
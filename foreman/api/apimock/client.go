@@ -0,0 +1,1456 @@
+// Code generated by apimock. DO NOT EDIT.
+
+// Package apimock provides a mock implementation of the api.ForemanClient
+// interface.
+package apimock
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	api "github.com/HanseMerkur/terraform-provider-foreman/foreman/api"
+)
+
+// Call records a call to a method of the mock with its arguments
+type Call struct {
+	Method string
+	Args   []interface{}
+}
+
+// Client is a mock implementation of api.ForemanClient.  Each method calls the
+// function of the same name suffixed with Func and panics if the function
+// is not set.  Calls are recorded in the order they are made.
+type Client struct {
+	mu    sync.Mutex
+	calls []Call
+
+	CreateArchitectureFunc                  func(a *api.ForemanArchitecture) (*api.ForemanArchitecture, error)
+	ReadArchitectureFunc                    func(id int) (*api.ForemanArchitecture, error)
+	UpdateArchitectureFunc                  func(a *api.ForemanArchitecture) (*api.ForemanArchitecture, error)
+	DeleteArchitectureFunc                  func(id int) error
+	QueryArchitectureFunc                   func(a *api.ForemanArchitecture) (api.QueryResponse, error)
+	SendBMCActionFunc                       func(hostId int, proxy *api.ForemanSmartProxy, bmc api.BMCInterface, action string, retry api.RetryConfig) error
+	SetBMCBootDeviceFunc                    func(hostId int, proxy *api.ForemanSmartProxy, bmc api.BMCInterface, device string, retry api.RetryConfig) error
+	ReadBMCBootDeviceFunc                   func(hostId int, proxy *api.ForemanSmartProxy, bmc api.BMCInterface) (string, error)
+	CreateCommonParameterFunc               func(d *api.ForemanCommonParameter) (*api.ForemanCommonParameter, error)
+	ReadCommonParameterFunc                 func(d *api.ForemanCommonParameter, id int) (*api.ForemanCommonParameter, error)
+	UpdateCommonParameterFunc               func(d *api.ForemanCommonParameter, id int) (*api.ForemanCommonParameter, error)
+	DeleteCommonParameterFunc               func(d *api.ForemanCommonParameter, id int) error
+	QueryCommonParameterFunc                func(d *api.ForemanCommonParameter) (api.QueryResponse, error)
+	ReadComputeProfileFunc                  func(id int) (*api.ForemanComputeProfile, error)
+	QueryComputeProfileFunc                 func(t *api.ForemanComputeProfile) (api.QueryResponse, error)
+	CreateComputeResourceFunc               func(d *api.ForemanComputeResource) (*api.ForemanComputeResource, error)
+	ReadComputeResourceFunc                 func(id int) (*api.ForemanComputeResource, error)
+	UpdateComputeResourceFunc               func(d *api.ForemanComputeResource) (*api.ForemanComputeResource, error)
+	DeleteComputeResourceFunc               func(id int) error
+	QueryComputeResourceFunc                func(d *api.ForemanComputeResource) (api.QueryResponse, error)
+	ListComputeResourceObjectsFunc          func(id int, kind string, clusterId string) ([]api.ForemanComputeResourceObject, error)
+	FindComputeResourceObjectFunc           func(id int, kind string, clusterId string, idOrName string) (*api.ForemanComputeResourceObject, error)
+	CreateDefaultTemplateFunc               func(d *api.ForemanDefaultTemplate) (*api.ForemanDefaultTemplate, error)
+	ReadDefaultTemplateFunc                 func(d *api.ForemanDefaultTemplate, id int) (*api.ForemanDefaultTemplate, error)
+	UpdateDefaultTemplateFunc               func(d *api.ForemanDefaultTemplate, id int) (*api.ForemanDefaultTemplate, error)
+	DeleteDefaultTemplateFunc               func(d *api.ForemanDefaultTemplate, id int) error
+	ListOperatingSystemDefaultTemplatesFunc func(osId int) ([]api.ForemanDefaultTemplate, error)
+	QueryDefaultTemplateFunc                func(d *api.ForemanDefaultTemplate) (api.QueryResponse, error)
+	CreateDomainFunc                        func(d *api.ForemanDomain) (*api.ForemanDomain, error)
+	ReadDomainFunc                          func(id int) (*api.ForemanDomain, error)
+	UpdateDomainFunc                        func(d *api.ForemanDomain) (*api.ForemanDomain, error)
+	DeleteDomainFunc                        func(id int) error
+	QueryDomainFunc                         func(d *api.ForemanDomain) (api.QueryResponse, error)
+	CreateEnvironmentFunc                   func(e *api.ForemanEnvironment) (*api.ForemanEnvironment, error)
+	ReadEnvironmentFunc                     func(id int) (*api.ForemanEnvironment, error)
+	UpdateEnvironmentFunc                   func(e *api.ForemanEnvironment) (*api.ForemanEnvironment, error)
+	DeleteEnvironmentFunc                   func(id int) error
+	QueryEnvironmentFunc                    func(e *api.ForemanEnvironment) (api.QueryResponse, error)
+	SendPowerCommandFunc                    func(h *api.ForemanHost, cmd interface{}, retry api.RetryConfig, verify api.PowerVerifyConfig) error
+	ProvisionBootFunc                       func(h *api.ForemanHost, retry api.RetryConfig, verify api.PowerVerifyConfig) error
+	ShutdownHostFunc                        func(h *api.ForemanHost, gracefulAction string, grace time.Duration, retry api.RetryConfig, verify api.PowerVerifyConfig) error
+	ReadPowerStateFunc                      func(id int) (string, error)
+	RenderHostTemplateFunc                  func(id int, kind string) (string, error)
+	RenderUnattendedTemplateFunc            func(token string, kind string) (string, error)
+	CreateHostFunc                          func(h *api.ForemanHost, retry api.RetryConfig) (*api.ForemanHost, error)
+	ReadHostFunc                            func(id int) (*api.ForemanHost, error)
+	UpdateHostFunc                          func(h *api.ForemanHost, retry api.RetryConfig) (*api.ForemanHost, error)
+	CancelHostBuildFunc                     func(id int) error
+	UnmanageHostFunc                        func(id int) error
+	DeleteHostFunc                          func(id int) error
+	SearchHostsFunc                         func(search string) (api.QueryResponse, error)
+	SendBulkPowerCommandFunc                func(ids []int, action string, retry api.RetryConfig) error
+	ReadKatelloHostCollectionFunc           func(id int) (*api.ForemanKatelloHostCollection, error)
+	AddKatelloHostCollectionHostsFunc       func(id int, hostIds []int) error
+	RemoveKatelloHostCollectionHostsFunc    func(id int, hostIds []int) error
+	ReadHostFactsFunc                       func(id int) (map[string]string, error)
+	ReadHostENCFunc                         func(id int) (string, error)
+	ReadHostLastConfigReportFunc            func(id int) (*api.ForemanConfigReport, error)
+	ReadHostStatusFunc                      func(id int, kind string) (*api.ForemanHostStatus, error)
+	ReadHostRepresentationFunc              func(id int) (map[string]interface{}, error)
+	CreateHostgroupFunc                     func(h *api.ForemanHostgroup) (*api.ForemanHostgroup, error)
+	ReadHostgroupFunc                       func(id int) (*api.ForemanHostgroup, error)
+	UpdateHostgroupFunc                     func(h *api.ForemanHostgroup) (*api.ForemanHostgroup, error)
+	DeleteHostgroupFunc                     func(id int) error
+	QueryHostgroupFunc                      func(h *api.ForemanHostgroup) (api.QueryResponse, error)
+	CreateImageFunc                         func(computeResourceId int, d *api.ForemanImage) (*api.ForemanImage, error)
+	ReadImageFunc                           func(computeResourceId int, id int) (*api.ForemanImage, error)
+	UpdateImageFunc                         func(computeResourceId int, d *api.ForemanImage) (*api.ForemanImage, error)
+	DeleteImageFunc                         func(computeResourceId int, id int) error
+	QueryImageFunc                          func(d *api.ForemanImage) (api.QueryResponse, error)
+	EnsureImageUserDataTemplateFunc         func(image *api.ForemanImage, templateId int) error
+	QueryHostInterfacesFunc                 func(hostId int) (api.QueryResponse, error)
+	CreateHostInterfaceFunc                 func(hostId int, i *api.ForemanInterfacesAttribute) (*api.ForemanInterfacesAttribute, error)
+	ReadHostInterfaceFunc                   func(hostId int, id int) (*api.ForemanInterfacesAttribute, error)
+	UpdateHostInterfaceFunc                 func(hostId int, i *api.ForemanInterfacesAttribute) (*api.ForemanInterfacesAttribute, error)
+	DeleteHostInterfaceFunc                 func(hostId int, id int) error
+	ReadLocationFunc                        func(id int) (*api.ForemanLocation, error)
+	QueryLocationFunc                       func(l *api.ForemanLocation) (api.QueryResponse, error)
+	SearchLocationsFunc                     func(search string) (api.QueryResponse, error)
+	CreateMediaFunc                         func(m *api.ForemanMedia) (*api.ForemanMedia, error)
+	ReadMediaFunc                           func(id int) (*api.ForemanMedia, error)
+	UpdateMediaFunc                         func(m *api.ForemanMedia) (*api.ForemanMedia, error)
+	DeleteMediaFunc                         func(id int) error
+	QueryMediaFunc                          func(m *api.ForemanMedia) (api.QueryResponse, error)
+	CreateModelFunc                         func(m *api.ForemanModel) (*api.ForemanModel, error)
+	ReadModelFunc                           func(id int) (*api.ForemanModel, error)
+	UpdateModelFunc                         func(m *api.ForemanModel) (*api.ForemanModel, error)
+	DeleteModelFunc                         func(id int) error
+	QueryModelFunc                          func(m *api.ForemanModel) (api.QueryResponse, error)
+	CreateOperatingSystemFunc               func(o *api.ForemanOperatingSystem) (*api.ForemanOperatingSystem, error)
+	ReadOperatingSystemFunc                 func(id int) (*api.ForemanOperatingSystem, error)
+	UpdateOperatingSystemFunc               func(o *api.ForemanOperatingSystem) (*api.ForemanOperatingSystem, error)
+	DeleteOperatingSystemFunc               func(id int) error
+	QueryOperatingSystemFunc                func(o *api.ForemanOperatingSystem) (api.QueryResponse, error)
+	EnsureOperatingSystemAssociationsFunc   func(requested *api.ForemanOperatingSystem, actual *api.ForemanOperatingSystem) (*api.ForemanOperatingSystem, error)
+	ReadOrchestrationTasksFunc              func(id string) ([]api.ForemanOrchestrationTask, error)
+	CreateOverrideValueFunc                 func(o *api.ForemanOverrideValue) (*api.ForemanOverrideValue, error)
+	ReadOverrideValueFunc                   func(smartClassParameterId int, id int) (*api.ForemanOverrideValue, error)
+	UpdateOverrideValueFunc                 func(o *api.ForemanOverrideValue) (*api.ForemanOverrideValue, error)
+	DeleteOverrideValueFunc                 func(smartClassParameterId int, id int) error
+	CreateParameterFunc                     func(d *api.ForemanParameter) (*api.ForemanParameter, error)
+	ReadParameterFunc                       func(d *api.ForemanParameter, id int) (*api.ForemanParameter, error)
+	UpdateParameterFunc                     func(d *api.ForemanParameter, id int) (*api.ForemanParameter, error)
+	DeleteParameterFunc                     func(d *api.ForemanParameter, id int) error
+	QueryParameterFunc                      func(d *api.ForemanParameter) (api.QueryResponse, error)
+	CreatePartitionTableFunc                func(t *api.ForemanPartitionTable) (*api.ForemanPartitionTable, error)
+	ReadPartitionTableFunc                  func(id int) (*api.ForemanPartitionTable, error)
+	UpdatePartitionTableFunc                func(t *api.ForemanPartitionTable) (*api.ForemanPartitionTable, error)
+	DeletePartitionTableFunc                func(id int) error
+	QueryPartitionTableFunc                 func(t *api.ForemanPartitionTable) (api.QueryResponse, error)
+	CreateProvisioningTemplateFunc          func(t *api.ForemanProvisioningTemplate) (*api.ForemanProvisioningTemplate, error)
+	ReadProvisioningTemplateFunc            func(id int) (*api.ForemanProvisioningTemplate, error)
+	UpdateProvisioningTemplateFunc          func(t *api.ForemanProvisioningTemplate) (*api.ForemanProvisioningTemplate, error)
+	DeleteProvisioningTemplateFunc          func(id int) error
+	QueryProvisioningTemplateFunc           func(t *api.ForemanProvisioningTemplate) (api.QueryResponse, error)
+	PreviewProvisioningTemplateFunc         func(id int, hostId int, template string) (string, error)
+	QueryFunc                               func(endpoint string, search string) (api.QueryResponse, error)
+	ForEachResultFunc                       func(endpoint string, search string, fn func(result json.RawMessage) error) error
+	CreateSmartProxyFunc                    func(s *api.ForemanSmartProxy) (*api.ForemanSmartProxy, error)
+	ReadSmartProxyFunc                      func(id int) (*api.ForemanSmartProxy, error)
+	UpdateSmartProxyFunc                    func(s *api.ForemanSmartProxy) (*api.ForemanSmartProxy, error)
+	RefreshSmartProxyFunc                   func(id int) (*api.ForemanSmartProxy, error)
+	DeleteSmartProxyFunc                    func(id int) error
+	QuerySmartProxyFunc                     func(s *api.ForemanSmartProxy) (api.QueryResponse, error)
+	CreateSubnetFunc                        func(s *api.ForemanSubnet) (*api.ForemanSubnet, error)
+	ReadSubnetFunc                          func(id int) (*api.ForemanSubnet, error)
+	UpdateSubnetFunc                        func(s *api.ForemanSubnet) (*api.ForemanSubnet, error)
+	DeleteSubnetFunc                        func(id int) error
+	QuerySubnetFunc                         func(s *api.ForemanSubnet) (api.QueryResponse, error)
+	CreateTemplateCombinationFunc           func(t *api.ForemanTemplateCombination) (*api.ForemanTemplateCombination, error)
+	ReadTemplateCombinationFunc             func(id int) (*api.ForemanTemplateCombination, error)
+	DeleteTemplateCombinationFunc           func(id int) error
+	ReadTemplateKindFunc                    func(id int) (*api.ForemanTemplateKind, error)
+	QueryTemplateKindFunc                   func(t *api.ForemanTemplateKind) (api.QueryResponse, error)
+	ReadTaskFunc                            func(id string) (*api.ForemanTask, error)
+	WaitForTaskFunc                         func(ctx context.Context, id string, wait api.TaskWaitConfig, progress func(*api.ForemanTask)) (*api.ForemanTask, error)
+	ReadUsergroupFunc                       func(id int) (*api.ForemanUsergroup, error)
+	AddUsergroupRoleFunc                    func(id int, roleId int) error
+	RemoveUsergroupRoleFunc                 func(id int, roleId int) error
+	RefreshExternalUsergroupFunc            func(id int, externalId int) error
+	QueryUsergroupFunc                      func(u *api.ForemanUsergroup) (api.QueryResponse, error)
+}
+
+// Client must implement api.ForemanClient
+var _ api.ForemanClient = (*Client)(nil)
+
+// Calls returns the calls made to the mock
+func (mock *Client) Calls() []Call {
+	mock.mu.Lock()
+	defer mock.mu.Unlock()
+	return append([]Call(nil), mock.calls...)
+}
+
+// record records a call to the method with the supplied name
+func (mock *Client) record(method string, args ...interface{}) {
+	mock.mu.Lock()
+	defer mock.mu.Unlock()
+	mock.calls = append(mock.calls, Call{Method: method, Args: args})
+}
+
+// CreateArchitecture calls CreateArchitectureFunc
+func (mock *Client) CreateArchitecture(a *api.ForemanArchitecture) (*api.ForemanArchitecture, error) {
+	mock.record("CreateArchitecture", a)
+	if mock.CreateArchitectureFunc == nil {
+		panic("apimock: CreateArchitectureFunc is not set")
+	}
+	return mock.CreateArchitectureFunc(a)
+}
+
+// ReadArchitecture calls ReadArchitectureFunc
+func (mock *Client) ReadArchitecture(id int) (*api.ForemanArchitecture, error) {
+	mock.record("ReadArchitecture", id)
+	if mock.ReadArchitectureFunc == nil {
+		panic("apimock: ReadArchitectureFunc is not set")
+	}
+	return mock.ReadArchitectureFunc(id)
+}
+
+// UpdateArchitecture calls UpdateArchitectureFunc
+func (mock *Client) UpdateArchitecture(a *api.ForemanArchitecture) (*api.ForemanArchitecture, error) {
+	mock.record("UpdateArchitecture", a)
+	if mock.UpdateArchitectureFunc == nil {
+		panic("apimock: UpdateArchitectureFunc is not set")
+	}
+	return mock.UpdateArchitectureFunc(a)
+}
+
+// DeleteArchitecture calls DeleteArchitectureFunc
+func (mock *Client) DeleteArchitecture(id int) error {
+	mock.record("DeleteArchitecture", id)
+	if mock.DeleteArchitectureFunc == nil {
+		panic("apimock: DeleteArchitectureFunc is not set")
+	}
+	return mock.DeleteArchitectureFunc(id)
+}
+
+// QueryArchitecture calls QueryArchitectureFunc
+func (mock *Client) QueryArchitecture(a *api.ForemanArchitecture) (api.QueryResponse, error) {
+	mock.record("QueryArchitecture", a)
+	if mock.QueryArchitectureFunc == nil {
+		panic("apimock: QueryArchitectureFunc is not set")
+	}
+	return mock.QueryArchitectureFunc(a)
+}
+
+// SendBMCAction calls SendBMCActionFunc
+func (mock *Client) SendBMCAction(hostId int, proxy *api.ForemanSmartProxy, bmc api.BMCInterface, action string, retry api.RetryConfig) error {
+	mock.record("SendBMCAction", hostId, proxy, bmc, action, retry)
+	if mock.SendBMCActionFunc == nil {
+		panic("apimock: SendBMCActionFunc is not set")
+	}
+	return mock.SendBMCActionFunc(hostId, proxy, bmc, action, retry)
+}
+
+// SetBMCBootDevice calls SetBMCBootDeviceFunc
+func (mock *Client) SetBMCBootDevice(hostId int, proxy *api.ForemanSmartProxy, bmc api.BMCInterface, device string, retry api.RetryConfig) error {
+	mock.record("SetBMCBootDevice", hostId, proxy, bmc, device, retry)
+	if mock.SetBMCBootDeviceFunc == nil {
+		panic("apimock: SetBMCBootDeviceFunc is not set")
+	}
+	return mock.SetBMCBootDeviceFunc(hostId, proxy, bmc, device, retry)
+}
+
+// ReadBMCBootDevice calls ReadBMCBootDeviceFunc
+func (mock *Client) ReadBMCBootDevice(hostId int, proxy *api.ForemanSmartProxy, bmc api.BMCInterface) (string, error) {
+	mock.record("ReadBMCBootDevice", hostId, proxy, bmc)
+	if mock.ReadBMCBootDeviceFunc == nil {
+		panic("apimock: ReadBMCBootDeviceFunc is not set")
+	}
+	return mock.ReadBMCBootDeviceFunc(hostId, proxy, bmc)
+}
+
+// CreateCommonParameter calls CreateCommonParameterFunc
+func (mock *Client) CreateCommonParameter(d *api.ForemanCommonParameter) (*api.ForemanCommonParameter, error) {
+	mock.record("CreateCommonParameter", d)
+	if mock.CreateCommonParameterFunc == nil {
+		panic("apimock: CreateCommonParameterFunc is not set")
+	}
+	return mock.CreateCommonParameterFunc(d)
+}
+
+// ReadCommonParameter calls ReadCommonParameterFunc
+func (mock *Client) ReadCommonParameter(d *api.ForemanCommonParameter, id int) (*api.ForemanCommonParameter, error) {
+	mock.record("ReadCommonParameter", d, id)
+	if mock.ReadCommonParameterFunc == nil {
+		panic("apimock: ReadCommonParameterFunc is not set")
+	}
+	return mock.ReadCommonParameterFunc(d, id)
+}
+
+// UpdateCommonParameter calls UpdateCommonParameterFunc
+func (mock *Client) UpdateCommonParameter(d *api.ForemanCommonParameter, id int) (*api.ForemanCommonParameter, error) {
+	mock.record("UpdateCommonParameter", d, id)
+	if mock.UpdateCommonParameterFunc == nil {
+		panic("apimock: UpdateCommonParameterFunc is not set")
+	}
+	return mock.UpdateCommonParameterFunc(d, id)
+}
+
+// DeleteCommonParameter calls DeleteCommonParameterFunc
+func (mock *Client) DeleteCommonParameter(d *api.ForemanCommonParameter, id int) error {
+	mock.record("DeleteCommonParameter", d, id)
+	if mock.DeleteCommonParameterFunc == nil {
+		panic("apimock: DeleteCommonParameterFunc is not set")
+	}
+	return mock.DeleteCommonParameterFunc(d, id)
+}
+
+// QueryCommonParameter calls QueryCommonParameterFunc
+func (mock *Client) QueryCommonParameter(d *api.ForemanCommonParameter) (api.QueryResponse, error) {
+	mock.record("QueryCommonParameter", d)
+	if mock.QueryCommonParameterFunc == nil {
+		panic("apimock: QueryCommonParameterFunc is not set")
+	}
+	return mock.QueryCommonParameterFunc(d)
+}
+
+// ReadComputeProfile calls ReadComputeProfileFunc
+func (mock *Client) ReadComputeProfile(id int) (*api.ForemanComputeProfile, error) {
+	mock.record("ReadComputeProfile", id)
+	if mock.ReadComputeProfileFunc == nil {
+		panic("apimock: ReadComputeProfileFunc is not set")
+	}
+	return mock.ReadComputeProfileFunc(id)
+}
+
+// QueryComputeProfile calls QueryComputeProfileFunc
+func (mock *Client) QueryComputeProfile(t *api.ForemanComputeProfile) (api.QueryResponse, error) {
+	mock.record("QueryComputeProfile", t)
+	if mock.QueryComputeProfileFunc == nil {
+		panic("apimock: QueryComputeProfileFunc is not set")
+	}
+	return mock.QueryComputeProfileFunc(t)
+}
+
+// CreateComputeResource calls CreateComputeResourceFunc
+func (mock *Client) CreateComputeResource(d *api.ForemanComputeResource) (*api.ForemanComputeResource, error) {
+	mock.record("CreateComputeResource", d)
+	if mock.CreateComputeResourceFunc == nil {
+		panic("apimock: CreateComputeResourceFunc is not set")
+	}
+	return mock.CreateComputeResourceFunc(d)
+}
+
+// ReadComputeResource calls ReadComputeResourceFunc
+func (mock *Client) ReadComputeResource(id int) (*api.ForemanComputeResource, error) {
+	mock.record("ReadComputeResource", id)
+	if mock.ReadComputeResourceFunc == nil {
+		panic("apimock: ReadComputeResourceFunc is not set")
+	}
+	return mock.ReadComputeResourceFunc(id)
+}
+
+// UpdateComputeResource calls UpdateComputeResourceFunc
+func (mock *Client) UpdateComputeResource(d *api.ForemanComputeResource) (*api.ForemanComputeResource, error) {
+	mock.record("UpdateComputeResource", d)
+	if mock.UpdateComputeResourceFunc == nil {
+		panic("apimock: UpdateComputeResourceFunc is not set")
+	}
+	return mock.UpdateComputeResourceFunc(d)
+}
+
+// DeleteComputeResource calls DeleteComputeResourceFunc
+func (mock *Client) DeleteComputeResource(id int) error {
+	mock.record("DeleteComputeResource", id)
+	if mock.DeleteComputeResourceFunc == nil {
+		panic("apimock: DeleteComputeResourceFunc is not set")
+	}
+	return mock.DeleteComputeResourceFunc(id)
+}
+
+// QueryComputeResource calls QueryComputeResourceFunc
+func (mock *Client) QueryComputeResource(d *api.ForemanComputeResource) (api.QueryResponse, error) {
+	mock.record("QueryComputeResource", d)
+	if mock.QueryComputeResourceFunc == nil {
+		panic("apimock: QueryComputeResourceFunc is not set")
+	}
+	return mock.QueryComputeResourceFunc(d)
+}
+
+// ListComputeResourceObjects calls ListComputeResourceObjectsFunc
+func (mock *Client) ListComputeResourceObjects(id int, kind string, clusterId string) ([]api.ForemanComputeResourceObject, error) {
+	mock.record("ListComputeResourceObjects", id, kind, clusterId)
+	if mock.ListComputeResourceObjectsFunc == nil {
+		panic("apimock: ListComputeResourceObjectsFunc is not set")
+	}
+	return mock.ListComputeResourceObjectsFunc(id, kind, clusterId)
+}
+
+// FindComputeResourceObject calls FindComputeResourceObjectFunc
+func (mock *Client) FindComputeResourceObject(id int, kind string, clusterId string, idOrName string) (*api.ForemanComputeResourceObject, error) {
+	mock.record("FindComputeResourceObject", id, kind, clusterId, idOrName)
+	if mock.FindComputeResourceObjectFunc == nil {
+		panic("apimock: FindComputeResourceObjectFunc is not set")
+	}
+	return mock.FindComputeResourceObjectFunc(id, kind, clusterId, idOrName)
+}
+
+// CreateDefaultTemplate calls CreateDefaultTemplateFunc
+func (mock *Client) CreateDefaultTemplate(d *api.ForemanDefaultTemplate) (*api.ForemanDefaultTemplate, error) {
+	mock.record("CreateDefaultTemplate", d)
+	if mock.CreateDefaultTemplateFunc == nil {
+		panic("apimock: CreateDefaultTemplateFunc is not set")
+	}
+	return mock.CreateDefaultTemplateFunc(d)
+}
+
+// ReadDefaultTemplate calls ReadDefaultTemplateFunc
+func (mock *Client) ReadDefaultTemplate(d *api.ForemanDefaultTemplate, id int) (*api.ForemanDefaultTemplate, error) {
+	mock.record("ReadDefaultTemplate", d, id)
+	if mock.ReadDefaultTemplateFunc == nil {
+		panic("apimock: ReadDefaultTemplateFunc is not set")
+	}
+	return mock.ReadDefaultTemplateFunc(d, id)
+}
+
+// UpdateDefaultTemplate calls UpdateDefaultTemplateFunc
+func (mock *Client) UpdateDefaultTemplate(d *api.ForemanDefaultTemplate, id int) (*api.ForemanDefaultTemplate, error) {
+	mock.record("UpdateDefaultTemplate", d, id)
+	if mock.UpdateDefaultTemplateFunc == nil {
+		panic("apimock: UpdateDefaultTemplateFunc is not set")
+	}
+	return mock.UpdateDefaultTemplateFunc(d, id)
+}
+
+// DeleteDefaultTemplate calls DeleteDefaultTemplateFunc
+func (mock *Client) DeleteDefaultTemplate(d *api.ForemanDefaultTemplate, id int) error {
+	mock.record("DeleteDefaultTemplate", d, id)
+	if mock.DeleteDefaultTemplateFunc == nil {
+		panic("apimock: DeleteDefaultTemplateFunc is not set")
+	}
+	return mock.DeleteDefaultTemplateFunc(d, id)
+}
+
+// ListOperatingSystemDefaultTemplates calls ListOperatingSystemDefaultTemplatesFunc
+func (mock *Client) ListOperatingSystemDefaultTemplates(osId int) ([]api.ForemanDefaultTemplate, error) {
+	mock.record("ListOperatingSystemDefaultTemplates", osId)
+	if mock.ListOperatingSystemDefaultTemplatesFunc == nil {
+		panic("apimock: ListOperatingSystemDefaultTemplatesFunc is not set")
+	}
+	return mock.ListOperatingSystemDefaultTemplatesFunc(osId)
+}
+
+// QueryDefaultTemplate calls QueryDefaultTemplateFunc
+func (mock *Client) QueryDefaultTemplate(d *api.ForemanDefaultTemplate) (api.QueryResponse, error) {
+	mock.record("QueryDefaultTemplate", d)
+	if mock.QueryDefaultTemplateFunc == nil {
+		panic("apimock: QueryDefaultTemplateFunc is not set")
+	}
+	return mock.QueryDefaultTemplateFunc(d)
+}
+
+// CreateDomain calls CreateDomainFunc
+func (mock *Client) CreateDomain(d *api.ForemanDomain) (*api.ForemanDomain, error) {
+	mock.record("CreateDomain", d)
+	if mock.CreateDomainFunc == nil {
+		panic("apimock: CreateDomainFunc is not set")
+	}
+	return mock.CreateDomainFunc(d)
+}
+
+// ReadDomain calls ReadDomainFunc
+func (mock *Client) ReadDomain(id int) (*api.ForemanDomain, error) {
+	mock.record("ReadDomain", id)
+	if mock.ReadDomainFunc == nil {
+		panic("apimock: ReadDomainFunc is not set")
+	}
+	return mock.ReadDomainFunc(id)
+}
+
+// UpdateDomain calls UpdateDomainFunc
+func (mock *Client) UpdateDomain(d *api.ForemanDomain) (*api.ForemanDomain, error) {
+	mock.record("UpdateDomain", d)
+	if mock.UpdateDomainFunc == nil {
+		panic("apimock: UpdateDomainFunc is not set")
+	}
+	return mock.UpdateDomainFunc(d)
+}
+
+// DeleteDomain calls DeleteDomainFunc
+func (mock *Client) DeleteDomain(id int) error {
+	mock.record("DeleteDomain", id)
+	if mock.DeleteDomainFunc == nil {
+		panic("apimock: DeleteDomainFunc is not set")
+	}
+	return mock.DeleteDomainFunc(id)
+}
+
+// QueryDomain calls QueryDomainFunc
+func (mock *Client) QueryDomain(d *api.ForemanDomain) (api.QueryResponse, error) {
+	mock.record("QueryDomain", d)
+	if mock.QueryDomainFunc == nil {
+		panic("apimock: QueryDomainFunc is not set")
+	}
+	return mock.QueryDomainFunc(d)
+}
+
+// CreateEnvironment calls CreateEnvironmentFunc
+func (mock *Client) CreateEnvironment(e *api.ForemanEnvironment) (*api.ForemanEnvironment, error) {
+	mock.record("CreateEnvironment", e)
+	if mock.CreateEnvironmentFunc == nil {
+		panic("apimock: CreateEnvironmentFunc is not set")
+	}
+	return mock.CreateEnvironmentFunc(e)
+}
+
+// ReadEnvironment calls ReadEnvironmentFunc
+func (mock *Client) ReadEnvironment(id int) (*api.ForemanEnvironment, error) {
+	mock.record("ReadEnvironment", id)
+	if mock.ReadEnvironmentFunc == nil {
+		panic("apimock: ReadEnvironmentFunc is not set")
+	}
+	return mock.ReadEnvironmentFunc(id)
+}
+
+// UpdateEnvironment calls UpdateEnvironmentFunc
+func (mock *Client) UpdateEnvironment(e *api.ForemanEnvironment) (*api.ForemanEnvironment, error) {
+	mock.record("UpdateEnvironment", e)
+	if mock.UpdateEnvironmentFunc == nil {
+		panic("apimock: UpdateEnvironmentFunc is not set")
+	}
+	return mock.UpdateEnvironmentFunc(e)
+}
+
+// DeleteEnvironment calls DeleteEnvironmentFunc
+func (mock *Client) DeleteEnvironment(id int) error {
+	mock.record("DeleteEnvironment", id)
+	if mock.DeleteEnvironmentFunc == nil {
+		panic("apimock: DeleteEnvironmentFunc is not set")
+	}
+	return mock.DeleteEnvironmentFunc(id)
+}
+
+// QueryEnvironment calls QueryEnvironmentFunc
+func (mock *Client) QueryEnvironment(e *api.ForemanEnvironment) (api.QueryResponse, error) {
+	mock.record("QueryEnvironment", e)
+	if mock.QueryEnvironmentFunc == nil {
+		panic("apimock: QueryEnvironmentFunc is not set")
+	}
+	return mock.QueryEnvironmentFunc(e)
+}
+
+// SendPowerCommand calls SendPowerCommandFunc
+func (mock *Client) SendPowerCommand(h *api.ForemanHost, cmd interface{}, retry api.RetryConfig, verify api.PowerVerifyConfig) error {
+	mock.record("SendPowerCommand", h, cmd, retry, verify)
+	if mock.SendPowerCommandFunc == nil {
+		panic("apimock: SendPowerCommandFunc is not set")
+	}
+	return mock.SendPowerCommandFunc(h, cmd, retry, verify)
+}
+
+// ProvisionBoot calls ProvisionBootFunc
+func (mock *Client) ProvisionBoot(h *api.ForemanHost, retry api.RetryConfig, verify api.PowerVerifyConfig) error {
+	mock.record("ProvisionBoot", h, retry, verify)
+	if mock.ProvisionBootFunc == nil {
+		panic("apimock: ProvisionBootFunc is not set")
+	}
+	return mock.ProvisionBootFunc(h, retry, verify)
+}
+
+// ShutdownHost calls ShutdownHostFunc
+func (mock *Client) ShutdownHost(h *api.ForemanHost, gracefulAction string, grace time.Duration, retry api.RetryConfig, verify api.PowerVerifyConfig) error {
+	mock.record("ShutdownHost", h, gracefulAction, grace, retry, verify)
+	if mock.ShutdownHostFunc == nil {
+		panic("apimock: ShutdownHostFunc is not set")
+	}
+	return mock.ShutdownHostFunc(h, gracefulAction, grace, retry, verify)
+}
+
+// ReadPowerState calls ReadPowerStateFunc
+func (mock *Client) ReadPowerState(id int) (string, error) {
+	mock.record("ReadPowerState", id)
+	if mock.ReadPowerStateFunc == nil {
+		panic("apimock: ReadPowerStateFunc is not set")
+	}
+	return mock.ReadPowerStateFunc(id)
+}
+
+// RenderHostTemplate calls RenderHostTemplateFunc
+func (mock *Client) RenderHostTemplate(id int, kind string) (string, error) {
+	mock.record("RenderHostTemplate", id, kind)
+	if mock.RenderHostTemplateFunc == nil {
+		panic("apimock: RenderHostTemplateFunc is not set")
+	}
+	return mock.RenderHostTemplateFunc(id, kind)
+}
+
+// RenderUnattendedTemplate calls RenderUnattendedTemplateFunc
+func (mock *Client) RenderUnattendedTemplate(token string, kind string) (string, error) {
+	mock.record("RenderUnattendedTemplate", token, kind)
+	if mock.RenderUnattendedTemplateFunc == nil {
+		panic("apimock: RenderUnattendedTemplateFunc is not set")
+	}
+	return mock.RenderUnattendedTemplateFunc(token, kind)
+}
+
+// CreateHost calls CreateHostFunc
+func (mock *Client) CreateHost(h *api.ForemanHost, retry api.RetryConfig) (*api.ForemanHost, error) {
+	mock.record("CreateHost", h, retry)
+	if mock.CreateHostFunc == nil {
+		panic("apimock: CreateHostFunc is not set")
+	}
+	return mock.CreateHostFunc(h, retry)
+}
+
+// ReadHost calls ReadHostFunc
+func (mock *Client) ReadHost(id int) (*api.ForemanHost, error) {
+	mock.record("ReadHost", id)
+	if mock.ReadHostFunc == nil {
+		panic("apimock: ReadHostFunc is not set")
+	}
+	return mock.ReadHostFunc(id)
+}
+
+// UpdateHost calls UpdateHostFunc
+func (mock *Client) UpdateHost(h *api.ForemanHost, retry api.RetryConfig) (*api.ForemanHost, error) {
+	mock.record("UpdateHost", h, retry)
+	if mock.UpdateHostFunc == nil {
+		panic("apimock: UpdateHostFunc is not set")
+	}
+	return mock.UpdateHostFunc(h, retry)
+}
+
+// CancelHostBuild calls CancelHostBuildFunc
+func (mock *Client) CancelHostBuild(id int) error {
+	mock.record("CancelHostBuild", id)
+	if mock.CancelHostBuildFunc == nil {
+		panic("apimock: CancelHostBuildFunc is not set")
+	}
+	return mock.CancelHostBuildFunc(id)
+}
+
+// UnmanageHost calls UnmanageHostFunc
+func (mock *Client) UnmanageHost(id int) error {
+	mock.record("UnmanageHost", id)
+	if mock.UnmanageHostFunc == nil {
+		panic("apimock: UnmanageHostFunc is not set")
+	}
+	return mock.UnmanageHostFunc(id)
+}
+
+// DeleteHost calls DeleteHostFunc
+func (mock *Client) DeleteHost(id int) error {
+	mock.record("DeleteHost", id)
+	if mock.DeleteHostFunc == nil {
+		panic("apimock: DeleteHostFunc is not set")
+	}
+	return mock.DeleteHostFunc(id)
+}
+
+// SearchHosts calls SearchHostsFunc
+func (mock *Client) SearchHosts(search string) (api.QueryResponse, error) {
+	mock.record("SearchHosts", search)
+	if mock.SearchHostsFunc == nil {
+		panic("apimock: SearchHostsFunc is not set")
+	}
+	return mock.SearchHostsFunc(search)
+}
+
+// SendBulkPowerCommand calls SendBulkPowerCommandFunc
+func (mock *Client) SendBulkPowerCommand(ids []int, action string, retry api.RetryConfig) error {
+	mock.record("SendBulkPowerCommand", ids, action, retry)
+	if mock.SendBulkPowerCommandFunc == nil {
+		panic("apimock: SendBulkPowerCommandFunc is not set")
+	}
+	return mock.SendBulkPowerCommandFunc(ids, action, retry)
+}
+
+// ReadKatelloHostCollection calls ReadKatelloHostCollectionFunc
+func (mock *Client) ReadKatelloHostCollection(id int) (*api.ForemanKatelloHostCollection, error) {
+	mock.record("ReadKatelloHostCollection", id)
+	if mock.ReadKatelloHostCollectionFunc == nil {
+		panic("apimock: ReadKatelloHostCollectionFunc is not set")
+	}
+	return mock.ReadKatelloHostCollectionFunc(id)
+}
+
+// AddKatelloHostCollectionHosts calls AddKatelloHostCollectionHostsFunc
+func (mock *Client) AddKatelloHostCollectionHosts(id int, hostIds []int) error {
+	mock.record("AddKatelloHostCollectionHosts", id, hostIds)
+	if mock.AddKatelloHostCollectionHostsFunc == nil {
+		panic("apimock: AddKatelloHostCollectionHostsFunc is not set")
+	}
+	return mock.AddKatelloHostCollectionHostsFunc(id, hostIds)
+}
+
+// RemoveKatelloHostCollectionHosts calls RemoveKatelloHostCollectionHostsFunc
+func (mock *Client) RemoveKatelloHostCollectionHosts(id int, hostIds []int) error {
+	mock.record("RemoveKatelloHostCollectionHosts", id, hostIds)
+	if mock.RemoveKatelloHostCollectionHostsFunc == nil {
+		panic("apimock: RemoveKatelloHostCollectionHostsFunc is not set")
+	}
+	return mock.RemoveKatelloHostCollectionHostsFunc(id, hostIds)
+}
+
+// ReadHostFacts calls ReadHostFactsFunc
+func (mock *Client) ReadHostFacts(id int) (map[string]string, error) {
+	mock.record("ReadHostFacts", id)
+	if mock.ReadHostFactsFunc == nil {
+		panic("apimock: ReadHostFactsFunc is not set")
+	}
+	return mock.ReadHostFactsFunc(id)
+}
+
+// ReadHostENC calls ReadHostENCFunc
+func (mock *Client) ReadHostENC(id int) (string, error) {
+	mock.record("ReadHostENC", id)
+	if mock.ReadHostENCFunc == nil {
+		panic("apimock: ReadHostENCFunc is not set")
+	}
+	return mock.ReadHostENCFunc(id)
+}
+
+// ReadHostLastConfigReport calls ReadHostLastConfigReportFunc
+func (mock *Client) ReadHostLastConfigReport(id int) (*api.ForemanConfigReport, error) {
+	mock.record("ReadHostLastConfigReport", id)
+	if mock.ReadHostLastConfigReportFunc == nil {
+		panic("apimock: ReadHostLastConfigReportFunc is not set")
+	}
+	return mock.ReadHostLastConfigReportFunc(id)
+}
+
+// ReadHostStatus calls ReadHostStatusFunc
+func (mock *Client) ReadHostStatus(id int, kind string) (*api.ForemanHostStatus, error) {
+	mock.record("ReadHostStatus", id, kind)
+	if mock.ReadHostStatusFunc == nil {
+		panic("apimock: ReadHostStatusFunc is not set")
+	}
+	return mock.ReadHostStatusFunc(id, kind)
+}
+
+// ReadHostRepresentation calls ReadHostRepresentationFunc
+func (mock *Client) ReadHostRepresentation(id int) (map[string]interface{}, error) {
+	mock.record("ReadHostRepresentation", id)
+	if mock.ReadHostRepresentationFunc == nil {
+		panic("apimock: ReadHostRepresentationFunc is not set")
+	}
+	return mock.ReadHostRepresentationFunc(id)
+}
+
+// CreateHostgroup calls CreateHostgroupFunc
+func (mock *Client) CreateHostgroup(h *api.ForemanHostgroup) (*api.ForemanHostgroup, error) {
+	mock.record("CreateHostgroup", h)
+	if mock.CreateHostgroupFunc == nil {
+		panic("apimock: CreateHostgroupFunc is not set")
+	}
+	return mock.CreateHostgroupFunc(h)
+}
+
+// ReadHostgroup calls ReadHostgroupFunc
+func (mock *Client) ReadHostgroup(id int) (*api.ForemanHostgroup, error) {
+	mock.record("ReadHostgroup", id)
+	if mock.ReadHostgroupFunc == nil {
+		panic("apimock: ReadHostgroupFunc is not set")
+	}
+	return mock.ReadHostgroupFunc(id)
+}
+
+// UpdateHostgroup calls UpdateHostgroupFunc
+func (mock *Client) UpdateHostgroup(h *api.ForemanHostgroup) (*api.ForemanHostgroup, error) {
+	mock.record("UpdateHostgroup", h)
+	if mock.UpdateHostgroupFunc == nil {
+		panic("apimock: UpdateHostgroupFunc is not set")
+	}
+	return mock.UpdateHostgroupFunc(h)
+}
+
+// DeleteHostgroup calls DeleteHostgroupFunc
+func (mock *Client) DeleteHostgroup(id int) error {
+	mock.record("DeleteHostgroup", id)
+	if mock.DeleteHostgroupFunc == nil {
+		panic("apimock: DeleteHostgroupFunc is not set")
+	}
+	return mock.DeleteHostgroupFunc(id)
+}
+
+// QueryHostgroup calls QueryHostgroupFunc
+func (mock *Client) QueryHostgroup(h *api.ForemanHostgroup) (api.QueryResponse, error) {
+	mock.record("QueryHostgroup", h)
+	if mock.QueryHostgroupFunc == nil {
+		panic("apimock: QueryHostgroupFunc is not set")
+	}
+	return mock.QueryHostgroupFunc(h)
+}
+
+// CreateImage calls CreateImageFunc
+func (mock *Client) CreateImage(computeResourceId int, d *api.ForemanImage) (*api.ForemanImage, error) {
+	mock.record("CreateImage", computeResourceId, d)
+	if mock.CreateImageFunc == nil {
+		panic("apimock: CreateImageFunc is not set")
+	}
+	return mock.CreateImageFunc(computeResourceId, d)
+}
+
+// ReadImage calls ReadImageFunc
+func (mock *Client) ReadImage(computeResourceId int, id int) (*api.ForemanImage, error) {
+	mock.record("ReadImage", computeResourceId, id)
+	if mock.ReadImageFunc == nil {
+		panic("apimock: ReadImageFunc is not set")
+	}
+	return mock.ReadImageFunc(computeResourceId, id)
+}
+
+// UpdateImage calls UpdateImageFunc
+func (mock *Client) UpdateImage(computeResourceId int, d *api.ForemanImage) (*api.ForemanImage, error) {
+	mock.record("UpdateImage", computeResourceId, d)
+	if mock.UpdateImageFunc == nil {
+		panic("apimock: UpdateImageFunc is not set")
+	}
+	return mock.UpdateImageFunc(computeResourceId, d)
+}
+
+// DeleteImage calls DeleteImageFunc
+func (mock *Client) DeleteImage(computeResourceId int, id int) error {
+	mock.record("DeleteImage", computeResourceId, id)
+	if mock.DeleteImageFunc == nil {
+		panic("apimock: DeleteImageFunc is not set")
+	}
+	return mock.DeleteImageFunc(computeResourceId, id)
+}
+
+// QueryImage calls QueryImageFunc
+func (mock *Client) QueryImage(d *api.ForemanImage) (api.QueryResponse, error) {
+	mock.record("QueryImage", d)
+	if mock.QueryImageFunc == nil {
+		panic("apimock: QueryImageFunc is not set")
+	}
+	return mock.QueryImageFunc(d)
+}
+
+// EnsureImageUserDataTemplate calls EnsureImageUserDataTemplateFunc
+func (mock *Client) EnsureImageUserDataTemplate(image *api.ForemanImage, templateId int) error {
+	mock.record("EnsureImageUserDataTemplate", image, templateId)
+	if mock.EnsureImageUserDataTemplateFunc == nil {
+		panic("apimock: EnsureImageUserDataTemplateFunc is not set")
+	}
+	return mock.EnsureImageUserDataTemplateFunc(image, templateId)
+}
+
+// QueryHostInterfaces calls QueryHostInterfacesFunc
+func (mock *Client) QueryHostInterfaces(hostId int) (api.QueryResponse, error) {
+	mock.record("QueryHostInterfaces", hostId)
+	if mock.QueryHostInterfacesFunc == nil {
+		panic("apimock: QueryHostInterfacesFunc is not set")
+	}
+	return mock.QueryHostInterfacesFunc(hostId)
+}
+
+// CreateHostInterface calls CreateHostInterfaceFunc
+func (mock *Client) CreateHostInterface(hostId int, i *api.ForemanInterfacesAttribute) (*api.ForemanInterfacesAttribute, error) {
+	mock.record("CreateHostInterface", hostId, i)
+	if mock.CreateHostInterfaceFunc == nil {
+		panic("apimock: CreateHostInterfaceFunc is not set")
+	}
+	return mock.CreateHostInterfaceFunc(hostId, i)
+}
+
+// ReadHostInterface calls ReadHostInterfaceFunc
+func (mock *Client) ReadHostInterface(hostId int, id int) (*api.ForemanInterfacesAttribute, error) {
+	mock.record("ReadHostInterface", hostId, id)
+	if mock.ReadHostInterfaceFunc == nil {
+		panic("apimock: ReadHostInterfaceFunc is not set")
+	}
+	return mock.ReadHostInterfaceFunc(hostId, id)
+}
+
+// UpdateHostInterface calls UpdateHostInterfaceFunc
+func (mock *Client) UpdateHostInterface(hostId int, i *api.ForemanInterfacesAttribute) (*api.ForemanInterfacesAttribute, error) {
+	mock.record("UpdateHostInterface", hostId, i)
+	if mock.UpdateHostInterfaceFunc == nil {
+		panic("apimock: UpdateHostInterfaceFunc is not set")
+	}
+	return mock.UpdateHostInterfaceFunc(hostId, i)
+}
+
+// DeleteHostInterface calls DeleteHostInterfaceFunc
+func (mock *Client) DeleteHostInterface(hostId int, id int) error {
+	mock.record("DeleteHostInterface", hostId, id)
+	if mock.DeleteHostInterfaceFunc == nil {
+		panic("apimock: DeleteHostInterfaceFunc is not set")
+	}
+	return mock.DeleteHostInterfaceFunc(hostId, id)
+}
+
+// ReadLocation calls ReadLocationFunc
+func (mock *Client) ReadLocation(id int) (*api.ForemanLocation, error) {
+	mock.record("ReadLocation", id)
+	if mock.ReadLocationFunc == nil {
+		panic("apimock: ReadLocationFunc is not set")
+	}
+	return mock.ReadLocationFunc(id)
+}
+
+// QueryLocation calls QueryLocationFunc
+func (mock *Client) QueryLocation(l *api.ForemanLocation) (api.QueryResponse, error) {
+	mock.record("QueryLocation", l)
+	if mock.QueryLocationFunc == nil {
+		panic("apimock: QueryLocationFunc is not set")
+	}
+	return mock.QueryLocationFunc(l)
+}
+
+// SearchLocations calls SearchLocationsFunc
+func (mock *Client) SearchLocations(search string) (api.QueryResponse, error) {
+	mock.record("SearchLocations", search)
+	if mock.SearchLocationsFunc == nil {
+		panic("apimock: SearchLocationsFunc is not set")
+	}
+	return mock.SearchLocationsFunc(search)
+}
+
+// CreateMedia calls CreateMediaFunc
+func (mock *Client) CreateMedia(m *api.ForemanMedia) (*api.ForemanMedia, error) {
+	mock.record("CreateMedia", m)
+	if mock.CreateMediaFunc == nil {
+		panic("apimock: CreateMediaFunc is not set")
+	}
+	return mock.CreateMediaFunc(m)
+}
+
+// ReadMedia calls ReadMediaFunc
+func (mock *Client) ReadMedia(id int) (*api.ForemanMedia, error) {
+	mock.record("ReadMedia", id)
+	if mock.ReadMediaFunc == nil {
+		panic("apimock: ReadMediaFunc is not set")
+	}
+	return mock.ReadMediaFunc(id)
+}
+
+// UpdateMedia calls UpdateMediaFunc
+func (mock *Client) UpdateMedia(m *api.ForemanMedia) (*api.ForemanMedia, error) {
+	mock.record("UpdateMedia", m)
+	if mock.UpdateMediaFunc == nil {
+		panic("apimock: UpdateMediaFunc is not set")
+	}
+	return mock.UpdateMediaFunc(m)
+}
+
+// DeleteMedia calls DeleteMediaFunc
+func (mock *Client) DeleteMedia(id int) error {
+	mock.record("DeleteMedia", id)
+	if mock.DeleteMediaFunc == nil {
+		panic("apimock: DeleteMediaFunc is not set")
+	}
+	return mock.DeleteMediaFunc(id)
+}
+
+// QueryMedia calls QueryMediaFunc
+func (mock *Client) QueryMedia(m *api.ForemanMedia) (api.QueryResponse, error) {
+	mock.record("QueryMedia", m)
+	if mock.QueryMediaFunc == nil {
+		panic("apimock: QueryMediaFunc is not set")
+	}
+	return mock.QueryMediaFunc(m)
+}
+
+// CreateModel calls CreateModelFunc
+func (mock *Client) CreateModel(m *api.ForemanModel) (*api.ForemanModel, error) {
+	mock.record("CreateModel", m)
+	if mock.CreateModelFunc == nil {
+		panic("apimock: CreateModelFunc is not set")
+	}
+	return mock.CreateModelFunc(m)
+}
+
+// ReadModel calls ReadModelFunc
+func (mock *Client) ReadModel(id int) (*api.ForemanModel, error) {
+	mock.record("ReadModel", id)
+	if mock.ReadModelFunc == nil {
+		panic("apimock: ReadModelFunc is not set")
+	}
+	return mock.ReadModelFunc(id)
+}
+
+// UpdateModel calls UpdateModelFunc
+func (mock *Client) UpdateModel(m *api.ForemanModel) (*api.ForemanModel, error) {
+	mock.record("UpdateModel", m)
+	if mock.UpdateModelFunc == nil {
+		panic("apimock: UpdateModelFunc is not set")
+	}
+	return mock.UpdateModelFunc(m)
+}
+
+// DeleteModel calls DeleteModelFunc
+func (mock *Client) DeleteModel(id int) error {
+	mock.record("DeleteModel", id)
+	if mock.DeleteModelFunc == nil {
+		panic("apimock: DeleteModelFunc is not set")
+	}
+	return mock.DeleteModelFunc(id)
+}
+
+// QueryModel calls QueryModelFunc
+func (mock *Client) QueryModel(m *api.ForemanModel) (api.QueryResponse, error) {
+	mock.record("QueryModel", m)
+	if mock.QueryModelFunc == nil {
+		panic("apimock: QueryModelFunc is not set")
+	}
+	return mock.QueryModelFunc(m)
+}
+
+// CreateOperatingSystem calls CreateOperatingSystemFunc
+func (mock *Client) CreateOperatingSystem(o *api.ForemanOperatingSystem) (*api.ForemanOperatingSystem, error) {
+	mock.record("CreateOperatingSystem", o)
+	if mock.CreateOperatingSystemFunc == nil {
+		panic("apimock: CreateOperatingSystemFunc is not set")
+	}
+	return mock.CreateOperatingSystemFunc(o)
+}
+
+// ReadOperatingSystem calls ReadOperatingSystemFunc
+func (mock *Client) ReadOperatingSystem(id int) (*api.ForemanOperatingSystem, error) {
+	mock.record("ReadOperatingSystem", id)
+	if mock.ReadOperatingSystemFunc == nil {
+		panic("apimock: ReadOperatingSystemFunc is not set")
+	}
+	return mock.ReadOperatingSystemFunc(id)
+}
+
+// UpdateOperatingSystem calls UpdateOperatingSystemFunc
+func (mock *Client) UpdateOperatingSystem(o *api.ForemanOperatingSystem) (*api.ForemanOperatingSystem, error) {
+	mock.record("UpdateOperatingSystem", o)
+	if mock.UpdateOperatingSystemFunc == nil {
+		panic("apimock: UpdateOperatingSystemFunc is not set")
+	}
+	return mock.UpdateOperatingSystemFunc(o)
+}
+
+// DeleteOperatingSystem calls DeleteOperatingSystemFunc
+func (mock *Client) DeleteOperatingSystem(id int) error {
+	mock.record("DeleteOperatingSystem", id)
+	if mock.DeleteOperatingSystemFunc == nil {
+		panic("apimock: DeleteOperatingSystemFunc is not set")
+	}
+	return mock.DeleteOperatingSystemFunc(id)
+}
+
+// QueryOperatingSystem calls QueryOperatingSystemFunc
+func (mock *Client) QueryOperatingSystem(o *api.ForemanOperatingSystem) (api.QueryResponse, error) {
+	mock.record("QueryOperatingSystem", o)
+	if mock.QueryOperatingSystemFunc == nil {
+		panic("apimock: QueryOperatingSystemFunc is not set")
+	}
+	return mock.QueryOperatingSystemFunc(o)
+}
+
+// EnsureOperatingSystemAssociations calls EnsureOperatingSystemAssociationsFunc
+func (mock *Client) EnsureOperatingSystemAssociations(requested *api.ForemanOperatingSystem, actual *api.ForemanOperatingSystem) (*api.ForemanOperatingSystem, error) {
+	mock.record("EnsureOperatingSystemAssociations", requested, actual)
+	if mock.EnsureOperatingSystemAssociationsFunc == nil {
+		panic("apimock: EnsureOperatingSystemAssociationsFunc is not set")
+	}
+	return mock.EnsureOperatingSystemAssociationsFunc(requested, actual)
+}
+
+// ReadOrchestrationTasks calls ReadOrchestrationTasksFunc
+func (mock *Client) ReadOrchestrationTasks(id string) ([]api.ForemanOrchestrationTask, error) {
+	mock.record("ReadOrchestrationTasks", id)
+	if mock.ReadOrchestrationTasksFunc == nil {
+		panic("apimock: ReadOrchestrationTasksFunc is not set")
+	}
+	return mock.ReadOrchestrationTasksFunc(id)
+}
+
+// CreateOverrideValue calls CreateOverrideValueFunc
+func (mock *Client) CreateOverrideValue(o *api.ForemanOverrideValue) (*api.ForemanOverrideValue, error) {
+	mock.record("CreateOverrideValue", o)
+	if mock.CreateOverrideValueFunc == nil {
+		panic("apimock: CreateOverrideValueFunc is not set")
+	}
+	return mock.CreateOverrideValueFunc(o)
+}
+
+// ReadOverrideValue calls ReadOverrideValueFunc
+func (mock *Client) ReadOverrideValue(smartClassParameterId int, id int) (*api.ForemanOverrideValue, error) {
+	mock.record("ReadOverrideValue", smartClassParameterId, id)
+	if mock.ReadOverrideValueFunc == nil {
+		panic("apimock: ReadOverrideValueFunc is not set")
+	}
+	return mock.ReadOverrideValueFunc(smartClassParameterId, id)
+}
+
+// UpdateOverrideValue calls UpdateOverrideValueFunc
+func (mock *Client) UpdateOverrideValue(o *api.ForemanOverrideValue) (*api.ForemanOverrideValue, error) {
+	mock.record("UpdateOverrideValue", o)
+	if mock.UpdateOverrideValueFunc == nil {
+		panic("apimock: UpdateOverrideValueFunc is not set")
+	}
+	return mock.UpdateOverrideValueFunc(o)
+}
+
+// DeleteOverrideValue calls DeleteOverrideValueFunc
+func (mock *Client) DeleteOverrideValue(smartClassParameterId int, id int) error {
+	mock.record("DeleteOverrideValue", smartClassParameterId, id)
+	if mock.DeleteOverrideValueFunc == nil {
+		panic("apimock: DeleteOverrideValueFunc is not set")
+	}
+	return mock.DeleteOverrideValueFunc(smartClassParameterId, id)
+}
+
+// CreateParameter calls CreateParameterFunc
+func (mock *Client) CreateParameter(d *api.ForemanParameter) (*api.ForemanParameter, error) {
+	mock.record("CreateParameter", d)
+	if mock.CreateParameterFunc == nil {
+		panic("apimock: CreateParameterFunc is not set")
+	}
+	return mock.CreateParameterFunc(d)
+}
+
+// ReadParameter calls ReadParameterFunc
+func (mock *Client) ReadParameter(d *api.ForemanParameter, id int) (*api.ForemanParameter, error) {
+	mock.record("ReadParameter", d, id)
+	if mock.ReadParameterFunc == nil {
+		panic("apimock: ReadParameterFunc is not set")
+	}
+	return mock.ReadParameterFunc(d, id)
+}
+
+// UpdateParameter calls UpdateParameterFunc
+func (mock *Client) UpdateParameter(d *api.ForemanParameter, id int) (*api.ForemanParameter, error) {
+	mock.record("UpdateParameter", d, id)
+	if mock.UpdateParameterFunc == nil {
+		panic("apimock: UpdateParameterFunc is not set")
+	}
+	return mock.UpdateParameterFunc(d, id)
+}
+
+// DeleteParameter calls DeleteParameterFunc
+func (mock *Client) DeleteParameter(d *api.ForemanParameter, id int) error {
+	mock.record("DeleteParameter", d, id)
+	if mock.DeleteParameterFunc == nil {
+		panic("apimock: DeleteParameterFunc is not set")
+	}
+	return mock.DeleteParameterFunc(d, id)
+}
+
+// QueryParameter calls QueryParameterFunc
+func (mock *Client) QueryParameter(d *api.ForemanParameter) (api.QueryResponse, error) {
+	mock.record("QueryParameter", d)
+	if mock.QueryParameterFunc == nil {
+		panic("apimock: QueryParameterFunc is not set")
+	}
+	return mock.QueryParameterFunc(d)
+}
+
+// CreatePartitionTable calls CreatePartitionTableFunc
+func (mock *Client) CreatePartitionTable(t *api.ForemanPartitionTable) (*api.ForemanPartitionTable, error) {
+	mock.record("CreatePartitionTable", t)
+	if mock.CreatePartitionTableFunc == nil {
+		panic("apimock: CreatePartitionTableFunc is not set")
+	}
+	return mock.CreatePartitionTableFunc(t)
+}
+
+// ReadPartitionTable calls ReadPartitionTableFunc
+func (mock *Client) ReadPartitionTable(id int) (*api.ForemanPartitionTable, error) {
+	mock.record("ReadPartitionTable", id)
+	if mock.ReadPartitionTableFunc == nil {
+		panic("apimock: ReadPartitionTableFunc is not set")
+	}
+	return mock.ReadPartitionTableFunc(id)
+}
+
+// UpdatePartitionTable calls UpdatePartitionTableFunc
+func (mock *Client) UpdatePartitionTable(t *api.ForemanPartitionTable) (*api.ForemanPartitionTable, error) {
+	mock.record("UpdatePartitionTable", t)
+	if mock.UpdatePartitionTableFunc == nil {
+		panic("apimock: UpdatePartitionTableFunc is not set")
+	}
+	return mock.UpdatePartitionTableFunc(t)
+}
+
+// DeletePartitionTable calls DeletePartitionTableFunc
+func (mock *Client) DeletePartitionTable(id int) error {
+	mock.record("DeletePartitionTable", id)
+	if mock.DeletePartitionTableFunc == nil {
+		panic("apimock: DeletePartitionTableFunc is not set")
+	}
+	return mock.DeletePartitionTableFunc(id)
+}
+
+// QueryPartitionTable calls QueryPartitionTableFunc
+func (mock *Client) QueryPartitionTable(t *api.ForemanPartitionTable) (api.QueryResponse, error) {
+	mock.record("QueryPartitionTable", t)
+	if mock.QueryPartitionTableFunc == nil {
+		panic("apimock: QueryPartitionTableFunc is not set")
+	}
+	return mock.QueryPartitionTableFunc(t)
+}
+
+// CreateProvisioningTemplate calls CreateProvisioningTemplateFunc
+func (mock *Client) CreateProvisioningTemplate(t *api.ForemanProvisioningTemplate) (*api.ForemanProvisioningTemplate, error) {
+	mock.record("CreateProvisioningTemplate", t)
+	if mock.CreateProvisioningTemplateFunc == nil {
+		panic("apimock: CreateProvisioningTemplateFunc is not set")
+	}
+	return mock.CreateProvisioningTemplateFunc(t)
+}
+
+// ReadProvisioningTemplate calls ReadProvisioningTemplateFunc
+func (mock *Client) ReadProvisioningTemplate(id int) (*api.ForemanProvisioningTemplate, error) {
+	mock.record("ReadProvisioningTemplate", id)
+	if mock.ReadProvisioningTemplateFunc == nil {
+		panic("apimock: ReadProvisioningTemplateFunc is not set")
+	}
+	return mock.ReadProvisioningTemplateFunc(id)
+}
+
+// UpdateProvisioningTemplate calls UpdateProvisioningTemplateFunc
+func (mock *Client) UpdateProvisioningTemplate(t *api.ForemanProvisioningTemplate) (*api.ForemanProvisioningTemplate, error) {
+	mock.record("UpdateProvisioningTemplate", t)
+	if mock.UpdateProvisioningTemplateFunc == nil {
+		panic("apimock: UpdateProvisioningTemplateFunc is not set")
+	}
+	return mock.UpdateProvisioningTemplateFunc(t)
+}
+
+// DeleteProvisioningTemplate calls DeleteProvisioningTemplateFunc
+func (mock *Client) DeleteProvisioningTemplate(id int) error {
+	mock.record("DeleteProvisioningTemplate", id)
+	if mock.DeleteProvisioningTemplateFunc == nil {
+		panic("apimock: DeleteProvisioningTemplateFunc is not set")
+	}
+	return mock.DeleteProvisioningTemplateFunc(id)
+}
+
+// QueryProvisioningTemplate calls QueryProvisioningTemplateFunc
+func (mock *Client) QueryProvisioningTemplate(t *api.ForemanProvisioningTemplate) (api.QueryResponse, error) {
+	mock.record("QueryProvisioningTemplate", t)
+	if mock.QueryProvisioningTemplateFunc == nil {
+		panic("apimock: QueryProvisioningTemplateFunc is not set")
+	}
+	return mock.QueryProvisioningTemplateFunc(t)
+}
+
+// PreviewProvisioningTemplate calls PreviewProvisioningTemplateFunc
+func (mock *Client) PreviewProvisioningTemplate(id int, hostId int, template string) (string, error) {
+	mock.record("PreviewProvisioningTemplate", id, hostId, template)
+	if mock.PreviewProvisioningTemplateFunc == nil {
+		panic("apimock: PreviewProvisioningTemplateFunc is not set")
+	}
+	return mock.PreviewProvisioningTemplateFunc(id, hostId, template)
+}
+
+// Query calls QueryFunc
+func (mock *Client) Query(endpoint string, search string) (api.QueryResponse, error) {
+	mock.record("Query", endpoint, search)
+	if mock.QueryFunc == nil {
+		panic("apimock: QueryFunc is not set")
+	}
+	return mock.QueryFunc(endpoint, search)
+}
+
+// ForEachResult calls ForEachResultFunc
+func (mock *Client) ForEachResult(endpoint string, search string, fn func(result json.RawMessage) error) error {
+	mock.record("ForEachResult", endpoint, search, fn)
+	if mock.ForEachResultFunc == nil {
+		panic("apimock: ForEachResultFunc is not set")
+	}
+	return mock.ForEachResultFunc(endpoint, search, fn)
+}
+
+// CreateSmartProxy calls CreateSmartProxyFunc
+func (mock *Client) CreateSmartProxy(s *api.ForemanSmartProxy) (*api.ForemanSmartProxy, error) {
+	mock.record("CreateSmartProxy", s)
+	if mock.CreateSmartProxyFunc == nil {
+		panic("apimock: CreateSmartProxyFunc is not set")
+	}
+	return mock.CreateSmartProxyFunc(s)
+}
+
+// ReadSmartProxy calls ReadSmartProxyFunc
+func (mock *Client) ReadSmartProxy(id int) (*api.ForemanSmartProxy, error) {
+	mock.record("ReadSmartProxy", id)
+	if mock.ReadSmartProxyFunc == nil {
+		panic("apimock: ReadSmartProxyFunc is not set")
+	}
+	return mock.ReadSmartProxyFunc(id)
+}
+
+// UpdateSmartProxy calls UpdateSmartProxyFunc
+func (mock *Client) UpdateSmartProxy(s *api.ForemanSmartProxy) (*api.ForemanSmartProxy, error) {
+	mock.record("UpdateSmartProxy", s)
+	if mock.UpdateSmartProxyFunc == nil {
+		panic("apimock: UpdateSmartProxyFunc is not set")
+	}
+	return mock.UpdateSmartProxyFunc(s)
+}
+
+// RefreshSmartProxy calls RefreshSmartProxyFunc
+func (mock *Client) RefreshSmartProxy(id int) (*api.ForemanSmartProxy, error) {
+	mock.record("RefreshSmartProxy", id)
+	if mock.RefreshSmartProxyFunc == nil {
+		panic("apimock: RefreshSmartProxyFunc is not set")
+	}
+	return mock.RefreshSmartProxyFunc(id)
+}
+
+// DeleteSmartProxy calls DeleteSmartProxyFunc
+func (mock *Client) DeleteSmartProxy(id int) error {
+	mock.record("DeleteSmartProxy", id)
+	if mock.DeleteSmartProxyFunc == nil {
+		panic("apimock: DeleteSmartProxyFunc is not set")
+	}
+	return mock.DeleteSmartProxyFunc(id)
+}
+
+// QuerySmartProxy calls QuerySmartProxyFunc
+func (mock *Client) QuerySmartProxy(s *api.ForemanSmartProxy) (api.QueryResponse, error) {
+	mock.record("QuerySmartProxy", s)
+	if mock.QuerySmartProxyFunc == nil {
+		panic("apimock: QuerySmartProxyFunc is not set")
+	}
+	return mock.QuerySmartProxyFunc(s)
+}
+
+// CreateSubnet calls CreateSubnetFunc
+func (mock *Client) CreateSubnet(s *api.ForemanSubnet) (*api.ForemanSubnet, error) {
+	mock.record("CreateSubnet", s)
+	if mock.CreateSubnetFunc == nil {
+		panic("apimock: CreateSubnetFunc is not set")
+	}
+	return mock.CreateSubnetFunc(s)
+}
+
+// ReadSubnet calls ReadSubnetFunc
+func (mock *Client) ReadSubnet(id int) (*api.ForemanSubnet, error) {
+	mock.record("ReadSubnet", id)
+	if mock.ReadSubnetFunc == nil {
+		panic("apimock: ReadSubnetFunc is not set")
+	}
+	return mock.ReadSubnetFunc(id)
+}
+
+// UpdateSubnet calls UpdateSubnetFunc
+func (mock *Client) UpdateSubnet(s *api.ForemanSubnet) (*api.ForemanSubnet, error) {
+	mock.record("UpdateSubnet", s)
+	if mock.UpdateSubnetFunc == nil {
+		panic("apimock: UpdateSubnetFunc is not set")
+	}
+	return mock.UpdateSubnetFunc(s)
+}
+
+// DeleteSubnet calls DeleteSubnetFunc
+func (mock *Client) DeleteSubnet(id int) error {
+	mock.record("DeleteSubnet", id)
+	if mock.DeleteSubnetFunc == nil {
+		panic("apimock: DeleteSubnetFunc is not set")
+	}
+	return mock.DeleteSubnetFunc(id)
+}
+
+// QuerySubnet calls QuerySubnetFunc
+func (mock *Client) QuerySubnet(s *api.ForemanSubnet) (api.QueryResponse, error) {
+	mock.record("QuerySubnet", s)
+	if mock.QuerySubnetFunc == nil {
+		panic("apimock: QuerySubnetFunc is not set")
+	}
+	return mock.QuerySubnetFunc(s)
+}
+
+// CreateTemplateCombination calls CreateTemplateCombinationFunc
+func (mock *Client) CreateTemplateCombination(t *api.ForemanTemplateCombination) (*api.ForemanTemplateCombination, error) {
+	mock.record("CreateTemplateCombination", t)
+	if mock.CreateTemplateCombinationFunc == nil {
+		panic("apimock: CreateTemplateCombinationFunc is not set")
+	}
+	return mock.CreateTemplateCombinationFunc(t)
+}
+
+// ReadTemplateCombination calls ReadTemplateCombinationFunc
+func (mock *Client) ReadTemplateCombination(id int) (*api.ForemanTemplateCombination, error) {
+	mock.record("ReadTemplateCombination", id)
+	if mock.ReadTemplateCombinationFunc == nil {
+		panic("apimock: ReadTemplateCombinationFunc is not set")
+	}
+	return mock.ReadTemplateCombinationFunc(id)
+}
+
+// DeleteTemplateCombination calls DeleteTemplateCombinationFunc
+func (mock *Client) DeleteTemplateCombination(id int) error {
+	mock.record("DeleteTemplateCombination", id)
+	if mock.DeleteTemplateCombinationFunc == nil {
+		panic("apimock: DeleteTemplateCombinationFunc is not set")
+	}
+	return mock.DeleteTemplateCombinationFunc(id)
+}
+
+// ReadTemplateKind calls ReadTemplateKindFunc
+func (mock *Client) ReadTemplateKind(id int) (*api.ForemanTemplateKind, error) {
+	mock.record("ReadTemplateKind", id)
+	if mock.ReadTemplateKindFunc == nil {
+		panic("apimock: ReadTemplateKindFunc is not set")
+	}
+	return mock.ReadTemplateKindFunc(id)
+}
+
+// QueryTemplateKind calls QueryTemplateKindFunc
+func (mock *Client) QueryTemplateKind(t *api.ForemanTemplateKind) (api.QueryResponse, error) {
+	mock.record("QueryTemplateKind", t)
+	if mock.QueryTemplateKindFunc == nil {
+		panic("apimock: QueryTemplateKindFunc is not set")
+	}
+	return mock.QueryTemplateKindFunc(t)
+}
+
+// ReadTask calls ReadTaskFunc
+func (mock *Client) ReadTask(id string) (*api.ForemanTask, error) {
+	mock.record("ReadTask", id)
+	if mock.ReadTaskFunc == nil {
+		panic("apimock: ReadTaskFunc is not set")
+	}
+	return mock.ReadTaskFunc(id)
+}
+
+// WaitForTask calls WaitForTaskFunc
+func (mock *Client) WaitForTask(ctx context.Context, id string, wait api.TaskWaitConfig, progress func(*api.ForemanTask)) (*api.ForemanTask, error) {
+	mock.record("WaitForTask", ctx, id, wait, progress)
+	if mock.WaitForTaskFunc == nil {
+		panic("apimock: WaitForTaskFunc is not set")
+	}
+	return mock.WaitForTaskFunc(ctx, id, wait, progress)
+}
+
+// ReadUsergroup calls ReadUsergroupFunc
+func (mock *Client) ReadUsergroup(id int) (*api.ForemanUsergroup, error) {
+	mock.record("ReadUsergroup", id)
+	if mock.ReadUsergroupFunc == nil {
+		panic("apimock: ReadUsergroupFunc is not set")
+	}
+	return mock.ReadUsergroupFunc(id)
+}
+
+// AddUsergroupRole calls AddUsergroupRoleFunc
+func (mock *Client) AddUsergroupRole(id int, roleId int) error {
+	mock.record("AddUsergroupRole", id, roleId)
+	if mock.AddUsergroupRoleFunc == nil {
+		panic("apimock: AddUsergroupRoleFunc is not set")
+	}
+	return mock.AddUsergroupRoleFunc(id, roleId)
+}
+
+// RemoveUsergroupRole calls RemoveUsergroupRoleFunc
+func (mock *Client) RemoveUsergroupRole(id int, roleId int) error {
+	mock.record("RemoveUsergroupRole", id, roleId)
+	if mock.RemoveUsergroupRoleFunc == nil {
+		panic("apimock: RemoveUsergroupRoleFunc is not set")
+	}
+	return mock.RemoveUsergroupRoleFunc(id, roleId)
+}
+
+// RefreshExternalUsergroup calls RefreshExternalUsergroupFunc
+func (mock *Client) RefreshExternalUsergroup(id int, externalId int) error {
+	mock.record("RefreshExternalUsergroup", id, externalId)
+	if mock.RefreshExternalUsergroupFunc == nil {
+		panic("apimock: RefreshExternalUsergroupFunc is not set")
+	}
+	return mock.RefreshExternalUsergroupFunc(id, externalId)
+}
+
+// QueryUsergroup calls QueryUsergroupFunc
+func (mock *Client) QueryUsergroup(u *api.ForemanUsergroup) (api.QueryResponse, error) {
+	mock.record("QueryUsergroup", u)
+	if mock.QueryUsergroupFunc == nil {
+		panic("apimock: QueryUsergroupFunc is not set")
+	}
+	return mock.QueryUsergroupFunc(u)
+}
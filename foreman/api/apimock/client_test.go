@@ -0,0 +1,50 @@
+package apimock
+
+import (
+	"testing"
+
+	"github.com/HanseMerkur/terraform-provider-foreman/foreman/api"
+)
+
+// Ensures the mock substitutes for the client, calling the supplied
+// functions and recording the calls
+func TestClient(t *testing.T) {
+	mock := &Client{
+		ReadHostFunc: func(id int) (*api.ForemanHost, error) {
+			h := &api.ForemanHost{}
+			h.Id = id
+			h.Name = "host01.example.com"
+			return h, nil
+		},
+		DeleteHostFunc: func(id int) error {
+			return api.ErrNotFound
+		},
+	}
+	var client api.ForemanClient = mock
+
+	h, readErr := client.ReadHost(3)
+	if readErr != nil || h.Name != "host01.example.com" {
+		t.Fatalf("Expected host [3] to be read, got [%+v], [%v]", h, readErr)
+	}
+	if deleteErr := client.DeleteHost(3); !api.IsNotFound(deleteErr) {
+		t.Fatalf("Expected the error of DeleteHostFunc, got [%v]", deleteErr)
+	}
+
+	calls := mock.Calls()
+	if len(calls) != 2 || calls[0].Method != "ReadHost" || calls[1].Method != "DeleteHost" {
+		t.Fatalf("Expected the calls to be recorded in order, got [%+v]", calls)
+	}
+	if len(calls[0].Args) != 1 || calls[0].Args[0] != 3 {
+		t.Fatalf("Expected the arguments to be recorded, got [%+v]", calls[0].Args)
+	}
+}
+
+// Ensures calling a method without its function set panics
+func TestClient_unset(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Fatalf("Expected calling ReadDomain without ReadDomainFunc to panic")
+		}
+	}()
+	(&Client{}).ReadDomain(1)
+}
@@ -211,33 +211,5 @@ func (c *Client) QueryArchitecture(a *ForemanArchitecture) (QueryResponse, error
 	reqQuery.Set("search", "name="+name)
 
 	req.URL.RawQuery = reqQuery.Encode()
-	sendErr := c.SendAndParse(req, &queryResponse)
-	if sendErr != nil {
-		return queryResponse, sendErr
-	}
-
-	log.Debugf("queryResponse: [%+v]", queryResponse)
-
-	// Results will be Unmarshaled into a []map[string]interface{}
-	//
-	// Encode back to JSON, then Unmarshal into []ForemanArchitecture for
-	// the results
-	results := []ForemanArchitecture{}
-	resultsBytes, jsonEncErr := json.Marshal(queryResponse.Results)
-	if jsonEncErr != nil {
-		return queryResponse, jsonEncErr
-	}
-	jsonDecErr := json.Unmarshal(resultsBytes, &results)
-	if jsonDecErr != nil {
-		return queryResponse, jsonDecErr
-	}
-	// convert the search results from []ForemanArchitecture to []interface
-	// and set the search results on the query
-	iArr := make([]interface{}, len(results))
-	for idx, val := range results {
-		iArr[idx] = val
-	}
-	queryResponse.Results = iArr
-
-	return queryResponse, nil
+	return sendAndParseQuery[ForemanArchitecture](c, req)
 }
@@ -0,0 +1,75 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+)
+
+// -----------------------------------------------------------------------------
+// Audit Attribution
+// -----------------------------------------------------------------------------
+
+// isWriteMethod returns whether requests with the supplied method change
+// objects in Foreman and are recorded in its audit log
+func isWriteMethod(method string) bool {
+	switch method {
+	case http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// addAuditAttribution adds the audit comment and headers of the client
+// configuration to the supplied write request.  Foreman records the
+// "audit_comment" of an object with the audit of the change, the comment is
+// added to the wrapped object of the request body (ie: {"host": {...}}).
+// Bodies which are not a single wrapped object are sent unchanged.
+func (client *Client) addAuditAttribution(req *http.Request) error {
+	if !isWriteMethod(req.Method) {
+		return nil
+	}
+	for name, value := range client.config.AuditHeaders {
+		req.Header.Set(name, value)
+	}
+	if client.config.AuditComment == "" || req.Body == nil || req.Body == http.NoBody {
+		return nil
+	}
+
+	body, readErr := io.ReadAll(req.Body)
+	req.Body.Close()
+	if readErr != nil {
+		return readErr
+	}
+	body = withAuditComment(body, client.config.AuditComment)
+	req.ContentLength = int64(len(body))
+	req.GetBody = func() (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(body)), nil
+	}
+	req.Body, _ = req.GetBody()
+	return nil
+}
+
+// withAuditComment returns the supplied JSON body with the audit comment set
+// on its wrapped object
+func withAuditComment(body []byte, comment string) []byte {
+	var wrapper map[string]json.RawMessage
+	if json.Unmarshal(body, &wrapper) != nil || len(wrapper) != 1 {
+		return body
+	}
+	for name, objJSON := range wrapper {
+		var obj map[string]json.RawMessage
+		if json.Unmarshal(objJSON, &obj) != nil || obj == nil {
+			return body
+		}
+		commentJSON, _ := json.Marshal(comment)
+		obj["audit_comment"] = commentJSON
+		wrapper[name], _ = json.Marshal(obj)
+	}
+	commented, jsonEncErr := json.Marshal(wrapper)
+	if jsonEncErr != nil {
+		return body
+	}
+	return commented
+}
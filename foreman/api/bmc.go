@@ -0,0 +1,359 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/wayfair/terraform-provider-utils/log"
+)
+
+const (
+	// BMCErrorAuth : the BMC rejected the credentials of the bmc interface
+	BMCErrorAuth = "auth"
+	// BMCErrorTransport : the BMC or the smart proxy talking to it could not
+	// be reached
+	BMCErrorTransport = "transport"
+	// BMCErrorProxy : no smart proxy with the BMC feature is available for the
+	// host
+	BMCErrorProxy = "proxy"
+	// BMCErrorUnknown : any other failure of a power operation
+	BMCErrorUnknown = "unknown"
+
+	// BMCActionIdentifyOn : turns the chassis identify LED on
+	BMCActionIdentifyOn = "identify_on"
+	// BMCActionIdentifyOff : turns the chassis identify LED off
+	BMCActionIdentifyOff = "identify_off"
+	// BMCActionColdReset : resets the BMC itself as if it was power cycled
+	BMCActionColdReset = "cold_reset"
+	// BMCActionWarmReset : restarts the firmware of the BMC
+	BMCActionWarmReset = "warm_reset"
+)
+
+// BMCActions are the actions of SendBMCAction
+var BMCActions = []string{
+	BMCActionIdentifyOn,
+	BMCActionIdentifyOff,
+	BMCActionColdReset,
+	BMCActionWarmReset,
+}
+
+// bmcActionPaths maps the BMC actions to the path and query of the smart
+// proxy's BMC API, relative to the host
+var bmcActionPaths = map[string]struct {
+	path  string
+	query url.Values
+}{
+	BMCActionIdentifyOn:  {"chassis/config/identify/on", url.Values{}},
+	BMCActionIdentifyOff: {"chassis/config/identify/off", url.Values{}},
+	BMCActionColdReset:   {"bmc/reset", url.Values{"type": []string{"cold"}}},
+	BMCActionWarmReset:   {"bmc/reset", url.Values{"type": []string{"warm"}}},
+}
+
+// bmcErrorPatterns maps the kinds of BMC errors to the messages of Foreman,
+// the smart proxy and ipmitool/freeipmi identifying them.  The messages are
+// compared case-insensitively.
+var bmcErrorPatterns = []struct {
+	kind     string
+	patterns []string
+}{
+	{BMCErrorProxy, []string{
+		"no proxy",
+		"no proxies",
+		"no bmc nic",
+		"bmc feature",
+		"no smart proxy",
+	}},
+	{BMCErrorAuth, []string{
+		"authentication",
+		"unauthorized",
+		"invalid user",
+		"password",
+		"rakp",
+		"insufficient privilege",
+	}},
+	{BMCErrorTransport, []string{
+		"timeout",
+		"timed out",
+		"connection refused",
+		"unreachable",
+		"no route to host",
+		"unable to establish",
+	}},
+}
+
+// -----------------------------------------------------------------------------
+// Struct Definition and Helpers
+// -----------------------------------------------------------------------------
+
+// BMCError is returned when a power operation of a host failed.  The kind of
+// the failure decides whether retrying the operation is worth it: a rejected
+// password or a missing smart proxy does not fix itself.
+type BMCError struct {
+	// The kind of the failure (ie: auth, transport, proxy)
+	Kind string
+	// The ID of the host the operation was sent for
+	HostId int
+	// The power action or boot device of the operation
+	Action string
+	// The error of the request
+	Err error
+}
+
+// Error implements the error interface
+func (e *BMCError) Error() string {
+	hint := ""
+	switch e.Kind {
+	case BMCErrorAuth:
+		hint = "the BMC rejected the credentials, check the username and " +
+			"password of the bmc interface"
+	case BMCErrorTransport:
+		hint = "the BMC or its smart proxy could not be reached"
+	case BMCErrorProxy:
+		hint = "no smart proxy with the BMC feature is available, associate " +
+			"one with the subnet of the bmc interface"
+	default:
+		hint = "the operation failed"
+	}
+	return fmt.Sprintf(
+		"BMC operation [%s] of host [%d] failed, %s: %s",
+		e.Action,
+		e.HostId,
+		hint,
+		e.Err,
+	)
+}
+
+// Unwrap returns the error of the request
+func (e *BMCError) Unwrap() error {
+	return e.Err
+}
+
+// classifyBMCError returns the kind of BMC error the supplied error of a
+// power operation is.  Network errors and gateway errors of the API are
+// transport errors, other errors are classified by their message.
+func classifyBMCError(err error) string {
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		return BMCErrorTransport
+	}
+	msg := err.Error()
+	var httpErr *HTTPError
+	if errors.As(err, &httpErr) {
+		switch httpErr.StatusCode {
+		case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+			return BMCErrorTransport
+		}
+		msg = string(httpErr.RespBody)
+	}
+	msg = strings.ToLower(msg)
+	for _, kind := range bmcErrorPatterns {
+		for _, pattern := range kind.patterns {
+			if strings.Contains(msg, pattern) {
+				return kind.kind
+			}
+		}
+	}
+	return BMCErrorUnknown
+}
+
+// newBMCError wraps the supplied error of a power operation in a BMCError
+func newBMCError(err error, hostId int, action string) *BMCError {
+	return &BMCError{
+		Kind:   classifyBMCError(err),
+		HostId: hostId,
+		Action: action,
+		Err:    err,
+	}
+}
+
+// IsBMCError returns whether the supplied error is a BMCError of the supplied
+// kind
+func IsBMCError(err error, kind string) bool {
+	var bmcErr *BMCError
+	return errors.As(err, &bmcErr) && bmcErr.Kind == kind
+}
+
+// bmcRetryConfig returns the RetryConfig of power operations.  Each kind of
+// BMC error has its own retry budget: authentication errors and missing smart
+// proxies are not retried, transport and other errors are retried as often as
+// the supplied RetryConfig allows.
+func bmcRetryConfig(retry RetryConfig) RetryConfig {
+	retry.Retryable = func(err error) bool {
+		switch classifyBMCError(err) {
+		case BMCErrorAuth, BMCErrorProxy:
+			return false
+		}
+		return true
+	}
+	return retry
+}
+
+// -----------------------------------------------------------------------------
+// BMC Actions
+// -----------------------------------------------------------------------------
+
+// BMCInterface is the BMC of a host as addressed by a smart proxy
+type BMCInterface struct {
+	// IP address or hostname of the BMC
+	Address string
+	// Provider of the BMC (ie: IPMI, Redfish)
+	Provider string
+	// Credentials of the BMC
+	Username string
+	Password string
+}
+
+// bmcActionResponse struct used for JSON decode of the smart proxy's answer
+// to a BMC action
+type bmcActionResponse struct {
+	Action string `json:"action"`
+	Result *bool  `json:"result"`
+}
+
+// newBMCProxyRequest creates a request to the BMC API of the supplied smart
+// proxy for the supplied BMC.  The path is relative to the BMC (ie:
+// "chassis/config/identify/on").
+func (c *Client) newBMCProxyRequest(method string, proxy *ForemanSmartProxy, bmc BMCInterface, path string, query url.Values) (*http.Request, error) {
+	proxyURL, parseErr := url.Parse(proxy.URL)
+	if parseErr != nil {
+		return nil, parseErr
+	}
+	proxyURL.Path = strings.TrimSuffix(proxyURL.Path, "/") +
+		"/bmc/" + url.PathEscape(bmc.Address) + "/" + path
+	reqQuery := url.Values{}
+	for key, values := range query {
+		reqQuery[key] = values
+	}
+	if bmc.Provider != "" {
+		reqQuery.Set("bmc_provider", strings.ToLower(bmc.Provider))
+	}
+	proxyURL.RawQuery = reqQuery.Encode()
+
+	req, reqErr := c.NewRequest(method, "/", nil)
+	if reqErr != nil {
+		return nil, reqErr
+	}
+	// NOTE(ALL): the smart proxy authenticates against the BMC with the
+	//   basic auth credentials of the request
+	req.URL = proxyURL
+	req.Host = proxyURL.Host
+	req.SetBasicAuth(bmc.Username, bmc.Password)
+
+	log.Debugf("BMC proxy request: [%s %s]", method, proxyURL.Redacted())
+
+	return req, nil
+}
+
+// SendBMCAction sends the supplied BMC action (ie: chassis identify, BMC
+// reset) to the BMC of the host identified by the supplied ID through the
+// supplied smart proxy.  Foreman's power API only covers power actions and
+// boot devices, these actions are sent to the BMC API of the smart proxy
+// directly.  The BMC credentials are sent instead of Foreman's, the smart
+// proxy must trust the provider's client.
+//
+// Example: https://<proxy>/bmc/<address>/chassis/config/identify/on
+func (c *Client) SendBMCAction(hostId int, proxy *ForemanSmartProxy, bmc BMCInterface, action string, retry RetryConfig) error {
+	log.Tracef("foreman/api/bmc.go#SendBMCAction")
+
+	actionPath, ok := bmcActionPaths[action]
+	if !ok {
+		return fmt.Errorf("Invalid BMC action: [%s]", action)
+	}
+	req, reqErr := c.newBMCProxyRequest(http.MethodPut, proxy, bmc, actionPath.path, actionPath.query)
+	if reqErr != nil {
+		return reqErr
+	}
+
+	var response json.RawMessage
+	sendErr := c.SendAndParseWithRetry(req, &response, bmcRetryConfig(retry))
+	if sendErr != nil {
+		return newBMCError(sendErr, hostId, action)
+	}
+
+	var actionResponse bmcActionResponse
+	if jsonDecErr := json.Unmarshal(response, &actionResponse); jsonDecErr != nil {
+		return jsonDecErr
+	}
+	if actionResponse.Result != nil && !*actionResponse.Result {
+		return fmt.Errorf("Failed BMC action [%s] on [%s]", action, bmc.Address)
+	}
+	return nil
+}
+
+// SetBMCBootDevice sets the boot device (ie: "disk", "pxe") of the BMC of the
+// host identified by the supplied ID through the supplied smart proxy.  Unlike
+// the BMCBoot of SendPowerCommand, which only applies to the next boot, the
+// boot device is kept for all following boots.
+//
+// Example: https://<proxy>/bmc/<address>/chassis/config/bootdevice/disk
+func (c *Client) SetBMCBootDevice(hostId int, proxy *ForemanSmartProxy, bmc BMCInterface, device string, retry RetryConfig) error {
+	log.Tracef("foreman/api/bmc.go#SetBMCBootDevice")
+
+	query := url.Values{
+		"persistent": []string{"true"},
+		"reboot":     []string{"false"},
+	}
+	req, reqErr := c.newBMCProxyRequest(http.MethodPut, proxy, bmc, "chassis/config/bootdevice/"+device, query)
+	if reqErr != nil {
+		return reqErr
+	}
+
+	var response bmcActionResponse
+	sendErr := c.SendAndParseWithRetry(req, &response, bmcRetryConfig(retry))
+	if sendErr != nil {
+		return newBMCError(sendErr, hostId, BootSuffix+" "+device)
+	}
+	if response.Result != nil && !*response.Result {
+		return fmt.Errorf("Failed to set the boot device [%s] on [%s]", device, bmc.Address)
+	}
+	return nil
+}
+
+// ReadBMCBootDevice reads the boot device of the BMC of the host identified by
+// the supplied ID through the supplied smart proxy.  The device reported by
+// the BMC is converted to one of the BootDevices, an empty string is returned
+// for devices which are not.
+//
+// Example: https://<proxy>/bmc/<address>/chassis/config/bootdevice
+func (c *Client) ReadBMCBootDevice(hostId int, proxy *ForemanSmartProxy, bmc BMCInterface) (string, error) {
+	log.Tracef("foreman/api/bmc.go#ReadBMCBootDevice")
+
+	req, reqErr := c.newBMCProxyRequest(http.MethodGet, proxy, bmc, "chassis/config/bootdevice", nil)
+	if reqErr != nil {
+		return "", reqErr
+	}
+
+	var response struct {
+		Result string `json:"result"`
+	}
+	sendErr := c.SendAndParse(req, &response)
+	if sendErr != nil {
+		return "", newBMCError(sendErr, hostId, BootSuffix)
+	}
+
+	log.Debugf("Boot device: [%s]", response.Result)
+
+	return normalizeBootDevice(response.Result), nil
+}
+
+// normalizeBootDevice converts the boot device reported by a BMC (ie: "Force
+// PXE", "Force Boot from default Hard-Drive") to one of the BootDevices
+func normalizeBootDevice(device string) string {
+	device = strings.ToLower(device)
+	switch {
+	case strings.Contains(device, "pxe"):
+		return BootPxe
+	case strings.Contains(device, "disk"), strings.Contains(device, "hard"):
+		return BootDisk
+	case strings.Contains(device, "cd"), strings.Contains(device, "dvd"):
+		return BootCdrom
+	case strings.Contains(device, "bios"), strings.Contains(device, "setup"):
+		return PowerBios
+	}
+	return ""
+}
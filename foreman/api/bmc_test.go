@@ -0,0 +1,117 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// ----------------------------------------------------------------------------
+// SendBMCAction
+// ----------------------------------------------------------------------------
+
+// Ensures BMC actions are sent to the BMC API of the smart proxy with the
+// credentials of the BMC, and fail when the smart proxy reports a failure.
+func TestSendBMCAction(t *testing.T) {
+	_, server, client := NewForemanAPIAndClient(ClientCredentials{Username: "admin", Password: "foreman"}, ClientConfig{})
+	defer server.Close()
+
+	result := "true"
+	requests := []string{}
+	proxyServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		username, password, _ := r.BasicAuth()
+		requests = append(requests, fmt.Sprintf("%s %s?%s %s:%s", r.Method, r.URL.Path, r.URL.RawQuery, username, password))
+		fmt.Fprintf(w, `{"action": "identify", "result": %s}`, result)
+	}))
+	defer proxyServer.Close()
+
+	proxy := &ForemanSmartProxy{URL: proxyServer.URL}
+	bmc := BMCInterface{
+		Address:  "10.0.0.10",
+		Provider: BMCProviderIPMI,
+		Username: "root",
+		Password: "calvin",
+	}
+
+	testCases := []struct {
+		action  string
+		request string
+	}{
+		{BMCActionIdentifyOn, "PUT /bmc/10.0.0.10/chassis/config/identify/on?bmc_provider=ipmi root:calvin"},
+		{BMCActionIdentifyOff, "PUT /bmc/10.0.0.10/chassis/config/identify/off?bmc_provider=ipmi root:calvin"},
+		{BMCActionColdReset, "PUT /bmc/10.0.0.10/bmc/reset?bmc_provider=ipmi&type=cold root:calvin"},
+	}
+	for _, testCase := range testCases {
+		requests = []string{}
+		if sendErr := client.SendBMCAction(1, proxy, bmc, testCase.action, RetryConfig{}); sendErr != nil {
+			t.Fatalf("SendBMCAction returned an error for [%s]: %s", testCase.action, sendErr)
+		}
+		if len(requests) != 1 || requests[0] != testCase.request {
+			t.Errorf("Expected the request [%s] for [%s], got %v", testCase.request, testCase.action, requests)
+		}
+	}
+
+	result = "false"
+	if sendErr := client.SendBMCAction(1, proxy, bmc, BMCActionIdentifyOn, RetryConfig{}); sendErr == nil {
+		t.Errorf("Expected a failed BMC action to return an error")
+	}
+	if sendErr := client.SendBMCAction(1, proxy, bmc, "explode", RetryConfig{}); sendErr == nil {
+		t.Errorf("Expected an invalid BMC action to return an error")
+	}
+}
+
+// ----------------------------------------------------------------------------
+// SetBMCBootDevice / ReadBMCBootDevice
+// ----------------------------------------------------------------------------
+
+// Ensures the boot device is set persistently and the device reported by the
+// BMC is converted to one of the BootDevices.
+func TestBMCBootDevice(t *testing.T) {
+	_, server, client := NewForemanAPIAndClient(ClientCredentials{}, ClientConfig{})
+	defer server.Close()
+
+	reported := ""
+	requests := []string{}
+	proxyServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, fmt.Sprintf("%s %s?%s", r.Method, r.URL.Path, r.URL.RawQuery))
+		if r.Method == http.MethodGet {
+			fmt.Fprintf(w, `{"action": "bootdevice", "result": "%s"}`, reported)
+			return
+		}
+		fmt.Fprint(w, `{"action": "bootdevice", "result": true}`)
+	}))
+	defer proxyServer.Close()
+
+	proxy := &ForemanSmartProxy{URL: proxyServer.URL}
+	bmc := BMCInterface{Address: "10.0.0.10"}
+
+	if setErr := client.SetBMCBootDevice(1, proxy, bmc, BootDisk, RetryConfig{}); setErr != nil {
+		t.Fatalf("SetBMCBootDevice returned an error: %s", setErr)
+	}
+	expected := "PUT /bmc/10.0.0.10/chassis/config/bootdevice/disk?persistent=true&reboot=false"
+	if len(requests) != 1 || requests[0] != expected {
+		t.Errorf("Expected the request [%s], got %v", expected, requests)
+	}
+
+	testCases := []struct {
+		reported string
+		expected string
+	}{
+		{"Force PXE", BootPxe},
+		{"Force Boot from default Hard-Drive", BootDisk},
+		{"Force Boot from CD/DVD", BootCdrom},
+		{"Force Boot into BIOS Setup", PowerBios},
+		{"No override", ""},
+	}
+	for _, testCase := range testCases {
+		reported = testCase.reported
+		device, readErr := client.ReadBMCBootDevice(1, proxy, bmc)
+		if readErr != nil {
+			t.Fatalf("ReadBMCBootDevice returned an error: %s", readErr)
+		}
+		if device != testCase.expected {
+			t.Errorf("Expected the boot device [%s] for [%s], got [%s]", testCase.expected, testCase.reported, device)
+		}
+	}
+}
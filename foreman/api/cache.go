@@ -0,0 +1,270 @@
+package api
+
+import (
+	"container/list"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/wayfair/terraform-provider-utils/log"
+)
+
+// ----------------------------------------------------------------------------
+// Response Cache
+// ----------------------------------------------------------------------------
+
+// cachedResponse is the body of a GET response along with the validators the
+// server sent for it
+type cachedResponse struct {
+	ETag         string `json:"etag"`
+	LastModified string `json:"last_modified"`
+	Body         []byte `json:"body"`
+	// Path of the request and when the response was stored, used to serve
+	// reference data without revalidation.  See fresh().
+	Path     string    `json:"path"`
+	StoredAt time.Time `json:"stored_at"`
+}
+
+// referenceCollections are the API collections holding rarely changing
+// reference data.  Their cached responses are served without contacting the
+// server until the reference cache TTL expires.
+var referenceCollections = map[string]bool{
+	ArchitectureEndpointPrefix:    true,
+	OperatingSystemEndpointPrefix: true,
+	TemplateKindEndpointPrefix:    true,
+}
+
+// apiCollection returns the API collection the supplied request path belongs
+// to (ie: "hostgroups" for "/api/hostgroups/1")
+func apiCollection(path string) string {
+	collection := strings.TrimPrefix(path, FOREMAN_API_URL_PREFIX+"/")
+	if idx := strings.Index(collection, "/"); idx >= 0 {
+		collection = collection[:idx]
+	}
+	return collection
+}
+
+// responseCacheSize is the number of responses kept in memory by the
+// response cache.  The least recently used responses are evicted first.
+var responseCacheSize = 256
+
+// isPagedRequest returns whether the supplied request reads a page of an
+// index endpoint (see ForEachResult).  Pages are never cached, walking a
+// collection only holds a single page in memory at a time.
+func isPagedRequest(req *http.Request) bool {
+	return req.URL.Query().Get("page") != ""
+}
+
+// responseCache stores the bodies of GET responses so repeated requests are
+// sent as conditional requests (If-None-Match, If-Modified-Since) and the
+// cached body is served when the server answers 304 Not Modified.
+//
+// The cache keeps the responseCacheSize most recently used responses in
+// memory.  If a directory is configured, the responses of the reference
+// collections are also persisted there so they survive across Terraform runs.
+// Other responses (ie: hosts read with their hidden parameters) are never
+// written to disk.
+type responseCache struct {
+	// Directory the entries are persisted to.  Empty when the cache is only
+	// kept in memory.
+	dir string
+
+	mu sync.Mutex
+	// The cached responses by key, and their keys from the most to the least
+	// recently used
+	entries map[string]*list.Element
+	lru     *list.List
+	// When each API collection was last modified through the client
+	modified map[string]time.Time
+}
+
+// responseCacheEntry is an element of the cache's LRU list
+type responseCacheEntry struct {
+	key      string
+	response cachedResponse
+}
+
+// newResponseCache creates a response cache persisting its entries to the
+// supplied directory.  An empty directory keeps the entries in memory only.
+func newResponseCache(dir string) *responseCache {
+	return &responseCache{
+		dir:      dir,
+		entries:  map[string]*list.Element{},
+		lru:      list.New(),
+		modified: map[string]time.Time{},
+	}
+}
+
+// remember keeps the response for the supplied key in memory, evicting the
+// least recently used responses beyond responseCacheSize.  The caller holds
+// the lock.
+func (rc *responseCache) remember(key string, entry cachedResponse) {
+	if elem, ok := rc.entries[key]; ok {
+		elem.Value.(*responseCacheEntry).response = entry
+		rc.lru.MoveToFront(elem)
+		return
+	}
+	rc.entries[key] = rc.lru.PushFront(&responseCacheEntry{key: key, response: entry})
+	for rc.lru.Len() > responseCacheSize {
+		oldest := rc.lru.Back()
+		rc.lru.Remove(oldest)
+		delete(rc.entries, oldest.Value.(*responseCacheEntry).key)
+	}
+}
+
+// fresh returns whether the cached response holds reference data stored less
+// than ttl ago, and can therefore be served without contacting the server.
+// Responses stored before the client modified their collection are stale.
+func (rc *responseCache) fresh(entry cachedResponse, ttl time.Duration) bool {
+	collection := apiCollection(entry.Path)
+	if ttl <= 0 || !referenceCollections[collection] {
+		return false
+	}
+
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	return time.Since(entry.StoredAt) < ttl && entry.StoredAt.After(rc.modified[collection])
+}
+
+// invalidate marks the API collection the supplied path belongs to as
+// modified, so its reference data is revalidated with the server again
+func (rc *responseCache) invalidate(path string) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	rc.modified[apiCollection(path)] = time.Now()
+}
+
+// key returns the cache key of the supplied request.  Responses depend on the
+// permissions of the authenticated user, so the user is part of the key.
+func (rc *responseCache) key(req *http.Request) string {
+	username, _, _ := req.BasicAuth()
+	sum := sha256.Sum256([]byte(username + "\n" + req.URL.String()))
+	return hex.EncodeToString(sum[:])
+}
+
+// get returns the cached response for the supplied key
+func (rc *responseCache) get(key string) (cachedResponse, bool) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	if elem, ok := rc.entries[key]; ok {
+		rc.lru.MoveToFront(elem)
+		return elem.Value.(*responseCacheEntry).response, true
+	}
+	if rc.dir == "" {
+		return cachedResponse{}, false
+	}
+
+	data, readErr := ioutil.ReadFile(filepath.Join(rc.dir, key+".json"))
+	if readErr != nil {
+		return cachedResponse{}, false
+	}
+	var entry cachedResponse
+	if jsonDecErr := json.Unmarshal(data, &entry); jsonDecErr != nil {
+		log.Debugf("Ignoring invalid cache entry [%s]: %s", key, jsonDecErr)
+		return cachedResponse{}, false
+	}
+	// NOTE(ALL): earlier versions of the provider persisted every response,
+	//   only reference data is trusted from disk
+	if !referenceCollections[apiCollection(entry.Path)] {
+		log.Debugf("Ignoring cache entry [%s] of [%s]", key, entry.Path)
+		return cachedResponse{}, false
+	}
+	rc.remember(key, entry)
+	return entry, true
+}
+
+// put stores the response for the supplied key.  Responses of reference
+// collections are persisted as well, failures to persist them are logged and
+// otherwise ignored.
+func (rc *responseCache) put(key string, entry cachedResponse) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	rc.remember(key, entry)
+	if rc.dir == "" || !referenceCollections[apiCollection(entry.Path)] {
+		return
+	}
+
+	data, jsonEncErr := json.Marshal(entry)
+	if jsonEncErr != nil {
+		log.Debugf("Failed to encode cache entry [%s]: %s", key, jsonEncErr)
+		return
+	}
+	// NOTE(ALL): responses may contain data only the authenticated user is
+	//   allowed to see, keep the cache private
+	if mkdirErr := os.MkdirAll(rc.dir, 0700); mkdirErr != nil {
+		log.Debugf("Failed to create cache directory [%s]: %s", rc.dir, mkdirErr)
+		return
+	}
+	if writeErr := ioutil.WriteFile(filepath.Join(rc.dir, key+".json"), data, 0600); writeErr != nil {
+		log.Debugf("Failed to persist cache entry [%s]: %s", key, writeErr)
+	}
+}
+
+// ----------------------------------------------------------------------------
+// Lookup Memoization
+// ----------------------------------------------------------------------------
+
+// memoizedResponse is the body of a memoized GET response along with the path
+// of the request, used for invalidation
+type memoizedResponse struct {
+	path string
+	body []byte
+}
+
+// lookupMemo memoizes the responses of lookups (ie: data sources, foreign key
+// resolution) for the lifetime of the provider instance.  Unlike the response
+// cache, memoized responses are served without contacting the server, so
+// hundreds of resources referencing the same object only look it up once.
+type lookupMemo struct {
+	mu      sync.Mutex
+	entries map[string]memoizedResponse
+}
+
+// newLookupMemo creates an empty lookup memo
+func newLookupMemo() *lookupMemo {
+	return &lookupMemo{
+		entries: map[string]memoizedResponse{},
+	}
+}
+
+// get returns the memoized response body for the supplied URL
+func (lm *lookupMemo) get(url string) ([]byte, bool) {
+	lm.mu.Lock()
+	defer lm.mu.Unlock()
+	entry, ok := lm.entries[url]
+	return entry.body, ok
+}
+
+// put memoizes the response body for the supplied URL and path
+func (lm *lookupMemo) put(url string, path string, body []byte) {
+	lm.mu.Lock()
+	defer lm.mu.Unlock()
+	lm.entries[url] = memoizedResponse{
+		path: path,
+		body: body,
+	}
+}
+
+// invalidate drops the memoized responses of the API collection the supplied
+// path belongs to (ie: "/api/hostgroups/1" drops all "/api/hostgroups"
+// responses).
+func (lm *lookupMemo) invalidate(path string) {
+	prefix := FOREMAN_API_URL_PREFIX + "/" + apiCollection(path)
+
+	lm.mu.Lock()
+	defer lm.mu.Unlock()
+	for url, entry := range lm.entries {
+		if entry.path == prefix || strings.HasPrefix(entry.path, prefix+"/") {
+			delete(lm.entries, url)
+		}
+	}
+}
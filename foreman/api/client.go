@@ -1,17 +1,23 @@
 package api
 
 import (
+	"context"
 	"crypto/tls"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"io/ioutil"
 	"net/http"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/wayfair/terraform-provider-utils/log"
 
 	cleanhttp "github.com/hashicorp/go-cleanhttp"
+	"go.opentelemetry.io/otel/trace"
+	"golang.org/x/sync/singleflight"
 )
 
 const (
@@ -45,23 +51,196 @@ type ClientConfig struct {
 	//
 	// See 'pkg/crypto/tls/#Config.InsecureSkipVerify' for more information
 	TLSInsecureEnabled bool
+	// Whether or not resources verify the objects they reference exist in
+	// Foreman while planning
+	ValidateReferences bool
+	// Whether or not BMC operations (ie: PXE boot through the BMC) are
+	// skipped for every host, regardless of the host's own configuration
+	DisableBMC bool
+	// Whether or not the Foreman server has the Katello plugin installed
+	KatelloEnabled bool
+	// Deadline applied to each request sent to the server.  A value of 0
+	// disables the deadline.
+	Timeout time.Duration
+	// Deadline applied to requests marked as long running through
+	// WithLongRunningTimeout (ie: host creation, power operations).  A value
+	// of 0 disables the deadline.
+	LongRunningTimeout time.Duration
+	// Directory the responses of GET requests for reference data (ie:
+	// architectures, template kinds) are cached in across runs.  The most
+	// recently used responses are always cached in memory; an empty value
+	// disables the persistence.
+	CacheDir string
+	// Duration cached reference data (ie: architectures, template kinds,
+	// operating systems) is served without contacting the server.  A value
+	// of 0 always revalidates the cached responses.
+	ReferenceCacheTTL time.Duration
+	// Maximum number of requests sent to the server simultaneously.  A value
+	// of 0 does not limit the number of requests.
+	Parallelism int
+	// Tracer provider the spans of the API calls are recorded with.  Nil
+	// disables the tracing.
+	TracerProvider trace.TracerProvider
+	// Metrics the API calls are counted in.  Several clients may share the
+	// same metrics.  Nil disables the counting.
+	Metrics *Metrics
+	// Optional lookups (see Lookups) which are skipped, because the account
+	// of the client is not permitted to perform them
+	DisabledLookups []string
+	// Comment recorded in Foreman's audit log with the changes of write
+	// requests (ie: "changed by Terraform run 42").  Empty sends no comment.
+	AuditComment string
+	// Headers added to write requests, so proxies in front of Foreman can
+	// attribute the changes (ie: impersonation headers where permitted)
+	AuditHeaders map[string]string
+	// Parameters added to every host the provider manages, unless the host
+	// sets a parameter of the same name
+	DefaultHostParameters map[string]string
 }
 
+// String implements the fmt.Stringer interface.  The values of the audit
+// headers may carry credentials (ie: impersonation tokens) and are redacted
+// so the configuration can be logged safely.
+func (cfg ClientConfig) String() string {
+	type plainClientConfig ClientConfig
+	redacted := plainClientConfig(cfg)
+	if len(cfg.AuditHeaders) > 0 {
+		redacted.AuditHeaders = make(map[string]string, len(cfg.AuditHeaders))
+		for name := range cfg.AuditHeaders {
+			redacted.AuditHeaders[name] = redactedValue
+		}
+	}
+	return fmt.Sprintf("%+v", redacted)
+}
+
+// longRunningKey is the context key marking a request as long running
+type longRunningKey struct{}
+
+// WithLongRunningTimeout marks the supplied request as long running.  The
+// client applies the LongRunningTimeout instead of the Timeout of its
+// configuration when sending it.
+func WithLongRunningTimeout(req *http.Request) *http.Request {
+	return req.WithContext(context.WithValue(req.Context(), longRunningKey{}, true))
+}
+
+// Client is a client of the Foreman API.  A Client and its copies (see
+// Memoized()) are safe for concurrent use by Terraform's parallel resource
+// operations: the configuration is never modified after creation and the
+// state shared between requests (caches, in-flight requests, request slots)
+// is synchronized.
 type Client struct {
 	// Foreman URL used to communicate and interact with the API.
 	server Server
 	// Set of credentials to authenticate the client
 	credentials ClientCredentials
+	// Configurable features the client was created with
+	config ClientConfig
 	// Instance of the HTTP client used to communicate with the webservice.  After
 	// the intial setup, the client should never modify or interact directly with
 	// the underlying HTTP client and should instead use the helper functions.
 	httpClient *http.Client
+	// Cache of the responses to GET requests, revalidated with conditional
+	// requests
+	cache *responseCache
+	// Memo of lookup responses shared by all copies of the client
+	memo *lookupMemo
+	// Whether or not the GET requests sent by this client are memoized.  See
+	// Memoized().
+	memoize bool
+	// In-flight GET requests shared by all copies of the client.  Identical
+	// GET requests sent concurrently are coalesced into a single request.
+	inflight *singleflight.Group
+	// Request slots shared by all copies of the client, limiting the number
+	// of requests sent simultaneously.  Nil when the number is not limited.
+	slots chan struct{}
+	// Tracer recording a span for every API call
+	tracer trace.Tracer
+	// Server and replicas shared by all copies of the client.  Nil when the
+	// server has no replicas.
+	endpoints *endpointPool
+}
+
+// sentResponse is the outcome of a request, shared by coalesced GET requests
+type sentResponse struct {
+	statusCode int
+	body       []byte
+	// Delay the server asked for before the request is sent again (see the
+	// Retry-After header).  0 if the server did not ask for a delay.
+	retryAfter time.Duration
 }
 
 // KVParameters are used in all inline Parameter Maps. i.e. Host, HostGroup
 type ForemanKVParameter struct {
 	Name  string `json:"name"`
 	Value string `json:"value"`
+	// Whether the value is masked in the Foreman UI
+	HiddenValue bool `json:"hidden_value"`
+	// Type the value is interpreted as by templates (see ParameterTypes).
+	// Empty leaves the type to Foreman, which defaults to "string".
+	ParameterType string `json:"parameter_type,omitempty"`
+}
+
+// ParameterTypes are the types of the values of ForemanKVParameters
+var ParameterTypes = []string{
+	"string",
+	"boolean",
+	"integer",
+	"real",
+	"array",
+	"hash",
+	"yaml",
+	"json",
+}
+
+// restoreHiddenParameters restores the values of the hidden parameters
+// received from the parameters sent.  Create and update responses mask the
+// values of hidden parameters.
+func restoreHiddenParameters(sent []ForemanKVParameter, received []ForemanKVParameter) {
+	sentValues := make(map[string]string, len(sent))
+	for _, param := range sent {
+		sentValues[param.Name] = param.Value
+	}
+	for idx, param := range received {
+		if value, ok := sentValues[param.Name]; ok && param.HiddenValue {
+			received[idx].Value = value
+		}
+	}
+}
+
+// Custom JSON unmarshal function.  The API reports the hidden flag as
+// "hidden_value?" but expects "hidden_value" on create and update.  Typed
+// parameters (ie: boolean, integer) are converted to their string value.
+func (kv *ForemanKVParameter) UnmarshalJSON(b []byte) error {
+	type plainKVParameter ForemanKVParameter
+	var kvJSON struct {
+		plainKVParameter
+		Value               interface{} `json:"value"`
+		ReportedHiddenValue *bool       `json:"hidden_value?"`
+	}
+	jsonDecErr := json.Unmarshal(b, &kvJSON)
+	if jsonDecErr != nil {
+		return jsonDecErr
+	}
+	*kv = ForemanKVParameter(kvJSON.plainKVParameter)
+
+	switch value := kvJSON.Value.(type) {
+	case nil:
+		kv.Value = ""
+	case string:
+		kv.Value = value
+	case bool, float64:
+		kv.Value = fmt.Sprint(value)
+	default:
+		valueJSON, jsonEncErr := json.Marshal(value)
+		if jsonEncErr != nil {
+			return jsonEncErr
+		}
+		kv.Value = string(valueJSON)
+	}
+	if kvJSON.ReportedHiddenValue != nil {
+		kv.HiddenValue = *kvJSON.ReportedHiddenValue
+	}
+	return nil
 }
 
 // NewClient creates a new instance of the REST client for communication with
@@ -90,10 +269,35 @@ func NewClient(s Server, c ClientCredentials, cfg ClientConfig) *Client {
 		httpClient:  cleanClient,
 		server:      s,
 		credentials: c,
+		config:      cfg,
+		cache:       newResponseCache(cfg.CacheDir),
+		memo:        newLookupMemo(),
+		inflight:    &singleflight.Group{},
+		tracer:      newTracer(cfg.TracerProvider),
+		endpoints:   newEndpointPool(s),
+	}
+	if cfg.Parallelism > 0 {
+		client.slots = make(chan struct{}, cfg.Parallelism)
 	}
 	return &client
 }
 
+// Config returns the configurable features the client was created with
+func (client *Client) Config() ClientConfig {
+	return client.config
+}
+
+// Memoized returns a copy of the client memoizing the responses of its GET
+// requests for the lifetime of the client.  Use it for lookups of objects
+// referenced by many resources.  Any other request sent through the client
+// or its copies invalidates the memoized responses of the API collection it
+// targets.
+func (client *Client) Memoized() *Client {
+	memoized := *client
+	memoized.memoize = true
+	return &memoized
+}
+
 // ----------------------------------------------------------------------------
 // Client Helper Functions
 // ----------------------------------------------------------------------------
@@ -112,6 +316,9 @@ func NewClient(s Server, c ClientCredentials, cfg ClientConfig) *Client {
 //   Content-Type
 //   Authorization
 //
+// Write requests additionally carry the audit comment and headers of the
+// client configuration (see addAuditAttribution).
+//
 // method
 //   The HTTP Verb to use.  This should correspond to a 'Method*' constant
 //   from 'net/http'.
@@ -136,7 +343,7 @@ func (client *Client) NewRequest(method string, endpoint string, body io.Reader)
 	}
 
 	// Build the URL for the request
-	reqURL := client.server.URL
+	reqURL := client.serverURL()
 	if strings.HasPrefix(endpoint, "/") {
 		reqURL.Path = FOREMAN_API_URL_PREFIX + endpoint
 	} else {
@@ -167,6 +374,9 @@ func (client *Client) NewRequest(method string, endpoint string, body io.Reader)
 	req.Header.Add("Accept", "application/json,version="+FOREMAN_API_VERSION)
 	req.Header.Add("Content-Type", "application/json")
 	req.SetBasicAuth(client.credentials.Username, client.credentials.Password)
+	if auditErr := client.addAuditAttribution(req); auditErr != nil {
+		return nil, auditErr
+	}
 	return req, nil
 }
 
@@ -212,22 +422,151 @@ func isValidRequestMethod(method string) bool {
 func (client *Client) Send(request *http.Request) (int, []byte, error) {
 	log.Tracef("foreman/api/client.go#Send")
 
+	sent, sendErr := client.sendRequest(request)
+	return sent.statusCode, sent.body, sendErr
+}
+
+// requestTimeout returns the deadline applied to the supplied request, the
+// LongRunningTimeout for requests marked with WithLongRunningTimeout and the
+// Timeout otherwise
+func (client *Client) requestTimeout(request *http.Request) time.Duration {
+	if longRunning, _ := request.Context().Value(longRunningKey{}).(bool); longRunning {
+		return client.config.LongRunningTimeout
+	}
+	return client.config.Timeout
+}
+
+// sendRequest implements Send, additionally returning the delay the server
+// asked for before the request is sent again
+func (client *Client) sendRequest(request *http.Request) (sent sentResponse, sendErr error) {
 	emptySlice := []byte{}
 
 	if request == nil {
 		log.Errorf("Client trying to send a nil request")
-		return -1, emptySlice, fmt.Errorf("Client trying to send a nil request")
+		return sentResponse{statusCode: -1, body: emptySlice}, fmt.Errorf("Client trying to send a nil request")
+	}
+
+	// Apply the configured deadline.  The deadline is derived from the
+	// request's context, so every retry of a request gets a fresh deadline.
+	timeout := client.requestTimeout(request)
+	if timeout > 0 {
+		ctx, cancel := context.WithTimeout(request.Context(), timeout)
+		defer cancel()
+		request = request.WithContext(ctx)
 	}
 
+	var span trace.Span
+	request, span = client.startSpan(request)
+	defer func() {
+		endSpan(span, sent, sendErr)
+	}()
+
+	if request.Method == http.MethodGet && client.memoize && client.memo != nil {
+		if body, ok := client.memo.get(request.URL.String()); ok {
+			log.Debugf("Serving memoized response for [%s]", request.URL)
+			return sentResponse{statusCode: http.StatusOK, body: body}, nil
+		}
+	} else if request.Method != http.MethodGet && client.memo != nil {
+		client.memo.invalidate(request.URL.Path)
+	}
+	if request.Method != http.MethodGet && client.cache != nil {
+		client.cache.invalidate(request.URL.Path)
+	}
+
+	// NOTE(ALL): when many resources refresh concurrently, identical GET
+	//   requests of the same user and deadline are coalesced into a single
+	//   outbound request.  The shared request is detached from the context of
+	//   the caller which started it and gets its own deadline, each caller
+	//   only stops waiting for it when its own context is done.
+	if request.Method == http.MethodGet && client.inflight != nil {
+		username, _, _ := request.BasicAuth()
+		key := fmt.Sprintf("%s\n%s\n%s", username, timeout, request.URL)
+		ch := client.inflight.DoChan(key, func() (interface{}, error) {
+			ctx := context.WithoutCancel(request.Context())
+			if timeout > 0 {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithTimeout(ctx, timeout)
+				defer cancel()
+			}
+			return client.send(request.WithContext(ctx))
+		})
+		select {
+		case result := <-ch:
+			if result.Shared {
+				log.Debugf("Shared in-flight response for [%s]", request.URL)
+			}
+			sent, sendErr = result.Val.(sentResponse), result.Err
+		case <-request.Context().Done():
+			return sentResponse{statusCode: -1, body: emptySlice}, request.Context().Err()
+		}
+	} else {
+		sent, sendErr = client.send(request)
+	}
+	if sendErr != nil {
+		return sent, sendErr
+	}
+
+	if request.Method == http.MethodGet && client.memoize && client.memo != nil &&
+		sent.statusCode == http.StatusOK {
+		client.memo.put(request.URL.String(), request.URL.Path, sent.body)
+	}
+
+	return sent, nil
+}
+
+// send sends the HTTP request to the server and reads its response.  GET
+// requests are revalidated against the response cache.
+func (client *Client) send(request *http.Request) (sent sentResponse, sendErr error) {
+	emptySlice := []byte{}
+
+	// NOTE(ALL): GET requests with a cached response are sent as conditional
+	//   requests.  The server answers 304 Not Modified with an empty body if
+	//   the cached response is still current.
+	cacheKey := ""
+	var cached cachedResponse
+	var isCached bool
+	if request.Method == http.MethodGet && client.cache != nil && !isPagedRequest(request) {
+		cacheKey = client.cache.key(request)
+		if cached, isCached = client.cache.get(cacheKey); isCached {
+			if client.cache.fresh(cached, client.config.ReferenceCacheTTL) {
+				log.Debugf("Serving cached reference data for [%s]", request.URL)
+				return sentResponse{statusCode: http.StatusOK, body: cached.Body}, nil
+			}
+			if cached.ETag != "" {
+				request.Header.Set("If-None-Match", cached.ETag)
+			}
+			if cached.LastModified != "" {
+				request.Header.Set("If-Modified-Since", cached.LastModified)
+			}
+		}
+	}
+
+	// NOTE(ALL): wait for a request slot, independent of Terraform's own
+	//   parallelism.  The slot is held until the response is read.
+	if client.slots != nil {
+		select {
+		case client.slots <- struct{}{}:
+			defer func() { <-client.slots }()
+		case <-request.Context().Done():
+			return sentResponse{statusCode: -1, body: emptySlice}, request.Context().Err()
+		}
+	}
+
+	start := time.Now()
+	defer func() {
+		failed := sendErr != nil || sent.statusCode >= 400
+		client.config.Metrics.record(request, time.Since(start), failed)
+	}()
+
 	// Send the request to the server
-	resp, respErr := client.httpClient.Do(request)
+	resp, respErr := client.do(request)
 	if respErr != nil {
 		log.Errorf(
 			"Error encountered when sending HTTP request to server\n"+
 				"  Error: %s",
 			respErr.Error(),
 		)
-		return -1, emptySlice, respErr
+		return sentResponse{statusCode: -1, body: emptySlice}, respErr
 	}
 	// NOTE(ALL): Golang stdlib dictates that it is the caller's resposibility
 	//   to close the response body.  See net/http Response type for more
@@ -242,10 +581,62 @@ func (client *Client) Send(request *http.Request) (int, []byte, error) {
 				"  Error: %s",
 			readErr.Error(),
 		)
-		return resp.StatusCode, emptySlice, readErr
+		return sentResponse{statusCode: resp.StatusCode, body: emptySlice}, readErr
 	}
 
-	return resp.StatusCode, respBody, nil
+	if cacheKey != "" {
+		if resp.StatusCode == http.StatusNotModified && isCached {
+			log.Debugf("Serving cached response for [%s]", request.URL)
+			respBody = cached.Body
+			resp.StatusCode = http.StatusOK
+		}
+		etag := resp.Header.Get("ETag")
+		lastModified := resp.Header.Get("Last-Modified")
+		// NOTE(ALL): reference data is cached without validators as well, it
+		//   is served from the cache until the TTL expires
+		isReference := client.config.ReferenceCacheTTL > 0 &&
+			referenceCollections[apiCollection(request.URL.Path)]
+		if resp.StatusCode == http.StatusOK && (etag != "" || lastModified != "" || isReference) {
+			client.cache.put(cacheKey, cachedResponse{
+				ETag:         etag,
+				LastModified: lastModified,
+				Body:         respBody,
+				Path:         request.URL.Path,
+				StoredAt:     time.Now(),
+			})
+		}
+	}
+
+	sent = sentResponse{
+		statusCode: resp.StatusCode,
+		body:       respBody,
+	}
+	// NOTE(ALL): Foreman (or its load balancer) sheds load with 429 Too Many
+	//   Requests or 503 Service Unavailable and tells how long to back off
+	if resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode == http.StatusServiceUnavailable {
+		sent.retryAfter = parseRetryAfter(resp.Header.Get("Retry-After"), time.Now())
+	}
+	return sent, nil
+}
+
+// parseRetryAfter returns the delay requested by the value of a Retry-After
+// header, given either as a number of seconds or as an HTTP date.  0 is
+// returned for a missing, invalid or past value.
+func parseRetryAfter(value string, now time.Time) time.Duration {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0
+	}
+	if seconds, convErr := strconv.Atoi(value); convErr == nil {
+		if seconds < 0 {
+			return 0
+		}
+		return time.Duration(seconds) * time.Second
+	}
+	if date, parseErr := http.ParseTime(value); parseErr == nil && date.After(now) {
+		return date.Sub(now)
+	}
+	return 0
 }
 
 // SendAndParse sends an HTTP request generated by Client.NewRequest() and
@@ -256,10 +647,11 @@ func (client *Client) Send(request *http.Request) (int, []byte, error) {
 func (client *Client) SendAndParse(req *http.Request, obj interface{}) error {
 	log.Tracef("foreman/api/client.go#SendAndParse")
 
-	statusCode, respBody, sendErr := client.Send(req)
+	sent, sendErr := client.sendRequest(req)
 	if sendErr != nil {
 		return sendErr
 	}
+	statusCode, respBody := sent.statusCode, sent.body
 
 	log.Debugf(
 		"server response:{\n"+
@@ -271,20 +663,20 @@ func (client *Client) SendAndParse(req *http.Request, obj interface{}) error {
 		req.URL,
 		req.Method,
 		statusCode,
-		respBody,
+		redactJSON(respBody),
 	)
 
 	if statusCode < 200 || statusCode > 299 {
-		return fmt.Errorf(
-			"HTTP Error:{\n"+
-				"  endpoint:   [%s]\n"+
-				"  statusCode: [%d]\n"+
-				"  respBody:   [%s]\n"+
-				"}",
-			req.URL,
-			statusCode,
-			respBody,
-		)
+		httpErr := &HTTPError{
+			Endpoint:   req.URL.String(),
+			StatusCode: statusCode,
+			RespBody:   respBody,
+			RetryAfter: sent.retryAfter,
+		}
+		if statusCode == http.StatusForbidden {
+			return newPermissionError(req, httpErr)
+		}
+		return httpErr
 	}
 
 	if obj != nil {
@@ -293,6 +685,146 @@ func (client *Client) SendAndParse(req *http.Request, obj interface{}) error {
 	return nil
 }
 
+// HTTPError is returned by SendAndParse when the server responds with a
+// status code outside of the 2xx range.  403 responses are returned as a
+// PermissionError wrapping the HTTPError.  See ErrNotFound, ErrConflict and
+// ValidationError for branching on the cause of the error.
+type HTTPError struct {
+	// The URL the request was sent to
+	Endpoint string
+	// The status code of the server's response
+	StatusCode int
+	// The body of the server's response
+	RespBody []byte
+	// Delay the server asked for before the request is sent again (see the
+	// Retry-After header of 429 and 503 responses)
+	RetryAfter time.Duration
+}
+
+// Error implements the error interface
+func (e *HTTPError) Error() string {
+	return fmt.Sprintf(
+		"HTTP Error:{\n"+
+			"  endpoint:   [%s]\n"+
+			"  statusCode: [%d]\n"+
+			"  respBody:   [%s]\n"+
+			"}",
+		e.Endpoint,
+		e.StatusCode,
+		e.RespBody,
+	)
+}
+
+// Is reports whether the error matches one of the sentinel errors of the
+// API, so callers can branch on the status code with errors.Is (ie:
+// errors.Is(err, api.ErrNotFound)).
+func (e *HTTPError) Is(target error) bool {
+	switch target {
+	case ErrNotFound:
+		return e.StatusCode == http.StatusNotFound
+	case ErrConflict:
+		return e.StatusCode == http.StatusConflict
+	}
+	return false
+}
+
+// As converts the error of a 422 response to a ValidationError, so callers
+// can read the rejected fields with errors.As.  The error stays an HTTPError
+// for callers inspecting its status code.
+func (e *HTTPError) As(target interface{}) bool {
+	validationErr, ok := target.(**ValidationError)
+	if !ok || e.StatusCode != http.StatusUnprocessableEntity {
+		return false
+	}
+	*validationErr = newValidationError(e)
+	return true
+}
+
+// IsNotFound returns whether the supplied error is caused by the server
+// responding with a 404 status code.
+func IsNotFound(err error) bool {
+	return errors.Is(err, ErrNotFound)
+}
+
+// RetryConfig controls how often and how fast a failed request is retried
+type RetryConfig struct {
+	// Number of attempts made before giving up
+	Count int
+	// Time to wait between two attempts
+	Delay time.Duration
+	// Whether the error of a failed attempt is worth retrying.  Nil retries
+	// every error.
+	Retryable func(err error) bool
+}
+
+// maxRetryDelay caps the delay between two attempts of a request without a
+// deadline, whatever the server asks for with Retry-After
+var maxRetryDelay = 5 * time.Minute
+
+// SendAndParseWithRetry sends an HTTP request generated by Client.NewRequest()
+// the same way SendAndParse does.  Failed attempts are retried as configured by
+// the supplied RetryConfig and the error of the last attempt is returned.  The
+// request is always sent at least once.
+func (client *Client) SendAndParseWithRetry(req *http.Request, obj interface{}, retry RetryConfig) error {
+	log.Tracef("foreman/api/client.go#SendAndParseWithRetry")
+
+	attempts := retry.Count
+	if attempts < 1 {
+		attempts = 1
+	}
+
+	var sendErr error
+	for attempt := 0; attempt < attempts; attempt++ {
+		if attempt > 0 {
+			// NOTE(ALL): back off as long as the server asked for when it is
+			//   shedding load, instead of burning the retries.  The delay is
+			//   capped by the deadline of the request, so a huge Retry-After
+			//   does not stall the apply.
+			delay := retry.Delay
+			if httpErr, ok := sendErr.(*HTTPError); ok && httpErr.RetryAfter > delay {
+				delay = httpErr.RetryAfter
+			}
+			maxDelay := client.requestTimeout(req)
+			if maxDelay <= 0 {
+				maxDelay = maxRetryDelay
+			}
+			if delay > maxDelay {
+				delay = maxDelay
+			}
+			log.Debugf("Retry #[%d] after [%s]: [%s]", attempt, delay, sendErr)
+			select {
+			case <-req.Context().Done():
+				return fmt.Errorf("%s, retries aborted: %w", sendErr, req.Context().Err())
+			case <-time.After(delay):
+			}
+			client.config.Metrics.recordRetry(req)
+			// NOTE(ALL): the body of the previous attempt was consumed - rewind
+			//   it before sending the request again
+			if req.GetBody != nil {
+				body, bodyErr := req.GetBody()
+				if bodyErr != nil {
+					return bodyErr
+				}
+				req.Body = body
+			}
+		}
+		if sendErr = client.SendAndParse(req, obj); sendErr == nil {
+			return nil
+		}
+		if IsPermissionError(sendErr) {
+			log.Debugf("Not retrying, the account lacks a permission: [%s]", sendErr)
+			return sendErr
+		}
+		if retry.Retryable != nil && !retry.Retryable(sendErr) {
+			log.Debugf("Not retrying: [%s]", sendErr)
+			return sendErr
+		}
+	}
+	return sendErr
+}
+
+// WrapJson marshals the supplied item into a JSON object nested under the
+// supplied name, as expected by the Foreman API for request bodies.
 func WrapJson(name string, item interface{}) ([]byte, error) {
 	wrapped := map[string]interface{}{
 		name: item,
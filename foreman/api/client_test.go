@@ -1,12 +1,24 @@
 package api
 
 import (
+	"bytes"
+	"compress/gzip"
+	"context"
 	"encoding/base64"
+	"errors"
+	"fmt"
+	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
+	"path/filepath"
 	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 )
 
 // ----------------------------------------------------------------------------
@@ -101,6 +113,23 @@ func TestNewClient_Credentials(t *testing.T) {
 	}
 }
 
+// Ensures the values of the audit headers are redacted when the client
+// configuration is logged
+func TestClientConfig_String(t *testing.T) {
+	conf := ClientConfig{
+		AuditComment: "changed by Terraform",
+		AuditHeaders: map[string]string{"X-Impersonate-Token": "s3cr3t"},
+	}
+
+	formatted := fmt.Sprintf("%+v", conf)
+	if strings.Contains(formatted, "s3cr3t") || !strings.Contains(formatted, "X-Impersonate-Token") {
+		t.Fatalf("Expected the audit header values to be redacted, got [%s]", formatted)
+	}
+	if conf.AuditHeaders["X-Impersonate-Token"] != "s3cr3t" {
+		t.Fatalf("Expected the configuration to be left unmodified, got [%v]", conf.AuditHeaders)
+	}
+}
+
 // Ensures if the client has enabled TLS insecure, then the client's
 // underlying HTTP transport has disabled TLS verification. Otherwise,
 // TLS verification should be enabled.
@@ -460,3 +489,656 @@ func TestSendAndParseStatusCodeNoError(t *testing.T) {
 		)
 	}
 }
+
+// Ensure SendAndParseWithRetry() retries failed attempts and sends the same
+// request body with every attempt
+func TestSendAndParseWithRetry_ResendsBody(t *testing.T) {
+	cred := ClientCredentials{
+		Username: "Admin",
+		Password: "ChangeMe",
+	}
+	conf := ClientConfig{}
+	mux, server, client := NewForemanAPIAndClient(cred, conf)
+	defer server.Close()
+
+	// dummy '[POST] /foo' endpoint - fails the first attempt
+	attempts := 0
+	mux.HandleFunc(FOREMAN_API_URL_PREFIX+"/foo", func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		body, _ := ioutil.ReadAll(r.Body)
+		if string(body) != "bar" {
+			t.Errorf("attempt [%d] sent body [%s], expected [bar]", attempts, body)
+		}
+		if attempts == 1 {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	})
+
+	req, _ := client.NewRequest(http.MethodPost, "/foo", bytes.NewBufferString("bar"))
+	sendErr := client.SendAndParseWithRetry(req, nil, RetryConfig{Count: 2})
+	if sendErr != nil {
+		t.Errorf(
+			"Client.SendAndParseWithRetry() returned an error after a successful " +
+				"retry. Expected [nil] got [error]",
+		)
+	}
+	if attempts != 2 {
+		t.Errorf("Expected [2] attempts, got [%d]", attempts)
+	}
+}
+
+// Ensure Send() applies the configured deadline and the longer deadline to
+// requests marked as long running
+func TestSend_Timeout(t *testing.T) {
+	cred := ClientCredentials{
+		Username: "Admin",
+		Password: "ChangeMe",
+	}
+	conf := ClientConfig{
+		Timeout:            50 * time.Millisecond,
+		LongRunningTimeout: 2 * time.Second,
+	}
+	mux, server, client := NewForemanAPIAndClient(cred, conf)
+	defer server.Close()
+
+	// dummy '[GET] /foo' endpoint - responds slower than the short deadline
+	mux.HandleFunc(FOREMAN_API_URL_PREFIX+"/foo", func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(200 * time.Millisecond)
+		w.WriteHeader(http.StatusOK)
+	})
+
+	req, _ := client.NewRequest(http.MethodGet, "/foo", nil)
+	if _, _, sendErr := client.Send(req); sendErr == nil {
+		t.Errorf(
+			"Client.Send() did not return an error when the server responded " +
+				"after the deadline. Expected [error] got [nil]",
+		)
+	}
+
+	req, _ = client.NewRequest(http.MethodGet, "/foo", nil)
+	if _, _, sendErr := client.Send(WithLongRunningTimeout(req)); sendErr != nil {
+		t.Errorf(
+			"Client.Send() returned an error for a long running request within "+
+				"its deadline. Expected [nil] got [%s]",
+			sendErr,
+		)
+	}
+}
+
+// Ensure Send() revalidates cached GET responses with conditional requests
+// and serves the cached body when the server answers 304 Not Modified, also
+// across clients sharing a cache directory
+func TestSend_ConditionalGetCache(t *testing.T) {
+	cred := ClientCredentials{
+		Username: "Admin",
+		Password: "ChangeMe",
+	}
+	conf := ClientConfig{
+		CacheDir: t.TempDir(),
+	}
+	mux, server, client := NewForemanAPIAndClient(cred, conf)
+	defer server.Close()
+
+	body := []byte(`{"id": 1}`)
+	conditionalRequests := 0
+	// dummy '[GET] /architectures/1' endpoint - answers 304 when the ETag matches
+	mux.HandleFunc(FOREMAN_API_URL_PREFIX+"/architectures/1", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("If-None-Match") == `W/"1"` {
+			conditionalRequests++
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `W/"1"`)
+		w.Write(body)
+	})
+
+	clients := []*Client{
+		client,
+		client,
+		NewClient(client.server, cred, conf),
+	}
+	for idx, c := range clients {
+		req, _ := c.NewRequest(http.MethodGet, "/architectures/1", nil)
+		statusCode, respBody, sendErr := c.Send(req)
+		if sendErr != nil {
+			t.Fatalf("Client.Send() returned an error: %s", sendErr)
+		}
+		if statusCode != http.StatusOK || !bytes.Equal(respBody, body) {
+			t.Fatalf(
+				"Client.Send() #%d returned [%d] [%s], expected [%d] [%s]",
+				idx,
+				statusCode,
+				respBody,
+				http.StatusOK,
+				body,
+			)
+		}
+	}
+
+	if conditionalRequests != 2 {
+		t.Errorf(
+			"Expected [2] conditional requests, the server received [%d]",
+			conditionalRequests,
+		)
+	}
+}
+
+// Ensure only reference data is persisted to the cache directory, responses
+// which may carry secrets (ie: hidden parameters) are only cached in memory
+func TestSend_ConditionalGetCachePersistsReferenceData(t *testing.T) {
+	cred := ClientCredentials{}
+	conf := ClientConfig{
+		CacheDir: t.TempDir(),
+	}
+	mux, server, client := NewForemanAPIAndClient(cred, conf)
+	defer server.Close()
+
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("ETag", `W/"1"`)
+		w.Write([]byte(`{"id": 1, "parameters": [{"name": "secret", "value": "s3cr3t"}]}`))
+	}
+	mux.HandleFunc(FOREMAN_API_URL_PREFIX+"/hosts/1", handler)
+	mux.HandleFunc(FOREMAN_API_URL_PREFIX+"/architectures/1", handler)
+
+	for _, endpoint := range []string{"/hosts/1", "/architectures/1"} {
+		req, _ := client.NewRequest(http.MethodGet, endpoint, nil)
+		req.URL.RawQuery = "show_hidden_parameters=true"
+		if _, _, sendErr := client.Send(req); sendErr != nil {
+			t.Fatalf("Client.Send() returned an error: %s", sendErr)
+		}
+	}
+
+	files, _ := filepath.Glob(filepath.Join(conf.CacheDir, "*.json"))
+	if len(files) != 1 {
+		t.Fatalf("Expected only the reference data to be persisted, got %v", files)
+	}
+	data, _ := ioutil.ReadFile(files[0])
+	if !strings.Contains(string(data), "/architectures/1") {
+		t.Fatalf("Expected the architecture to be persisted, got [%s]", data)
+	}
+}
+
+// Ensure the in-memory response cache only keeps the most recently used
+// responses and never caches the pages of an index endpoint
+func TestSend_ConditionalGetCacheBounded(t *testing.T) {
+	defer func(size int) { responseCacheSize = size }(responseCacheSize)
+	responseCacheSize = 2
+
+	cred := ClientCredentials{}
+	conf := ClientConfig{}
+	mux, server, client := NewForemanAPIAndClient(cred, conf)
+	defer server.Close()
+
+	conditional := map[string]bool{}
+	// dummy '/foo/' endpoints - answer 304 when the ETag matches
+	mux.HandleFunc(FOREMAN_API_URL_PREFIX+"/foo/", func(w http.ResponseWriter, r *http.Request) {
+		conditional[r.URL.RequestURI()] = r.Header.Get("If-None-Match") != ""
+		if r.Header.Get("If-None-Match") == `W/"1"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `W/"1"`)
+		w.Write([]byte(`{"results": []}`))
+	})
+
+	send := func(endpoint string, query string) bool {
+		req, _ := client.NewRequest(http.MethodGet, endpoint, nil)
+		req.URL.RawQuery = query
+		if _, _, sendErr := client.Send(req); sendErr != nil {
+			t.Fatalf("Client.Send() returned an error: %s", sendErr)
+		}
+		return conditional[req.URL.RequestURI()]
+	}
+
+	for _, endpoint := range []string{"/foo/1", "/foo/2", "/foo/3"} {
+		send(endpoint, "")
+	}
+	if send("/foo/1", "") {
+		t.Errorf("Expected the least recently used response to be evicted")
+	}
+	if !send("/foo/3", "") {
+		t.Errorf("Expected the most recently used response to be revalidated")
+	}
+
+	send("/foo/4", "page=1")
+	if send("/foo/4", "page=1") {
+		t.Errorf("Expected the page of an index endpoint not to be cached")
+	}
+}
+
+// Ensure memoized clients serve repeated GET requests without contacting the
+// server until a request modifies the collection
+func TestSend_Memoized(t *testing.T) {
+	cred := ClientCredentials{}
+	conf := ClientConfig{}
+	mux, server, client := NewForemanAPIAndClient(cred, conf)
+	defer server.Close()
+
+	requests := 0
+	// dummy '/hostgroups/1' endpoint - counts the requests it receives
+	mux.HandleFunc(FOREMAN_API_URL_PREFIX+"/hostgroups/1", func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Write([]byte(`{"id": 1}`))
+	})
+
+	send := func(c *Client, method string) {
+		req, _ := c.NewRequest(method, "/hostgroups/1", nil)
+		if _, _, sendErr := c.Send(req); sendErr != nil {
+			t.Fatalf("Client.Send() returned an error: %s", sendErr)
+		}
+	}
+
+	memoized := client.Memoized()
+	send(memoized, http.MethodGet)
+	send(memoized, http.MethodGet)
+	send(client.Memoized(), http.MethodGet)
+	if requests != 1 {
+		t.Fatalf("Expected [1] request for memoized lookups, the server received [%d]", requests)
+	}
+
+	send(client, http.MethodGet)
+	if requests != 2 {
+		t.Fatalf("Expected the client to bypass the memo, the server received [%d] requests", requests)
+	}
+
+	send(client, http.MethodPut)
+	send(memoized, http.MethodGet)
+	if requests != 4 {
+		t.Fatalf("Expected the update to invalidate the memo, the server received [%d] requests", requests)
+	}
+}
+
+// Ensure identical GET requests sent concurrently are coalesced into a single
+// request to the server
+func TestSend_CoalescesInflightGets(t *testing.T) {
+	cred := ClientCredentials{}
+	conf := ClientConfig{}
+	mux, server, client := NewForemanAPIAndClient(cred, conf)
+	defer server.Close()
+
+	var requests int32
+	release := make(chan struct{})
+	// dummy '/hostgroups/1' endpoint - blocks until all requests were sent
+	mux.HandleFunc(FOREMAN_API_URL_PREFIX+"/hostgroups/1", func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		<-release
+		w.Write([]byte(`{"id": 1}`))
+	})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			req, _ := client.NewRequest(http.MethodGet, "/hostgroups/1", nil)
+			statusCode, respBody, sendErr := client.Send(req)
+			if sendErr != nil || statusCode != http.StatusOK || string(respBody) != `{"id": 1}` {
+				t.Errorf("Client.Send() returned [%d] [%s] [%v]", statusCode, respBody, sendErr)
+			}
+		}()
+	}
+	// give the requests time to reach the client before the server answers
+	time.Sleep(100 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	if got := atomic.LoadInt32(&requests); got != 1 {
+		t.Fatalf("Expected [1] request for concurrent GETs, the server received [%d]", got)
+	}
+}
+
+// Ensure gzip compressed responses are transparently decompressed
+func TestSend_GzipResponse(t *testing.T) {
+	cred := ClientCredentials{}
+	conf := ClientConfig{}
+	mux, server, client := NewForemanAPIAndClient(cred, conf)
+	defer server.Close()
+
+	// dummy '/hosts' endpoint - compresses the response if the client accepts
+	mux.HandleFunc(FOREMAN_API_URL_PREFIX+"/hosts", func(w http.ResponseWriter, r *http.Request) {
+		if !strings.Contains(r.Header.Get("Accept-Encoding"), "gzip") {
+			t.Errorf("Expected the client to accept gzip, got [%s]", r.Header.Get("Accept-Encoding"))
+			w.Write([]byte(`{"results": []}`))
+			return
+		}
+		w.Header().Set("Content-Encoding", "gzip")
+		gz := gzip.NewWriter(w)
+		gz.Write([]byte(`{"results": []}`))
+		gz.Close()
+	})
+
+	req, _ := client.NewRequest(http.MethodGet, "/hosts", nil)
+	_, respBody, sendErr := client.Send(req)
+	if sendErr != nil {
+		t.Fatalf("Client.Send() returned an error: %s", sendErr)
+	}
+	if string(respBody) != `{"results": []}` {
+		t.Fatalf("Expected the decompressed response, got [%s]", respBody)
+	}
+}
+
+// Ensure a coalesced GET request is not cancelled by the caller which started
+// it, and requests of different users are not coalesced
+func TestSend_CoalescedGetsDetachedFromCaller(t *testing.T) {
+	cred := ClientCredentials{Username: "admin"}
+	conf := ClientConfig{}
+	mux, server, client := NewForemanAPIAndClient(cred, conf)
+	defer server.Close()
+
+	var requests int32
+	received := make(chan struct{}, 3)
+	release := make(chan struct{})
+	// dummy '/hostgroups/1' endpoint - blocks until released
+	mux.HandleFunc(FOREMAN_API_URL_PREFIX+"/hostgroups/1", func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		received <- struct{}{}
+		<-release
+		w.Write([]byte(`{"id": 1}`))
+	})
+
+	type result struct {
+		body string
+		err  error
+	}
+	send := func(ctx context.Context, username string) chan result {
+		done := make(chan result, 1)
+		req, _ := client.NewRequest(http.MethodGet, "/hostgroups/1", nil)
+		req.SetBasicAuth(username, "")
+		go func() {
+			_, respBody, sendErr := client.Send(req.WithContext(ctx))
+			done <- result{string(respBody), sendErr}
+		}()
+		return done
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	first := send(ctx, "admin")
+	<-received
+	second := send(context.Background(), "admin")
+	other := send(context.Background(), "viewer")
+	<-received
+	// give the second request time to reach the client before it is released
+	time.Sleep(100 * time.Millisecond)
+
+	cancel()
+	if res := <-first; res.err == nil {
+		t.Fatalf("Expected the cancelled caller to return an error")
+	}
+	close(release)
+	if res := <-second; res.err != nil || res.body != `{"id": 1}` {
+		t.Fatalf("Expected the shared request to finish, got [%s] [%v]", res.body, res.err)
+	}
+	if res := <-other; res.err != nil || res.body != `{"id": 1}` {
+		t.Fatalf("Expected the request of the other user to finish, got [%s] [%v]", res.body, res.err)
+	}
+	if got := atomic.LoadInt32(&requests); got != 2 {
+		t.Fatalf("Expected [2] requests, one per user, the server received [%d]", got)
+	}
+}
+
+// Ensure cached reference data is served without contacting the server until
+// its TTL expires or the client modifies its collection, also across clients
+// sharing a cache directory
+func TestSend_ReferenceCacheTTL(t *testing.T) {
+	cred := ClientCredentials{}
+	conf := ClientConfig{
+		CacheDir:          t.TempDir(),
+		ReferenceCacheTTL: time.Hour,
+	}
+	mux, server, client := NewForemanAPIAndClient(cred, conf)
+	defer server.Close()
+
+	requests := map[string]int{}
+	// dummy endpoints - count the requests they receive, without validators
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		requests[r.URL.Path]++
+		w.Write([]byte(`{"id": 1}`))
+	}
+	mux.HandleFunc(FOREMAN_API_URL_PREFIX+"/architectures/1", handler)
+	mux.HandleFunc(FOREMAN_API_URL_PREFIX+"/hosts/1", handler)
+
+	send := func(c *Client, method string, endpoint string) {
+		req, _ := c.NewRequest(method, endpoint, nil)
+		if _, _, sendErr := c.Send(req); sendErr != nil {
+			t.Fatalf("Client.Send() returned an error: %s", sendErr)
+		}
+	}
+
+	send(client, http.MethodGet, "/architectures/1")
+	send(client, http.MethodGet, "/architectures/1")
+	send(NewClient(client.server, cred, conf), http.MethodGet, "/architectures/1")
+	send(client, http.MethodGet, "/hosts/1")
+	send(client, http.MethodGet, "/hosts/1")
+	if requests["/api/architectures/1"] != 1 || requests["/api/hosts/1"] != 2 {
+		t.Fatalf("Expected only the reference data to be cached, the server received [%v]", requests)
+	}
+
+	send(client, http.MethodPut, "/architectures/1")
+	send(client, http.MethodGet, "/architectures/1")
+	if requests["/api/architectures/1"] != 3 {
+		t.Fatalf("Expected the update to invalidate the reference data, the server received [%v]", requests)
+	}
+
+	expired := conf
+	expired.ReferenceCacheTTL = time.Nanosecond
+	send(NewClient(client.server, cred, expired), http.MethodGet, "/architectures/1")
+	if requests["/api/architectures/1"] != 4 {
+		t.Fatalf("Expected the expired reference data to be requested, the server received [%v]", requests)
+	}
+}
+
+// Ensure the client never sends more requests simultaneously than its
+// configured parallelism
+func TestSend_Parallelism(t *testing.T) {
+	cred := ClientCredentials{}
+	conf := ClientConfig{
+		Parallelism: 2,
+	}
+	mux, server, client := NewForemanAPIAndClient(cred, conf)
+	defer server.Close()
+
+	var active, maxActive int32
+	// dummy '/hosts/' endpoint - tracks the number of simultaneous requests
+	mux.HandleFunc(FOREMAN_API_URL_PREFIX+"/hosts/", func(w http.ResponseWriter, r *http.Request) {
+		current := atomic.AddInt32(&active, 1)
+		defer atomic.AddInt32(&active, -1)
+		for {
+			seen := atomic.LoadInt32(&maxActive)
+			if current <= seen || atomic.CompareAndSwapInt32(&maxActive, seen, current) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+		w.Write([]byte(`{}`))
+	})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(id int) {
+			defer wg.Done()
+			// NOTE(ALL): distinct endpoints, identical GETs would be coalesced
+			req, _ := client.NewRequest(http.MethodGet, "/hosts/"+strconv.Itoa(id), nil)
+			if _, _, sendErr := client.Send(req); sendErr != nil {
+				t.Errorf("Client.Send() returned an error: %s", sendErr)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	if got := atomic.LoadInt32(&maxActive); got < 1 || got > 2 {
+		t.Fatalf("Expected at most [2] simultaneous requests, the server received [%d]", got)
+	}
+}
+
+// Ensure SendAndParseWithRetry() backs off as long as the server asks for in
+// the Retry-After header of a 429 response
+func TestSendAndParseWithRetry_RetryAfter(t *testing.T) {
+	cred := ClientCredentials{}
+	conf := ClientConfig{}
+	mux, server, client := NewForemanAPIAndClient(cred, conf)
+	defer server.Close()
+
+	// dummy '/foo' endpoint - sheds the first attempt
+	attempts := 0
+	mux.HandleFunc(FOREMAN_API_URL_PREFIX+"/foo", func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts == 1 {
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	})
+
+	req, _ := client.NewRequest(http.MethodGet, "/foo", nil)
+	start := time.Now()
+	sendErr := client.SendAndParseWithRetry(req, nil, RetryConfig{Count: 2, Delay: time.Millisecond})
+	if sendErr != nil {
+		t.Fatalf("Client.SendAndParseWithRetry() returned an error: %s", sendErr)
+	}
+	if elapsed := time.Since(start); attempts != 2 || elapsed < time.Second {
+		t.Errorf("Expected [2] attempts at least [1s] apart, got [%d] in [%s]", attempts, elapsed)
+	}
+}
+
+// Ensure a huge Retry-After is capped by the deadline of the request and the
+// wait is aborted when the request's context is cancelled
+func TestSendAndParseWithRetry_RetryAfterBounded(t *testing.T) {
+	cred := ClientCredentials{}
+	conf := ClientConfig{
+		Timeout: 100 * time.Millisecond,
+	}
+	mux, server, client := NewForemanAPIAndClient(cred, conf)
+	defer server.Close()
+
+	// dummy '/foo' endpoint - sheds every attempt for a day
+	var attempts int32
+	mux.HandleFunc(FOREMAN_API_URL_PREFIX+"/foo", func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&attempts, 1)
+		w.Header().Set("Retry-After", "86400")
+		w.WriteHeader(http.StatusTooManyRequests)
+	})
+
+	req, _ := client.NewRequest(http.MethodGet, "/foo", nil)
+	start := time.Now()
+	client.SendAndParseWithRetry(req, nil, RetryConfig{Count: 2, Delay: time.Millisecond})
+	if elapsed := time.Since(start); atomic.LoadInt32(&attempts) != 2 || elapsed > 5*time.Second {
+		t.Fatalf("Expected [2] attempts within the deadline, got [%d] in [%s]", attempts, elapsed)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(50*time.Millisecond, cancel)
+	client.config.Timeout = 0
+	req, _ = client.NewRequest(http.MethodGet, "/foo", nil)
+	start = time.Now()
+	sendErr := client.SendAndParseWithRetry(req.WithContext(ctx), nil, RetryConfig{Count: 2, Delay: time.Millisecond})
+	if !errors.Is(sendErr, context.Canceled) {
+		t.Fatalf("Expected the retries to be aborted, got [%v]", sendErr)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Fatalf("Expected the cancellation to abort the wait, waited [%s]", elapsed)
+	}
+}
+
+// Ensure Retry-After values are parsed as seconds or HTTP dates
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	testCases := map[string]time.Duration{
+		"":                              0,
+		"120":                           2 * time.Minute,
+		" 3 ":                           3 * time.Second,
+		"-1":                            0,
+		"soon":                          0,
+		"Tue, 02 Jan 2024 03:04:35 GMT": 30 * time.Second,
+		"Tue, 02 Jan 2024 03:00:00 GMT": 0,
+	}
+	for value, expected := range testCases {
+		if actual := parseRetryAfter(value, now); actual != expected {
+			t.Errorf("parseRetryAfter(%q) returned [%s], expected [%s]", value, actual, expected)
+		}
+	}
+}
+
+// Ensure 403 responses are returned as a PermissionError naming the missing
+// permission and resource, and are not retried
+func TestSendAndParseWithRetry_PermissionError(t *testing.T) {
+	cred := ClientCredentials{}
+	conf := ClientConfig{}
+	mux, server, client := NewForemanAPIAndClient(cred, conf)
+	defer server.Close()
+
+	// dummy '/hosts/3/power' endpoint - the account lacks the permission
+	attempts := 0
+	mux.HandleFunc(FOREMAN_API_URL_PREFIX+"/hosts/3/power", func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.WriteHeader(http.StatusForbidden)
+		fmt.Fprint(w, `{"error":{"message":"Access denied","details":"Missing one of the required permissions: power_hosts"}}`)
+	})
+
+	req, _ := client.NewRequest(http.MethodPut, "/hosts/3/power", nil)
+	sendErr := client.SendAndParseWithRetry(req, nil, RetryConfig{Count: 3})
+	if attempts != 1 {
+		t.Errorf("Expected [1] attempt, got [%d]", attempts)
+	}
+	permErr, ok := sendErr.(*PermissionError)
+	if !ok {
+		t.Fatalf("Expected a PermissionError, got [%v]", sendErr)
+	}
+	if !reflect.DeepEqual(permErr.Permissions, []string{"power_hosts"}) || permErr.Resource != "hosts" {
+		t.Errorf(
+			"Expected the permission [power_hosts] on resource [hosts], got [%v] on [%s]",
+			permErr.Permissions,
+			permErr.Resource,
+		)
+	}
+	if !strings.Contains(sendErr.Error(), "missing permission [power_hosts] on resource [hosts]") {
+		t.Errorf("Expected the error to name the permission, got [%s]", sendErr)
+	}
+	if IsNotFound(sendErr) || !IsPermissionError(sendErr) {
+		t.Errorf("Expected only IsPermissionError to match [%s]", sendErr)
+	}
+}
+
+// Ensure write requests carry the audit comment in their wrapped object and
+// the audit headers, and read requests are sent unchanged
+func TestNewRequest_AuditAttribution(t *testing.T) {
+	cred := ClientCredentials{}
+	conf := ClientConfig{
+		AuditComment: "changed by Terraform run 42",
+		AuditHeaders: map[string]string{"X-Remote-User": "jdoe"},
+	}
+	mux, server, client := NewForemanAPIAndClient(cred, conf)
+	defer server.Close()
+
+	bodies := []string{}
+	users := []string{}
+	mux.HandleFunc(FOREMAN_API_URL_PREFIX+"/domains", func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		bodies = append(bodies, string(body))
+		users = append(users, r.Header.Get("X-Remote-User"))
+		w.WriteHeader(http.StatusOK)
+	})
+
+	req, _ := client.NewRequest(http.MethodPost, "/domains", bytes.NewBufferString(`{"domain":{"name":"example.com"}}`))
+	if sendErr := client.SendAndParse(req, nil); sendErr != nil {
+		t.Fatalf("Client.SendAndParse() returned an error: [%s]", sendErr)
+	}
+	req, _ = client.NewRequest(http.MethodGet, "/domains", nil)
+	if sendErr := client.SendAndParse(req, nil); sendErr != nil {
+		t.Fatalf("Client.SendAndParse() returned an error: [%s]", sendErr)
+	}
+
+	expectedBodies := []string{
+		`{"domain":{"audit_comment":"changed by Terraform run 42","name":"example.com"}}`,
+		"",
+	}
+	if !reflect.DeepEqual(bodies, expectedBodies) {
+		t.Errorf("Expected the bodies [%v], got [%v]", expectedBodies, bodies)
+	}
+	if expectedUsers := []string{"jdoe", ""}; !reflect.DeepEqual(users, expectedUsers) {
+		t.Errorf("Expected the audit headers [%v], got [%v]", expectedUsers, users)
+	}
+}
@@ -27,6 +27,43 @@ type ForemanCommonParameter struct {
 	// The CommonParameter we actually send
 	Name  string `json:"name"`
 	Value string `json:"value"`
+	// Whether the value is masked in the Foreman UI
+	HiddenValue bool `json:"hidden_value"`
+}
+
+// Custom JSON unmarshal function.  Unmarshal the common Foreman object
+// properties and then read the parameter attributes from a generic map.
+func (fcp *ForemanCommonParameter) UnmarshalJSON(b []byte) error {
+	var jsonDecErr error
+
+	// Unmarshal the common Foreman object properties
+	var fo ForemanObject
+	jsonDecErr = json.Unmarshal(b, &fo)
+	if jsonDecErr != nil {
+		return jsonDecErr
+	}
+	fcp.ForemanObject = fo
+
+	var fcpMap map[string]interface{}
+	jsonDecErr = json.Unmarshal(b, &fcpMap)
+	if jsonDecErr != nil {
+		return jsonDecErr
+	}
+
+	var ok bool
+	if fcp.Name, ok = fcpMap["name"].(string); !ok {
+		fcp.Name = ""
+	}
+	if fcp.Value, ok = fcpMap["value"].(string); !ok {
+		fcp.Value = ""
+	}
+	// NOTE(ALL): the API reports the flag as "hidden_value?" but expects
+	//   "hidden_value" on create and update
+	if fcp.HiddenValue, ok = fcpMap["hidden_value?"].(bool); !ok {
+		fcp.HiddenValue = false
+	}
+
+	return nil
 }
 
 // -----------------------------------------------------------------------------
@@ -68,7 +105,11 @@ func (c *Client) CreateCommonParameter(d *ForemanCommonParameter) (*ForemanCommo
 
 	d.Id = createdCommonParameter.Id
 	d.Name = createdCommonParameter.Name
-	d.Value = createdCommonParameter.Value
+	d.HiddenValue = createdCommonParameter.HiddenValue
+	// NOTE(ALL): hidden values come back masked, keep the value we sent
+	if !d.HiddenValue {
+		d.Value = createdCommonParameter.Value
+	}
 	return d, nil
 }
 
@@ -88,6 +129,11 @@ func (c *Client) ReadCommonParameter(d *ForemanCommonParameter, id int) (*Forema
 		return nil, reqErr
 	}
 
+	// NOTE(ALL): hidden values are masked in the response unless requested
+	reqQuery := req.URL.Query()
+	reqQuery.Set("show_hidden", "true")
+	req.URL.RawQuery = reqQuery.Encode()
+
 	var readCommonParameter ForemanCommonParameter
 	sendErr := c.SendAndParse(req, &readCommonParameter)
 	if sendErr != nil {
@@ -99,6 +145,7 @@ func (c *Client) ReadCommonParameter(d *ForemanCommonParameter, id int) (*Forema
 	d.Id = readCommonParameter.Id
 	d.Name = readCommonParameter.Name
 	d.Value = readCommonParameter.Value
+	d.HiddenValue = readCommonParameter.HiddenValue
 	return d, nil
 }
 
@@ -135,7 +182,11 @@ func (c *Client) UpdateCommonParameter(d *ForemanCommonParameter, id int) (*Fore
 
 	d.Id = updatedCommonParameter.Id
 	d.Name = updatedCommonParameter.Name
-	d.Value = updatedCommonParameter.Value
+	d.HiddenValue = updatedCommonParameter.HiddenValue
+	// NOTE(ALL): hidden values come back masked, keep the value we sent
+	if !d.HiddenValue {
+		d.Value = updatedCommonParameter.Value
+	}
 	return d, nil
 }
 
@@ -183,35 +234,8 @@ func (c *Client) QueryCommonParameter(d *ForemanCommonParameter) (QueryResponse,
 	reqQuery := req.URL.Query()
 	name := `"` + d.Name + `"`
 	reqQuery.Set("search", "name="+name)
+	reqQuery.Set("show_hidden", "true")
 
 	req.URL.RawQuery = reqQuery.Encode()
-	sendErr := c.SendAndParse(req, &queryResponse)
-	if sendErr != nil {
-		return queryResponse, sendErr
-	}
-
-	log.Debugf("queryResponse: [%+v]", queryResponse)
-
-	// Results will be Unmarshaled into a []map[string]interface{}
-	//
-	// Encode back to JSON, then Unmarshal into []ForemanCommonParameter for
-	// the results
-	results := []ForemanCommonParameter{}
-	resultsBytes, jsonEncErr := json.Marshal(queryResponse.Results)
-	if jsonEncErr != nil {
-		return queryResponse, jsonEncErr
-	}
-	jsonDecErr := json.Unmarshal(resultsBytes, &results)
-	if jsonDecErr != nil {
-		return queryResponse, jsonDecErr
-	}
-	// convert the search results from []ForemanCommonParameter to []interface
-	// and set the search results on the query
-	iArr := make([]interface{}, len(results))
-	for idx, val := range results {
-		iArr[idx] = val
-	}
-	queryResponse.Results = iArr
-
-	return queryResponse, nil
+	return sendAndParseQuery[ForemanCommonParameter](c, req)
 }
@@ -1,7 +1,6 @@
 package api
 
 import (
-	"encoding/json"
 	"fmt"
 	"net/http"
 
@@ -16,6 +15,9 @@ const (
 // Struct Definition and Helpers
 // -----------------------------------------------------------------------------
 
+// The ForemanComputeProfile API model represents a compute profile.  Compute
+// profiles hold the default compute attributes of the virtual machines
+// created on each compute resource.
 type ForemanComputeProfile struct {
 	// Inherits the base object's attributes
 	ForemanObject
@@ -80,33 +82,5 @@ func (c *Client) QueryComputeProfile(t *ForemanComputeProfile) (QueryResponse, e
 	reqQuery.Set("search", "name="+name)
 
 	req.URL.RawQuery = reqQuery.Encode()
-	sendErr := c.SendAndParse(req, &queryResponse)
-	if sendErr != nil {
-		return queryResponse, sendErr
-	}
-
-	log.Debugf("queryResponse: [%+v]", queryResponse)
-
-	// Results will be Unmarshaled into a []map[string]interface{}
-	//
-	// Encode back to JSON, then Unmarshal into []ForemanComputeProfile for
-	// the results
-	results := []ForemanComputeProfile{}
-	resultsBytes, jsonEncErr := json.Marshal(queryResponse.Results)
-	if jsonEncErr != nil {
-		return queryResponse, jsonEncErr
-	}
-	jsonDecErr := json.Unmarshal(resultsBytes, &results)
-	if jsonDecErr != nil {
-		return queryResponse, jsonDecErr
-	}
-	// convert the search results from []ForemanComputeProfile to []interface
-	// and set the search results on the query
-	iArr := make([]interface{}, len(results))
-	for idx, val := range results {
-		iArr[idx] = val
-	}
-	queryResponse.Results = iArr
-
-	return queryResponse, nil
+	return sendAndParseQuery[ForemanComputeProfile](c, req)
 }
@@ -5,6 +5,8 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"net/url"
+	"strings"
 
 	"github.com/wayfair/terraform-provider-utils/log"
 )
@@ -17,13 +19,14 @@ const (
 // Struct Definition and Helpers
 // -----------------------------------------------------------------------------
 
-// The ForemanComputeResource API model represents the computeresource name. ComputeResources serve as an
-// identification string that defines autonomy, authority, or control for
-// a portion of a network.
-
+// The ForemanComputeResource API model represents a compute resource.  Compute
+// resources are the hypervisors and clouds Foreman creates the virtual
+// machines of hosts on.
 type ForemanComputeResource struct {
 	// Inherits the base object's attributes
 	ForemanObject
+	// Locations and organizations the compute resource is associated with
+	ForemanTaxonomies
 
 	Description string `json:"description"`
 	URL         string `json:"url"`
@@ -40,6 +43,17 @@ type ForemanComputeResource struct {
 	CachingEnabled     bool `json:"caching_enabled,omitempty"`
 }
 
+// String implements the fmt.Stringer interface.  The password is redacted so
+// the compute resource can be logged safely.
+func (fcr ForemanComputeResource) String() string {
+	type plainComputeResource ForemanComputeResource
+	redacted := plainComputeResource(fcr)
+	if redacted.Password != "" {
+		redacted.Password = redactedValue
+	}
+	return fmt.Sprintf("%+v", redacted)
+}
+
 // Custom JSON unmarshal function. Unmarshal to the unexported JSON struct
 // and then convert over to a ForemanComputeResource struct.
 func (fcr *ForemanComputeResource) UnmarshalJSON(b []byte) error {
@@ -52,6 +66,9 @@ func (fcr *ForemanComputeResource) UnmarshalJSON(b []byte) error {
 		return jsonDecErr
 	}
 	fcr.ForemanObject = fo
+	if jsonDecErr = fcr.decodeTaxonomies(b); jsonDecErr != nil {
+		return jsonDecErr
+	}
 
 	// Unmarshal into mapstructure and set the rest of the struct properties
 	// NOTE(ALL): Properties unmarshalled are of type float64 as opposed to int, hence the below testing
@@ -61,7 +78,7 @@ func (fcr *ForemanComputeResource) UnmarshalJSON(b []byte) error {
 	if jsonDecErr != nil {
 		return jsonDecErr
 	}
-	log.Debugf("fcrMap: [%v]", fcrMap)
+	log.Debugf("fcrMap: [%v]", redactValue(fcrMap))
 	var ok bool
 	if fcr.Description, ok = fcrMap["description"].(string); !ok {
 		fcr.Description = ""
@@ -118,7 +135,7 @@ func (c *Client) CreateComputeResource(d *ForemanComputeResource) (*ForemanCompu
 		return nil, jsonEncErr
 	}
 
-	log.Debugf("computeresourceJSONBytes: [%s]", computeresourceJSONBytes)
+	log.Debugf("computeresourceJSONBytes: [%s]", redactJSON(computeresourceJSONBytes))
 
 	req, reqErr := c.NewRequest(
 		http.MethodPost,
@@ -180,7 +197,7 @@ func (c *Client) UpdateComputeResource(d *ForemanComputeResource) (*ForemanCompu
 		return nil, jsonEncErr
 	}
 
-	log.Debugf("computeresourceJSONBytes: [%s]", computeresourceJSONBytes)
+	log.Debugf("computeresourceJSONBytes: [%s]", redactJSON(computeresourceJSONBytes))
 
 	req, reqErr := c.NewRequest(
 		http.MethodPut,
@@ -248,33 +265,113 @@ func (c *Client) QueryComputeResource(d *ForemanComputeResource) (QueryResponse,
 	reqQuery.Set("search", "name="+name)
 
 	req.URL.RawQuery = reqQuery.Encode()
+	return sendAndParseQuery[ForemanComputeResource](c, req)
+}
+
+// -----------------------------------------------------------------------------
+// Available Objects
+// -----------------------------------------------------------------------------
+
+// ForemanComputeResourceObject is an object available on a compute resource
+// (ie: a cluster, template or storage domain of an oVirt compute resource).
+// The IDs are assigned by the hypervisor and are usually not numeric.
+type ForemanComputeResourceObject struct {
+	Id   string
+	Name string
+}
+
+// Custom JSON unmarshal function.  The hypervisors report their IDs either
+// as numbers or as strings (ie: UUIDs).  Objects without an ID (ie: VMware
+// images) are identified by their UUID.
+func (o *ForemanComputeResourceObject) UnmarshalJSON(b []byte) error {
+	var oMap map[string]interface{}
+	decoder := json.NewDecoder(bytes.NewReader(b))
+	// NOTE(ALL): keep numeric IDs as they are sent instead of float64
+	decoder.UseNumber()
+	if jsonDecErr := decoder.Decode(&oMap); jsonDecErr != nil {
+		return jsonDecErr
+	}
+
+	o.Name, _ = oMap["name"].(string)
+	o.Id = ""
+	for _, key := range []string{"id", "uuid"} {
+		if id, ok := oMap[key]; ok && id != nil {
+			o.Id = fmt.Sprint(id)
+			break
+		}
+	}
+	return nil
+}
+
+// ListComputeResourceObjects lists the objects of the supplied kind (ie:
+// "clusters", "images", "storage_domains") available on the compute resource
+// identified by the supplied ID.  Objects scoped to a cluster (ie:
+// "networks") are listed for the cluster identified by clusterId, unless it
+// is empty.
+//
+// Example: https://<foreman>/api/compute_resources/<id>/available_clusters
+func (c *Client) ListComputeResourceObjects(id int, kind string, clusterId string) ([]ForemanComputeResourceObject, error) {
+	log.Tracef("foreman/api/computeresource.go#ListObjects")
+
+	reqEndpoint := fmt.Sprintf("/%s/%d/available_%s", ComputeResourceEndpointPrefix, id, kind)
+	if clusterId != "" {
+		reqEndpoint = fmt.Sprintf(
+			"/%s/%d/available_clusters/%s/available_%s",
+			ComputeResourceEndpointPrefix,
+			id,
+			url.PathEscape(clusterId),
+			kind,
+		)
+	}
+
+	req, reqErr := c.NewRequest(
+		http.MethodGet,
+		reqEndpoint,
+		nil,
+	)
+	if reqErr != nil {
+		return nil, reqErr
+	}
+
+	var queryResponse typedQueryResponse[ForemanComputeResourceObject]
 	sendErr := c.SendAndParse(req, &queryResponse)
 	if sendErr != nil {
-		return queryResponse, sendErr
+		return nil, sendErr
 	}
 
-	log.Debugf("queryResponse: [%+v]", queryResponse)
+	log.Debugf("available %s: [%+v]", kind, queryResponse.Results)
 
-	// Results will be Unmarshaled into a []map[string]interface{}
-	//
-	// Encode back to JSON, then Unmarshal into []ForemanComputeResource for
-	// the results
-	results := []ForemanComputeResource{}
-	resultsBytes, jsonEncErr := json.Marshal(queryResponse.Results)
-	if jsonEncErr != nil {
-		return queryResponse, jsonEncErr
+	return queryResponse.Results, nil
+}
+
+// FindComputeResourceObject returns the object of the supplied kind
+// available on the compute resource identified by the supplied ID, which has
+// the supplied ID or name.  IDs take precedence over names.  An error listing
+// the available objects is returned if none matches.
+func (c *Client) FindComputeResourceObject(id int, kind string, clusterId string, idOrName string) (*ForemanComputeResourceObject, error) {
+	log.Tracef("foreman/api/computeresource.go#FindObject")
+
+	objects, listErr := c.ListComputeResourceObjects(id, kind, clusterId)
+	if listErr != nil {
+		return nil, listErr
 	}
-	jsonDecErr := json.Unmarshal(resultsBytes, &results)
-	if jsonDecErr != nil {
-		return queryResponse, jsonDecErr
+	for idx := range objects {
+		if objects[idx].Id == idOrName {
+			return &objects[idx], nil
+		}
 	}
-	// convert the search results from []ForemanComputeResource to []interface
-	// and set the search results on the query
-	iArr := make([]interface{}, len(results))
-	for idx, val := range results {
-		iArr[idx] = val
+	names := make([]string, 0, len(objects))
+	for idx := range objects {
+		if objects[idx].Name == idOrName {
+			return &objects[idx], nil
+		}
+		names = append(names, objects[idx].Name)
 	}
-	queryResponse.Results = iArr
-
-	return queryResponse, nil
+	return nil, fmt.Errorf(
+		"Compute resource [%d] has no %s with the ID or name [%s], available: [%s]",
+		id,
+		strings.TrimSuffix(kind, "s"),
+		idOrName,
+		strings.Join(names, ", "),
+	)
 }
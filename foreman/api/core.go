@@ -1,6 +1,9 @@
 package api
 
 import (
+	"bytes"
+	"encoding/json"
+	"fmt"
 	"strconv"
 )
 
@@ -66,16 +69,81 @@ func foremanObjectArrayToIdIntArray(foa []ForemanObject) []int {
 	return intArr
 }
 
-// unmarshalInteger is used to grab a clean copy of the integer from the 
-// interface{} inside the JSON map. For some reason a simple integer conversion
-// does not do the trick here.
-// TODO: Investigate the reason for this issue
-func unmarshalInteger(mapValue interface{}) (retVal int) {
-	if _, ok := mapValue.(float64); !ok {
-		return 0
-	} else {
-		return int(mapValue.(float64))
+// jsonValue decodes a single JSON property leniently.  A null or a value of
+// an unexpected type (ie: a string where a number is expected) leaves the
+// zero value and Valid unset instead of failing the decode of the whole
+// object.  Numeric IDs are decoded as float64 so fractional values are
+// truncated rather than rejected.
+type jsonValue[T any] struct {
+	Value T
+	Valid bool
+}
+
+// Implement the Unmarshaler interface
+func (jv *jsonValue[T]) UnmarshalJSON(b []byte) error {
+	var value T
+	if bytes.Equal(b, []byte("null")) || json.Unmarshal(b, &value) != nil {
+		return nil
+	}
+	jv.Value = value
+	jv.Valid = true
+	return nil
+}
+
+// redactedValue replaces the value of secrets in log output
+const redactedValue = "[REDACTED]"
+
+// sensitiveKeys are the JSON keys of the attributes carrying secrets.  Their
+// values are never written to the logs.
+var sensitiveKeys = map[string]bool{
+	"password":      true,
+	"root_pass":     true,
+	"root_password": true,
+}
+
+// redactValue returns a copy of the supplied decoded JSON value with the
+// values of all sensitive keys replaced.  Maps and arrays are walked
+// recursively, any other value is returned unmodified.
+func redactValue(v interface{}) interface{} {
+	switch val := v.(type) {
+	case map[string]interface{}:
+		redacted := make(map[string]interface{}, len(val))
+		for key, elem := range val {
+			if sensitiveKeys[key] {
+				redacted[key] = redactedValue
+			} else {
+				redacted[key] = redactValue(elem)
+			}
+		}
+		return redacted
+	case []interface{}:
+		redacted := make([]interface{}, len(val))
+		for idx, elem := range val {
+			redacted[idx] = redactValue(elem)
+		}
+		return redacted
+	}
+	return v
+}
+
+// redactedRawBodyLimit is the number of bytes of a body which is not JSON
+// written to the logs
+const redactedRawBodyLimit = 2048
+
+// redactJSON returns the supplied JSON document as a string safe for logging.
+// The values of all sensitive keys are replaced.  A body which is not JSON
+// (ie: the HTML error page of a proxy) cannot carry the sensitive keys and is
+// returned as is, truncated to redactedRawBodyLimit bytes.
+func redactJSON(b []byte) string {
+	var v interface{}
+	if err := json.Unmarshal(b, &v); err != nil {
+		if len(b) > redactedRawBodyLimit {
+			return fmt.Sprintf("%s... <%d bytes>", b[:redactedRawBodyLimit], len(b))
+		}
+		return string(b)
 	}
+	redactedBytes, _ := json.Marshal(redactValue(v))
+	return string(redactedBytes)
 }
 
 // ----------------------------------------------------------------------------
@@ -3,6 +3,7 @@ package api
 import (
 	"math/rand"
 	"strconv"
+	"strings"
 	"testing"
 )
 
@@ -139,3 +140,40 @@ func TestForemanObjectArrayToIdIntArray_Value(t *testing.T) {
 	}
 
 }
+
+// ----------------------------------------------------------------------------
+// redactJSON
+// ----------------------------------------------------------------------------
+
+// Ensure the values of sensitive keys are removed, including nested ones
+func TestRedactJSON_RemovesSecrets(t *testing.T) {
+
+	input := []byte(`{"host":{"name":"a","root_pass":"s3cr3t",` +
+		`"interfaces_attributes":[{"type":"bmc","password":"s3cr3t"}]}}`)
+
+	output := redactJSON(input)
+	if strings.Contains(output, "s3cr3t") {
+		t.Fatalf("redactJSON did not redact secrets. Got [%s]", output)
+	}
+	if !strings.Contains(output, `"name":"a"`) {
+		t.Fatalf("redactJSON removed non-sensitive values. Got [%s]", output)
+	}
+
+}
+
+// Ensure a body which is not JSON is kept for diagnostics, truncated
+func TestRedactJSON_KeepsRawBody(t *testing.T) {
+
+	page := "<html><body>502 Bad Gateway</body></html>"
+	if output := redactJSON([]byte(page)); output != page {
+		t.Fatalf("redactJSON did not keep the raw body. Got [%s]", output)
+	}
+
+	long := strings.Repeat("x", redactedRawBodyLimit+10)
+	output := redactJSON([]byte(long))
+	expected := strings.Repeat("x", redactedRawBodyLimit) + "... <2058 bytes>"
+	if output != expected {
+		t.Fatalf("redactJSON did not truncate the raw body. Got [%s]", output)
+	}
+
+}
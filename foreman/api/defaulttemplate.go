@@ -2,7 +2,6 @@ package api
 
 import (
 	"bytes"
-	"encoding/json"
 	"fmt"
 	"net/http"
 
@@ -146,6 +145,33 @@ func (c *Client) DeleteDefaultTemplate(d *ForemanDefaultTemplate, id int) error
 	return c.SendAndParse(req, nil)
 }
 
+// ListOperatingSystemDefaultTemplates returns the default templates of the
+// operating system identified by the supplied ID, one per template kind.
+func (c *Client) ListOperatingSystemDefaultTemplates(osId int) ([]ForemanDefaultTemplate, error) {
+	log.Tracef("foreman/api/defaulttemplate.go#List")
+
+	reqEndpoint := fmt.Sprintf(DefaultTemplateEndpointPrefix, osId)
+
+	req, reqErr := c.NewRequest(
+		http.MethodGet,
+		reqEndpoint,
+		nil,
+	)
+	if reqErr != nil {
+		return nil, reqErr
+	}
+
+	var queryResponse typedQueryResponse[ForemanDefaultTemplate]
+	sendErr := c.SendAndParse(req, &queryResponse)
+	if sendErr != nil {
+		return nil, sendErr
+	}
+
+	log.Debugf("defaultTemplates: [%+v]", queryResponse.Results)
+
+	return queryResponse.Results, nil
+}
+
 // -----------------------------------------------------------------------------
 // Query Implementation
 // -----------------------------------------------------------------------------
@@ -174,33 +200,5 @@ func (c *Client) QueryDefaultTemplate(d *ForemanDefaultTemplate) (QueryResponse,
 	reqQuery.Set("search", "name="+name)
 
 	req.URL.RawQuery = reqQuery.Encode()
-	sendErr := c.SendAndParse(req, &queryResponse)
-	if sendErr != nil {
-		return queryResponse, sendErr
-	}
-
-	log.Debugf("queryResponse: [%+v]", queryResponse)
-
-	// Results will be Unmarshaled into a []map[string]interface{}
-	//
-	// Encode back to JSON, then Unmarshal into []ForemanDefaultTemplate for
-	// the results
-	results := []ForemanDefaultTemplate{}
-	resultsBytes, jsonEncErr := json.Marshal(queryResponse.Results)
-	if jsonEncErr != nil {
-		return queryResponse, jsonEncErr
-	}
-	jsonDecErr := json.Unmarshal(resultsBytes, &results)
-	if jsonDecErr != nil {
-		return queryResponse, jsonDecErr
-	}
-	// convert the search results from []ForemanDefaultTemplate to []interface
-	// and set the search results on the query
-	iArr := make([]interface{}, len(results))
-	for idx, val := range results {
-		iArr[idx] = val
-	}
-	queryResponse.Results = iArr
-
-	return queryResponse, nil
+	return sendAndParseQuery[ForemanDefaultTemplate](c, req)
 }
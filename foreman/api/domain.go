@@ -23,9 +23,53 @@ const (
 type ForemanDomain struct {
 	// Inherits the base object's attributes
 	ForemanObject
+	// Locations and organizations the domain is associated with
+	ForemanTaxonomies
 
 	// Fully qualified domain name
 	Fullname string `json:"fullname"`
+	// ID of the smart proxy managing the DNS records of the domain.  0 if
+	// Foreman does not manage the DNS zone of the domain.
+	DnsId int `json:"dns_id"`
+	// Parameters of the domain consumed by templates.  Nil leaves the
+	// parameters of the domain unchanged, an empty slice removes them all.
+	DomainParameters []ForemanKVParameter `json:"-"`
+}
+
+// Custom JSON marshal function.  The parameters are only sent when they are
+// managed, Foreman replaces all parameters of the domain with the ones sent.
+// A DNS proxy ID of 0 is sent as null, removing the DNS proxy.
+func (fd ForemanDomain) MarshalJSON() ([]byte, error) {
+	type plainDomain ForemanDomain
+	fdJSON := struct {
+		plainDomain
+		DnsId            interface{}           `json:"dns_id"`
+		DomainParameters *[]ForemanKVParameter `json:"domain_parameters_attributes,omitempty"`
+	}{
+		plainDomain: plainDomain(fd),
+		DnsId:       intIdToJSONString(fd.DnsId),
+	}
+	if fd.DomainParameters != nil {
+		fdJSON.DomainParameters = &fd.DomainParameters
+	}
+	return json.Marshal(fdJSON)
+}
+
+// Custom JSON unmarshal function.  The locations and organizations are
+// decoded to their IDs, the parameters are reported as "parameters".
+func (fd *ForemanDomain) UnmarshalJSON(b []byte) error {
+	type plainDomain ForemanDomain
+	if jsonDecErr := json.Unmarshal(b, (*plainDomain)(fd)); jsonDecErr != nil {
+		return jsonDecErr
+	}
+	var paramsJSON struct {
+		Parameters []ForemanKVParameter `json:"parameters"`
+	}
+	if jsonDecErr := json.Unmarshal(b, &paramsJSON); jsonDecErr != nil {
+		return jsonDecErr
+	}
+	fd.DomainParameters = paramsJSON.Parameters
+	return fd.decodeTaxonomies(b)
 }
 
 // -----------------------------------------------------------------------------
@@ -63,6 +107,8 @@ func (c *Client) CreateDomain(d *ForemanDomain) (*ForemanDomain, error) {
 		return nil, sendErr
 	}
 
+	restoreHiddenParameters(d.DomainParameters, createdDomain.DomainParameters)
+
 	log.Debugf("createdDomain: [%+v]", createdDomain)
 
 	return &createdDomain, nil
@@ -83,6 +129,11 @@ func (c *Client) ReadDomain(id int) (*ForemanDomain, error) {
 	if reqErr != nil {
 		return nil, reqErr
 	}
+	// NOTE(ALL): read the values of hidden parameters, they are masked
+	//   otherwise
+	reqQuery := req.URL.Query()
+	reqQuery.Set("show_hidden_parameters", "true")
+	req.URL.RawQuery = reqQuery.Encode()
 
 	var readDomain ForemanDomain
 	sendErr := c.SendAndParse(req, &readDomain)
@@ -125,6 +176,8 @@ func (c *Client) UpdateDomain(d *ForemanDomain) (*ForemanDomain, error) {
 		return nil, sendErr
 	}
 
+	restoreHiddenParameters(d.DomainParameters, updatedDomain.DomainParameters)
+
 	log.Debugf("updatedDomain: [%+v]", updatedDomain)
 
 	return &updatedDomain, nil
@@ -176,33 +229,5 @@ func (c *Client) QueryDomain(d *ForemanDomain) (QueryResponse, error) {
 	reqQuery.Set("search", "name="+name)
 
 	req.URL.RawQuery = reqQuery.Encode()
-	sendErr := c.SendAndParse(req, &queryResponse)
-	if sendErr != nil {
-		return queryResponse, sendErr
-	}
-
-	log.Debugf("queryResponse: [%+v]", queryResponse)
-
-	// Results will be Unmarshaled into a []map[string]interface{}
-	//
-	// Encode back to JSON, then Unmarshal into []ForemanDomain for
-	// the results
-	results := []ForemanDomain{}
-	resultsBytes, jsonEncErr := json.Marshal(queryResponse.Results)
-	if jsonEncErr != nil {
-		return queryResponse, jsonEncErr
-	}
-	jsonDecErr := json.Unmarshal(resultsBytes, &results)
-	if jsonDecErr != nil {
-		return queryResponse, jsonDecErr
-	}
-	// convert the search results from []ForemanDomain to []interface
-	// and set the search results on the query
-	iArr := make([]interface{}, len(results))
-	for idx, val := range results {
-		iArr[idx] = val
-	}
-	queryResponse.Results = iArr
-
-	return queryResponse, nil
+	return sendAndParseQuery[ForemanDomain](c, req)
 }
@@ -21,6 +21,18 @@ const (
 type ForemanEnvironment struct {
 	// Inherits the base object's attributes
 	ForemanObject
+	// Locations and organizations the environment is associated with
+	ForemanTaxonomies
+}
+
+// Custom JSON unmarshal function.  The locations and organizations are
+// decoded to their IDs.
+func (fe *ForemanEnvironment) UnmarshalJSON(b []byte) error {
+	type plainEnvironment ForemanEnvironment
+	if jsonDecErr := json.Unmarshal(b, (*plainEnvironment)(fe)); jsonDecErr != nil {
+		return jsonDecErr
+	}
+	return fe.decodeTaxonomies(b)
 }
 
 // -----------------------------------------------------------------------------
@@ -173,33 +185,5 @@ func (c *Client) QueryEnvironment(e *ForemanEnvironment) (QueryResponse, error)
 	reqQuery.Set("search", "name="+name)
 
 	req.URL.RawQuery = reqQuery.Encode()
-	sendErr := c.SendAndParse(req, &queryResponse)
-	if sendErr != nil {
-		return queryResponse, sendErr
-	}
-
-	log.Debugf("queryResponse: [%+v]", queryResponse)
-
-	// Results will be Unmarshaled into a []map[string]interface{}
-	//
-	// Encode back to JSON, then Unmarshal into []ForemanEnvironment for
-	// the results
-	results := []ForemanEnvironment{}
-	resultsBytes, jsonEncErr := json.Marshal(queryResponse.Results)
-	if jsonEncErr != nil {
-		return queryResponse, jsonEncErr
-	}
-	jsonDecErr := json.Unmarshal(resultsBytes, &results)
-	if jsonDecErr != nil {
-		return queryResponse, jsonDecErr
-	}
-	// convert the search results from []ForemanEnvironment to []interface
-	// and set the search results on the query
-	iArr := make([]interface{}, len(results))
-	for idx, val := range results {
-		iArr[idx] = val
-	}
-	queryResponse.Results = iArr
-
-	return queryResponse, nil
+	return sendAndParseQuery[ForemanEnvironment](c, req)
 }
@@ -0,0 +1,95 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Sentinel errors of the API.  The HTTPError of a response matches the
+// sentinel of its status code with errors.Is.
+var (
+	// ErrNotFound : the requested object does not exist (404)
+	ErrNotFound = errors.New("foreman: not found")
+	// ErrConflict : the request conflicts with the state of the object, ie:
+	// an object of the same name exists or a task is running (409)
+	ErrConflict = errors.New("foreman: conflict")
+)
+
+// -----------------------------------------------------------------------------
+// Struct Definition and Helpers
+// -----------------------------------------------------------------------------
+
+// ValidationError is the error of a request Foreman rejected as invalid with
+// a 422 status code.  The HTTPError of such a response converts to a
+// ValidationError with errors.As.
+type ValidationError struct {
+	// The messages of the rejected attributes, keyed by attribute name (ie:
+	// "name": ["has already been taken"]).  Empty if the server did not
+	// report the attributes.
+	Fields map[string][]string
+	// The full messages reported by the server (ie: "Name has already been
+	// taken")
+	Messages []string
+	// The error of the request
+	Err *HTTPError
+}
+
+// Error implements the error interface
+func (e *ValidationError) Error() string {
+	messages := e.Messages
+	if len(messages) == 0 {
+		names := make([]string, 0, len(e.Fields))
+		for name := range e.Fields {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			messages = append(messages, fmt.Sprintf("%s %s", name, strings.Join(e.Fields[name], ", ")))
+		}
+	}
+	if len(messages) == 0 {
+		return e.Err.Error()
+	}
+	return fmt.Sprintf(
+		"Validation failed: %s\n%s",
+		strings.Join(messages, "; "),
+		e.Err,
+	)
+}
+
+// Unwrap returns the HTTPError of the request
+func (e *ValidationError) Unwrap() error {
+	return e.Err
+}
+
+// newValidationError creates the ValidationError of the supplied error of a
+// 422 response.  The rejected attributes are taken from the "errors" of the
+// response, the messages from its "full_messages" or "message".
+func newValidationError(httpErr *HTTPError) *ValidationError {
+	validationErr := ValidationError{
+		Fields: map[string][]string{},
+		Err:    httpErr,
+	}
+
+	var respJSON struct {
+		Error struct {
+			Message      string              `json:"message"`
+			Errors       map[string][]string `json:"errors"`
+			FullMessages []string            `json:"full_messages"`
+		} `json:"error"`
+	}
+	if json.Unmarshal(httpErr.RespBody, &respJSON) != nil {
+		return &validationErr
+	}
+	for name, messages := range respJSON.Error.Errors {
+		validationErr.Fields[name] = messages
+	}
+	validationErr.Messages = respJSON.Error.FullMessages
+	if len(validationErr.Messages) == 0 && respJSON.Error.Message != "" {
+		validationErr.Messages = []string{respJSON.Error.Message}
+	}
+	return &validationErr
+}
@@ -0,0 +1,72 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+// ----------------------------------------------------------------------------
+// Typed Errors
+// ----------------------------------------------------------------------------
+
+// Ensures the errors of the API match the sentinel of their status code,
+// also when wrapped
+func TestHTTPError_Is(t *testing.T) {
+	testCases := []struct {
+		statusCode int
+		sentinel   error
+		expected   bool
+	}{
+		{http.StatusNotFound, ErrNotFound, true},
+		{http.StatusNotFound, ErrConflict, false},
+		{http.StatusConflict, ErrConflict, true},
+		{http.StatusInternalServerError, ErrNotFound, false},
+	}
+
+	for _, tc := range testCases {
+		err := fmt.Errorf("reading host: %w", &HTTPError{StatusCode: tc.statusCode})
+		if errors.Is(err, tc.sentinel) != tc.expected {
+			t.Errorf(
+				"Expected status code [%d] to match [%s] to be [%t]",
+				tc.statusCode,
+				tc.sentinel,
+				tc.expected,
+			)
+		}
+	}
+}
+
+// Ensures the rejected fields of a 422 response are read from the error
+// returned by the client, which stays an HTTPError
+func TestSendAndParse_ValidationError(t *testing.T) {
+	mux, server, client := NewForemanAPIAndClient(ClientCredentials{}, ClientConfig{})
+	defer server.Close()
+
+	mux.HandleFunc(FOREMAN_API_URL_PREFIX+"/hosts", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		fmt.Fprint(w, `{"error": {"id": null, "errors": {"name": ["has already been taken"]}, "full_messages": ["Name has already been taken"]}}`)
+	})
+
+	req, _ := client.NewRequest(http.MethodPost, "/hosts", nil)
+	sendErr := client.SendAndParse(req, nil)
+
+	var validationErr *ValidationError
+	if !errors.As(sendErr, &validationErr) {
+		t.Fatalf("Expected a ValidationError, got [%v]", sendErr)
+	}
+	if !reflect.DeepEqual(validationErr.Fields, map[string][]string{"name": {"has already been taken"}}) {
+		t.Errorf("Expected the rejected name, got [%v]", validationErr.Fields)
+	}
+	if !reflect.DeepEqual(validationErr.Messages, []string{"Name has already been taken"}) {
+		t.Errorf("Expected the full messages, got [%v]", validationErr.Messages)
+	}
+	if httpErr, ok := sendErr.(*HTTPError); !ok || httpErr.StatusCode != http.StatusUnprocessableEntity {
+		t.Errorf("Expected the error to stay an HTTPError, got [%T]", sendErr)
+	}
+	if errors.As(&HTTPError{StatusCode: http.StatusBadRequest}, &validationErr) {
+		t.Errorf("Expected only 422 responses to convert to a ValidationError")
+	}
+}
@@ -0,0 +1,197 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/wayfair/terraform-provider-utils/log"
+)
+
+const (
+	// HealthCheckEndpoint : Endpoint requested to check whether a server or
+	// one of its replicas is healthy before failing over to it
+	HealthCheckEndpoint = "/status"
+)
+
+// failoverProbeTimeout is the deadline of a health check of a server or
+// one of its replicas
+var failoverProbeTimeout = 10 * time.Second
+
+// -----------------------------------------------------------------------------
+// Failover
+// -----------------------------------------------------------------------------
+
+// endpointPool holds the URLs of a server and its replicas, shared by all
+// copies of a client.  Requests are sent to the active endpoint, the pool
+// fails over to the next healthy endpoint when the active one cannot be
+// reached.
+type endpointPool struct {
+	mu sync.Mutex
+	// The server followed by its replicas
+	urls []url.URL
+	// Index of the endpoint requests are sent to
+	active int
+}
+
+// newEndpointPool returns the pool of the supplied server, or nil if the
+// server has no replicas to fail over to
+func newEndpointPool(s Server) *endpointPool {
+	if len(s.Replicas) == 0 {
+		return nil
+	}
+	urls := make([]url.URL, 0, len(s.Replicas)+1)
+	urls = append(urls, s.URL)
+	urls = append(urls, s.Replicas...)
+	return &endpointPool{urls: urls}
+}
+
+// current returns the URL of the active endpoint
+func (ep *endpointPool) current() url.URL {
+	ep.mu.Lock()
+	defer ep.mu.Unlock()
+
+	return ep.urls[ep.active]
+}
+
+// serverURL returns the URL of the endpoint requests are sent to
+func (client *Client) serverURL() url.URL {
+	if client.endpoints == nil {
+		return client.server.URL
+	}
+	return client.endpoints.current()
+}
+
+// isFailoverError returns whether a request which failed with the supplied
+// error is sent again to a replica of the server.  Requests which could not
+// connect never reached the server and are always sent again.  Requests
+// which failed once connected may have been processed, so only idempotent
+// requests are sent again.
+func isFailoverError(req *http.Request, err error) bool {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	var opErr *net.OpError
+	if errors.As(err, &opErr) && opErr.Op == "dial" {
+		return true
+	}
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodPut, http.MethodDelete, http.MethodOptions:
+		return true
+	}
+	return false
+}
+
+// failover switches the active endpoint away from the endpoint of the
+// supplied URL, which could not be reached, to the first healthy endpoint
+// after it.  If another request already failed over, the active endpoint is
+// returned without checking it again.  False is returned if no endpoint is
+// healthy.
+func (client *Client) failover(failed *url.URL) (url.URL, bool) {
+	ep := client.endpoints
+
+	ep.mu.Lock()
+	from := ep.active
+	if active := ep.urls[from]; !sameEndpoint(active, *failed) {
+		ep.mu.Unlock()
+		return active, true
+	}
+	ep.mu.Unlock()
+
+	// NOTE(ALL): a health check may block for up to failoverProbeTimeout,
+	//   the pool is not locked meanwhile so that requests to the active
+	//   endpoint are not held up by the probes
+	for offset := 1; offset < len(ep.urls); offset++ {
+		idx := (from + offset) % len(ep.urls)
+		if !client.healthy(ep.urls[idx]) {
+			continue
+		}
+
+		ep.mu.Lock()
+		defer ep.mu.Unlock()
+		if ep.active != from {
+			// Another request failed over while the replicas were probed
+			return ep.urls[ep.active], true
+		}
+		log.Warningf(
+			"Foreman server [%s] cannot be reached, failing over to [%s]",
+			ep.urls[from].Host,
+			ep.urls[idx].Host,
+		)
+		ep.active = idx
+		return ep.urls[idx], true
+	}
+	return url.URL{}, false
+}
+
+// sameEndpoint returns whether the supplied URLs address the same endpoint
+func sameEndpoint(a, b url.URL) bool {
+	return a.Scheme == b.Scheme && a.Host == b.Host
+}
+
+// healthy returns whether the Foreman API at the supplied URL answers its
+// status endpoint
+func (client *Client) healthy(serverURL url.URL) bool {
+	ctx, cancel := context.WithTimeout(context.Background(), failoverProbeTimeout)
+	defer cancel()
+
+	serverURL.Path = FOREMAN_API_URL_PREFIX + HealthCheckEndpoint
+	req, reqErr := http.NewRequestWithContext(ctx, http.MethodGet, serverURL.String(), nil)
+	if reqErr != nil {
+		return false
+	}
+	req.Header.Add("User-Agent", "terraform-provider-foreman")
+	req.Header.Add("Accept", "application/json,version="+FOREMAN_API_VERSION)
+	req.SetBasicAuth(client.credentials.Username, client.credentials.Password)
+
+	resp, respErr := client.httpClient.Do(req)
+	if respErr != nil {
+		log.Debugf("Health check of [%s] failed: %s", serverURL.Host, respErr)
+		return false
+	}
+	resp.Body.Close()
+	log.Debugf("Health check of [%s] returned [%d]", serverURL.Host, resp.StatusCode)
+	return resp.StatusCode < http.StatusInternalServerError
+}
+
+// do sends the supplied request through the HTTP client.  When the server
+// cannot be reached, the request is sent again to the healthy replica the
+// client fails over to.  Only the scheme and host of the request are
+// replaced, replicas serve the API under the same path as the server (see
+// NewRequest, which always requests FOREMAN_API_URL_PREFIX).
+func (client *Client) do(request *http.Request) (*http.Response, error) {
+	resp, respErr := client.httpClient.Do(request)
+	if client.endpoints == nil {
+		return resp, respErr
+	}
+	for attempt := 1; respErr != nil && attempt < len(client.endpoints.urls); attempt++ {
+		if !isFailoverError(request, respErr) {
+			break
+		}
+		if request.Body != nil && request.Body != http.NoBody && request.GetBody == nil {
+			break
+		}
+		serverURL, ok := client.failover(request.URL)
+		if !ok {
+			break
+		}
+		retry := request.Clone(request.Context())
+		retry.URL.Scheme = serverURL.Scheme
+		retry.URL.Host = serverURL.Host
+		retry.Host = ""
+		if request.GetBody != nil {
+			body, bodyErr := request.GetBody()
+			if bodyErr != nil {
+				break
+			}
+			retry.Body = body
+		}
+		request = retry
+		resp, respErr = client.httpClient.Do(request)
+	}
+	return resp, respErr
+}
@@ -0,0 +1,157 @@
+package api
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+// ----------------------------------------------------------------------------
+// Failover
+// ----------------------------------------------------------------------------
+
+// Ensures requests fail over to the first healthy replica when the server
+// cannot be reached, and keep being sent to it
+func TestSend_Failover(t *testing.T) {
+	_, down := NewForemanAPI()
+	downURL, _ := url.Parse(down.URL)
+	down.Close()
+
+	unhealthyMux, unhealthy := NewForemanAPI()
+	defer unhealthy.Close()
+	unhealthyMux.HandleFunc(FOREMAN_API_URL_PREFIX+HealthCheckEndpoint, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+	unhealthyURL, _ := url.Parse(unhealthy.URL)
+
+	replicaMux, replica := NewForemanAPI()
+	defer replica.Close()
+	replicaMux.HandleFunc(FOREMAN_API_URL_PREFIX+HealthCheckEndpoint, func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"result": "ok"}`)
+	})
+	var bodies []string
+	replicaMux.HandleFunc(FOREMAN_API_URL_PREFIX+"/hosts", func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, strings.TrimSpace(r.Method+" "+string(body)))
+		fmt.Fprint(w, `{"id": 1}`)
+	})
+	replicaURL, _ := url.Parse(replica.URL)
+
+	client := NewClient(
+		Server{URL: *downURL, Replicas: []url.URL{*unhealthyURL, *replicaURL}},
+		ClientCredentials{},
+		ClientConfig{},
+	)
+
+	req, _ := client.NewRequest(http.MethodPost, "/hosts", strings.NewReader(`{"host": {}}`))
+	if sendErr := client.SendAndParse(req, &struct{}{}); sendErr != nil {
+		t.Fatalf("expected the request to fail over, got [%s]", sendErr)
+	}
+	req, _ = client.NewRequest(http.MethodGet, "/hosts", nil)
+	if req.URL.Host != replicaURL.Host {
+		t.Fatalf("expected requests to be sent to [%s], got [%s]", replicaURL.Host, req.URL.Host)
+	}
+	if sendErr := client.SendAndParse(req, &struct{}{}); sendErr != nil {
+		t.Fatalf("expected no error, got [%s]", sendErr)
+	}
+	if len(bodies) != 2 || bodies[0] != `POST {"host": {}}` || bodies[1] != "GET" {
+		t.Fatalf("expected the replica to receive both requests with their body, got %q", bodies)
+	}
+}
+
+// Ensures the connection error is returned when no replica is healthy
+func TestSend_FailoverUnhealthy(t *testing.T) {
+	_, down := NewForemanAPI()
+	downURL, _ := url.Parse(down.URL)
+	down.Close()
+
+	unhealthyMux, unhealthy := NewForemanAPI()
+	defer unhealthy.Close()
+	requested := false
+	unhealthyMux.HandleFunc(FOREMAN_API_URL_PREFIX+HealthCheckEndpoint, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	})
+	unhealthyMux.HandleFunc(FOREMAN_API_URL_PREFIX+"/hosts", func(w http.ResponseWriter, r *http.Request) {
+		requested = true
+	})
+	unhealthyURL, _ := url.Parse(unhealthy.URL)
+
+	client := NewClient(
+		Server{URL: *downURL, Replicas: []url.URL{*unhealthyURL}},
+		ClientCredentials{},
+		ClientConfig{},
+	)
+
+	req, _ := client.NewRequest(http.MethodGet, "/hosts", nil)
+	if _, _, sendErr := client.Send(req); sendErr == nil {
+		t.Fatalf("expected the connection error, got none")
+	}
+	if requested {
+		t.Fatalf("expected no request to be sent to the unhealthy replica")
+	}
+}
+
+// Ensures the health checks of the replicas do not hold up the requests to
+// the active endpoint, and a request failing over while another one already
+// did uses its choice
+func TestSend_FailoverProbeUnlocked(t *testing.T) {
+	_, down := NewForemanAPI()
+	downURL, _ := url.Parse(down.URL)
+	down.Close()
+
+	probing := make(chan struct{})
+	release := make(chan struct{})
+	replicaMux, replica := NewForemanAPI()
+	defer replica.Close()
+	replicaMux.HandleFunc(FOREMAN_API_URL_PREFIX+HealthCheckEndpoint, func(w http.ResponseWriter, r *http.Request) {
+		probing <- struct{}{}
+		<-release
+		fmt.Fprint(w, `{"result": "ok"}`)
+	})
+	replicaURL, _ := url.Parse(replica.URL)
+
+	client := NewClient(
+		Server{URL: *downURL, Replicas: []url.URL{*replicaURL}},
+		ClientCredentials{},
+		ClientConfig{},
+	)
+
+	done := make(chan url.URL)
+	go func() {
+		active, _ := client.failover(downURL)
+		done <- active
+	}()
+	<-probing
+
+	current := make(chan url.URL)
+	go func() { current <- client.serverURL() }()
+	select {
+	case active := <-current:
+		if active.Host != downURL.Host {
+			t.Errorf("expected [%s] to be active while probing, got [%s]", downURL.Host, active.Host)
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("expected the active endpoint to be readable while probing")
+	}
+
+	// a concurrent failover probing the replica as well
+	concurrent := make(chan url.URL)
+	go func() {
+		active, _ := client.failover(downURL)
+		concurrent <- active
+	}()
+	<-probing
+
+	release <- struct{}{}
+	if active := <-done; active.Host != replicaURL.Host {
+		t.Fatalf("expected to fail over to [%s], got [%s]", replicaURL.Host, active.Host)
+	}
+	release <- struct{}{}
+	if active := <-concurrent; active.Host != replicaURL.Host {
+		t.Fatalf("expected the concurrent failover to use [%s], got [%s]", replicaURL.Host, active.Host)
+	}
+}
@@ -0,0 +1,233 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+)
+
+//go:generate go run ../../cmd/apimock -o apimock/client.go
+
+// -----------------------------------------------------------------------------
+// Interface Definition
+// -----------------------------------------------------------------------------
+
+// ForemanClient is the set of Foreman API operations implemented by Client.
+// Programs and tests depending on ForemanClient rather than on Client can
+// substitute the generated apimock.Client for a live Foreman.
+//
+// Resources follow the same conventions throughout: Create and Update take
+// and return a reference to the API model, Read and Delete take the ID of
+// the object and Query searches for objects matching the attributes of the
+// supplied model.  Objects nested under another object (ie: host interfaces,
+// compute resource images) take the ID of their parent first.  Errors
+// returned by the Foreman API can be matched with errors.Is against
+// ErrNotFound and ErrConflict and converted with errors.As to a
+// *ValidationError.
+type ForemanClient interface {
+	// Architectures
+	CreateArchitecture(a *ForemanArchitecture) (*ForemanArchitecture, error)
+	ReadArchitecture(id int) (*ForemanArchitecture, error)
+	UpdateArchitecture(a *ForemanArchitecture) (*ForemanArchitecture, error)
+	DeleteArchitecture(id int) error
+	QueryArchitecture(a *ForemanArchitecture) (QueryResponse, error)
+
+	// BMC
+	SendBMCAction(hostId int, proxy *ForemanSmartProxy, bmc BMCInterface, action string, retry RetryConfig) error
+	SetBMCBootDevice(hostId int, proxy *ForemanSmartProxy, bmc BMCInterface, device string, retry RetryConfig) error
+	ReadBMCBootDevice(hostId int, proxy *ForemanSmartProxy, bmc BMCInterface) (string, error)
+
+	// Global parameters
+	CreateCommonParameter(d *ForemanCommonParameter) (*ForemanCommonParameter, error)
+	ReadCommonParameter(d *ForemanCommonParameter, id int) (*ForemanCommonParameter, error)
+	UpdateCommonParameter(d *ForemanCommonParameter, id int) (*ForemanCommonParameter, error)
+	DeleteCommonParameter(d *ForemanCommonParameter, id int) error
+	QueryCommonParameter(d *ForemanCommonParameter) (QueryResponse, error)
+
+	// Compute profiles
+	ReadComputeProfile(id int) (*ForemanComputeProfile, error)
+	QueryComputeProfile(t *ForemanComputeProfile) (QueryResponse, error)
+
+	// Compute resources
+	CreateComputeResource(d *ForemanComputeResource) (*ForemanComputeResource, error)
+	ReadComputeResource(id int) (*ForemanComputeResource, error)
+	UpdateComputeResource(d *ForemanComputeResource) (*ForemanComputeResource, error)
+	DeleteComputeResource(id int) error
+	QueryComputeResource(d *ForemanComputeResource) (QueryResponse, error)
+	ListComputeResourceObjects(id int, kind string, clusterId string) ([]ForemanComputeResourceObject, error)
+	FindComputeResourceObject(id int, kind string, clusterId string, idOrName string) (*ForemanComputeResourceObject, error)
+
+	// Default templates
+	CreateDefaultTemplate(d *ForemanDefaultTemplate) (*ForemanDefaultTemplate, error)
+	ReadDefaultTemplate(d *ForemanDefaultTemplate, id int) (*ForemanDefaultTemplate, error)
+	UpdateDefaultTemplate(d *ForemanDefaultTemplate, id int) (*ForemanDefaultTemplate, error)
+	DeleteDefaultTemplate(d *ForemanDefaultTemplate, id int) error
+	ListOperatingSystemDefaultTemplates(osId int) ([]ForemanDefaultTemplate, error)
+	QueryDefaultTemplate(d *ForemanDefaultTemplate) (QueryResponse, error)
+
+	// Domains
+	CreateDomain(d *ForemanDomain) (*ForemanDomain, error)
+	ReadDomain(id int) (*ForemanDomain, error)
+	UpdateDomain(d *ForemanDomain) (*ForemanDomain, error)
+	DeleteDomain(id int) error
+	QueryDomain(d *ForemanDomain) (QueryResponse, error)
+
+	// Environments
+	CreateEnvironment(e *ForemanEnvironment) (*ForemanEnvironment, error)
+	ReadEnvironment(id int) (*ForemanEnvironment, error)
+	UpdateEnvironment(e *ForemanEnvironment) (*ForemanEnvironment, error)
+	DeleteEnvironment(id int) error
+	QueryEnvironment(e *ForemanEnvironment) (QueryResponse, error)
+
+	// Hosts
+	SendPowerCommand(h *ForemanHost, cmd interface{}, retry RetryConfig, verify PowerVerifyConfig) error
+	ProvisionBoot(h *ForemanHost, retry RetryConfig, verify PowerVerifyConfig) error
+	ShutdownHost(h *ForemanHost, gracefulAction string, grace time.Duration, retry RetryConfig, verify PowerVerifyConfig) error
+	ReadPowerState(id int) (string, error)
+	RenderHostTemplate(id int, kind string) (string, error)
+	RenderUnattendedTemplate(token string, kind string) (string, error)
+	CreateHost(h *ForemanHost, retry RetryConfig) (*ForemanHost, error)
+	ReadHost(id int) (*ForemanHost, error)
+	UpdateHost(h *ForemanHost, retry RetryConfig) (*ForemanHost, error)
+	CancelHostBuild(id int) error
+	UnmanageHost(id int) error
+	DeleteHost(id int) error
+	SearchHosts(search string) (QueryResponse, error)
+
+	// Bulk host actions
+	SendBulkPowerCommand(ids []int, action string, retry RetryConfig) error
+
+	// Katello host collections
+	ReadKatelloHostCollection(id int) (*ForemanKatelloHostCollection, error)
+	AddKatelloHostCollectionHosts(id int, hostIds []int) error
+	RemoveKatelloHostCollectionHosts(id int, hostIds []int) error
+
+	// Host details
+	ReadHostFacts(id int) (map[string]string, error)
+	ReadHostENC(id int) (string, error)
+	ReadHostLastConfigReport(id int) (*ForemanConfigReport, error)
+	ReadHostStatus(id int, kind string) (*ForemanHostStatus, error)
+	ReadHostRepresentation(id int) (map[string]interface{}, error)
+
+	// Hostgroups
+	CreateHostgroup(h *ForemanHostgroup) (*ForemanHostgroup, error)
+	ReadHostgroup(id int) (*ForemanHostgroup, error)
+	UpdateHostgroup(h *ForemanHostgroup) (*ForemanHostgroup, error)
+	DeleteHostgroup(id int) error
+	QueryHostgroup(h *ForemanHostgroup) (QueryResponse, error)
+
+	// Images
+	CreateImage(computeResourceId int, d *ForemanImage) (*ForemanImage, error)
+	ReadImage(computeResourceId int, id int) (*ForemanImage, error)
+	UpdateImage(computeResourceId int, d *ForemanImage) (*ForemanImage, error)
+	DeleteImage(computeResourceId int, id int) error
+	QueryImage(d *ForemanImage) (QueryResponse, error)
+	EnsureImageUserDataTemplate(image *ForemanImage, templateId int) error
+
+	// Host interfaces
+	QueryHostInterfaces(hostId int) (QueryResponse, error)
+	CreateHostInterface(hostId int, i *ForemanInterfacesAttribute) (*ForemanInterfacesAttribute, error)
+	ReadHostInterface(hostId int, id int) (*ForemanInterfacesAttribute, error)
+	UpdateHostInterface(hostId int, i *ForemanInterfacesAttribute) (*ForemanInterfacesAttribute, error)
+	DeleteHostInterface(hostId int, id int) error
+
+	// Locations
+	ReadLocation(id int) (*ForemanLocation, error)
+	QueryLocation(l *ForemanLocation) (QueryResponse, error)
+	SearchLocations(search string) (QueryResponse, error)
+
+	// Installation media
+	CreateMedia(m *ForemanMedia) (*ForemanMedia, error)
+	ReadMedia(id int) (*ForemanMedia, error)
+	UpdateMedia(m *ForemanMedia) (*ForemanMedia, error)
+	DeleteMedia(id int) error
+	QueryMedia(m *ForemanMedia) (QueryResponse, error)
+
+	// Hardware models
+	CreateModel(m *ForemanModel) (*ForemanModel, error)
+	ReadModel(id int) (*ForemanModel, error)
+	UpdateModel(m *ForemanModel) (*ForemanModel, error)
+	DeleteModel(id int) error
+	QueryModel(m *ForemanModel) (QueryResponse, error)
+
+	// Operating systems
+	CreateOperatingSystem(o *ForemanOperatingSystem) (*ForemanOperatingSystem, error)
+	ReadOperatingSystem(id int) (*ForemanOperatingSystem, error)
+	UpdateOperatingSystem(o *ForemanOperatingSystem) (*ForemanOperatingSystem, error)
+	DeleteOperatingSystem(id int) error
+	QueryOperatingSystem(o *ForemanOperatingSystem) (QueryResponse, error)
+	EnsureOperatingSystemAssociations(requested *ForemanOperatingSystem, actual *ForemanOperatingSystem) (*ForemanOperatingSystem, error)
+
+	// Orchestration
+	ReadOrchestrationTasks(id string) ([]ForemanOrchestrationTask, error)
+
+	// Smart class parameter override values
+	CreateOverrideValue(o *ForemanOverrideValue) (*ForemanOverrideValue, error)
+	ReadOverrideValue(smartClassParameterId int, id int) (*ForemanOverrideValue, error)
+	UpdateOverrideValue(o *ForemanOverrideValue) (*ForemanOverrideValue, error)
+	DeleteOverrideValue(smartClassParameterId int, id int) error
+
+	// Host, hostgroup and other parameters
+	CreateParameter(d *ForemanParameter) (*ForemanParameter, error)
+	ReadParameter(d *ForemanParameter, id int) (*ForemanParameter, error)
+	UpdateParameter(d *ForemanParameter, id int) (*ForemanParameter, error)
+	DeleteParameter(d *ForemanParameter, id int) error
+	QueryParameter(d *ForemanParameter) (QueryResponse, error)
+
+	// Partition tables
+	CreatePartitionTable(t *ForemanPartitionTable) (*ForemanPartitionTable, error)
+	ReadPartitionTable(id int) (*ForemanPartitionTable, error)
+	UpdatePartitionTable(t *ForemanPartitionTable) (*ForemanPartitionTable, error)
+	DeletePartitionTable(id int) error
+	QueryPartitionTable(t *ForemanPartitionTable) (QueryResponse, error)
+
+	// Provisioning templates
+	CreateProvisioningTemplate(t *ForemanProvisioningTemplate) (*ForemanProvisioningTemplate, error)
+	ReadProvisioningTemplate(id int) (*ForemanProvisioningTemplate, error)
+	UpdateProvisioningTemplate(t *ForemanProvisioningTemplate) (*ForemanProvisioningTemplate, error)
+	DeleteProvisioningTemplate(id int) error
+	QueryProvisioningTemplate(t *ForemanProvisioningTemplate) (QueryResponse, error)
+	PreviewProvisioningTemplate(id int, hostId int, template string) (string, error)
+
+	// Generic queries
+	Query(endpoint string, search string) (QueryResponse, error)
+	ForEachResult(endpoint string, search string, fn func(result json.RawMessage) error) error
+
+	// Smart proxies
+	CreateSmartProxy(s *ForemanSmartProxy) (*ForemanSmartProxy, error)
+	ReadSmartProxy(id int) (*ForemanSmartProxy, error)
+	UpdateSmartProxy(s *ForemanSmartProxy) (*ForemanSmartProxy, error)
+	RefreshSmartProxy(id int) (*ForemanSmartProxy, error)
+	DeleteSmartProxy(id int) error
+	QuerySmartProxy(s *ForemanSmartProxy) (QueryResponse, error)
+
+	// Subnets
+	CreateSubnet(s *ForemanSubnet) (*ForemanSubnet, error)
+	ReadSubnet(id int) (*ForemanSubnet, error)
+	UpdateSubnet(s *ForemanSubnet) (*ForemanSubnet, error)
+	DeleteSubnet(id int) error
+	QuerySubnet(s *ForemanSubnet) (QueryResponse, error)
+
+	// Template combinations
+	CreateTemplateCombination(t *ForemanTemplateCombination) (*ForemanTemplateCombination, error)
+	ReadTemplateCombination(id int) (*ForemanTemplateCombination, error)
+	DeleteTemplateCombination(id int) error
+
+	// Template kinds
+	ReadTemplateKind(id int) (*ForemanTemplateKind, error)
+	QueryTemplateKind(t *ForemanTemplateKind) (QueryResponse, error)
+
+	// Tasks
+	ReadTask(id string) (*ForemanTask, error)
+	WaitForTask(ctx context.Context, id string, wait TaskWaitConfig, progress func(*ForemanTask)) (*ForemanTask, error)
+
+	// Usergroups
+	ReadUsergroup(id int) (*ForemanUsergroup, error)
+	AddUsergroupRole(id int, roleId int) error
+	RemoveUsergroupRole(id int, roleId int) error
+	RefreshExternalUsergroup(id int, externalId int) error
+	QueryUsergroup(u *ForemanUsergroup) (QueryResponse, error)
+}
+
+// Client must implement ForemanClient
+var _ ForemanClient = (*Client)(nil)
@@ -0,0 +1,494 @@
+// Package foremantest provides an in-memory mock of the Foreman API for
+// testing code built on the api package without a live Foreman.
+//
+// The mock serves the hosts and hostgroups of the Foreman API and the host
+// collections of the Katello API.  Objects are kept in memory, created,
+// read, updated, deleted and searched like Foreman does.  Endpoints the mock
+// does not implement can be registered on the server's Mux.
+package foremantest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/HanseMerkur/terraform-provider-foreman/foreman/api"
+)
+
+// Resources served by the mock
+const (
+	// Hosts : hosts of the Foreman API
+	Hosts = "hosts"
+	// Hostgroups : hostgroups of the Foreman API
+	Hostgroups = "hostgroups"
+	// HostCollections : host collections of the Katello API
+	HostCollections = "host_collections"
+)
+
+// -----------------------------------------------------------------------------
+// Struct Definition and Helpers
+// -----------------------------------------------------------------------------
+
+// resource describes how the mock serves the objects of a resource
+type resource struct {
+	// Prefix of the resource's URL path
+	prefix string
+	// Name of the object a request's body is wrapped in (ie: "host")
+	wrapper string
+	// Nested attributes renamed when the object is saved.  Foreman expects
+	//   them as "<name>_attributes" and reports them under another name.
+	nested map[string]string
+	// Called on every object created or updated
+	normalize func(s *Server, obj map[string]interface{})
+
+	objects map[int]map[string]interface{}
+	lastId  int
+}
+
+// Server is a mock Foreman API.  It embeds the httptest.Server serving it,
+// the caller has to Close() the server when finished to prevent a resource
+// leak.
+type Server struct {
+	*httptest.Server
+
+	// Mux routes the requests of the server.  Additional endpoints can be
+	// registered on it, more specific patterns take precedence over the
+	// endpoints of the mock.
+	Mux *http.ServeMux
+
+	mutex     sync.Mutex
+	resources map[string]*resource
+}
+
+// NewServer starts a mock Foreman API serving no objects
+func NewServer() *Server {
+	s := &Server{
+		Mux: http.NewServeMux(),
+		resources: map[string]*resource{
+			Hosts: &resource{
+				prefix:  api.FOREMAN_API_URL_PREFIX + "/" + api.HostEndpointPrefix,
+				wrapper: "host",
+				nested: map[string]string{
+					"host_parameters_attributes": "parameters",
+					"interfaces_attributes":      "interfaces",
+				},
+			},
+			Hostgroups: &resource{
+				prefix:  api.FOREMAN_API_URL_PREFIX + "/" + api.HostgroupEndpointPrefix,
+				wrapper: "hostgroup",
+				nested: map[string]string{
+					"group_parameters_attributes": "parameters",
+				},
+				normalize: normalizeHostgroup,
+			},
+			HostCollections: &resource{
+				prefix:  api.KATELLO_API_URL_PREFIX + "/" + api.HostCollectionEndpointPrefix,
+				wrapper: "host_collection",
+			},
+		},
+	}
+	for _, r := range s.resources {
+		r := r
+		r.objects = map[int]map[string]interface{}{}
+		s.Mux.HandleFunc(r.prefix, func(w http.ResponseWriter, req *http.Request) {
+			s.serveCollection(r, w, req)
+		})
+		s.Mux.HandleFunc(r.prefix+"/", func(w http.ResponseWriter, req *http.Request) {
+			s.serveObject(r, w, req)
+		})
+	}
+	s.Server = httptest.NewServer(s.Mux)
+	return s
+}
+
+// NewClient returns a client communicating with the mock
+func (s *Server) NewClient(cred api.ClientCredentials, conf api.ClientConfig) *api.Client {
+	// NOTE(ALL): the server's URL is generated by httptest and always valid
+	serverURL, _ := url.Parse(s.URL)
+	return api.NewClient(api.Server{URL: *serverURL}, cred, conf)
+}
+
+// Add adds a fixture to the objects of the supplied resource and returns its
+// ID.  Fixtures without an "id" are assigned the next free ID.
+func (s *Server) Add(name string, obj map[string]interface{}) int {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return s.add(s.resource(name), copyObject(obj))
+}
+
+// Get returns a copy of the object of the supplied resource identified by the
+// supplied ID, with its values as decoded from JSON (ie: numbers are
+// float64).  The boolean is false if there is no such object.
+func (s *Server) Get(name string, id int) (map[string]interface{}, bool) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	obj, ok := s.resource(name).objects[id]
+	if !ok {
+		return nil, false
+	}
+	return copyObject(obj), true
+}
+
+// List returns copies of the objects of the supplied resource, ordered by ID
+func (s *Server) List(name string) []map[string]interface{} {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return s.list(s.resource(name), nil)
+}
+
+// resource returns the resource of the supplied name.  Panics on resources
+// the mock does not serve, which is a mistake of the test.
+func (s *Server) resource(name string) *resource {
+	r, ok := s.resources[name]
+	if !ok {
+		panic(fmt.Sprintf("foremantest: resource [%s] is not served by the mock", name))
+	}
+	return r
+}
+
+// add saves the supplied object, assigning it an ID if it has none
+func (s *Server) add(r *resource, obj map[string]interface{}) int {
+	id := objectId(obj)
+	if id == 0 {
+		id = r.lastId + 1
+	}
+	if id > r.lastId {
+		r.lastId = id
+	}
+	obj["id"] = id
+	s.save(r, obj)
+	return id
+}
+
+// save renames the nested attributes of the supplied object and stores it
+func (s *Server) save(r *resource, obj map[string]interface{}) {
+	for attr, name := range r.nested {
+		items, ok := obj[attr].([]interface{})
+		if !ok {
+			continue
+		}
+		delete(obj, attr)
+		existing, _ := obj[name].([]interface{})
+		obj[name] = mergeNested(existing, items)
+	}
+	if r.normalize != nil {
+		r.normalize(s, obj)
+	}
+	r.objects[objectId(obj)] = obj
+}
+
+// list returns copies of the objects of the supplied resource matching the
+// supplied search terms, ordered by ID
+func (s *Server) list(r *resource, terms map[string]string) []map[string]interface{} {
+	ids := make([]int, 0, len(r.objects))
+	for id := range r.objects {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+
+	objects := []map[string]interface{}{}
+	for _, id := range ids {
+		if matchesSearch(r.objects[id], terms) {
+			objects = append(objects, copyObject(r.objects[id]))
+		}
+	}
+	return objects
+}
+
+// normalizeHostgroup sets the title of a hostgroup from the title of its
+// parent, like Foreman does
+func normalizeHostgroup(s *Server, obj map[string]interface{}) {
+	name, _ := obj["name"].(string)
+	obj["title"] = name
+	parentId := idOf(obj["parent_id"])
+	if parent, ok := s.resources[Hostgroups].objects[parentId]; ok && parentId != objectId(obj) {
+		obj["title"] = fmt.Sprintf("%s/%s", parent["title"], name)
+	}
+}
+
+// -----------------------------------------------------------------------------
+// Handlers
+// -----------------------------------------------------------------------------
+
+// serveCollection lists and creates the objects of a resource
+func (s *Server) serveCollection(r *resource, w http.ResponseWriter, req *http.Request) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	switch req.Method {
+	case http.MethodGet:
+		search := req.URL.Query().Get("search")
+		terms, searchErr := parseSearch(search)
+		if searchErr != nil {
+			writeError(w, http.StatusUnprocessableEntity, searchErr.Error())
+			return
+		}
+		results := s.list(r, terms)
+		writeJSON(w, http.StatusOK, map[string]interface{}{
+			"total":    len(r.objects),
+			"subtotal": len(results),
+			"page":     1,
+			"per_page": len(results),
+			"search":   search,
+			"results":  results,
+		})
+	case http.MethodPost:
+		obj, readErr := readObject(r, req)
+		if readErr != nil {
+			writeError(w, http.StatusUnprocessableEntity, readErr.Error())
+			return
+		}
+		delete(obj, "id")
+		id := s.add(r, obj)
+		writeJSON(w, http.StatusCreated, r.objects[id])
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+// serveObject reads, updates and deletes a single object of a resource, and
+// serves the actions of host collections
+func (s *Server) serveObject(r *resource, w http.ResponseWriter, req *http.Request) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	path := strings.Split(strings.TrimPrefix(req.URL.Path, r.prefix+"/"), "/")
+	id, _ := strconv.Atoi(path[0])
+	obj, ok := r.objects[id]
+	if !ok {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("Resource %s not found by id '%s'", r.wrapper, path[0]))
+		return
+	}
+
+	if len(path) == 2 && r == s.resources[HostCollections] && req.Method == http.MethodPut {
+		s.serveHostCollectionHosts(obj, path[1], w, req)
+		return
+	}
+	if len(path) > 1 {
+		http.NotFound(w, req)
+		return
+	}
+
+	switch req.Method {
+	case http.MethodGet:
+		writeJSON(w, http.StatusOK, obj)
+	case http.MethodPut:
+		update, readErr := readObject(r, req)
+		if readErr != nil {
+			writeError(w, http.StatusUnprocessableEntity, readErr.Error())
+			return
+		}
+		for attr, value := range update {
+			if attr != "id" {
+				obj[attr] = value
+			}
+		}
+		s.save(r, obj)
+		writeJSON(w, http.StatusOK, obj)
+	case http.MethodDelete:
+		delete(r.objects, id)
+		writeJSON(w, http.StatusOK, obj)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+// serveHostCollectionHosts adds hosts to or removes hosts from a host
+// collection, answering like Katello does.  Unknown hosts are reported as
+// error messages.
+func (s *Server) serveHostCollectionHosts(obj map[string]interface{}, action string, w http.ResponseWriter, req *http.Request) {
+	var body struct {
+		HostIds []int `json:"host_ids"`
+	}
+	if decodeErr := json.NewDecoder(req.Body).Decode(&body); decodeErr != nil {
+		writeError(w, http.StatusUnprocessableEntity, decodeErr.Error())
+		return
+	}
+
+	members := map[int]bool{}
+	hostIds, _ := obj["host_ids"].([]interface{})
+	for _, hostId := range hostIds {
+		members[idOf(hostId)] = true
+	}
+
+	success, failed := []string{}, []string{}
+	for _, hostId := range body.HostIds {
+		if _, ok := s.resources[Hosts].objects[hostId]; !ok {
+			failed = append(failed, fmt.Sprintf("Host with ID %d not found.", hostId))
+			continue
+		}
+		switch action {
+		case "add_hosts":
+			members[hostId] = true
+		case "remove_hosts":
+			delete(members, hostId)
+		default:
+			http.NotFound(w, req)
+			return
+		}
+		success = append(success, fmt.Sprintf("Successfully changed host %d.", hostId))
+	}
+
+	ids := make([]int, 0, len(members))
+	for hostId := range members {
+		ids = append(ids, hostId)
+	}
+	sort.Ints(ids)
+	saved := make([]interface{}, 0, len(ids))
+	for _, hostId := range ids {
+		saved = append(saved, hostId)
+	}
+	obj["host_ids"] = saved
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"displayMessages": map[string]interface{}{
+			"success": success,
+			"error":   failed,
+		},
+	})
+}
+
+// -----------------------------------------------------------------------------
+// Helper Functions
+// -----------------------------------------------------------------------------
+
+// readObject decodes the object of a request's body.  The object may be
+// wrapped in the resource's wrapper, like the api package sends it.
+func readObject(r *resource, req *http.Request) (map[string]interface{}, error) {
+	var body map[string]interface{}
+	if decodeErr := json.NewDecoder(req.Body).Decode(&body); decodeErr != nil {
+		return nil, decodeErr
+	}
+	if wrapped, ok := body[r.wrapper].(map[string]interface{}); ok {
+		return wrapped, nil
+	}
+	return body, nil
+}
+
+// mergeNested merges the supplied nested items into the existing ones.
+// Items are matched by their ID, items flagged "_destroy" are removed and
+// new items are assigned the next free ID.
+func mergeNested(existing []interface{}, items []interface{}) []interface{} {
+	merged := []interface{}{}
+	byId := map[int]map[string]interface{}{}
+	lastId := 0
+	for _, item := range existing {
+		itemMap, _ := item.(map[string]interface{})
+		id := objectId(itemMap)
+		byId[id] = itemMap
+		if id > lastId {
+			lastId = id
+		}
+		merged = append(merged, itemMap)
+	}
+
+	for _, item := range items {
+		itemMap, _ := item.(map[string]interface{})
+		id := objectId(itemMap)
+		if destroy, _ := itemMap["_destroy"].(bool); destroy {
+			delete(byId, id)
+			continue
+		}
+		if current, ok := byId[id]; ok && id != 0 {
+			for attr, value := range itemMap {
+				current[attr] = value
+			}
+			continue
+		}
+		lastId++
+		itemMap["id"] = lastId
+		byId[lastId] = itemMap
+		merged = append(merged, itemMap)
+	}
+
+	kept := []interface{}{}
+	for _, item := range merged {
+		if _, ok := byId[objectId(item.(map[string]interface{}))]; ok {
+			kept = append(kept, item)
+		}
+	}
+	return kept
+}
+
+// parseSearch parses a Foreman search filter of "attribute = value" terms
+// joined by "and".  Values may be quoted.  Other operators are not supported
+// by the mock.
+func parseSearch(search string) (map[string]string, error) {
+	terms := map[string]string{}
+	if strings.TrimSpace(search) == "" {
+		return terms, nil
+	}
+	for _, term := range strings.Split(search, " and ") {
+		parts := strings.SplitN(term, "=", 2)
+		if len(parts) != 2 || strings.ContainsAny(parts[0], "!~<>") {
+			return nil, fmt.Errorf("foremantest: unsupported search term [%s]", term)
+		}
+		terms[strings.TrimSpace(parts[0])] = strings.Trim(strings.TrimSpace(parts[1]), `"'`)
+	}
+	return terms, nil
+}
+
+// matchesSearch returns whether or not the supplied object matches every
+// search term
+func matchesSearch(obj map[string]interface{}, terms map[string]string) bool {
+	for attr, value := range terms {
+		if fmt.Sprint(obj[attr]) != value {
+			return false
+		}
+	}
+	return true
+}
+
+// objectId returns the ID of the supplied object, 0 if it has none
+func objectId(obj map[string]interface{}) int {
+	return idOf(obj["id"])
+}
+
+// idOf converts an ID decoded from JSON or set by a fixture to an int
+func idOf(v interface{}) int {
+	switch id := v.(type) {
+	case int:
+		return id
+	case float64:
+		return int(id)
+	case string:
+		i, _ := strconv.Atoi(id)
+		return i
+	}
+	return 0
+}
+
+// copyObject returns a deep copy of the supplied object, so callers can not
+// modify the objects of the mock
+func copyObject(obj map[string]interface{}) map[string]interface{} {
+	// NOTE(ALL): the objects only hold JSON values
+	b, _ := json.Marshal(obj)
+	var copied map[string]interface{}
+	json.Unmarshal(b, &copied)
+	return copied
+}
+
+// writeJSON writes the supplied value as the JSON body of the response
+func writeJSON(w http.ResponseWriter, statusCode int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	json.NewEncoder(w).Encode(v)
+}
+
+// writeError writes an error response like Foreman does
+func writeError(w http.ResponseWriter, statusCode int, message string) {
+	writeJSON(w, statusCode, map[string]interface{}{
+		"error": map[string]interface{}{
+			"message": message,
+		},
+	})
+}
@@ -0,0 +1,102 @@
+package foremantest
+
+import (
+	"testing"
+
+	"github.com/HanseMerkur/terraform-provider-foreman/foreman/api"
+)
+
+// Ensures hosts are created, read, updated and deleted through the client
+// like on a live Foreman
+func TestServer_Hosts(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+	client := s.NewClient(api.ClientCredentials{}, api.ClientConfig{})
+
+	h := &api.ForemanHost{}
+	h.Name = "host01.example.com"
+	h.HostParameters = []api.ForemanKVParameter{{Name: "role", Value: "web"}}
+	created, createErr := client.CreateHost(h, api.RetryConfig{})
+	if createErr != nil {
+		t.Fatalf("Expected no error, got [%s]", createErr)
+	}
+	if created.Id != 1 || created.Name != "host01.example.com" {
+		t.Fatalf("Expected host [1] to be created, got [%+v]", created)
+	}
+
+	read, readErr := client.ReadHost(created.Id)
+	if readErr != nil {
+		t.Fatalf("Expected no error, got [%s]", readErr)
+	}
+	if len(read.HostParameters) != 1 || read.HostParameters[0].Value != "web" {
+		t.Fatalf("Expected the host's parameters to be reported, got [%+v]", read.HostParameters)
+	}
+
+	read.Comment = "updated"
+	if _, updateErr := client.UpdateHost(read, api.RetryConfig{}); updateErr != nil {
+		t.Fatalf("Expected no error, got [%s]", updateErr)
+	}
+	if obj, _ := s.Get(Hosts, created.Id); obj["comment"] != "updated" {
+		t.Fatalf("Expected the host to be updated, got [%v]", obj)
+	}
+
+	if deleteErr := client.DeleteHost(created.Id); deleteErr != nil {
+		t.Fatalf("Expected no error, got [%s]", deleteErr)
+	}
+	if _, readErr = client.ReadHost(created.Id); !api.IsNotFound(readErr) {
+		t.Fatalf("Expected the host to be deleted, got [%v]", readErr)
+	}
+}
+
+// Ensures hostgroups are titled after their parents and searched by title
+func TestServer_Hostgroups(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+	client := s.NewClient(api.ClientCredentials{}, api.ClientConfig{})
+
+	parentId := s.Add(Hostgroups, map[string]interface{}{"name": "BO1"})
+	s.Add(Hostgroups, map[string]interface{}{"name": "VM", "parent_id": parentId})
+
+	hg := &api.ForemanHostgroup{}
+	hg.Title = "BO1/VM"
+	results, queryErr := client.QueryHostgroup(hg)
+	if queryErr != nil {
+		t.Fatalf("Expected no error, got [%s]", queryErr)
+	}
+	if results.Subtotal != 1 {
+		t.Fatalf("Expected hostgroup [BO1/VM] to be found, got [%+v]", results)
+	}
+}
+
+// Ensures hosts are added to and removed from host collections, unknown
+// hosts failing like on Katello
+func TestServer_HostCollections(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+	client := s.NewClient(api.ClientCredentials{}, api.ClientConfig{})
+
+	hostId := s.Add(Hosts, map[string]interface{}{"name": "host01.example.com"})
+	collectionId := s.Add(HostCollections, map[string]interface{}{"name": "web"})
+
+	if addErr := client.AddKatelloHostCollectionHosts(collectionId, []int{hostId}); addErr != nil {
+		t.Fatalf("Expected no error, got [%s]", addErr)
+	}
+	collection, readErr := client.ReadKatelloHostCollection(collectionId)
+	if readErr != nil {
+		t.Fatalf("Expected no error, got [%s]", readErr)
+	}
+	if len(collection.HostIds) != 1 || collection.HostIds[0] != hostId {
+		t.Fatalf("Expected host [%d] in the host collection, got %v", hostId, collection.HostIds)
+	}
+
+	if addErr := client.AddKatelloHostCollectionHosts(collectionId, []int{42}); addErr == nil {
+		t.Fatalf("Expected an error adding an unknown host")
+	}
+
+	if removeErr := client.RemoveKatelloHostCollectionHosts(collectionId, []int{hostId}); removeErr != nil {
+		t.Fatalf("Expected no error, got [%s]", removeErr)
+	}
+	if collection, _ = client.ReadKatelloHostCollection(collectionId); len(collection.HostIds) != 0 {
+		t.Fatalf("Expected the host collection to be empty, got %v", collection.HostIds)
+	}
+}
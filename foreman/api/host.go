@@ -3,9 +3,12 @@ package api
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
+	"net/url"
 	"strings"
+	"time"
 
 	"github.com/wayfair/terraform-provider-utils/log"
 )
@@ -21,8 +24,13 @@ const (
 	PowerOff = "off"
 	// PowerSoft : Power reboot operation (soft)
 	PowerSoft = "soft"
+	// PowerStop : Shut down operation.  Graceful for virtual machines, BMCs
+	// power the host off.
+	PowerStop = "stop"
 	// PowerCycle : Power reset operation (hard)
 	PowerCycle = "cycle"
+	// PowerReboot : Reboot operation.  Graceful for virtual machines.
+	PowerReboot = "reboot"
 	// PowerState : Power state check operation
 	PowerState = "state"
 	// BootSuffix : Suffix appended to API url for power operations
@@ -35,8 +43,25 @@ const (
 	BootPxe = "pxe"
 	// PowerBios : Boot to BIOS
 	PowerBios = "bios"
+	// TemplateSuffix : Suffix appended to API url for template previews
+	TemplateSuffix = "template"
 )
 
+// BMC providers of BMC interfaces
+const (
+	// BMCProviderIPMI : BMC operations through IPMI
+	BMCProviderIPMI = "IPMI"
+	// BMCProviderRedfish : BMC operations through the Redfish REST API
+	BMCProviderRedfish = "Redfish"
+)
+
+// BMCProviders are the providers of BMC interfaces
+var BMCProviders = []string{BMCProviderIPMI, BMCProviderRedfish}
+
+// BootDevices are the boot devices accepted by the boot API.  They are used
+// to validate the schema attributes driving BMC operations at plan time.
+var BootDevices = []string{BootDisk, BootCdrom, BootPxe, PowerBios}
+
 // -----------------------------------------------------------------------------
 // Struct Definition and Helpers
 // -----------------------------------------------------------------------------
@@ -64,12 +89,18 @@ type ForemanHost struct {
 	MediumId int `json:"medium_id"`
 	// ID of the image that should be cloned for this host
 	ImageId int `json:"image_id"`
+	// ID of the partition table of the host.  Overrides the one inherited
+	// from the hostgroup.
+	PtableId int `json:"ptable_id"`
 	// Whether or not to Enable BMC Functionality on this host
 	EnableBMC bool
 	// Boolean to track success of BMC Calls
 	BMCSuccess bool
 	// Additional information about this host
 	Comment string `json:"comment"`
+	// Root password of the host. Overrides the one inherited from the
+	// hostgroup.
+	RootPassword string `json:"root_pass,omitempty"`
 	// Nested struct defining any interfaces associated with the Host
 	InterfacesAttributes []ForemanInterfacesAttribute `json:"interfaces_attributes"`
 	// Map of HostParameters
@@ -78,10 +109,34 @@ type ForemanHost struct {
 	ComputeResourceId int `json:"compute_resource_id,omitempty"`
 	// ComputeProfileId specifies the Attributes via the Profile Id on the Hypervisor
 	ComputeProfileId int `json:"compute_profile_id,omitempty"`
+	// ComputeAttributes are hypervisor specific attributes of the virtual
+	// machine, using the attribute names of the compute resource type (ie:
+	// "cpuHotAddEnabled" for VMware).  Foreman does not report them back.
+	ComputeAttributes map[string]interface{} `json:"-"`
+	// UUID tracking the orchestration tasks of the host's creation.  See
+	// ReadOrchestrationTasks.
+	ProgressReportId string `json:"progress_report_id,omitempty"`
 }
 
-type foremanHostParameterJSON struct {
-	HostParameters []ForemanKVParameter `json:"host_parameters_attributes"`
+// FQDN returns the fully qualified domain name of the host.  Foreman returns
+// the FQDN as the host's name, the domain is stripped from the name when
+// unmarshalling and added back here.
+func (fh ForemanHost) FQDN() string {
+	if fh.DomainName == "" {
+		return fh.Name
+	}
+	return fh.Name + "." + fh.DomainName
+}
+
+// String implements the fmt.Stringer interface.  The root password is
+// redacted so the host can be logged safely.
+func (fh ForemanHost) String() string {
+	type plainHost ForemanHost
+	redacted := plainHost(fh)
+	if redacted.RootPassword != "" {
+		redacted.RootPassword = redactedValue
+	}
+	return fmt.Sprintf("%+v", redacted)
 }
 
 // ForemanInterfacesAttribute representing a hosts defined network interfaces
@@ -120,9 +175,44 @@ type ForemanInterfacesAttribute struct {
 	Destroy bool `json:"_destroy,omitempty"`
 }
 
-// foremanHostJSON struct used for JSON decode.
+// String implements the fmt.Stringer interface.  The BMC password is
+// redacted so the interface can be logged safely.
+func (fia ForemanInterfacesAttribute) String() string {
+	// NOTE(ALL): convert to a type without the String method, otherwise
+	//   formatting would recurse into this function
+	type plainInterfacesAttribute ForemanInterfacesAttribute
+	redacted := plainInterfacesAttribute(fia)
+	if redacted.Password != "" {
+		redacted.Password = redactedValue
+	}
+	return fmt.Sprintf("%+v", redacted)
+}
+
+// foremanHostJSON struct used for JSON decode.  The whole host is decoded in
+// a single pass, properties with differently named keys or types are
+// converted to the ForemanHost afterwards.
 type foremanHostJSON struct {
+	ForemanObject
+
 	InterfacesAttributes []ForemanInterfacesAttribute `json:"interfaces"`
+	// NOTE(ALL): the API reports the host parameters as "parameters" but
+	//   expects "host_parameters_attributes" on create and update
+	Parameters     []ForemanKVParameter `json:"parameters"`
+	HostParameters []ForemanKVParameter `json:"host_parameters_attributes"`
+
+	Build      jsonValue[bool]   `json:"build"`
+	Method     jsonValue[string] `json:"method"`
+	Comment    jsonValue[string] `json:"comment"`
+	DomainName jsonValue[string] `json:"domain_name"`
+
+	DomainId          jsonValue[float64] `json:"domain_id"`
+	EnvironmentId     jsonValue[float64] `json:"environment_id"`
+	HostgroupId       jsonValue[float64] `json:"hostgroup_id"`
+	OperatingSystemId jsonValue[float64] `json:"operatingsystem_id"`
+	MediumId          jsonValue[float64] `json:"medium_id"`
+	PtableId          jsonValue[float64] `json:"ptable_id"`
+	ComputeResourceId jsonValue[float64] `json:"compute_resource_id"`
+	ComputeProfileId  jsonValue[float64] `json:"compute_profile_id"`
 }
 
 // Power struct for marshal/unmarshal of power state
@@ -144,31 +234,61 @@ type BMCBoot struct {
 	} `json:"boot,omitempty"`
 }
 
+// bmcBootResponse struct used for JSON decode of the response to a boot
+// device change.
+//
+// NOTE(ALL): IPMI reports the result of the change.  Redfish applies the boot
+//   device as a one-time override of the next boot, which is accepted
+//   without a result.  Only an explicit negative result is a failure.
+type bmcBootResponse struct {
+	Boot struct {
+		Action string `json:"action"`
+		Result *bool  `json:"result"`
+	} `json:"boot"`
+}
+
 // Implement the Marshaler interface
 func (fh ForemanHost) MarshalJSON() ([]byte, error) {
 	log.Tracef("foreman/api/host.go#MarshalJSON")
 
 	fhMap := map[string]interface{}{}
 
-	fhMap["name"] = fh.Name
+	// NOTE(ALL): omit an empty name so Foreman's name generator assigns one
+	if fh.Name != "" {
+		fhMap["name"] = fh.Name
+	}
 	fhMap["comment"] = fh.Comment
-	fhMap["build"] = fh.Build
+	// NOTE(ALL): only send the build flag to mark the host for (re)build.
+	//   Sending false would cancel a build that is still pending.
+	if fh.Build {
+		fhMap["build"] = fh.Build
+	}
 	fhMap["provision_method"] = fh.Method
 	fhMap["domain_id"] = intIdToJSONString(fh.DomainId)
 	fhMap["operatingsystem_id"] = intIdToJSONString(fh.OperatingSystemId)
 	fhMap["medium_id"] = intIdToJSONString(fh.MediumId)
 	fhMap["image_id"] = intIdToJSONString(fh.ImageId)
+	fhMap["ptable_id"] = intIdToJSONString(fh.PtableId)
 	fhMap["hostgroup_id"] = intIdToJSONString(fh.HostgroupId)
 	fhMap["environment_id"] = intIdToJSONString(fh.EnvironmentId)
 	fhMap["compute_resource_id"] = intIdToJSONString(fh.ComputeResourceId)
 	fhMap["compute_profile_id"] = intIdToJSONString(fh.ComputeProfileId)
+	if len(fh.ComputeAttributes) > 0 {
+		fhMap["compute_attributes"] = fh.ComputeAttributes
+	}
+	if fh.RootPassword != "" {
+		fhMap["root_pass"] = fh.RootPassword
+	}
 	if len(fh.InterfacesAttributes) > 0 {
 		fhMap["interfaces_attributes"] = fh.InterfacesAttributes
 	}
 	if len(fh.HostParameters) > 0 {
 		fhMap["host_parameters_attributes"] = fh.HostParameters
 	}
-	log.Debugf("fhMap: [%+v]", fhMap)
+	if fh.ProgressReportId != "" {
+		fhMap["progress_report_id"] = fh.ProgressReportId
+	}
+	log.Debugf("fhMap: [%+v]", redactValue(fhMap))
 
 	return json.Marshal(fhMap)
 }
@@ -176,63 +296,36 @@ func (fh ForemanHost) MarshalJSON() ([]byte, error) {
 // Custom JSON unmarshal function. Unmarshal to the unexported JSON struct
 // and then convert over to a ForemanHost struct.
 func (fh *ForemanHost) UnmarshalJSON(b []byte) error {
-	var jsonDecErr error
-
-	// Unmarshal the common Foreman object properties
-	var fo ForemanObject
-	jsonDecErr = json.Unmarshal(b, &fo)
-	if jsonDecErr != nil {
-		return jsonDecErr
-	}
-	fh.ForemanObject = fo
-
-	// Unmarshal to temporary JSON struct to get the properties with differently
-	// named keys
 	var fhJSON foremanHostJSON
-	jsonDecErr = json.Unmarshal(b, &fhJSON)
+	jsonDecErr := json.Unmarshal(b, &fhJSON)
 	if jsonDecErr != nil {
 		return jsonDecErr
 	}
-	fh.InterfacesAttributes = fhJSON.InterfacesAttributes
 
-	var fhParameterJSON foremanHostParameterJSON
-	jsonDecErr = json.Unmarshal(b, &fhParameterJSON)
-	if jsonDecErr != nil {
-		return jsonDecErr
+	fh.ForemanObject = fhJSON.ForemanObject
+	fh.InterfacesAttributes = fhJSON.InterfacesAttributes
+	fh.HostParameters = fhJSON.Parameters
+	if len(fh.HostParameters) == 0 {
+		fh.HostParameters = fhJSON.HostParameters
 	}
-	fh.HostParameters = fhParameterJSON.HostParameters
 
-	// Unmarshal into mapstructure and set the rest of the struct properties
-	// NOTE(ALL): Properties unmarshalled are of type float64 as opposed to int, hence the below testing
-	// Without this, properties will define as default values in state file.
-	var fhMap map[string]interface{}
-	jsonDecErr = json.Unmarshal(b, &fhMap)
-	if jsonDecErr != nil {
-		return jsonDecErr
-	}
-	log.Debugf("fhMap: [%v]", fhMap)
-	var ok bool
-	if fh.Build, ok = fhMap["build"].(bool); !ok {
-		fh.Build = false
-	}
-	if fh.Method, ok = fhMap["method"].(string); !ok {
-		fh.Method = "build"
+	fh.Build = fhJSON.Build.Value
+	fh.Method = "build"
+	if fhJSON.Method.Valid {
+		fh.Method = fhJSON.Method.Value
 	}
-	if fh.Comment, ok = fhMap["comment"].(string); !ok {
-		fh.Comment = ""
-	}
-	if fh.DomainName, ok = fhMap["domain_name"].(string); !ok {
-		fh.DomainName = ""
-	}
-
-	// Unmarshal the remaining foreign keys to their id
-	fh.DomainId = unmarshalInteger(fhMap["domain_id"])
-	fh.EnvironmentId = unmarshalInteger(fhMap["environment_id"])
-	fh.HostgroupId = unmarshalInteger(fhMap["hostgroup_id"])
-	fh.OperatingSystemId = unmarshalInteger(fhMap["operatingsystem_id"])
-	fh.MediumId = unmarshalInteger(fhMap["medium_id"])
-	fh.ComputeResourceId = unmarshalInteger(fhMap["compute_resource_id"])
-	fh.ComputeProfileId = unmarshalInteger(fhMap["compute_profile_id"])
+	fh.Comment = fhJSON.Comment.Value
+	fh.DomainName = fhJSON.DomainName.Value
+
+	// Convert the remaining foreign keys to their id
+	fh.DomainId = int(fhJSON.DomainId.Value)
+	fh.EnvironmentId = int(fhJSON.EnvironmentId.Value)
+	fh.HostgroupId = int(fhJSON.HostgroupId.Value)
+	fh.OperatingSystemId = int(fhJSON.OperatingSystemId.Value)
+	fh.MediumId = int(fhJSON.MediumId.Value)
+	fh.PtableId = int(fhJSON.PtableId.Value)
+	fh.ComputeResourceId = int(fhJSON.ComputeResourceId.Value)
+	fh.ComputeProfileId = int(fhJSON.ComputeProfileId.Value)
 
 	// Foreman returns FQDN as Name but doesnt accept it as Name in return. Great times
 	if fh.DomainName != "" && strings.Contains(fh.ForemanObject.Name, fh.DomainName) {
@@ -242,22 +335,65 @@ func (fh *ForemanHost) UnmarshalJSON(b []byte) error {
 	return nil
 }
 
+// PowerVerifyConfig controls the power state queries confirming a power
+// action took effect.  BMCs frequently acknowledge an action before the host
+// changes its state.  The queries stop at whichever of Count and Timeout is
+// reached first, with both set to 0 the verification is disabled.
+type PowerVerifyConfig struct {
+	// Number of state queries made before giving up.  With a Timeout, 0
+	// queries the state until the timeout expires.
+	Count int
+	// Time to wait before each state query
+	Delay time.Duration
+	// Time after which no further state query is made.  0 disables the
+	// timeout.
+	Timeout time.Duration
+}
+
+// enabled returns whether or not power actions are verified
+func (verify PowerVerifyConfig) enabled() bool {
+	return verify.Count > 0 || verify.Timeout > 0
+}
+
+// powerVerifyMinDelay is the delay between state queries made until a
+// timeout expires, when no delay is configured
+var powerVerifyMinDelay = time.Second
+
+// expectedPowerState returns the power state a host reports once the supplied
+// power action took effect.  An empty string is returned for actions whose
+// outcome can not be verified.
+func expectedPowerState(action string) string {
+	switch action {
+	case PowerOn, PowerCycle, PowerReboot:
+		return PowerOn
+	case PowerOff, PowerSoft, PowerStop:
+		return PowerOff
+	}
+	return ""
+}
+
 // SendPowerCommand sends provided Action and State to foreman.  This
 // performs an IPMI action against the provided host Expects Power or
-// BMCBoot type struct populated with an action
+// BMCBoot type struct populated with an action.  Power actions are confirmed
+// with state queries as configured by the supplied PowerVerifyConfig.
 //
 // Example: https://<foreman>/api/hosts/<hostname>/boot
-func (c *Client) SendPowerCommand(h *ForemanHost, cmd interface{}, retryCount int) error {
+func (c *Client) SendPowerCommand(h *ForemanHost, cmd interface{}, retry RetryConfig, verify PowerVerifyConfig) error {
 	// Initialize suffix variable,
 	suffix := ""
+	expectedState := ""
+	action := ""
 
 	// Defines the suffix to append to the URL per operation type
 	// Switch-Case against interface type to determine URL suffix
 	switch v := cmd.(type) {
 	case Power:
 		suffix = PowerSuffix
+		expectedState = expectedPowerState(v.PowerAction)
+		action = v.PowerAction
 	case BMCBoot:
 		suffix = BootSuffix
+		action = BootSuffix + " " + v.Device
 	default:
 		return fmt.Errorf("Invalid Operation: [%v]", v)
 	}
@@ -274,38 +410,300 @@ func (c *Client) SendPowerCommand(h *ForemanHost, cmd interface{}, retryCount in
 	if reqErr != nil {
 		return reqErr
 	}
+	req = WithLongRunningTimeout(req)
 
-	retry := 0
-	var sendErr error
 	// retry until the successful Operation
 	// or until # of allowed retries is reached
-	for retry < retryCount {
-		log.Debugf("SendPower: Retry #[%d]", retry)
-		sendErr = c.SendAndParse(req, &cmd)
-		if sendErr != nil {
-			retry++
-		} else {
+	var response json.RawMessage
+	sendErr := c.SendAndParseWithRetry(req, &response, bmcRetryConfig(retry))
+	if sendErr != nil {
+		return newBMCError(sendErr, h.Id, action)
+	}
+
+	log.Debugf("Power Response: [%s]", response)
+
+	// Test operation and return an error if result is false
+	if suffix == BootSuffix {
+		var bootResponse bmcBootResponse
+		if jsonDecErr := json.Unmarshal(response, &bootResponse); jsonDecErr != nil {
+			return jsonDecErr
+		}
+		if bootResponse.Boot.Result != nil && !*bootResponse.Boot.Result {
+			return fmt.Errorf("Failed Boot Device Operation")
+		}
+		return nil
+	}
+	var powerMap map[string]interface{}
+	if jsonDecErr := json.Unmarshal(response, &powerMap); jsonDecErr != nil {
+		return jsonDecErr
+	}
+	if powerMap[PowerSuffix] == false {
+		return fmt.Errorf("Failed Power Operation")
+	}
+
+	if !verify.enabled() || expectedState == "" {
+		return nil
+	}
+	return c.verifyPowerState(h.Id, expectedState, verify)
+}
+
+// verifyPowerState queries the power state of the host identified by the
+// supplied ID until it reports the expected state, as configured by the
+// supplied PowerVerifyConfig.
+func (c *Client) verifyPowerState(id int, expectedState string, verify PowerVerifyConfig) error {
+	log.Tracef("foreman/api/host.go#verifyPowerState")
+
+	var deadline time.Time
+	if verify.Timeout > 0 {
+		deadline = time.Now().Add(verify.Timeout)
+		if verify.Count < 1 && verify.Delay < powerVerifyMinDelay {
+			verify.Delay = powerVerifyMinDelay
+		}
+	}
+
+	var state string
+	var stateErr error
+	attempt := 0
+	for ; verify.Count < 1 || attempt < verify.Count; attempt++ {
+		// NOTE(ALL): the state is queried at least once, even if the delay
+		//   exceeds the timeout
+		if attempt > 0 && !deadline.IsZero() && time.Now().Add(verify.Delay).After(deadline) {
 			break
 		}
+		time.Sleep(verify.Delay)
+		state, stateErr = c.ReadPowerState(id)
+		if stateErr != nil {
+			log.Debugf("Power state query #[%d] failed: [%s]", attempt, stateErr)
+			continue
+		}
+		log.Debugf("Power state query #[%d]: [%s]", attempt, state)
+		if state == expectedState {
+			return nil
+		}
+	}
+	if stateErr != nil {
+		return fmt.Errorf(
+			"Failed to verify the power state of host [%d]: %s",
+			id,
+			stateErr,
+		)
 	}
+	return fmt.Errorf(
+		"Host [%d] reports power state [%s] after [%d] queries, expected [%s]",
+		id,
+		state,
+		attempt,
+		expectedState,
+	)
+}
 
-	if sendErr != nil {
-		return sendErr
+// bmcCommandDelay is the time waited between chained BMC operations, BMCs
+// tend to reject an operation which follows right after another one
+var bmcCommandDelay = 3 * time.Second
+
+// PoweredByComputeResource returns whether or not the power actions of the
+// host are performed through the VM power API of its compute resource.  This
+// is the case for virtual machines without a BMC interface.
+func (fh ForemanHost) PoweredByComputeResource() bool {
+	if fh.ComputeResourceId == 0 {
+		return false
 	}
+	for _, iface := range fh.InterfacesAttributes {
+		if iface.Type == "bmc" && !iface.Destroy {
+			return false
+		}
+	}
+	return true
+}
 
-	// Type Assertion to access map fields for Power and BMCBoot types
-	powerMap, _ := cmd.(map[string]interface{})
-	bootMap, _ := cmd.(map[string]map[string]interface{})
+// ProvisionBoot boots the supplied host from PXE so it picks up its
+// provisioning.  The next boot device is set to PXE through the BMC, then the
+// host is power cycled.  The power cycle is confirmed as configured by the
+// supplied PowerVerifyConfig.
+//
+// Hosts powered by their compute resource have no boot device to set, virtual
+// machines boot from the network as configured by their compute profile.
+// They are powered on, or power cycled if they are running already.
+func (c *Client) ProvisionBoot(h *ForemanHost, retry RetryConfig, verify PowerVerifyConfig) error {
+	log.Tracef("foreman/api/host.go#ProvisionBoot")
+
+	if h.PoweredByComputeResource() {
+		powerAction := PowerCycle
+		if state, stateErr := c.ReadPowerState(h.Id); stateErr == nil && state == PowerOff {
+			powerAction = PowerOn
+		}
+		log.Debugf("Powering virtual machine [%d] with [%s]", h.Id, powerAction)
+		return c.SendPowerCommand(h, Power{PowerAction: powerAction}, retry, verify)
+	}
 
-	log.Debugf("Power Response: [%+v]", cmd)
+	bootErr := c.SendPowerCommand(h, BMCBoot{Device: BootPxe}, retry, verify)
+	if bootErr != nil {
+		return fmt.Errorf("Setting the boot device of host [%d] to PXE failed: %s", h.Id, bootErr)
+	}
 
-	// Test operation and return an error if result is false
-	if powerMap[PowerSuffix] == false || bootMap[BootSuffix]["result"] == false {
-		return fmt.Errorf("Failed Power Operation")
+	time.Sleep(bmcCommandDelay)
+
+	cycleErr := c.SendPowerCommand(h, Power{PowerAction: PowerCycle}, retry, verify)
+	if cycleErr != nil {
+		return fmt.Errorf("Power cycling host [%d] failed: %s", h.Id, cycleErr)
 	}
 	return nil
 }
 
+// ShutdownHost shuts the supplied host down before it is decommissioned.  The
+// graceful power action (ie: "stop" for virtual machines, "soft" for BMCs) is
+// sent first.  If the host does not report being off within the grace period,
+// it is powered off hard, which is confirmed as configured by the supplied
+// PowerVerifyConfig.  A grace period of 0 powers the host off hard right away.
+// Hosts already reported as off are left untouched.
+func (c *Client) ShutdownHost(h *ForemanHost, gracefulAction string, grace time.Duration, retry RetryConfig, verify PowerVerifyConfig) error {
+	log.Tracef("foreman/api/host.go#ShutdownHost")
+
+	state, stateErr := c.ReadPowerState(h.Id)
+	if stateErr == nil && state == PowerOff {
+		log.Debugf("Host [%d] is already powered off", h.Id)
+		return nil
+	}
+
+	if grace > 0 {
+		graceVerify := PowerVerifyConfig{
+			Delay:   verify.Delay,
+			Timeout: grace,
+		}
+		gracefulErr := c.SendPowerCommand(h, Power{PowerAction: gracefulAction}, retry, graceVerify)
+		if gracefulErr == nil {
+			return nil
+		}
+		log.Debugf(
+			"Host [%d] did not shut down within [%s], powering it off: %s",
+			h.Id,
+			grace,
+			gracefulErr,
+		)
+	}
+
+	return c.SendPowerCommand(h, Power{PowerAction: PowerOff}, retry, verify)
+}
+
+// ReadPowerState queries the BMC power state of the host identified by the
+// supplied ID using the "state" power action and returns the reported state
+// (ie: "on", "off").  Unlike SendPowerCommand, this does not mutate the host.
+//
+// Example: https://<foreman>/api/hosts/<id>/power
+func (c *Client) ReadPowerState(id int) (string, error) {
+	log.Tracef("foreman/api/host.go#ReadPowerState")
+
+	reqEndpoint := fmt.Sprintf("/%s/%d/%s", HostEndpointPrefix, id, PowerSuffix)
+
+	JSONBytes, jsonEncErr := json.Marshal(Power{PowerAction: PowerState})
+	if jsonEncErr != nil {
+		return "", jsonEncErr
+	}
+
+	req, reqErr := c.NewRequest(http.MethodPut, reqEndpoint, bytes.NewBuffer(JSONBytes))
+	if reqErr != nil {
+		return "", reqErr
+	}
+
+	// NOTE(ALL): Foreman answers the "state" action with the state string
+	//   in the "power" key, while other actions answer with a boolean
+	var powerMap map[string]interface{}
+	sendErr := c.SendAndParse(req, &powerMap)
+	if sendErr != nil {
+		return "", newBMCError(sendErr, id, PowerState)
+	}
+
+	log.Debugf("Power State Response: [%+v]", powerMap)
+
+	state, ok := powerMap[PowerSuffix].(string)
+	if !ok {
+		return "", fmt.Errorf("Unexpected power state response: [%v]", powerMap)
+	}
+	return state, nil
+}
+
+// foremanHostTemplateJSON struct used for JSON decode of a rendered host
+// template preview.
+type foremanHostTemplateJSON struct {
+	Template string `json:"template"`
+}
+
+// RenderHostTemplate renders the provisioning template of the supplied kind
+// (ie: "provision", "PXELinux", "user_data") that Foreman resolved for the
+// host identified by the supplied ID, and returns the rendered content.
+//
+// Example: https://<foreman>/api/hosts/<id>/template/provision
+func (c *Client) RenderHostTemplate(id int, kind string) (string, error) {
+	log.Tracef("foreman/api/host.go#RenderHostTemplate")
+
+	reqEndpoint := fmt.Sprintf("/%s/%d/%s/%s", HostEndpointPrefix, id, TemplateSuffix, kind)
+
+	req, reqErr := c.NewRequest(
+		http.MethodGet,
+		reqEndpoint,
+		nil,
+	)
+	if reqErr != nil {
+		return "", reqErr
+	}
+
+	var rendered foremanHostTemplateJSON
+	sendErr := c.SendAndParse(req, &rendered)
+	if sendErr != nil {
+		return "", sendErr
+	}
+
+	return rendered.Template, nil
+}
+
+// UnattendedPath : Path of the unattended endpoint serving the provisioning
+// templates to the hosts being built.  The endpoint is not part of the API.
+const UnattendedPath = "/unattended/%s"
+
+// RenderUnattendedTemplate fetches the provisioning template of the supplied
+// kind (ie: "provision", "finish", "user_data") from the unattended endpoint,
+// identifying the host by its build token.  The content is exactly what the
+// host receives while it is built, rendered with the host's current
+// settings.  Foreman only serves the templates of hosts in build mode.
+//
+// Example: https://<foreman>/unattended/provision?token=<token>
+func (c *Client) RenderUnattendedTemplate(token string, kind string) (string, error) {
+	log.Tracef("foreman/api/host.go#RenderUnattendedTemplate")
+
+	req, reqErr := c.NewRequest(
+		http.MethodGet,
+		"/",
+		nil,
+	)
+	if reqErr != nil {
+		return "", reqErr
+	}
+	req.URL.Path = fmt.Sprintf(UnattendedPath, url.PathEscape(kind))
+	req.URL.RawQuery = url.Values{"token": []string{token}}.Encode()
+
+	statusCode, respBody, sendErr := c.Send(req)
+	if sendErr != nil {
+		return "", sendErr
+	}
+	if statusCode < 200 || statusCode > 299 {
+		// NOTE(ALL): do not report the token with the endpoint
+		req.URL.RawQuery = ""
+		return "", &HTTPError{
+			Endpoint:   req.URL.String(),
+			StatusCode: statusCode,
+			RespBody:   respBody,
+		}
+	}
+	return string(respBody), nil
+}
+
+// restoreHiddenHostParameters restores the values of the hidden parameters of
+// the received host from the sent host.  Create and update responses mask the
+// values of hidden parameters.
+func restoreHiddenHostParameters(sent *ForemanHost, received *ForemanHost) {
+	restoreHiddenParameters(sent.HostParameters, received.HostParameters)
+}
+
 // -----------------------------------------------------------------------------
 // CRUD Implementation
 // -----------------------------------------------------------------------------
@@ -314,7 +712,12 @@ func (c *Client) SendPowerCommand(h *ForemanHost, cmd interface{}, retryCount in
 // ForemanHost reference and returns the created ForemanHost reference.  The
 // returned reference will have its ID and other API default values set by this
 // function.
-func (c *Client) CreateHost(h *ForemanHost, retryCount int) (*ForemanHost, error) {
+//
+// If the host carries a ProgressReportId, the orchestration tasks of the
+// creation are polled while the request is running, logging their progress.
+// Failed orchestrations (ie: a DNS conflict, an existing DHCP lease) are
+// returned as an OrchestrationError without retrying the request.
+func (c *Client) CreateHost(h *ForemanHost, retry RetryConfig) (*ForemanHost, error) {
 	log.Tracef("foreman/api/host.go#Create")
 
 	reqEndpoint := fmt.Sprintf("/%s", HostEndpointPrefix)
@@ -324,7 +727,7 @@ func (c *Client) CreateHost(h *ForemanHost, retryCount int) (*ForemanHost, error
 		return nil, jsonEncErr
 	}
 
-	log.Debugf("hJSONBytes: [%s]", hJSONBytes)
+	log.Debugf("hJSONBytes: [%s]", redactJSON(hJSONBytes))
 
 	req, reqErr := c.NewRequest(
 		http.MethodPost,
@@ -334,27 +737,39 @@ func (c *Client) CreateHost(h *ForemanHost, retryCount int) (*ForemanHost, error
 	if reqErr != nil {
 		return nil, reqErr
 	}
+	// NOTE(ALL): creating a host on a compute resource creates the VM within
+	//   the same request, which routinely takes minutes
+	req = WithLongRunningTimeout(req)
 
 	var createdHost ForemanHost
 
-	retry := 0
-	var sendErr error
+	var watcher *orchestrationWatcher
+	if h.ProgressReportId != "" && c.config.LookupEnabled(LookupBuildStatus) {
+		watcher = c.watchOrchestration(h.ProgressReportId)
+	}
+	retry.Retryable = func(err error) bool {
+		return !isUnrecoverableHostCreationError(err) && len(watcher.failedTasks()) == 0
+	}
+
 	// retry until successful Host creation
 	// or until # of allowed retries is reached
-	for retry < retryCount {
-		log.Debugf("CreatedHost: Retry #[%d]", retry)
-		sendErr = c.SendAndParse(req, &createdHost)
-		if sendErr != nil {
-			retry++
-		} else {
-			break
+	sendErr := c.SendAndParseWithRetry(req, &createdHost, retry)
+	// NOTE(ALL): failed orchestrations are rolled back and reported by the
+	//   server, read the final status of the tasks in that case
+	var httpErr *HTTPError
+	isHTTPErr := errors.As(sendErr, &httpErr)
+	if failedTasks := watcher.stop(isHTTPErr); sendErr != nil {
+		if len(failedTasks) > 0 {
+			return nil, &OrchestrationError{
+				Tasks: failedTasks,
+				Err:   sendErr,
+			}
 		}
-	}
-
-	if sendErr != nil {
 		return nil, sendErr
 	}
 
+	restoreHiddenHostParameters(h, &createdHost)
+
 	log.Debugf("createdHost: [%+v]", createdHost)
 
 	return &createdHost, nil
@@ -375,6 +790,11 @@ func (c *Client) ReadHost(id int) (*ForemanHost, error) {
 	if reqErr != nil {
 		return nil, reqErr
 	}
+	// NOTE(ALL): read the parameters (including the values of hidden ones)
+	//   and the interfaces with the host instead of looking them up separately
+	reqQuery := req.URL.Query()
+	reqQuery.Set("show_hidden_parameters", "true")
+	req.URL.RawQuery = reqQuery.Encode()
 
 	var readHost ForemanHost
 	sendErr := c.SendAndParse(req, &readHost)
@@ -390,7 +810,7 @@ func (c *Client) ReadHost(id int) (*ForemanHost, error) {
 // UpdateHost updates a ForemanHost's attributes.  The host with the ID of the
 // supplied ForemanHost will be updated. A new ForemanHost reference is
 // returned with the attributes from the result of the update operation.
-func (c *Client) UpdateHost(h *ForemanHost, retryCount int) (*ForemanHost, error) {
+func (c *Client) UpdateHost(h *ForemanHost, retry RetryConfig) (*ForemanHost, error) {
 	log.Tracef("foreman/api/host.go#Update")
 
 	reqEndpoint := fmt.Sprintf("/%s/%d", HostEndpointPrefix, h.Id)
@@ -400,7 +820,7 @@ func (c *Client) UpdateHost(h *ForemanHost, retryCount int) (*ForemanHost, error
 		return nil, jsonEncErr
 	}
 
-	log.Debugf("hostJSONBytes: [%s]", hJSONBytes)
+	log.Debugf("hostJSONBytes: [%s]", redactJSON(hJSONBytes))
 
 	req, reqErr := c.NewRequest(
 		http.MethodPut,
@@ -410,31 +830,78 @@ func (c *Client) UpdateHost(h *ForemanHost, retryCount int) (*ForemanHost, error
 	if reqErr != nil {
 		return nil, reqErr
 	}
+	req = WithLongRunningTimeout(req)
 
 	var updatedHost ForemanHost
-	retry := 0
-	var sendErr error
 	// retry until the successful Host Update
 	// or until # of allowed retries is reached
-	for retry < retryCount {
-		log.Debugf("UpdateHost: Retry #[%d]", retry)
-		sendErr = c.SendAndParse(req, &updatedHost)
-		if sendErr != nil {
-			retry++
-		} else {
-			break
-		}
-	}
-
+	sendErr := c.SendAndParseWithRetry(req, &updatedHost, retry)
 	if sendErr != nil {
 		return nil, sendErr
 	}
 
+	restoreHiddenHostParameters(h, &updatedHost)
+
 	log.Debugf("updatedHost: [%+v]", updatedHost)
 
 	return &updatedHost, nil
 }
 
+// CancelHostBuild cancels the pending build of the host identified by the
+// supplied ID.  Clearing the build flag expires the host's build token and
+// removes its PXE configuration, so the host boots from its disk again
+// instead of reinstalling.
+func (c *Client) CancelHostBuild(id int) error {
+	log.Tracef("foreman/api/host.go#CancelBuild")
+
+	reqEndpoint := fmt.Sprintf("/%s/%d", HostEndpointPrefix, id)
+
+	hJSONBytes, jsonEncErr := WrapJson("host", map[string]bool{"build": false})
+	if jsonEncErr != nil {
+		return jsonEncErr
+	}
+
+	req, reqErr := c.NewRequest(
+		http.MethodPut,
+		reqEndpoint,
+		bytes.NewBuffer(hJSONBytes),
+	)
+	if reqErr != nil {
+		return reqErr
+	}
+
+	return c.SendAndParse(req, nil)
+}
+
+// UnmanageHost releases the host identified by the supplied ID from
+// Foreman's management instead of deleting it.  The pending build of the
+// host is cancelled and Foreman no longer manages its provisioning, DNS and
+// DHCP, while the host and its history remain in the inventory.
+func (c *Client) UnmanageHost(id int) error {
+	log.Tracef("foreman/api/host.go#Unmanage")
+
+	reqEndpoint := fmt.Sprintf("/%s/%d", HostEndpointPrefix, id)
+
+	hJSONBytes, jsonEncErr := WrapJson("host", map[string]bool{
+		"build":   false,
+		"managed": false,
+	})
+	if jsonEncErr != nil {
+		return jsonEncErr
+	}
+
+	req, reqErr := c.NewRequest(
+		http.MethodPut,
+		reqEndpoint,
+		bytes.NewBuffer(hJSONBytes),
+	)
+	if reqErr != nil {
+		return reqErr
+	}
+
+	return c.SendAndParse(req, nil)
+}
+
 // DeleteHost deletes the ForemanHost identified by the supplied ID
 func (c *Client) DeleteHost(id int) error {
 	log.Tracef("foreman/api/host.go#Delete")
@@ -452,3 +919,33 @@ func (c *Client) DeleteHost(id int) error {
 
 	return c.SendAndParse(req, nil)
 }
+
+// -----------------------------------------------------------------------------
+// Query Implementation
+// -----------------------------------------------------------------------------
+
+// SearchHosts queries for all ForemanHosts matching the supplied Foreman
+// search string and returns a QueryResponse struct containing query/response
+// metadata and the matching hosts.
+func (c *Client) SearchHosts(search string) (QueryResponse, error) {
+	log.Tracef("foreman/api/host.go#Search")
+
+	queryResponse := QueryResponse{}
+
+	reqEndpoint := fmt.Sprintf("/%s", HostEndpointPrefix)
+	req, reqErr := c.NewRequest(
+		http.MethodGet,
+		reqEndpoint,
+		nil,
+	)
+	if reqErr != nil {
+		return queryResponse, reqErr
+	}
+
+	reqQuery := req.URL.Query()
+	reqQuery.Set("search", search)
+	reqQuery.Set("per_page", "all")
+
+	req.URL.RawQuery = reqQuery.Encode()
+	return sendAndParseQuery[ForemanHost](c, req)
+}
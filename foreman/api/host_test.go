@@ -0,0 +1,424 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// ----------------------------------------------------------------------------
+// SendPowerCommand
+// ----------------------------------------------------------------------------
+
+// Ensures power actions are confirmed with state queries until the host
+// reports the expected state, and fail once the queries are exhausted.
+func TestSendPowerCommand_VerifyState(t *testing.T) {
+	urlMux, server, client := NewForemanAPIAndClient(ClientCredentials{}, ClientConfig{})
+	defer server.Close()
+
+	stateQueries := 0
+	urlMux.HandleFunc(FOREMAN_API_URL_PREFIX+"/hosts/1/power", func(w http.ResponseWriter, r *http.Request) {
+		var power Power
+		json.NewDecoder(r.Body).Decode(&power)
+		if power.PowerAction != PowerState {
+			fmt.Fprint(w, `{"power": true}`)
+			return
+		}
+		stateQueries++
+		// NOTE(ALL): the host reports its new state on the third query
+		if stateQueries < 3 {
+			fmt.Fprint(w, `{"power": "off"}`)
+			return
+		}
+		fmt.Fprint(w, `{"power": "on"}`)
+	})
+
+	h := &ForemanHost{}
+	h.Id = 1
+	cmd := Power{PowerAction: PowerOn}
+
+	sendErr := client.SendPowerCommand(h, cmd, RetryConfig{}, PowerVerifyConfig{Count: 5})
+	if sendErr != nil {
+		t.Fatalf("SendPowerCommand returned an error: %s", sendErr)
+	}
+	if stateQueries != 3 {
+		t.Fatalf("Expected [3] power state queries, got [%d]", stateQueries)
+	}
+
+	stateQueries = 0
+	sendErr = client.SendPowerCommand(h, cmd, RetryConfig{}, PowerVerifyConfig{Count: 2})
+	if sendErr == nil {
+		t.Fatalf("SendPowerCommand did not fail although the host never reported the expected state")
+	}
+
+	stateQueries = 0
+	sendErr = client.SendPowerCommand(h, cmd, RetryConfig{}, PowerVerifyConfig{})
+	if sendErr != nil || stateQueries != 0 {
+		t.Fatalf(
+			"Expected no power state queries without verification, got [%d] (%v)",
+			stateQueries,
+			sendErr,
+		)
+	}
+
+	defer func(delay time.Duration) { powerVerifyMinDelay = delay }(powerVerifyMinDelay)
+	powerVerifyMinDelay = time.Millisecond
+
+	stateQueries = 0
+	verify := PowerVerifyConfig{Delay: 10 * time.Millisecond, Timeout: time.Second}
+	sendErr = client.SendPowerCommand(h, cmd, RetryConfig{}, verify)
+	if sendErr != nil || stateQueries != 3 {
+		t.Fatalf(
+			"Expected the state to be queried until the host reports it, got "+
+				"[%d] queries (%v)",
+			stateQueries,
+			sendErr,
+		)
+	}
+
+	// NOTE(ALL): the host never reports the expected state after the first
+	//   query, the queries stop once the timeout expires
+	stateQueries = -1000
+	verify = PowerVerifyConfig{Delay: 10 * time.Millisecond, Timeout: 50 * time.Millisecond}
+	start := time.Now()
+	sendErr = client.SendPowerCommand(h, cmd, RetryConfig{}, verify)
+	if sendErr == nil {
+		t.Fatalf("SendPowerCommand did not fail although the timeout expired")
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Fatalf("Expected the queries to stop after the timeout, took [%s]", elapsed)
+	}
+}
+
+// Ensures boot device changes only fail on an explicit negative result, as
+// Redfish BMCs accept the change without reporting a result.
+func TestSendPowerCommand_BootResult(t *testing.T) {
+	urlMux, server, client := NewForemanAPIAndClient(ClientCredentials{}, ClientConfig{})
+	defer server.Close()
+
+	response := ""
+	urlMux.HandleFunc(FOREMAN_API_URL_PREFIX+"/hosts/1/boot", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, response)
+	})
+
+	h := &ForemanHost{}
+	h.Id = 1
+	cmd := BMCBoot{Device: BootPxe}
+
+	testCases := []struct {
+		response string
+		fail     bool
+	}{
+		{`{"boot": {"action": "pxe", "result": true}}`, false},
+		{`{"boot": {"action": "pxe", "result": false}}`, true},
+		{`{"boot": {"action": "pxe"}}`, false},
+	}
+	for _, testCase := range testCases {
+		response = testCase.response
+		sendErr := client.SendPowerCommand(h, cmd, RetryConfig{}, PowerVerifyConfig{})
+		if testCase.fail != (sendErr != nil) {
+			t.Errorf("Unexpected result [%v] for the boot response [%s]", sendErr, testCase.response)
+		}
+	}
+}
+
+// Ensures failed power operations are classified, and authentication errors
+// and missing smart proxies are not retried.
+func TestSendPowerCommand_BMCErrorRetries(t *testing.T) {
+	urlMux, server, client := NewForemanAPIAndClient(ClientCredentials{}, ClientConfig{})
+	defer server.Close()
+
+	status := http.StatusInternalServerError
+	body := ""
+	requests := 0
+	urlMux.HandleFunc(FOREMAN_API_URL_PREFIX+"/hosts/1/power", func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.WriteHeader(status)
+		fmt.Fprint(w, body)
+	})
+
+	h := &ForemanHost{}
+	h.Id = 1
+	retry := RetryConfig{Count: 3}
+
+	testCases := []struct {
+		status   int
+		body     string
+		kind     string
+		requests int
+	}{
+		{http.StatusInternalServerError, `{"error": {"message": "RAKP 2 HMAC is invalid"}}`, BMCErrorAuth, 1},
+		{http.StatusUnprocessableEntity, `{"error": {"message": "No BMC NIC available for host"}}`, BMCErrorProxy, 1},
+		{http.StatusInternalServerError, `{"error": {"message": "Connection timed out"}}`, BMCErrorTransport, 3},
+		{http.StatusGatewayTimeout, ``, BMCErrorTransport, 3},
+		{http.StatusInternalServerError, `{"error": {"message": "boom"}}`, BMCErrorUnknown, 3},
+	}
+	for _, testCase := range testCases {
+		status, body, requests = testCase.status, testCase.body, 0
+		sendErr := client.SendPowerCommand(h, Power{PowerAction: PowerOn}, retry, PowerVerifyConfig{})
+		if !IsBMCError(sendErr, testCase.kind) {
+			t.Errorf("Expected a [%s] BMC error for [%s], got: %v", testCase.kind, testCase.body, sendErr)
+		}
+		if requests != testCase.requests {
+			t.Errorf(
+				"Expected [%d] requests for [%s], got [%d]",
+				testCase.requests,
+				testCase.body,
+				requests,
+			)
+		}
+	}
+}
+
+// Ensures the boot device is set to PXE before the host is power cycled, and
+// the host is not power cycled if the boot device could not be set.
+func TestProvisionBoot(t *testing.T) {
+	urlMux, server, client := NewForemanAPIAndClient(ClientCredentials{}, ClientConfig{})
+	defer server.Close()
+
+	defer func(delay time.Duration) { bmcCommandDelay = delay }(bmcCommandDelay)
+	bmcCommandDelay = 0
+
+	bootStatus := http.StatusOK
+	operations := []string{}
+	urlMux.HandleFunc(FOREMAN_API_URL_PREFIX+"/hosts/1/boot", func(w http.ResponseWriter, r *http.Request) {
+		var boot BMCBoot
+		json.NewDecoder(r.Body).Decode(&boot)
+		operations = append(operations, "boot "+boot.Device)
+		w.WriteHeader(bootStatus)
+		fmt.Fprintf(w, `{"boot": {"action": "%s", "result": true}}`, boot.Device)
+	})
+	urlMux.HandleFunc(FOREMAN_API_URL_PREFIX+"/hosts/1/power", func(w http.ResponseWriter, r *http.Request) {
+		var power Power
+		json.NewDecoder(r.Body).Decode(&power)
+		operations = append(operations, "power "+power.PowerAction)
+		if power.PowerAction == PowerState {
+			fmt.Fprint(w, `{"power": "off"}`)
+			return
+		}
+		fmt.Fprint(w, `{"power": true}`)
+	})
+
+	h := &ForemanHost{}
+	h.Id = 1
+
+	if bootErr := client.ProvisionBoot(h, RetryConfig{}, PowerVerifyConfig{}); bootErr != nil {
+		t.Fatalf("ProvisionBoot returned an error: %s", bootErr)
+	}
+	if !reflect.DeepEqual(operations, []string{"boot pxe", "power cycle"}) {
+		t.Fatalf("Expected the operations [boot pxe, power cycle], got %v", operations)
+	}
+
+	bootStatus = http.StatusInternalServerError
+	operations = []string{}
+	if bootErr := client.ProvisionBoot(h, RetryConfig{}, PowerVerifyConfig{}); bootErr == nil {
+		t.Fatalf("ProvisionBoot did not fail although the boot device could not be set")
+	}
+	if !reflect.DeepEqual(operations, []string{"boot pxe"}) {
+		t.Fatalf("Expected the host not to be power cycled, got %v", operations)
+	}
+
+	// NOTE(ALL): virtual machines without a BMC interface have no boot device
+	//   to set and are powered on through their compute resource
+	h.ComputeResourceId = 2
+	operations = []string{}
+	if bootErr := client.ProvisionBoot(h, RetryConfig{}, PowerVerifyConfig{}); bootErr != nil {
+		t.Fatalf("ProvisionBoot returned an error for a virtual machine: %s", bootErr)
+	}
+	if !reflect.DeepEqual(operations, []string{"power state", "power on"}) {
+		t.Fatalf("Expected the operations [power state, power on], got %v", operations)
+	}
+}
+
+// Ensures a host which does not shut down gracefully within the grace period
+// is powered off, and hosts which are already off are left untouched.
+func TestShutdownHost(t *testing.T) {
+	urlMux, server, client := NewForemanAPIAndClient(ClientCredentials{}, ClientConfig{})
+	defer server.Close()
+
+	state := PowerOn
+	actions := []string{}
+	urlMux.HandleFunc(FOREMAN_API_URL_PREFIX+"/hosts/1/power", func(w http.ResponseWriter, r *http.Request) {
+		var power Power
+		json.NewDecoder(r.Body).Decode(&power)
+		if power.PowerAction == PowerState {
+			fmt.Fprintf(w, `{"power": "%s"}`, state)
+			return
+		}
+		actions = append(actions, power.PowerAction)
+		// NOTE(ALL): the host ignores the graceful shutdown
+		if power.PowerAction == PowerOff {
+			state = PowerOff
+		}
+		fmt.Fprint(w, `{"power": true}`)
+	})
+
+	defer func(delay time.Duration) { powerVerifyMinDelay = delay }(powerVerifyMinDelay)
+	powerVerifyMinDelay = time.Millisecond
+
+	h := &ForemanHost{}
+	h.Id = 1
+	verify := PowerVerifyConfig{Count: 1, Delay: 10 * time.Millisecond}
+
+	shutdownErr := client.ShutdownHost(h, PowerStop, 50*time.Millisecond, RetryConfig{}, verify)
+	if shutdownErr != nil {
+		t.Fatalf("ShutdownHost returned an error: %s", shutdownErr)
+	}
+	if len(actions) != 2 || actions[0] != PowerStop || actions[1] != PowerOff {
+		t.Fatalf("Expected the power actions [stop off], got %v", actions)
+	}
+
+	actions = []string{}
+	shutdownErr = client.ShutdownHost(h, PowerStop, 50*time.Millisecond, RetryConfig{}, verify)
+	if shutdownErr != nil || len(actions) != 0 {
+		t.Fatalf("Expected no power actions for a host which is off, got %v (%v)", actions, shutdownErr)
+	}
+}
+
+// ----------------------------------------------------------------------------
+// ReadHost
+// ----------------------------------------------------------------------------
+
+// Ensures the host is read with its hidden parameters in a single request and
+// the parameters reported by the API are decoded.
+func TestReadHost_Parameters(t *testing.T) {
+	urlMux, server, client := NewForemanAPIAndClient(ClientCredentials{}, ClientConfig{})
+	defer server.Close()
+
+	requests := 0
+	urlMux.HandleFunc(FOREMAN_API_URL_PREFIX+"/hosts/1", func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.URL.Query().Get("show_hidden_parameters") != "true" {
+			t.Errorf("Expected show_hidden_parameters query, got [%s]", r.URL.RawQuery)
+		}
+		fmt.Fprint(w, `{
+			"id": 1,
+			"name": "host.example.com",
+			"parameters": [
+				{"name": "plain", "value": "a", "hidden_value?": false},
+				{"name": "secret", "value": "s3cret", "hidden_value?": true},
+				{"name": "enabled", "value": true, "hidden_value?": false}
+			],
+			"interfaces": [
+				{"id": 2, "identifier": "eth0", "primary": true}
+			]
+		}`)
+	})
+
+	host, err := client.ReadHost(1)
+	if err != nil {
+		t.Fatalf("ReadHost returned an error: %s", err)
+	}
+	if requests != 1 {
+		t.Errorf("Expected a single request, got [%d]", requests)
+	}
+	expected := []ForemanKVParameter{
+		{Name: "plain", Value: "a"},
+		{Name: "secret", Value: "s3cret", HiddenValue: true},
+		{Name: "enabled", Value: "true"},
+	}
+	if len(host.HostParameters) != len(expected) {
+		t.Fatalf("Expected parameters [%+v], got [%+v]", expected, host.HostParameters)
+	}
+	for idx, param := range expected {
+		if host.HostParameters[idx] != param {
+			t.Errorf("Expected parameter [%+v], got [%+v]", param, host.HostParameters[idx])
+		}
+	}
+	if len(host.InterfacesAttributes) != 1 || host.InterfacesAttributes[0].Identifier != "eth0" {
+		t.Errorf("Expected interface eth0, got [%+v]", host.InterfacesAttributes)
+	}
+}
+
+// ----------------------------------------------------------------------------
+// UnmarshalJSON
+// ----------------------------------------------------------------------------
+
+// Ensures the host is decoded in a single pass and null or mistyped
+// properties fall back to their defaults instead of failing the decode.
+func TestForemanHost_UnmarshalJSON(t *testing.T) {
+	var host ForemanHost
+	err := json.Unmarshal([]byte(`{
+		"id": 4,
+		"name": "web01.example.com",
+		"domain_name": "example.com",
+		"build": true,
+		"method": null,
+		"comment": 42,
+		"domain_id": 2,
+		"hostgroup_id": null,
+		"medium_id": "invalid",
+		"compute_resource_id": 3.0,
+		"host_parameters_attributes": [{"name": "a", "value": "b"}]
+	}`), &host)
+	if err != nil {
+		t.Fatalf("UnmarshalJSON returned an error: %s", err)
+	}
+
+	expected := ForemanHost{
+		DomainName:        "example.com",
+		Build:             true,
+		Method:            "build",
+		DomainId:          2,
+		ComputeResourceId: 3,
+		HostParameters:    []ForemanKVParameter{{Name: "a", Value: "b"}},
+	}
+	expected.Id = 4
+	expected.Name = "web01"
+	if host.String() != expected.String() {
+		t.Errorf("Expected host [%s], got [%s]", expected, host)
+	}
+}
+
+// ----------------------------------------------------------------------------
+// CreateHost
+// ----------------------------------------------------------------------------
+
+// Ensures the orchestration tasks of a host creation are polled and a failed
+// orchestration is returned without retrying the creation.
+func TestCreateHost_OrchestrationFailure(t *testing.T) {
+	urlMux, server, client := NewForemanAPIAndClient(ClientCredentials{}, ClientConfig{})
+	defer server.Close()
+
+	defer func(interval time.Duration) { orchestrationPollInterval = interval }(orchestrationPollInterval)
+	orchestrationPollInterval = 10 * time.Millisecond
+
+	attempts := 0
+	urlMux.HandleFunc(FOREMAN_API_URL_PREFIX+"/hosts", func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		var body map[string]map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&body)
+		if body["host"]["progress_report_id"] != "abc" {
+			t.Errorf("Expected progress_report_id [abc], got [%v]", body["host"]["progress_report_id"])
+		}
+		time.Sleep(50 * time.Millisecond)
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		fmt.Fprint(w, `{"error": {"full_messages": ["DNS conflict"]}}`)
+	})
+	urlMux.HandleFunc(FOREMAN_API_URL_PREFIX+"/orchestration/abc/tasks", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `[
+			{"name": "Create IPv4 DNS record for web01.example.com", "status": "conflict"},
+			{"name": "Create DHCP Settings for web01.example.com", "status": "pending"}
+		]`)
+	})
+
+	h := &ForemanHost{ProgressReportId: "abc"}
+	h.Name = "web01"
+	_, err := client.CreateHost(h, RetryConfig{Count: 3})
+	orchestrationErr, ok := err.(*OrchestrationError)
+	if !ok {
+		t.Fatalf("Expected an OrchestrationError, got [%v]", err)
+	}
+	if attempts != 1 {
+		t.Errorf("Expected [1] attempt, got [%d]", attempts)
+	}
+	if len(orchestrationErr.Tasks) != 1 || orchestrationErr.Tasks[0].Status != OrchestrationTaskConflict {
+		t.Errorf("Expected the conflicting DNS task, got [%+v]", orchestrationErr.Tasks)
+	}
+	if _, ok := orchestrationErr.Unwrap().(*HTTPError); !ok {
+		t.Errorf("Expected the HTTPError of the request, got [%v]", orchestrationErr.Unwrap())
+	}
+}
@@ -0,0 +1,65 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+
+	"github.com/wayfair/terraform-provider-utils/log"
+)
+
+const (
+	// HostBulkPowerEndpoint : Endpoint of the bulk hosts API changing the power
+	// state of hosts
+	HostBulkPowerEndpoint = "hosts/bulk/change_power_state"
+)
+
+// -----------------------------------------------------------------------------
+// Struct Definition and Helpers
+// -----------------------------------------------------------------------------
+
+// hostBulkSelection selects the hosts of a bulk action
+type hostBulkSelection struct {
+	Ids []int `json:"ids"`
+}
+
+// hostBulkPower struct used for JSON encode of a bulk power action
+type hostBulkPower struct {
+	Included hostBulkSelection `json:"included"`
+	Power    string            `json:"power"`
+}
+
+// -----------------------------------------------------------------------------
+// Bulk Actions
+// -----------------------------------------------------------------------------
+
+// SendBulkPowerCommand sends the supplied power action (ie: "on", "off") to
+// all hosts identified by the supplied IDs with a single request to the bulk
+// hosts API.  Foreman versions without the bulk hosts API answer with a 404,
+// see IsNotFound.
+//
+// Example: https://<foreman>/api/hosts/bulk/change_power_state
+func (c *Client) SendBulkPowerCommand(ids []int, action string, retry RetryConfig) error {
+	log.Tracef("foreman/api/hostbulk.go#SendBulkPowerCommand")
+
+	JSONBytes, jsonEncErr := json.Marshal(hostBulkPower{
+		Included: hostBulkSelection{Ids: ids},
+		Power:    action,
+	})
+	if jsonEncErr != nil {
+		return jsonEncErr
+	}
+	log.Debugf("JSONBytes: [%s]", JSONBytes)
+
+	req, reqErr := c.NewRequest(http.MethodPut, HostBulkPowerEndpoint, bytes.NewBuffer(JSONBytes))
+	if reqErr != nil {
+		return reqErr
+	}
+	req = WithLongRunningTimeout(req)
+
+	// NOTE(ALL): a missing bulk API is not worth retrying
+	retry.Retryable = func(err error) bool {
+		return !IsNotFound(err)
+	}
+	return c.SendAndParseWithRetry(req, nil, retry)
+}
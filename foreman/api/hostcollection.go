@@ -0,0 +1,153 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/wayfair/terraform-provider-utils/log"
+)
+
+const (
+	// KATELLO_API_URL_PREFIX : Prefix of the API of the Katello plugin.  The
+	// Katello API is not served underneath FOREMAN_API_URL_PREFIX.
+	KATELLO_API_URL_PREFIX = "/katello/api"
+	// HostCollectionEndpointPrefix : Prefix appended to the Katello API url
+	// for host collections
+	HostCollectionEndpointPrefix = "host_collections"
+)
+
+// -----------------------------------------------------------------------------
+// Struct Definition and Helpers
+// -----------------------------------------------------------------------------
+
+// ForemanKatelloHostCollection API model represents a host collection of the
+// Katello plugin.  Host collections group hosts of an organization for bulk
+// content actions.
+type ForemanKatelloHostCollection struct {
+	// Inherits the base object's attributes
+	ForemanObject
+
+	// IDs of the hosts in the host collection
+	HostIds []int `json:"host_ids"`
+}
+
+// hostCollectionHosts struct used for JSON encode of the hosts added to or
+// removed from a host collection
+type hostCollectionHosts struct {
+	HostIds []int `json:"host_ids"`
+}
+
+// hostCollectionHostsResponse struct used for JSON decode of the answer to
+// hosts added to or removed from a host collection.  Katello answers with a
+// 200 and reports the hosts it could not add or remove as error messages.
+type hostCollectionHostsResponse struct {
+	DisplayMessages struct {
+		Success []string `json:"success"`
+		Error   []string `json:"error"`
+	} `json:"displayMessages"`
+}
+
+// newKatelloRequest constructs a request to the API of the Katello plugin the
+// same way NewRequest does for the Foreman API
+func (c *Client) newKatelloRequest(method string, endpoint string, body io.Reader) (*http.Request, error) {
+	req, reqErr := c.NewRequest(method, endpoint, body)
+	if reqErr != nil {
+		return nil, reqErr
+	}
+	req.URL.Path = KATELLO_API_URL_PREFIX + strings.TrimPrefix(req.URL.Path, FOREMAN_API_URL_PREFIX)
+	return req, nil
+}
+
+// -----------------------------------------------------------------------------
+// CRUD Implementation
+// -----------------------------------------------------------------------------
+
+// ReadKatelloHostCollection reads the attributes of a
+// ForemanKatelloHostCollection identified by the supplied ID and returns a
+// ForemanKatelloHostCollection reference.
+func (c *Client) ReadKatelloHostCollection(id int) (*ForemanKatelloHostCollection, error) {
+	log.Tracef("foreman/api/hostcollection.go#Read")
+
+	reqEndpoint := fmt.Sprintf("/%s/%d", HostCollectionEndpointPrefix, id)
+
+	req, reqErr := c.newKatelloRequest(
+		http.MethodGet,
+		reqEndpoint,
+		nil,
+	)
+	if reqErr != nil {
+		return nil, reqErr
+	}
+
+	var readHostCollection ForemanKatelloHostCollection
+	sendErr := c.SendAndParse(req, &readHostCollection)
+	if sendErr != nil {
+		return nil, sendErr
+	}
+
+	log.Debugf("readHostCollection: [%+v]", readHostCollection)
+
+	return &readHostCollection, nil
+}
+
+// AddKatelloHostCollectionHosts adds the hosts identified by the supplied IDs
+// to the host collection identified by the supplied ID.  Hosts already in the
+// host collection are kept.
+//
+// Example: https://<foreman>/katello/api/host_collections/<id>/add_hosts
+func (c *Client) AddKatelloHostCollectionHosts(id int, hostIds []int) error {
+	log.Tracef("foreman/api/hostcollection.go#AddHosts")
+
+	return c.sendKatelloHostCollectionHosts(id, "add_hosts", hostIds)
+}
+
+// RemoveKatelloHostCollectionHosts removes the hosts identified by the
+// supplied IDs from the host collection identified by the supplied ID.  Other
+// hosts are kept in the host collection.
+//
+// Example: https://<foreman>/katello/api/host_collections/<id>/remove_hosts
+func (c *Client) RemoveKatelloHostCollectionHosts(id int, hostIds []int) error {
+	log.Tracef("foreman/api/hostcollection.go#RemoveHosts")
+
+	return c.sendKatelloHostCollectionHosts(id, "remove_hosts", hostIds)
+}
+
+// sendKatelloHostCollectionHosts sends the hosts identified by the supplied
+// IDs to the supplied membership action of a host collection
+func (c *Client) sendKatelloHostCollectionHosts(id int, action string, hostIds []int) error {
+	reqEndpoint := fmt.Sprintf("/%s/%d/%s", HostCollectionEndpointPrefix, id, action)
+
+	JSONBytes, jsonEncErr := json.Marshal(hostCollectionHosts{HostIds: hostIds})
+	if jsonEncErr != nil {
+		return jsonEncErr
+	}
+	log.Debugf("JSONBytes: [%s]", JSONBytes)
+
+	req, reqErr := c.newKatelloRequest(
+		http.MethodPut,
+		reqEndpoint,
+		bytes.NewBuffer(JSONBytes),
+	)
+	if reqErr != nil {
+		return reqErr
+	}
+
+	var response hostCollectionHostsResponse
+	sendErr := c.SendAndParse(req, &response)
+	if sendErr != nil {
+		return sendErr
+	}
+	if len(response.DisplayMessages.Error) > 0 {
+		return fmt.Errorf(
+			"Host collection [%d] %s failed: %s",
+			id,
+			action,
+			strings.Join(response.DisplayMessages.Error, "; "),
+		)
+	}
+	return nil
+}
@@ -0,0 +1,202 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/wayfair/terraform-provider-utils/log"
+)
+
+const (
+	// FactsSuffix : Suffix appended to API url for the facts of a host
+	FactsSuffix = "facts"
+	// ENCSuffix : Suffix appended to API url for the ENC data of a host
+	ENCSuffix = "enc"
+	// LastConfigReportSuffix : Suffix appended to API url for the last
+	// configuration report of a host
+	LastConfigReportSuffix = "config_reports/last"
+	// HostStatusSuffix : Suffix appended to API url for the statuses of a host
+	HostStatusSuffix = "status"
+	// HostStatusBuild : Status of the build of a host
+	HostStatusBuild = "build"
+	// HostStatusGlobal : Status summarizing all statuses of a host
+	HostStatusGlobal = "global"
+)
+
+// -----------------------------------------------------------------------------
+// Struct Definition and Helpers
+// -----------------------------------------------------------------------------
+
+// ForemanConfigReport is a configuration management report (ie: a Puppet
+// run) of a host
+type ForemanConfigReport struct {
+	// Unique identifier of the report
+	Id int `json:"id"`
+	// Timestamp of when the report was generated by the host
+	ReportedAt string `json:"reported_at"`
+	// Number of resources per status (ie: applied, failed, skipped)
+	Status map[string]int `json:"status"`
+}
+
+// ForemanHostStatus is one of the statuses of a host (ie: build,
+// configuration, global)
+type ForemanHostStatus struct {
+	// Numeric value of the status, its meaning depends on the kind of status
+	Status int `json:"status"`
+	// Human readable status (ie: "Installed", "Pending installation")
+	StatusLabel string `json:"status_label"`
+	// Description of the kind of status
+	Description string `json:"description"`
+}
+
+// -----------------------------------------------------------------------------
+// Host Sub-Objects
+// -----------------------------------------------------------------------------
+
+// NOTE(ALL): the sub-objects of a host are expensive to compute server side
+//   and large.  They are never read along with the host, only on demand.
+
+// ReadHostFacts reads the facts reported for the host identified by the
+// supplied ID, keyed by fact name
+func (c *Client) ReadHostFacts(id int) (map[string]string, error) {
+	log.Tracef("foreman/api/hostdetails.go#ReadFacts")
+
+	reqEndpoint := fmt.Sprintf("/%s/%d/%s", HostEndpointPrefix, id, FactsSuffix)
+	req, reqErr := c.NewRequest(
+		http.MethodGet,
+		reqEndpoint,
+		nil,
+	)
+	if reqErr != nil {
+		return nil, reqErr
+	}
+
+	reqQuery := req.URL.Query()
+	reqQuery.Set("per_page", "all")
+	req.URL.RawQuery = reqQuery.Encode()
+
+	// NOTE(ALL): the facts are reported per host name
+	var factsResponse struct {
+		Results map[string]map[string]interface{} `json:"results"`
+	}
+	sendErr := c.SendAndParse(req, &factsResponse)
+	if sendErr != nil {
+		return nil, sendErr
+	}
+
+	facts := map[string]string{}
+	for _, hostFacts := range factsResponse.Results {
+		for name, value := range hostFacts {
+			if value == nil {
+				facts[name] = ""
+				continue
+			}
+			facts[name] = fmt.Sprint(value)
+		}
+	}
+	return facts, nil
+}
+
+// ReadHostENC reads the external node classifier data (classes, parameters,
+// environment) of the host identified by the supplied ID as JSON
+func (c *Client) ReadHostENC(id int) (string, error) {
+	log.Tracef("foreman/api/hostdetails.go#ReadENC")
+
+	reqEndpoint := fmt.Sprintf("/%s/%d/%s", HostEndpointPrefix, id, ENCSuffix)
+	req, reqErr := c.NewRequest(
+		http.MethodGet,
+		reqEndpoint,
+		nil,
+	)
+	if reqErr != nil {
+		return "", reqErr
+	}
+
+	var encResponse struct {
+		Data json.RawMessage `json:"data"`
+	}
+	sendErr := c.SendAndParse(req, &encResponse)
+	if sendErr != nil {
+		return "", sendErr
+	}
+	return string(encResponse.Data), nil
+}
+
+// ReadHostLastConfigReport reads the last configuration report of the host
+// identified by the supplied ID
+func (c *Client) ReadHostLastConfigReport(id int) (*ForemanConfigReport, error) {
+	log.Tracef("foreman/api/hostdetails.go#ReadLastConfigReport")
+
+	reqEndpoint := fmt.Sprintf("/%s/%d/%s", HostEndpointPrefix, id, LastConfigReportSuffix)
+	req, reqErr := c.NewRequest(
+		http.MethodGet,
+		reqEndpoint,
+		nil,
+	)
+	if reqErr != nil {
+		return nil, reqErr
+	}
+
+	var report ForemanConfigReport
+	sendErr := c.SendAndParse(req, &report)
+	if sendErr != nil {
+		return nil, sendErr
+	}
+
+	log.Debugf("report: [%+v]", report)
+
+	return &report, nil
+}
+
+// ReadHostStatus reads the status of the supplied kind (ie: "build",
+// "global") of the host identified by the supplied ID
+func (c *Client) ReadHostStatus(id int, kind string) (*ForemanHostStatus, error) {
+	log.Tracef("foreman/api/hostdetails.go#ReadStatus")
+
+	reqEndpoint := fmt.Sprintf("/%s/%d/%s/%s", HostEndpointPrefix, id, HostStatusSuffix, kind)
+	req, reqErr := c.NewRequest(
+		http.MethodGet,
+		reqEndpoint,
+		nil,
+	)
+	if reqErr != nil {
+		return nil, reqErr
+	}
+
+	var status ForemanHostStatus
+	sendErr := c.SendAndParse(req, &status)
+	if sendErr != nil {
+		return nil, sendErr
+	}
+
+	log.Debugf("status: [%+v]", status)
+
+	return &status, nil
+}
+
+// ReadHostRepresentation reads the full representation of the host
+// identified by the supplied ID, as Foreman returns it.  Unlike ReadHost, the
+// representation keeps the attributes the provider does not model (ie: the
+// inherited parameters and the interfaces' details).  Values of hidden
+// parameters stay masked.
+func (c *Client) ReadHostRepresentation(id int) (map[string]interface{}, error) {
+	log.Tracef("foreman/api/hostdetails.go#ReadRepresentation")
+
+	reqEndpoint := fmt.Sprintf("/%s/%d", HostEndpointPrefix, id)
+	req, reqErr := c.NewRequest(
+		http.MethodGet,
+		reqEndpoint,
+		nil,
+	)
+	if reqErr != nil {
+		return nil, reqErr
+	}
+
+	var representation map[string]interface{}
+	sendErr := c.SendAndParse(req, &representation)
+	if sendErr != nil {
+		return nil, sendErr
+	}
+	return representation, nil
+}
@@ -69,8 +69,41 @@ type ForemanHostgroup struct {
 	HostGroupParameters []ForemanKVParameter
 }
 
-type foremanHostGroupParameterJSON struct {
+// String implements the fmt.Stringer interface.  The root password is
+// redacted so the hostgroup can be logged safely.
+func (fh ForemanHostgroup) String() string {
+	type plainHostgroup ForemanHostgroup
+	redacted := plainHostgroup(fh)
+	if redacted.RootPassword != "" {
+		redacted.RootPassword = redactedValue
+	}
+	return fmt.Sprintf("%+v", redacted)
+}
+
+// foremanHostgroupJSON struct used for JSON decode.  The whole hostgroup is
+// decoded in a single pass, properties with differently named keys or types
+// are converted to the ForemanHostgroup afterwards.
+type foremanHostgroupJSON struct {
+	ForemanObject
+
 	HostGroupParameters []ForemanKVParameter `json:"group_parameters_attributes"`
+
+	Title        jsonValue[string] `json:"title"`
+	RootPassword jsonValue[string] `json:"root_password"`
+	PXELoader    jsonValue[string] `json:"pxe_loader"`
+
+	ArchitectureId    jsonValue[float64] `json:"architecture_id"`
+	ComputeProfileId  jsonValue[float64] `json:"compute_profile_id"`
+	DomainId          jsonValue[float64] `json:"domain_id"`
+	EnvironmentId     jsonValue[float64] `json:"environment_id"`
+	MediumId          jsonValue[float64] `json:"medium_id"`
+	OperatingSystemId jsonValue[float64] `json:"operatingsystem_id"`
+	ParentId          jsonValue[float64] `json:"parent_id"`
+	PartitionTableId  jsonValue[float64] `json:"ptable_id"`
+	PuppetCAProxyId   jsonValue[float64] `json:"puppet_ca_proxy_id"`
+	PuppetProxyId     jsonValue[float64] `json:"puppet_proxy_id"`
+	RealmId           jsonValue[float64] `json:"realm_id"`
+	SubnetId          jsonValue[float64] `json:"subnet_id"`
 }
 
 // Implement the Marshaler interface
@@ -83,7 +116,9 @@ func (fh ForemanHostgroup) MarshalJSON() ([]byte, error) {
 	fhMap := map[string]interface{}{}
 
 	fhMap["name"] = fh.Name
-	fhMap["root_pass"] = fh.RootPassword
+	if fh.RootPassword != "" {
+		fhMap["root_pass"] = fh.RootPassword
+	}
 	fhMap["pxe_loader"] = fh.PXELoader
 
 	fhMap["architecture_id"] = intIdToJSONString(fh.ArchitectureId)
@@ -103,56 +138,39 @@ func (fh ForemanHostgroup) MarshalJSON() ([]byte, error) {
 		fhMap["group_parameters_attributes"] = fh.HostGroupParameters
 	}
 
-	log.Debugf("fhMap: [%v]", fhMap)
+	log.Debugf("fhMap: [%v]", redactValue(fhMap))
 
 	return json.Marshal(fhMap)
 }
 
+// Custom JSON unmarshal function.  Unmarshal to the unexported JSON struct
+// and then convert over to a ForemanHostgroup struct.
 func (fh *ForemanHostgroup) UnmarshalJSON(b []byte) error {
-	var jsonDecErr error
-
-	// Unmarshal the common Foreman object properties
-	var fo ForemanObject
-	jsonDecErr = json.Unmarshal(b, &fo)
-	if jsonDecErr != nil {
-		return jsonDecErr
-	}
-	fh.ForemanObject = fo
-
-	var fhParameterJSON foremanHostGroupParameterJSON
-	jsonDecErr = json.Unmarshal(b, &fhParameterJSON)
-	if jsonDecErr != nil {
-		return jsonDecErr
-	}
-	fh.HostGroupParameters = fhParameterJSON.HostGroupParameters
-
-	// Unmarshal into mapstructure and set the rest of the struct properties
-	var fhMap map[string]interface{}
-	jsonDecErr = json.Unmarshal(b, &fhMap)
+	var fhJSON foremanHostgroupJSON
+	jsonDecErr := json.Unmarshal(b, &fhJSON)
 	if jsonDecErr != nil {
 		return jsonDecErr
 	}
-	var ok bool
-	if fh.RootPassword, ok = fhMap["root_password"].(string); !ok {
-		fh.RootPassword = ""
-	}
-	if fh.PXELoader, ok = fhMap["pxe_loader"].(string); !ok {
-		fh.PXELoader = ""
-	}
 
-	// Unmarshal the remaining foreign keys to their id
-	fh.ArchitectureId = unmarshalInteger(fhMap["architecture_id"])
-	fh.ComputeProfileId = unmarshalInteger(fhMap["compute_profile_id"])
-	fh.DomainId = unmarshalInteger(fhMap["domain_id"])
-	fh.EnvironmentId = unmarshalInteger(fhMap["environment_id"])
-	fh.MediumId = unmarshalInteger(fhMap["medium_id"])
-	fh.OperatingSystemId = unmarshalInteger(fhMap["operatingsystem_id"])
-	fh.ParentId = unmarshalInteger(fhMap["parent_id"])
-	fh.PartitionTableId = unmarshalInteger(fhMap["ptable_id"])
-	fh.PuppetCAProxyId = unmarshalInteger(fhMap["puppet_ca_proxy_id"])
-	fh.PuppetProxyId = unmarshalInteger(fhMap["puppet_proxy_id"])
-	fh.RealmId = unmarshalInteger(fhMap["realm_id"])
-	fh.SubnetId = unmarshalInteger(fhMap["subnet_id"])
+	fh.ForemanObject = fhJSON.ForemanObject
+	fh.HostGroupParameters = fhJSON.HostGroupParameters
+	fh.Title = fhJSON.Title.Value
+	fh.RootPassword = fhJSON.RootPassword.Value
+	fh.PXELoader = fhJSON.PXELoader.Value
+
+	// Convert the remaining foreign keys to their id
+	fh.ArchitectureId = int(fhJSON.ArchitectureId.Value)
+	fh.ComputeProfileId = int(fhJSON.ComputeProfileId.Value)
+	fh.DomainId = int(fhJSON.DomainId.Value)
+	fh.EnvironmentId = int(fhJSON.EnvironmentId.Value)
+	fh.MediumId = int(fhJSON.MediumId.Value)
+	fh.OperatingSystemId = int(fhJSON.OperatingSystemId.Value)
+	fh.ParentId = int(fhJSON.ParentId.Value)
+	fh.PartitionTableId = int(fhJSON.PartitionTableId.Value)
+	fh.PuppetCAProxyId = int(fhJSON.PuppetCAProxyId.Value)
+	fh.PuppetProxyId = int(fhJSON.PuppetProxyId.Value)
+	fh.RealmId = int(fhJSON.RealmId.Value)
+	fh.SubnetId = int(fhJSON.SubnetId.Value)
 
 	return nil
 }
@@ -175,7 +193,7 @@ func (c *Client) CreateHostgroup(h *ForemanHostgroup) (*ForemanHostgroup, error)
 		return nil, jsonEncErr
 	}
 
-	log.Debugf("hostgroupJSONBytes: [%s]", hJSONBytes)
+	log.Debugf("hostgroupJSONBytes: [%s]", redactJSON(hJSONBytes))
 
 	req, reqErr := c.NewRequest(
 		http.MethodPost,
@@ -238,7 +256,7 @@ func (c *Client) UpdateHostgroup(h *ForemanHostgroup) (*ForemanHostgroup, error)
 		return nil, jsonEncErr
 	}
 
-	log.Debugf("hostgroupJSONBytes: [%s]", hJSONBytes)
+	log.Debugf("hostgroupJSONBytes: [%s]", redactJSON(hJSONBytes))
 
 	req, reqErr := c.NewRequest(
 		http.MethodPut,
@@ -306,33 +324,5 @@ func (c *Client) QueryHostgroup(h *ForemanHostgroup) (QueryResponse, error) {
 	reqQuery.Set("search", "title="+title)
 
 	req.URL.RawQuery = reqQuery.Encode()
-	sendErr := c.SendAndParse(req, &queryResponse)
-	if sendErr != nil {
-		return queryResponse, sendErr
-	}
-
-	log.Debugf("queryResponse: [%+v]", queryResponse)
-
-	// Results will be Unmarshaled into a []map[string]interface{}
-	//
-	// Encode back to JSON, then Unmarshal into []ForemanHostgroup for
-	// the results
-	results := []ForemanHostgroup{}
-	resultsBytes, jsonEncErr := json.Marshal(queryResponse.Results)
-	if jsonEncErr != nil {
-		return queryResponse, jsonEncErr
-	}
-	jsonDecErr := json.Unmarshal(resultsBytes, &results)
-	if jsonDecErr != nil {
-		return queryResponse, jsonDecErr
-	}
-	// convert the search results from []ForemanHostgroup to []interface
-	// and set the search results on the query
-	iArr := make([]interface{}, len(results))
-	for idx, val := range results {
-		iArr[idx] = val
-	}
-	queryResponse.Results = iArr
-
-	return queryResponse, nil
+	return sendAndParseQuery[ForemanHostgroup](c, req)
 }
@@ -11,16 +11,18 @@ import (
 
 const (
 	ComputeResourceEndpoint = "compute_resources"
+
+	// UserDataTemplateKind is the name of the template kind rendered into the
+	// user data of hosts cloned from an image
+	UserDataTemplateKind = "user_data"
 )
 
 // -----------------------------------------------------------------------------
 // Struct Definition and Helpers
 // -----------------------------------------------------------------------------
 
-// The ForemanImage API model represents the image name. Images serve as an
-// identification string that defines autonomy, authority, or control for
-// a portion of a network.
-
+// The ForemanImage API model represents an image of a compute resource.
+// Hosts created on the compute resource are cloned from their image.
 type ForemanImage struct {
 	// Inherits the base object's attributes
 	ForemanObject
@@ -38,6 +40,10 @@ type ForemanImage struct {
 	ComputeResourceID int `json:"compute_resource_id"`
 	// ArchitectureId of the architecture this image works on
 	ArchitectureID int `json:"architecture_id"`
+	// Whether or not the image supports user data.  Hosts cloned from an
+	// image with user data get the rendered user_data template of their
+	// operating system passed to the compute resource (ie: cloud-init).
+	UserData bool `json:"user_data"`
 }
 
 // Custom JSON unmarshal function. Unmarshal to the unexported JSON struct
@@ -73,13 +79,24 @@ func (fi *ForemanImage) UnmarshalJSON(b []byte) error {
 	if fi.UUID, ok = fiMap["uuid"].(string); !ok {
 		fi.UUID = ""
 	}
-	if fi.OperatingSystemID, ok = fiMap["operating_system_id"].(int); !ok {
+	if fi.UserData, ok = fiMap["user_data"].(bool); !ok {
+		fi.UserData = false
+	}
+
+	var id float64
+	if id, ok = fiMap["operating_system_id"].(float64); ok {
+		fi.OperatingSystemID = int(id)
+	} else {
 		fi.OperatingSystemID = 0
 	}
-	if fi.ComputeResourceID, ok = fiMap["compute_resource_id"].(int); !ok {
+	if id, ok = fiMap["compute_resource_id"].(float64); ok {
+		fi.ComputeResourceID = int(id)
+	} else {
 		fi.ComputeResourceID = 0
 	}
-	if fi.ArchitectureID, ok = fiMap["architecture_id"].(int); !ok {
+	if id, ok = fiMap["architecture_id"].(float64); ok {
+		fi.ArchitectureID = int(id)
+	} else {
 		fi.ArchitectureID = 0
 	}
 
@@ -90,14 +107,15 @@ func (fi *ForemanImage) UnmarshalJSON(b []byte) error {
 // CRUD Implementation
 // -----------------------------------------------------------------------------
 
-// CreateImage creates a new ForemanImage with the attributes of the supplied
-// ForemanImage reference and returns the created ForemanImage reference.
-// The returned reference will have its ID and other API default values set by
-// this function.
-func (c *Client) CreateImage(d *ForemanImage, compute_resource int) (*ForemanImage, error) {
+// CreateImage creates a new ForemanImage on the compute resource identified
+// by the supplied ID with the attributes of the supplied ForemanImage
+// reference and returns the created ForemanImage reference.  The returned
+// reference will have its ID and other API default values set by this
+// function.
+func (c *Client) CreateImage(computeResourceId int, d *ForemanImage) (*ForemanImage, error) {
 	log.Tracef("foreman/api/image.go#Create")
 
-	reqEndpoint := fmt.Sprintf("%s/%d/images", ComputeResourceEndpoint, compute_resource)
+	reqEndpoint := fmt.Sprintf("/%s/%d/images", ComputeResourceEndpoint, computeResourceId)
 
 	imageJSONBytes, jsonEncErr := WrapJson("image", d)
 	if jsonEncErr != nil {
@@ -126,12 +144,13 @@ func (c *Client) CreateImage(d *ForemanImage, compute_resource int) (*ForemanIma
 	return &createdImage, nil
 }
 
-// ReadImage reads the attributes of a ForemanImage identified by the
-// supplied ID and returns a ForemanImage reference.
-func (c *Client) ReadImage(d *ForemanImage) (*ForemanImage, error) {
+// ReadImage reads the attributes of the ForemanImage identified by the
+// supplied ID on the compute resource identified by the supplied ID and
+// returns a ForemanImage reference.
+func (c *Client) ReadImage(computeResourceId int, id int) (*ForemanImage, error) {
 	log.Tracef("foreman/api/image.go#Read")
 
-	reqEndpoint := fmt.Sprintf("/%s/%d/images/%d", ComputeResourceEndpoint, d.ComputeResourceID, d.Id)
+	reqEndpoint := fmt.Sprintf("/%s/%d/images/%d", ComputeResourceEndpoint, computeResourceId, id)
 
 	req, reqErr := c.NewRequest(
 		http.MethodGet,
@@ -154,12 +173,13 @@ func (c *Client) ReadImage(d *ForemanImage) (*ForemanImage, error) {
 }
 
 // UpdateImage updates a ForemanImage's attributes.  The image with the ID
-// of the supplied ForemanImage will be updated. A new ForemanImage reference
-// is returned with the attributes from the result of the update operation.
-func (c *Client) UpdateImage(d *ForemanImage) (*ForemanImage, error) {
+// of the supplied ForemanImage on the compute resource identified by the
+// supplied ID will be updated.  A new ForemanImage reference is returned with
+// the attributes from the result of the update operation.
+func (c *Client) UpdateImage(computeResourceId int, d *ForemanImage) (*ForemanImage, error) {
 	log.Tracef("foreman/api/image.go#Update")
 
-	reqEndpoint := fmt.Sprintf("/%s/%d/images/%d", ComputeResourceEndpoint, d.ComputeResourceID, d.Id)
+	reqEndpoint := fmt.Sprintf("/%s/%d/images/%d", ComputeResourceEndpoint, computeResourceId, d.Id)
 
 	imageJSONBytes, jsonEncErr := WrapJson("image", d)
 	if jsonEncErr != nil {
@@ -188,11 +208,12 @@ func (c *Client) UpdateImage(d *ForemanImage) (*ForemanImage, error) {
 	return &updatedImage, nil
 }
 
-// DeleteImage deletes the ForemanImage identified by the supplied ID
-func (c *Client) DeleteImage(compute_resource, id int) error {
+// DeleteImage deletes the ForemanImage identified by the supplied ID on the
+// compute resource identified by the supplied ID
+func (c *Client) DeleteImage(computeResourceId int, id int) error {
 	log.Tracef("foreman/api/image.go#Delete")
 
-	reqEndpoint := fmt.Sprintf("/%s/%d/images/%d", ComputeResourceEndpoint, compute_resource, id)
+	reqEndpoint := fmt.Sprintf("/%s/%d/images/%d", ComputeResourceEndpoint, computeResourceId, id)
 
 	req, reqErr := c.NewRequest(
 		http.MethodDelete,
@@ -234,33 +255,84 @@ func (c *Client) QueryImage(d *ForemanImage) (QueryResponse, error) {
 	reqQuery.Set("search", "name="+name)
 
 	req.URL.RawQuery = reqQuery.Encode()
-	sendErr := c.SendAndParse(req, &queryResponse)
-	if sendErr != nil {
-		return queryResponse, sendErr
-	}
+	return sendAndParseQuery[ForemanImage](c, req)
+}
+
+// -----------------------------------------------------------------------------
+// User Data Template
+// -----------------------------------------------------------------------------
 
-	log.Debugf("queryResponse: [%+v]", queryResponse)
+// EnsureImageUserDataTemplate makes the provisioning template identified by
+// templateId the user data template of hosts cloned from the supplied image.
+// Foreman selects the user data template through the operating system of the
+// host, so the template is associated with the operating system of the image
+// and set as the operating system's default template of the user_data kind.
+// An error is returned if the template is not of the user_data kind.
+func (c *Client) EnsureImageUserDataTemplate(image *ForemanImage, templateId int) error {
+	log.Tracef("foreman/api/image.go#EnsureUserDataTemplate")
+
+	if image.OperatingSystemID == 0 {
+		return fmt.Errorf(
+			"Image [%d] has no operating system to select the user data template [%d] through",
+			image.Id,
+			templateId,
+		)
+	}
 
-	// Results will be Unmarshaled into a []map[string]interface{}
-	//
-	// Encode back to JSON, then Unmarshal into []ForemanImage for
-	// the results
-	results := []ForemanImage{}
-	resultsBytes, jsonEncErr := json.Marshal(queryResponse.Results)
-	if jsonEncErr != nil {
-		return queryResponse, jsonEncErr
+	template, readErr := c.ReadProvisioningTemplate(templateId)
+	if readErr != nil {
+		return readErr
 	}
-	jsonDecErr := json.Unmarshal(resultsBytes, &results)
-	if jsonDecErr != nil {
-		return queryResponse, jsonDecErr
+	kind, kindErr := c.ReadTemplateKind(template.TemplateKindId)
+	if kindErr != nil {
+		return kindErr
 	}
-	// convert the search results from []ForemanImage to []interface
-	// and set the search results on the query
-	iArr := make([]interface{}, len(results))
-	for idx, val := range results {
-		iArr[idx] = val
+	if kind.Name != UserDataTemplateKind {
+		return fmt.Errorf(
+			"Provisioning template [%d] is of kind [%s], a user data template "+
+				"must be of kind [%s]",
+			templateId,
+			kind.Name,
+			UserDataTemplateKind,
+		)
 	}
-	queryResponse.Results = iArr
 
-	return queryResponse, nil
+	// NOTE(ALL): Foreman refuses operating system defaults with templates
+	//   that are not associated with the operating system
+	for _, assoc := range operatingSystemAssociations {
+		if assoc.endpoint != ProvisioningTemplateEndpointPrefix {
+			continue
+		}
+		if assocErr := c.associateOperatingSystem(assoc, templateId, image.OperatingSystemID); assocErr != nil {
+			return assocErr
+		}
+	}
+
+	defaults, listErr := c.ListOperatingSystemDefaultTemplates(image.OperatingSystemID)
+	if listErr != nil {
+		return listErr
+	}
+	wanted := ForemanDefaultTemplate{
+		OperatingSystemId:      image.OperatingSystemID,
+		ProvisioningTemplateId: templateId,
+		TemplateKindId:         kind.Id,
+	}
+	for _, d := range defaults {
+		if d.TemplateKindId != kind.Id {
+			continue
+		}
+		if d.ProvisioningTemplateId == templateId {
+			return nil
+		}
+		log.Infof(
+			"Replacing the user data template [%d] of operating system [%d] with [%d]",
+			d.ProvisioningTemplateId,
+			image.OperatingSystemID,
+			templateId,
+		)
+		_, updateErr := c.UpdateDefaultTemplate(&wanted, d.Id)
+		return updateErr
+	}
+	_, createErr := c.CreateDefaultTemplate(&wanted)
+	return createErr
 }
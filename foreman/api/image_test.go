@@ -0,0 +1,77 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+// ----------------------------------------------------------------------------
+// EnsureImageUserDataTemplate
+// ----------------------------------------------------------------------------
+
+// Ensures the user data template is associated with the operating system of
+// the image and replaces the operating system's default user data template
+func TestEnsureImageUserDataTemplate(t *testing.T) {
+	urlMux, server, client := NewForemanAPIAndClient(ClientCredentials{}, ClientConfig{})
+	defer server.Close()
+
+	associated := false
+	urlMux.HandleFunc(FOREMAN_API_URL_PREFIX+"/provisioning_templates/7", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPut {
+			associated = true
+		}
+		fmt.Fprint(w, `{"id": 7, "template_kind_id": 9, "operatingsystems": [{"id": 1}]}`)
+	})
+	urlMux.HandleFunc(FOREMAN_API_URL_PREFIX+"/template_kinds/9", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id": 9, "name": "user_data"}`)
+	})
+	urlMux.HandleFunc(FOREMAN_API_URL_PREFIX+"/operatingsystems/2/os_default_templates", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"results": [
+			{"id": 4, "provisioning_template_id": 5, "template_kind_id": 3},
+			{"id": 6, "provisioning_template_id": 8, "template_kind_id": 9}
+		]}`)
+	})
+	var updated ForemanDefaultTemplate
+	urlMux.HandleFunc(FOREMAN_API_URL_PREFIX+"/operatingsystems/2/os_default_templates/6", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut {
+			t.Fatalf("Expected a PUT request, got [%s]", r.Method)
+		}
+		var body map[string]ForemanDefaultTemplate
+		json.NewDecoder(r.Body).Decode(&body)
+		updated = body["os_default_template"]
+		fmt.Fprint(w, `{"id": 6}`)
+	})
+
+	image := &ForemanImage{OperatingSystemID: 2}
+	if ensureErr := client.EnsureImageUserDataTemplate(image, 7); ensureErr != nil {
+		t.Fatalf("EnsureImageUserDataTemplate returned an error: %s", ensureErr)
+	}
+	if !associated {
+		t.Fatalf("Expected the template to be associated with the operating system")
+	}
+	if updated.ProvisioningTemplateId != 7 || updated.TemplateKindId != 9 {
+		t.Fatalf("Expected the user data default to be replaced with template [7], got [%+v]", updated)
+	}
+}
+
+// Ensures templates of another kind are refused as user data templates
+func TestEnsureImageUserDataTemplate_WrongKind(t *testing.T) {
+	urlMux, server, client := NewForemanAPIAndClient(ClientCredentials{}, ClientConfig{})
+	defer server.Close()
+
+	urlMux.HandleFunc(FOREMAN_API_URL_PREFIX+"/provisioning_templates/7", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id": 7, "template_kind_id": 1}`)
+	})
+	urlMux.HandleFunc(FOREMAN_API_URL_PREFIX+"/template_kinds/1", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id": 1, "name": "provision"}`)
+	})
+
+	image := &ForemanImage{OperatingSystemID: 2}
+	ensureErr := client.EnsureImageUserDataTemplate(image, 7)
+	if ensureErr == nil || !strings.Contains(ensureErr.Error(), "kind [provision]") {
+		t.Fatalf("Expected an error for the template kind, got [%v]", ensureErr)
+	}
+}
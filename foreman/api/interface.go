@@ -0,0 +1,176 @@
+package api
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+
+	"github.com/wayfair/terraform-provider-utils/log"
+)
+
+const (
+	// InterfaceSuffix : Suffix appended to API url for host interfaces
+	InterfaceSuffix = "interfaces"
+)
+
+// -----------------------------------------------------------------------------
+// Query Implementation
+// -----------------------------------------------------------------------------
+
+// QueryHostInterfaces lists the network interfaces of the host identified by
+// the supplied ID and returns a QueryResponse struct containing
+// query/response metadata and the host's interfaces.
+//
+// Example: https://<foreman>/api/hosts/<id>/interfaces
+func (c *Client) QueryHostInterfaces(hostId int) (QueryResponse, error) {
+	log.Tracef("foreman/api/interface.go#Search")
+
+	queryResponse := QueryResponse{}
+
+	reqEndpoint := fmt.Sprintf("/%s/%d/%s", HostEndpointPrefix, hostId, InterfaceSuffix)
+	req, reqErr := c.NewRequest(
+		http.MethodGet,
+		reqEndpoint,
+		nil,
+	)
+	if reqErr != nil {
+		return queryResponse, reqErr
+	}
+
+	reqQuery := req.URL.Query()
+	reqQuery.Set("per_page", "all")
+
+	req.URL.RawQuery = reqQuery.Encode()
+	return sendAndParseQuery[ForemanInterfacesAttribute](c, req)
+}
+
+// -----------------------------------------------------------------------------
+// CRUD Implementation
+// -----------------------------------------------------------------------------
+
+// hostInterfaceEndpoint returns the endpoint of the interfaces of the host
+// identified by the supplied ID
+func hostInterfaceEndpoint(hostId int) string {
+	return fmt.Sprintf("/%s/%d/%s", HostEndpointPrefix, hostId, InterfaceSuffix)
+}
+
+// CreateHostInterface attaches a new interface with the attributes of the
+// supplied ForemanInterfacesAttribute reference to the host identified by the
+// supplied ID and returns the created ForemanInterfacesAttribute reference.
+// The returned reference will have its ID and other API default values set by
+// this function.
+//
+// Example: https://<foreman>/api/hosts/<id>/interfaces
+func (c *Client) CreateHostInterface(hostId int, i *ForemanInterfacesAttribute) (*ForemanInterfacesAttribute, error) {
+	log.Tracef("foreman/api/interface.go#Create")
+
+	iJSONBytes, jsonEncErr := WrapJson("interface", i)
+	if jsonEncErr != nil {
+		return nil, jsonEncErr
+	}
+
+	log.Debugf("interfaceJSONBytes: [%s]", redactJSON(iJSONBytes))
+
+	req, reqErr := c.NewRequest(
+		http.MethodPost,
+		hostInterfaceEndpoint(hostId),
+		bytes.NewBuffer(iJSONBytes),
+	)
+	if reqErr != nil {
+		return nil, reqErr
+	}
+
+	var createdInterface ForemanInterfacesAttribute
+	sendErr := c.SendAndParse(req, &createdInterface)
+	if sendErr != nil {
+		return nil, sendErr
+	}
+
+	log.Debugf("createdInterface: [%s]", createdInterface)
+
+	return &createdInterface, nil
+}
+
+// ReadHostInterface reads the attributes of the interface identified by the
+// supplied ID of the host identified by the supplied host ID and returns a
+// ForemanInterfacesAttribute reference.
+func (c *Client) ReadHostInterface(hostId int, id int) (*ForemanInterfacesAttribute, error) {
+	log.Tracef("foreman/api/interface.go#Read")
+
+	reqEndpoint := fmt.Sprintf("%s/%d", hostInterfaceEndpoint(hostId), id)
+
+	req, reqErr := c.NewRequest(
+		http.MethodGet,
+		reqEndpoint,
+		nil,
+	)
+	if reqErr != nil {
+		return nil, reqErr
+	}
+
+	var readInterface ForemanInterfacesAttribute
+	sendErr := c.SendAndParse(req, &readInterface)
+	if sendErr != nil {
+		return nil, sendErr
+	}
+
+	log.Debugf("readInterface: [%s]", readInterface)
+
+	return &readInterface, nil
+}
+
+// UpdateHostInterface updates the attributes of an interface of the host
+// identified by the supplied ID.  The interface with the ID of the supplied
+// ForemanInterfacesAttribute will be updated.  A new
+// ForemanInterfacesAttribute reference is returned with the attributes from
+// the result of the update operation.
+func (c *Client) UpdateHostInterface(hostId int, i *ForemanInterfacesAttribute) (*ForemanInterfacesAttribute, error) {
+	log.Tracef("foreman/api/interface.go#Update")
+
+	reqEndpoint := fmt.Sprintf("%s/%d", hostInterfaceEndpoint(hostId), i.Id)
+
+	iJSONBytes, jsonEncErr := WrapJson("interface", i)
+	if jsonEncErr != nil {
+		return nil, jsonEncErr
+	}
+
+	log.Debugf("interfaceJSONBytes: [%s]", redactJSON(iJSONBytes))
+
+	req, reqErr := c.NewRequest(
+		http.MethodPut,
+		reqEndpoint,
+		bytes.NewBuffer(iJSONBytes),
+	)
+	if reqErr != nil {
+		return nil, reqErr
+	}
+
+	var updatedInterface ForemanInterfacesAttribute
+	sendErr := c.SendAndParse(req, &updatedInterface)
+	if sendErr != nil {
+		return nil, sendErr
+	}
+
+	log.Debugf("updatedInterface: [%s]", updatedInterface)
+
+	return &updatedInterface, nil
+}
+
+// DeleteHostInterface detaches the interface identified by the supplied ID
+// from the host identified by the supplied host ID
+func (c *Client) DeleteHostInterface(hostId int, id int) error {
+	log.Tracef("foreman/api/interface.go#Delete")
+
+	reqEndpoint := fmt.Sprintf("%s/%d", hostInterfaceEndpoint(hostId), id)
+
+	req, reqErr := c.NewRequest(
+		http.MethodDelete,
+		reqEndpoint,
+		nil,
+	)
+	if reqErr != nil {
+		return reqErr
+	}
+
+	return c.SendAndParse(req, nil)
+}
@@ -0,0 +1,115 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/wayfair/terraform-provider-utils/log"
+)
+
+const (
+	LocationEndpointPrefix = "locations"
+)
+
+// -----------------------------------------------------------------------------
+// Struct Definition and Helpers
+// -----------------------------------------------------------------------------
+
+// The ForemanLocation API model represents a location taxonomy.  Locations
+// are organized in a tree-like structure, the relationship being maintained
+// through the parent_id attribute.
+type ForemanLocation struct {
+	// Inherits the base object's attributes
+	ForemanObject
+
+	// The title is a computed property representing the fullname of the
+	// location.  A location's title is a path-like string from the head
+	// of the location tree down to this location.  The title will be
+	// in the form of: "<parent 1>/<parent 2>/.../<name>"
+	Title string `json:"title"`
+	// Description of the location
+	Description string `json:"description"`
+	// ID of this location's parent location
+	ParentId int `json:"parent_id"`
+}
+
+// -----------------------------------------------------------------------------
+// CRUD Implementation
+// -----------------------------------------------------------------------------
+
+// ReadLocation reads the attributes of a ForemanLocation identified by the
+// supplied ID and returns a ForemanLocation reference.
+func (c *Client) ReadLocation(id int) (*ForemanLocation, error) {
+	log.Tracef("foreman/api/location.go#Read")
+
+	reqEndpoint := fmt.Sprintf("/%s/%d", LocationEndpointPrefix, id)
+
+	req, reqErr := c.NewRequest(
+		http.MethodGet,
+		reqEndpoint,
+		nil,
+	)
+	if reqErr != nil {
+		return nil, reqErr
+	}
+
+	var readLocation ForemanLocation
+	sendErr := c.SendAndParse(req, &readLocation)
+	if sendErr != nil {
+		return nil, sendErr
+	}
+
+	log.Debugf("readLocation: [%+v]", readLocation)
+
+	return &readLocation, nil
+}
+
+// -----------------------------------------------------------------------------
+// Query Implementation
+// -----------------------------------------------------------------------------
+
+// QueryLocation queries for a ForemanLocation based on the attributes of the
+// supplied ForemanLocation reference and returns a QueryResponse struct
+// containing query/response metadata and the matching locations.
+//
+// Nested locations share their name with locations in other branches of the
+// tree, so the full title is preferred as the search criteria when it is set.
+func (c *Client) QueryLocation(l *ForemanLocation) (QueryResponse, error) {
+	log.Tracef("foreman/api/location.go#Search")
+
+	if l.Title != "" {
+		return c.SearchLocations("title=\"" + l.Title + "\"")
+	}
+	return c.SearchLocations("name=\"" + l.Name + "\"")
+}
+
+// SearchLocations queries for all ForemanLocations matching the supplied
+// Foreman search string and returns a QueryResponse struct containing
+// query/response metadata and the matching locations.  An empty search
+// string lists every location visible to the client.
+func (c *Client) SearchLocations(search string) (QueryResponse, error) {
+	log.Tracef("foreman/api/location.go#SearchLocations")
+
+	queryResponse := QueryResponse{}
+
+	reqEndpoint := fmt.Sprintf("/%s", LocationEndpointPrefix)
+	req, reqErr := c.NewRequest(
+		http.MethodGet,
+		reqEndpoint,
+		nil,
+	)
+	if reqErr != nil {
+		return queryResponse, reqErr
+	}
+
+	reqQuery := req.URL.Query()
+	if search != "" {
+		reqQuery.Set("search", search)
+	}
+	// NOTE(ALL): the listing is used to build collections in the plural data
+	//   source - request everything instead of the default first page
+	reqQuery.Set("per_page", "all")
+
+	req.URL.RawQuery = reqQuery.Encode()
+	return sendAndParseQuery[ForemanLocation](c, req)
+}
@@ -21,6 +21,8 @@ const (
 type ForemanMedia struct {
 	// Inherits the base object's attributes
 	ForemanObject
+	// Locations and organizations the media is associated with
+	ForemanTaxonomies
 
 	// The path to the medium, can be a URL or a valid NFS server (exclusive
 	// of the architecture).  For example:
@@ -69,6 +71,9 @@ func (fm *ForemanMedia) UnmarshalJSON(b []byte) error {
 		return jsonDecErr
 	}
 	fm.OperatingSystemIds = foremanObjectArrayToIdIntArray(fmJSON.OperatingSystems)
+	if jsonDecErr = fm.decodeTaxonomies(b); jsonDecErr != nil {
+		return jsonDecErr
+	}
 
 	// Unmarshal into mapstructure and set the rest of the struct properties
 	var fmMap map[string]interface{}
@@ -235,33 +240,5 @@ func (c *Client) QueryMedia(m *ForemanMedia) (QueryResponse, error) {
 	reqQuery.Set("search", "name="+name)
 
 	req.URL.RawQuery = reqQuery.Encode()
-	sendErr := c.SendAndParse(req, &queryResponse)
-	if sendErr != nil {
-		return queryResponse, sendErr
-	}
-
-	log.Debugf("queryResponse: [%+v]", queryResponse)
-
-	// Results will be Unmarshaled into a []map[string]interface{}
-	//
-	// Encode back to JSON, then Unmarshal into []ForemanMedia for
-	// the results
-	results := []ForemanMedia{}
-	resultsBytes, jsonEncErr := json.Marshal(queryResponse.Results)
-	if jsonEncErr != nil {
-		return queryResponse, jsonEncErr
-	}
-	jsonDecErr := json.Unmarshal(resultsBytes, &results)
-	if jsonDecErr != nil {
-		return queryResponse, jsonDecErr
-	}
-	// convert the search results from []ForemanMedia to []interface
-	// and set the search results on the query
-	iArr := make([]interface{}, len(results))
-	for idx, val := range results {
-		iArr[idx] = val
-	}
-	queryResponse.Results = iArr
-
-	return queryResponse, nil
+	return sendAndParseQuery[ForemanMedia](c, req)
 }
@@ -0,0 +1,142 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ----------------------------------------------------------------------------
+// API Call Metrics
+// ----------------------------------------------------------------------------
+
+// EndpointMetrics are the counters of the API calls sent to a single endpoint
+type EndpointMetrics struct {
+	// Number of requests sent to the server
+	Calls int
+	// Number of requests that were retries of a failed attempt
+	Retries int
+	// Number of requests that failed (ie: connection errors, 4xx and 5xx
+	// responses)
+	Failures int
+	// Total time spent waiting for the server's responses
+	Duration time.Duration
+}
+
+// Metrics counts the API calls of one or more clients per endpoint.  An
+// endpoint is the method and API collection of a request (ie: "GET hosts"),
+// so the counters are not split by object ID.  A nil *Metrics discards
+// everything it is handed.
+type Metrics struct {
+	mu        sync.Mutex
+	endpoints map[string]*EndpointMetrics
+}
+
+// NewMetrics creates an empty set of API call metrics
+func NewMetrics() *Metrics {
+	return &Metrics{
+		endpoints: map[string]*EndpointMetrics{},
+	}
+}
+
+// metricsEndpoint returns the endpoint the supplied request is counted for
+func metricsEndpoint(req *http.Request) string {
+	return fmt.Sprintf("%s %s", req.Method, apiCollection(req.URL.Path))
+}
+
+// endpoint returns the counters of the supplied endpoint.  The caller must
+// hold the lock.
+func (m *Metrics) endpoint(name string) *EndpointMetrics {
+	em, ok := m.endpoints[name]
+	if !ok {
+		em = &EndpointMetrics{}
+		m.endpoints[name] = em
+	}
+	return em
+}
+
+// record counts a request sent to the server
+func (m *Metrics) record(req *http.Request, duration time.Duration, failed bool) {
+	if m == nil {
+		return
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	em := m.endpoint(metricsEndpoint(req))
+	em.Calls++
+	em.Duration += duration
+	if failed {
+		em.Failures++
+	}
+}
+
+// recordRetry counts a retry of a failed request
+func (m *Metrics) recordRetry(req *http.Request) {
+	if m == nil {
+		return
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.endpoint(metricsEndpoint(req)).Retries++
+}
+
+// Endpoints returns a copy of the counters of every endpoint called
+func (m *Metrics) Endpoints() map[string]EndpointMetrics {
+	endpoints := map[string]EndpointMetrics{}
+	if m == nil {
+		return endpoints
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for name, em := range m.endpoints {
+		endpoints[name] = *em
+	}
+	return endpoints
+}
+
+// Summary formats the totals and the counters of every endpoint, with the
+// most called endpoints first
+func (m *Metrics) Summary() string {
+	endpoints := m.Endpoints()
+	names := make([]string, 0, len(endpoints))
+	var total EndpointMetrics
+	for name, em := range endpoints {
+		names = append(names, name)
+		total.Calls += em.Calls
+		total.Retries += em.Retries
+		total.Failures += em.Failures
+		total.Duration += em.Duration
+	}
+	sort.Slice(names, func(i, j int) bool {
+		if endpoints[names[i]].Calls != endpoints[names[j]].Calls {
+			return endpoints[names[i]].Calls > endpoints[names[j]].Calls
+		}
+		return names[i] < names[j]
+	})
+
+	var summary strings.Builder
+	fmt.Fprintf(
+		&summary,
+		"API calls: [%d], time: [%s], retries: [%d], failures: [%d]",
+		total.Calls,
+		total.Duration.Round(time.Millisecond),
+		total.Retries,
+		total.Failures,
+	)
+	for _, name := range names {
+		em := endpoints[name]
+		fmt.Fprintf(
+			&summary,
+			"\n  %s: calls: [%d], time: [%s], retries: [%d], failures: [%d]",
+			name,
+			em.Calls,
+			em.Duration.Round(time.Millisecond),
+			em.Retries,
+			em.Failures,
+		)
+	}
+	return summary.String()
+}
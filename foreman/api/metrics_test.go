@@ -0,0 +1,70 @@
+package api
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+)
+
+// ----------------------------------------------------------------------------
+// API Call Metrics
+// ----------------------------------------------------------------------------
+
+// Ensure the API calls, retries and failures are counted per endpoint
+func TestSend_Metrics(t *testing.T) {
+	metrics := NewMetrics()
+	cred := ClientCredentials{}
+	conf := ClientConfig{
+		Metrics: metrics,
+	}
+	mux, server, client := NewForemanAPIAndClient(cred, conf)
+	defer server.Close()
+
+	// dummy '/hosts/' endpoint - only host 1 exists, creation fails once
+	creations := 0
+	mux.HandleFunc(FOREMAN_API_URL_PREFIX+"/hosts", func(w http.ResponseWriter, r *http.Request) {
+		creations++
+		if creations == 1 {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.Write([]byte(`{}`))
+	})
+	mux.HandleFunc(FOREMAN_API_URL_PREFIX+"/hosts/", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != FOREMAN_API_URL_PREFIX+"/hosts/1" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write([]byte(`{}`))
+	})
+
+	for _, endpoint := range []string{"/hosts/1", "/hosts/2"} {
+		req, _ := client.NewRequest(http.MethodGet, endpoint, nil)
+		client.Send(req)
+	}
+	req, _ := client.NewRequest(http.MethodPost, "/hosts", nil)
+	if sendErr := client.SendAndParseWithRetry(req, nil, RetryConfig{Count: 2}); sendErr != nil {
+		t.Fatalf("Client.SendAndParseWithRetry() returned an error: %s", sendErr)
+	}
+
+	expected := map[string]EndpointMetrics{
+		"GET hosts":  {Calls: 2, Failures: 1},
+		"POST hosts": {Calls: 2, Retries: 1, Failures: 1},
+	}
+	endpoints := metrics.Endpoints()
+	if len(endpoints) != len(expected) {
+		t.Fatalf("Expected the endpoints [%v], got [%v]", expected, endpoints)
+	}
+	for name, em := range expected {
+		actual := endpoints[name]
+		actual.Duration = 0
+		if actual != em {
+			t.Errorf("Expected [%s] to be [%+v], got [%+v]", name, em, actual)
+		}
+	}
+
+	summary := metrics.Summary()
+	if !strings.HasPrefix(summary, "API calls: [4]") || !strings.Contains(summary, "retries: [1], failures: [2]") {
+		t.Errorf("Unexpected summary [%s]", summary)
+	}
+}
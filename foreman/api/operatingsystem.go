@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"strings"
 
 	"github.com/wayfair/terraform-provider-utils/log"
 )
@@ -49,16 +50,38 @@ type ForemanOperatingSystem struct {
 	ArchitectureIds []int `json:"architecture_ids,omitempty"`
 	// Partitiontable Ids
 	PartitiontableIds []int `json:"ptable_ids,omitempty"`
+	// Parameters of the operating system consumed by templates (ie: kernel
+	// options).  Nil leaves the parameters of the operating system unchanged,
+	// an empty slice removes them all.
+	OperatingSystemParameters []ForemanKVParameter `json:"-"`
 }
 
 // ForemanOperating struct used for JSON decode.  Foreman API returns the ids
 // back as a list of ForemanObjects with some of the attributes of the data
 // types. However, we are only interested in the IDs returned.
 type foremanOsRespJSON struct {
-	ProvisioningTemplates []ForemanObject `json:"provisioning_templates"`
-	Media                 []ForemanObject `json:"media"`
-	Architectures         []ForemanObject `json:"architectures"`
-	Partitiontables       []ForemanObject `json:"ptables"`
+	ProvisioningTemplates []ForemanObject      `json:"provisioning_templates"`
+	Media                 []ForemanObject      `json:"media"`
+	Architectures         []ForemanObject      `json:"architectures"`
+	Partitiontables       []ForemanObject      `json:"ptables"`
+	Parameters            []ForemanKVParameter `json:"parameters"`
+}
+
+// Custom JSON marshal function.  The parameters are only sent when they are
+// managed, Foreman replaces all parameters of the operating system with the
+// ones sent.
+func (o ForemanOperatingSystem) MarshalJSON() ([]byte, error) {
+	type plainOperatingSystem ForemanOperatingSystem
+	oJSON := struct {
+		plainOperatingSystem
+		OperatingSystemParameters *[]ForemanKVParameter `json:"os_parameters_attributes,omitempty"`
+	}{
+		plainOperatingSystem: plainOperatingSystem(o),
+	}
+	if o.OperatingSystemParameters != nil {
+		oJSON.OperatingSystemParameters = &o.OperatingSystemParameters
+	}
+	return json.Marshal(oJSON)
 }
 
 // Implement the Unmarshaler interface
@@ -81,6 +104,7 @@ func (o *ForemanOperatingSystem) UnmarshalJSON(b []byte) error {
 	o.ArchitectureIds = foremanObjectArrayToIdIntArray(foJSON.Architectures)
 	o.MediumIds = foremanObjectArrayToIdIntArray(foJSON.Media)
 	o.PartitiontableIds = foremanObjectArrayToIdIntArray(foJSON.Partitiontables)
+	o.OperatingSystemParameters = foJSON.Parameters
 
 	var foMap map[string]interface{}
 	jsonDecErr = json.Unmarshal(b, &foMap)
@@ -150,6 +174,8 @@ func (c *Client) CreateOperatingSystem(o *ForemanOperatingSystem) (*ForemanOpera
 		return nil, sendErr
 	}
 
+	restoreHiddenParameters(o.OperatingSystemParameters, createdOperatingSystem.OperatingSystemParameters)
+
 	log.Debugf("createdOperatingSystem: [%+v]", createdOperatingSystem)
 
 	return &createdOperatingSystem, nil
@@ -171,6 +197,11 @@ func (c *Client) ReadOperatingSystem(id int) (*ForemanOperatingSystem, error) {
 	if reqErr != nil {
 		return nil, reqErr
 	}
+	// NOTE(ALL): read the values of hidden parameters, they are masked
+	//   otherwise
+	reqQuery := req.URL.Query()
+	reqQuery.Set("show_hidden_parameters", "true")
+	req.URL.RawQuery = reqQuery.Encode()
 
 	var readOperatingSystem ForemanOperatingSystem
 	sendErr := c.SendAndParse(req, &readOperatingSystem)
@@ -214,6 +245,8 @@ func (c *Client) UpdateOperatingSystem(o *ForemanOperatingSystem) (*ForemanOpera
 		return nil, sendErr
 	}
 
+	restoreHiddenParameters(o.OperatingSystemParameters, updatedOperatingSystem.OperatingSystemParameters)
+
 	log.Debugf("updatedOperatingSystem: [%+v]", updatedOperatingSystem)
 
 	return &updatedOperatingSystem, nil
@@ -246,6 +279,10 @@ func (c *Client) DeleteOperatingSystem(id int) error {
 // attributes of the supplied ForemanOperatingSystem reference and returns a
 // QueryResponse struct containing query/response metadata and the matching
 // operating systems.
+//
+// If the title is set, the operating system is searched by its title only.
+// Otherwise the search matches on each of the name, family and major version
+// that are set, which can yield multiple operating systems.
 func (c *Client) QueryOperatingSystem(o *ForemanOperatingSystem) (QueryResponse, error) {
 	log.Tracef("foreman/api/operatingsystem.go#Search")
 
@@ -264,37 +301,192 @@ func (c *Client) QueryOperatingSystem(o *ForemanOperatingSystem) (QueryResponse,
 
 	// dynamically build the query based on the attributes
 	reqQuery := req.URL.Query()
-	title := `"` + o.Title + `"`
-	reqQuery.Set("search", "title="+title)
+	if o.Title != "" {
+		title := `"` + o.Title + `"`
+		reqQuery.Set("search", "title="+title)
+	} else {
+		search := []string{}
+		if o.Name != "" {
+			search = append(search, `name="`+o.Name+`"`)
+		}
+		if o.Family != "" {
+			search = append(search, `family="`+o.Family+`"`)
+		}
+		if o.Major != "" {
+			search = append(search, `major="`+o.Major+`"`)
+		}
+		reqQuery.Set("search", strings.Join(search, " and "))
+		reqQuery.Set("per_page", "all")
+	}
 
 	req.URL.RawQuery = reqQuery.Encode()
-	sendErr := c.SendAndParse(req, &queryResponse)
-	if sendErr != nil {
-		return queryResponse, sendErr
+	return sendAndParseQuery[ForemanOperatingSystem](c, req)
+}
+
+// -----------------------------------------------------------------------------
+// Association Implementation
+// -----------------------------------------------------------------------------
+
+// operatingSystemAssociation describes objects an operating system is
+// associated with by ID.  Foreman stores each association once, it can be
+// changed from the operating system or from the associated object.
+type operatingSystemAssociation struct {
+	// Name of the associated objects in messages
+	name string
+	// Endpoint prefix and JSON wrapper of the associated objects
+	endpoint string
+	wrapper  string
+	// IDs of the associated objects of the operating system
+	ids func(o *ForemanOperatingSystem) []int
+	// IDs of the operating systems of the associated object
+	readOperatingSystemIds func(c *Client, id int) ([]int, error)
+}
+
+var operatingSystemAssociations = []operatingSystemAssociation{
+	{
+		name:     "provisioning templates",
+		endpoint: ProvisioningTemplateEndpointPrefix,
+		wrapper:  "provisioning_template",
+		ids:      func(o *ForemanOperatingSystem) []int { return o.ProvisioningTemplateIds },
+		readOperatingSystemIds: func(c *Client, id int) ([]int, error) {
+			t, readErr := c.ReadProvisioningTemplate(id)
+			if readErr != nil {
+				return nil, readErr
+			}
+			return t.OperatingSystemIds, nil
+		},
+	},
+	{
+		name:     "media",
+		endpoint: MediaEndpointPrefix,
+		wrapper:  "medium",
+		ids:      func(o *ForemanOperatingSystem) []int { return o.MediumIds },
+		readOperatingSystemIds: func(c *Client, id int) ([]int, error) {
+			m, readErr := c.ReadMedia(id)
+			if readErr != nil {
+				return nil, readErr
+			}
+			return m.OperatingSystemIds, nil
+		},
+	},
+	{
+		name:     "partition tables",
+		endpoint: PartitionTableEndpointPrefix,
+		wrapper:  "ptable",
+		ids:      func(o *ForemanOperatingSystem) []int { return o.PartitiontableIds },
+		readOperatingSystemIds: func(c *Client, id int) ([]int, error) {
+			t, readErr := c.ReadPartitionTable(id)
+			if readErr != nil {
+				return nil, readErr
+			}
+			return t.OperatingSystemIds, nil
+		},
+	},
+}
+
+// missingIds returns the IDs of the wanted slice which are not in the actual
+// slice
+func missingIds(wanted []int, actual []int) []int {
+	missing := []int{}
+	for _, id := range wanted {
+		found := false
+		for _, actualId := range actual {
+			if actualId == id {
+				found = true
+				break
+			}
+		}
+		if !found {
+			missing = append(missing, id)
+		}
 	}
+	return missing
+}
 
-	log.Debugf("queryResponse: [%+v]", queryResponse)
+// EnsureOperatingSystemAssociations verifies that the operating system
+// returned by the API is associated with the provisioning templates, media
+// and partition tables of the requested ForemanOperatingSystem.  Foreman
+// drops associations it does not accept without an error, and hosts of the
+// operating system only fail later on while building (ie: "no provision
+// template found").
+//
+// Missing associations are created from the side of the associated object.
+// The operating system is then read again to verify them, and an error is
+// returned if associations are still missing.  The returned
+// ForemanOperatingSystem reference is never nil, it is the supplied actual
+// operating system if it could not be read again.
+func (c *Client) EnsureOperatingSystemAssociations(requested *ForemanOperatingSystem, actual *ForemanOperatingSystem) (*ForemanOperatingSystem, error) {
+	log.Tracef("foreman/api/operatingsystem.go#EnsureAssociations")
+
+	associated := false
+	for _, assoc := range operatingSystemAssociations {
+		for _, id := range missingIds(assoc.ids(requested), assoc.ids(actual)) {
+			log.Infof(
+				"Operating system [%d] is not associated with %s [%d], associating it",
+				actual.Id,
+				assoc.name,
+				id,
+			)
+			if assocErr := c.associateOperatingSystem(assoc, id, actual.Id); assocErr != nil {
+				return actual, assocErr
+			}
+			associated = true
+		}
+	}
+	if !associated {
+		return actual, nil
+	}
 
-	// Results will be Unmarshaled into a []map[string]interface{}
-	//
-	// Encode back to JSON, then Unmarshal into []ForemanOperatingSystem for
-	// the results
-	results := []ForemanOperatingSystem{}
-	resultsBytes, jsonEncErr := json.Marshal(queryResponse.Results)
-	if jsonEncErr != nil {
-		return queryResponse, jsonEncErr
+	readOs, readErr := c.ReadOperatingSystem(actual.Id)
+	if readErr != nil {
+		return actual, readErr
 	}
-	jsonDecErr := json.Unmarshal(resultsBytes, &results)
-	if jsonDecErr != nil {
-		return queryResponse, jsonDecErr
+	for _, assoc := range operatingSystemAssociations {
+		if missing := missingIds(assoc.ids(requested), assoc.ids(readOs)); len(missing) > 0 {
+			return readOs, fmt.Errorf(
+				"Operating system [%d] could not be associated with %s %v. Verify "+
+					"they share the locations and organizations of the operating system",
+				readOs.Id,
+				assoc.name,
+				missing,
+			)
+		}
+	}
+	return readOs, nil
+}
+
+// associateOperatingSystem adds the operating system identified by osId to
+// the operating systems of the associated object identified by id.  Only the
+// operating systems of the object are sent, its other attributes are left
+// unchanged.
+func (c *Client) associateOperatingSystem(assoc operatingSystemAssociation, id int, osId int) error {
+	osIds, readErr := assoc.readOperatingSystemIds(c, id)
+	if readErr != nil {
+		return readErr
+	}
+	if len(missingIds([]int{osId}, osIds)) == 0 {
+		return nil
+	}
+
+	reqEndpoint := fmt.Sprintf("/%s/%d", assoc.endpoint, id)
+
+	assocJSONBytes, jsonEncErr := WrapJson(assoc.wrapper, map[string][]int{
+		"operatingsystem_ids": append(osIds, osId),
+	})
+	if jsonEncErr != nil {
+		return jsonEncErr
 	}
-	// conver the search results from []ForemanOperatingSystem to []interface
-	// and set the search results on the query
-	iArr := make([]interface{}, len(results))
-	for idx, val := range results {
-		iArr[idx] = val
+
+	log.Debugf("assocJSONBytes: [%s]", assocJSONBytes)
+
+	req, reqErr := c.NewRequest(
+		http.MethodPut,
+		reqEndpoint,
+		bytes.NewBuffer(assocJSONBytes),
+	)
+	if reqErr != nil {
+		return reqErr
 	}
-	queryResponse.Results = iArr
 
-	return queryResponse, nil
+	return c.SendAndParse(req, nil)
 }
@@ -0,0 +1,78 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+// ----------------------------------------------------------------------------
+// EnsureOperatingSystemAssociations
+// ----------------------------------------------------------------------------
+
+// Ensures missing associations of an operating system are created from the
+// side of the associated objects and verified afterwards.
+func TestEnsureOperatingSystemAssociations(t *testing.T) {
+	urlMux, server, client := NewForemanAPIAndClient(ClientCredentials{}, ClientConfig{})
+	defer server.Close()
+
+	var sentOsIds []int
+	urlMux.HandleFunc(FOREMAN_API_URL_PREFIX+"/provisioning_templates/3", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			fmt.Fprint(w, `{"id": 3, "operatingsystems": [{"id": 1}]}`)
+			return
+		}
+		var body struct {
+			ProvisioningTemplate struct {
+				OperatingSystemIds []int `json:"operatingsystem_ids"`
+			} `json:"provisioning_template"`
+		}
+		json.NewDecoder(r.Body).Decode(&body)
+		sentOsIds = body.ProvisioningTemplate.OperatingSystemIds
+		fmt.Fprint(w, `{"id": 3}`)
+	})
+	osReads := 0
+	urlMux.HandleFunc(FOREMAN_API_URL_PREFIX+"/operatingsystems/2", func(w http.ResponseWriter, r *http.Request) {
+		osReads++
+		fmt.Fprint(w, `{"id": 2, "provisioning_templates": [{"id": 3}]}`)
+	})
+
+	requested := &ForemanOperatingSystem{ProvisioningTemplateIds: []int{3}}
+	actual := &ForemanOperatingSystem{}
+	actual.Id = 2
+
+	ensuredOs, ensureErr := client.EnsureOperatingSystemAssociations(requested, actual)
+	if ensureErr != nil {
+		t.Fatalf("EnsureOperatingSystemAssociations returned an error: %s", ensureErr)
+	}
+	if !reflect.DeepEqual(sentOsIds, []int{1, 2}) {
+		t.Fatalf("Expected the operating systems [1 2] to be sent, got [%v]", sentOsIds)
+	}
+	if osReads != 1 || !reflect.DeepEqual(ensuredOs.ProvisioningTemplateIds, []int{3}) {
+		t.Fatalf("Expected the operating system to be read again, got [%+v]", ensuredOs)
+	}
+
+	// associations already present are not changed
+	osReads = 0
+	sentOsIds = nil
+	ensuredOs, ensureErr = client.EnsureOperatingSystemAssociations(requested, ensuredOs)
+	if ensureErr != nil || osReads != 0 || sentOsIds != nil {
+		t.Fatalf(
+			"Expected no requests for present associations, got [%d] reads (%v)",
+			osReads,
+			ensureErr,
+		)
+	}
+
+	// associations dropped by Foreman are reported
+	requested.MediumIds = []int{4}
+	urlMux.HandleFunc(FOREMAN_API_URL_PREFIX+"/media/4", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id": 4, "operatingsystems": []}`)
+	})
+	_, ensureErr = client.EnsureOperatingSystemAssociations(requested, ensuredOs)
+	if ensureErr == nil {
+		t.Fatalf("EnsureOperatingSystemAssociations did not fail although the medium was not associated")
+	}
+}
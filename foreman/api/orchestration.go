@@ -0,0 +1,220 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/wayfair/terraform-provider-utils/log"
+)
+
+const (
+	// OrchestrationEndpointPrefix : Prefix appended to API url for the
+	// orchestration tasks of a host
+	OrchestrationEndpointPrefix = "orchestration"
+	// OrchestrationTaskFailed : Status of a failed orchestration task
+	OrchestrationTaskFailed = "failed"
+	// OrchestrationTaskConflict : Status of an orchestration task conflicting
+	// with an existing record (ie: DNS record, DHCP lease)
+	OrchestrationTaskConflict = "conflict"
+)
+
+// orchestrationPollInterval is the time between two polls of the
+// orchestration tasks of a host creation
+var orchestrationPollInterval = 5 * time.Second
+
+// -----------------------------------------------------------------------------
+// Struct Definition and Helpers
+// -----------------------------------------------------------------------------
+
+// ForemanOrchestrationTask is a step of the orchestration Foreman performs
+// when creating a host (ie: creating the VM, DNS records and DHCP leases)
+type ForemanOrchestrationTask struct {
+	// Description of the task (ie: "Create DNS record for host.example.com")
+	Name string `json:"name"`
+	// Status of the task (ie: pending, running, completed, failed, conflict)
+	Status string `json:"status"`
+}
+
+// OrchestrationError is returned when Foreman's orchestration of a host
+// failed.  These errors (ie: a DNS conflict, an existing DHCP lease) are not
+// resolved by retrying the request.
+type OrchestrationError struct {
+	// The orchestration tasks that failed
+	Tasks []ForemanOrchestrationTask
+	// The error of the request the orchestration was part of
+	Err error
+}
+
+// Error implements the error interface
+func (e *OrchestrationError) Error() string {
+	tasks := make([]string, len(e.Tasks))
+	for idx, task := range e.Tasks {
+		tasks[idx] = fmt.Sprintf("[%s]: [%s]", task.Name, task.Status)
+	}
+	return fmt.Sprintf(
+		"Orchestration failed: %s\n%s",
+		strings.Join(tasks, ", "),
+		e.Err,
+	)
+}
+
+// Unwrap returns the error of the request the orchestration was part of
+func (e *OrchestrationError) Unwrap() error {
+	return e.Err
+}
+
+// isUnrecoverableHostCreationError returns whether retrying a failed host
+// creation is pointless: failed orchestrations and the conflict (409) and
+// validation (422) errors of the API.
+func isUnrecoverableHostCreationError(err error) bool {
+	if _, ok := err.(*OrchestrationError); ok {
+		return true
+	}
+	var validationErr *ValidationError
+	return errors.Is(err, ErrConflict) || errors.As(err, &validationErr)
+}
+
+// -----------------------------------------------------------------------------
+// Orchestration Tasks
+// -----------------------------------------------------------------------------
+
+// ReadOrchestrationTasks reads the orchestration tasks tracked by the supplied
+// progress report ID.  See ForemanHost.ProgressReportId.
+func (c *Client) ReadOrchestrationTasks(id string) ([]ForemanOrchestrationTask, error) {
+	log.Tracef("foreman/api/orchestration.go#Read")
+
+	reqEndpoint := fmt.Sprintf("/%s/%s/tasks", OrchestrationEndpointPrefix, id)
+
+	req, reqErr := c.NewRequest(
+		http.MethodGet,
+		reqEndpoint,
+		nil,
+	)
+	if reqErr != nil {
+		return nil, reqErr
+	}
+
+	var tasksJSON json.RawMessage
+	sendErr := c.SendAndParse(req, &tasksJSON)
+	if sendErr != nil {
+		return nil, sendErr
+	}
+
+	// NOTE(ALL): depending on the Foreman version the tasks are reported as
+	//   a plain array or as the results of an index response
+	var tasks []ForemanOrchestrationTask
+	if bytes.HasPrefix(bytes.TrimSpace(tasksJSON), []byte("[")) {
+		if jsonDecErr := json.Unmarshal(tasksJSON, &tasks); jsonDecErr != nil {
+			return nil, jsonDecErr
+		}
+		return tasks, nil
+	}
+	var tasksResponse struct {
+		Results []ForemanOrchestrationTask `json:"results"`
+	}
+	if jsonDecErr := json.Unmarshal(tasksJSON, &tasksResponse); jsonDecErr != nil {
+		return nil, jsonDecErr
+	}
+	return tasksResponse.Results, nil
+}
+
+// orchestrationWatcher polls the orchestration tasks of a host creation in
+// the background, logging the progress of the tasks and recording the ones
+// that failed.
+type orchestrationWatcher struct {
+	client *Client
+	id     string
+
+	done     chan struct{}
+	finished chan struct{}
+
+	mu       sync.Mutex
+	statuses map[string]string
+	failed   []ForemanOrchestrationTask
+}
+
+// watchOrchestration starts polling the orchestration tasks tracked by the
+// supplied progress report ID.  The watcher must be stopped.
+func (c *Client) watchOrchestration(id string) *orchestrationWatcher {
+	w := &orchestrationWatcher{
+		client:   c,
+		id:       id,
+		done:     make(chan struct{}),
+		finished: make(chan struct{}),
+		statuses: map[string]string{},
+	}
+	go w.run()
+	return w
+}
+
+// run polls the tasks until the watcher is stopped
+func (w *orchestrationWatcher) run() {
+	defer close(w.finished)
+
+	ticker := time.NewTicker(orchestrationPollInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-w.done:
+			return
+		case <-ticker.C:
+			w.poll()
+		}
+	}
+}
+
+// poll reads the tasks once, logging the tasks that changed their status
+func (w *orchestrationWatcher) poll() {
+	tasks, readErr := w.client.ReadOrchestrationTasks(w.id)
+	if readErr != nil {
+		// NOTE(ALL): the tasks are only tracked while the orchestration runs,
+		//   the progress is informational
+		log.Debugf("Failed to read orchestration tasks [%s]: %s", w.id, readErr)
+		return
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.failed = nil
+	for _, task := range tasks {
+		if w.statuses[task.Name] != task.Status {
+			log.Infof("Orchestration [%s]: [%s]", task.Name, task.Status)
+			w.statuses[task.Name] = task.Status
+		}
+		if task.Status == OrchestrationTaskFailed || task.Status == OrchestrationTaskConflict {
+			w.failed = append(w.failed, task)
+		}
+	}
+}
+
+// failedTasks returns the failed tasks seen by the last poll.  A nil watcher
+// has no failed tasks.
+func (w *orchestrationWatcher) failedTasks() []ForemanOrchestrationTask {
+	if w == nil {
+		return nil
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return append([]ForemanOrchestrationTask(nil), w.failed...)
+}
+
+// stop stops polling and returns the failed tasks.  If final is set, the
+// tasks are polled once more to capture their final status.  Stopping a nil
+// watcher is a no-op.
+func (w *orchestrationWatcher) stop(final bool) []ForemanOrchestrationTask {
+	if w == nil {
+		return nil
+	}
+	close(w.done)
+	<-w.finished
+	if final {
+		w.poll()
+	}
+	return w.failedTasks()
+}
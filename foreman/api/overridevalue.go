@@ -0,0 +1,204 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/wayfair/terraform-provider-utils/log"
+)
+
+const (
+	// SmartClassParameterEndpointPrefix : Prefix appended to the API url for
+	// smart class parameters
+	SmartClassParameterEndpointPrefix = "smart_class_parameters"
+	// OverrideValueEndpointPrefix : Prefix appended to the url of a smart
+	// class parameter for its override values
+	OverrideValueEndpointPrefix = "override_values"
+)
+
+// -----------------------------------------------------------------------------
+// Struct Definition and Helpers
+// -----------------------------------------------------------------------------
+
+// The ForemanOverrideValue API model represents a value of a smart class
+// parameter (a parameter of a Puppet class) which overrides the default
+// value for the hosts matching its matcher (ie: "fqdn=host.example.com").
+type ForemanOverrideValue struct {
+	// Unique identifier of the override value
+	Id int `json:"id,omitempty"`
+	// ID of the smart class parameter the value overrides.  Not part of the
+	// JSON, the override values are nested underneath their parameter.
+	SmartClassParameterId int `json:"-"`
+	// The matcher of the hosts the value applies to (ie: "fqdn=host.example.com")
+	Match string `json:"match"`
+	// The value, encoded as the type of the smart class parameter (ie: a JSON
+	// hash for a parameter of type "hash")
+	Value string `json:"value"`
+	// Whether or not the parameter is omitted from the Puppet class of the
+	// matching hosts, instead of overriding its value
+	Omit bool `json:"omit"`
+}
+
+// Custom JSON unmarshal function.  Foreman returns the value typed as the
+// smart class parameter, values which are not strings are decoded to their
+// JSON representation.
+func (fo *ForemanOverrideValue) UnmarshalJSON(b []byte) error {
+	var foJSON struct {
+		Id    int             `json:"id"`
+		Match string          `json:"match"`
+		Value json.RawMessage `json:"value"`
+		Omit  bool            `json:"omit"`
+	}
+	if jsonDecErr := json.Unmarshal(b, &foJSON); jsonDecErr != nil {
+		return jsonDecErr
+	}
+	fo.Id = foJSON.Id
+	fo.Match = foJSON.Match
+	fo.Omit = foJSON.Omit
+	fo.Value = ""
+	if len(foJSON.Value) > 0 && string(foJSON.Value) != "null" {
+		if json.Unmarshal(foJSON.Value, &fo.Value) != nil {
+			fo.Value = string(foJSON.Value)
+		}
+	}
+	return nil
+}
+
+// overrideValueEndpoint returns the endpoint of the override values of the
+// supplied smart class parameter
+func overrideValueEndpoint(smartClassParameterId int) string {
+	return fmt.Sprintf(
+		"/%s/%d/%s",
+		SmartClassParameterEndpointPrefix,
+		smartClassParameterId,
+		OverrideValueEndpointPrefix,
+	)
+}
+
+// -----------------------------------------------------------------------------
+// CRUD Implementation
+// -----------------------------------------------------------------------------
+
+// CreateOverrideValue creates a new ForemanOverrideValue with the attributes
+// of the supplied ForemanOverrideValue reference and returns the created
+// ForemanOverrideValue reference.  The returned reference will have its ID
+// and other API default values set by this function.
+func (c *Client) CreateOverrideValue(o *ForemanOverrideValue) (*ForemanOverrideValue, error) {
+	log.Tracef("foreman/api/overridevalue.go#Create")
+
+	reqEndpoint := overrideValueEndpoint(o.SmartClassParameterId)
+
+	oJSONBytes, jsonEncErr := WrapJson("override_value", o)
+	if jsonEncErr != nil {
+		return nil, jsonEncErr
+	}
+
+	log.Debugf("overrideValueJSONBytes: [%s]", oJSONBytes)
+
+	req, reqErr := c.NewRequest(
+		http.MethodPost,
+		reqEndpoint,
+		bytes.NewBuffer(oJSONBytes),
+	)
+	if reqErr != nil {
+		return nil, reqErr
+	}
+
+	var createdOverrideValue ForemanOverrideValue
+	sendErr := c.SendAndParse(req, &createdOverrideValue)
+	if sendErr != nil {
+		return nil, sendErr
+	}
+	createdOverrideValue.SmartClassParameterId = o.SmartClassParameterId
+
+	log.Debugf("createdOverrideValue: [%+v]", createdOverrideValue)
+
+	return &createdOverrideValue, nil
+}
+
+// ReadOverrideValue reads the attributes of the ForemanOverrideValue
+// identified by the supplied ID of the supplied smart class parameter and
+// returns a ForemanOverrideValue reference.
+func (c *Client) ReadOverrideValue(smartClassParameterId int, id int) (*ForemanOverrideValue, error) {
+	log.Tracef("foreman/api/overridevalue.go#Read")
+
+	reqEndpoint := fmt.Sprintf("%s/%d", overrideValueEndpoint(smartClassParameterId), id)
+
+	req, reqErr := c.NewRequest(
+		http.MethodGet,
+		reqEndpoint,
+		nil,
+	)
+	if reqErr != nil {
+		return nil, reqErr
+	}
+
+	var readOverrideValue ForemanOverrideValue
+	sendErr := c.SendAndParse(req, &readOverrideValue)
+	if sendErr != nil {
+		return nil, sendErr
+	}
+	readOverrideValue.SmartClassParameterId = smartClassParameterId
+
+	log.Debugf("readOverrideValue: [%+v]", readOverrideValue)
+
+	return &readOverrideValue, nil
+}
+
+// UpdateOverrideValue updates a ForemanOverrideValue's attributes.  The
+// override value with the ID of the supplied ForemanOverrideValue will be
+// updated.  A new ForemanOverrideValue reference is returned with the
+// attributes from the result of the update operation.
+func (c *Client) UpdateOverrideValue(o *ForemanOverrideValue) (*ForemanOverrideValue, error) {
+	log.Tracef("foreman/api/overridevalue.go#Update")
+
+	reqEndpoint := fmt.Sprintf("%s/%d", overrideValueEndpoint(o.SmartClassParameterId), o.Id)
+
+	oJSONBytes, jsonEncErr := WrapJson("override_value", o)
+	if jsonEncErr != nil {
+		return nil, jsonEncErr
+	}
+
+	log.Debugf("overrideValueJSONBytes: [%s]", oJSONBytes)
+
+	req, reqErr := c.NewRequest(
+		http.MethodPut,
+		reqEndpoint,
+		bytes.NewBuffer(oJSONBytes),
+	)
+	if reqErr != nil {
+		return nil, reqErr
+	}
+
+	var updatedOverrideValue ForemanOverrideValue
+	sendErr := c.SendAndParse(req, &updatedOverrideValue)
+	if sendErr != nil {
+		return nil, sendErr
+	}
+	updatedOverrideValue.SmartClassParameterId = o.SmartClassParameterId
+
+	log.Debugf("updatedOverrideValue: [%+v]", updatedOverrideValue)
+
+	return &updatedOverrideValue, nil
+}
+
+// DeleteOverrideValue deletes the ForemanOverrideValue identified by the
+// supplied ID of the supplied smart class parameter
+func (c *Client) DeleteOverrideValue(smartClassParameterId int, id int) error {
+	log.Tracef("foreman/api/overridevalue.go#Delete")
+
+	reqEndpoint := fmt.Sprintf("%s/%d", overrideValueEndpoint(smartClassParameterId), id)
+
+	req, reqErr := c.NewRequest(
+		http.MethodDelete,
+		reqEndpoint,
+		nil,
+	)
+	if reqErr != nil {
+		return reqErr
+	}
+
+	return c.SendAndParse(req, nil)
+}
@@ -53,6 +53,8 @@ func (fp *ForemanParameter) apiEndpoint() (string, int) {
 	return "", -1
 }
 
+// Custom JSON unmarshal function.  Unmarshal the common Foreman object
+// properties and then read the parameter attributes from a generic map.
 func (fp *ForemanParameter) UnmarshalJSON(b []byte) error {
 	var jsonDecErr error
 
@@ -77,6 +79,9 @@ func (fp *ForemanParameter) UnmarshalJSON(b []byte) error {
 	if fp.Parameter.Value, ok = fpMap["value"].(string); !ok {
 		fp.Parameter.Value = ""
 	}
+	if fp.Parameter.HiddenValue, ok = fpMap["hidden_value?"].(bool); !ok {
+		fp.Parameter.HiddenValue = false
+	}
 
 	return nil
 }
@@ -120,7 +125,12 @@ func (c *Client) CreateParameter(d *ForemanParameter) (*ForemanParameter, error)
 	log.Debugf("createdParameter: [%+v]", createdParameter)
 
 	d.Id = createdParameter.Id
+	// NOTE(ALL): hidden values come back masked, keep the value we sent
+	sentValue := d.Parameter.Value
 	d.Parameter = createdParameter.Parameter
+	if d.Parameter.HiddenValue {
+		d.Parameter.Value = sentValue
+	}
 	return d, nil
 }
 
@@ -141,6 +151,11 @@ func (c *Client) ReadParameter(d *ForemanParameter, id int) (*ForemanParameter,
 		return nil, reqErr
 	}
 
+	// NOTE(ALL): hidden values are masked in the response unless requested
+	reqQuery := req.URL.Query()
+	reqQuery.Set("show_hidden", "true")
+	req.URL.RawQuery = reqQuery.Encode()
+
 	var readParameter ForemanParameter
 	sendErr := c.SendAndParse(req, &readParameter)
 	if sendErr != nil {
@@ -187,7 +202,12 @@ func (c *Client) UpdateParameter(d *ForemanParameter, id int) (*ForemanParameter
 	log.Debugf("updatedParameter: [%+v]", updatedParameter)
 
 	d.Id = updatedParameter.Id
+	// NOTE(ALL): hidden values come back masked, keep the value we sent
+	sentValue := d.Parameter.Value
 	d.Parameter = updatedParameter.Parameter
+	if d.Parameter.HiddenValue {
+		d.Parameter.Value = sentValue
+	}
 	return d, nil
 }
 
@@ -236,35 +256,8 @@ func (c *Client) QueryParameter(d *ForemanParameter) (QueryResponse, error) {
 	reqQuery := req.URL.Query()
 	name := `"` + d.Name + `"`
 	reqQuery.Set("search", "name="+name)
+	reqQuery.Set("show_hidden", "true")
 
 	req.URL.RawQuery = reqQuery.Encode()
-	sendErr := c.SendAndParse(req, &queryResponse)
-	if sendErr != nil {
-		return queryResponse, sendErr
-	}
-
-	log.Debugf("queryResponse: [%+v]", queryResponse)
-
-	// Results will be Unmarshaled into a []map[string]interface{}
-	//
-	// Encode back to JSON, then Unmarshal into []ForemanParameter for
-	// the results
-	results := []ForemanParameter{}
-	resultsBytes, jsonEncErr := json.Marshal(queryResponse.Results)
-	if jsonEncErr != nil {
-		return queryResponse, jsonEncErr
-	}
-	jsonDecErr := json.Unmarshal(resultsBytes, &results)
-	if jsonDecErr != nil {
-		return queryResponse, jsonDecErr
-	}
-	// convert the search results from []ForemanParameter to []interface
-	// and set the search results on the query
-	iArr := make([]interface{}, len(results))
-	for idx, val := range results {
-		iArr[idx] = val
-	}
-	queryResponse.Results = iArr
-
-	return queryResponse, nil
+	return sendAndParseQuery[ForemanParameter](c, req)
 }
@@ -0,0 +1,133 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+)
+
+const (
+	// LookupBuildStatus : the build status and orchestration tasks of hosts
+	// read during and after their creation
+	LookupBuildStatus = "build_status"
+	// LookupPowerState : the power state of hosts read to verify their BMC
+	LookupPowerState = "power_state"
+	// LookupBootDevice : the boot device of hosts read from their BMC
+	LookupBootDevice = "boot_device"
+	// LookupSmartProxy : the BMC smart proxies read to verify their providers
+	// while planning
+	LookupSmartProxy = "smart_proxy"
+)
+
+// Lookups are the optional lookups which can be disabled for accounts not
+// permitted to perform them.  See ClientConfig.DisabledLookups.
+var Lookups = []string{
+	LookupBuildStatus,
+	LookupPowerState,
+	LookupBootDevice,
+	LookupSmartProxy,
+}
+
+// missingPermissionsPattern matches the permissions listed in the message of
+// a 403 response (ie: "Missing one of the required permissions: view_hosts")
+var missingPermissionsPattern = regexp.MustCompile(`(?i)missing (?:one of )?the required permissions?: ([a-z_, ]+)`)
+
+// -----------------------------------------------------------------------------
+// Struct Definition and Helpers
+// -----------------------------------------------------------------------------
+
+// PermissionError is returned by SendAndParse when the server responds with a
+// 403 status code: the account of the client is not permitted to perform the
+// request.  Unlike other errors of the API, permission errors are never
+// retried.
+type PermissionError struct {
+	// The permissions the account is missing (ie: view_hosts).  Empty if the
+	// server did not report them.
+	Permissions []string
+	// The API collection the request targeted (ie: hosts)
+	Resource string
+	// The method of the request
+	Method string
+	// The error of the request
+	Err *HTTPError
+}
+
+// Error implements the error interface
+func (e *PermissionError) Error() string {
+	permission := "the required permission"
+	if len(e.Permissions) > 0 {
+		permission = fmt.Sprintf("permission [%s]", strings.Join(e.Permissions, " or "))
+	}
+	return fmt.Sprintf(
+		"The account is missing %s on resource [%s] (%s %s). Grant it to the "+
+			"role of the account, or disable the lookup in the provider "+
+			"features if it is optional",
+		permission,
+		e.Resource,
+		e.Method,
+		e.Err.Endpoint,
+	)
+}
+
+// Unwrap returns the HTTPError of the request
+func (e *PermissionError) Unwrap() error {
+	return e.Err
+}
+
+// newPermissionError creates the PermissionError of the 403 response to the
+// supplied request.  The missing permissions are taken from the
+// "missing_permissions" of the response or the message of the error.
+func newPermissionError(req *http.Request, httpErr *HTTPError) *PermissionError {
+	permErr := PermissionError{
+		Resource: apiCollection(req.URL.Path),
+		Method:   req.Method,
+		Err:      httpErr,
+	}
+
+	var respJSON struct {
+		Error struct {
+			Message            string   `json:"message"`
+			Details            string   `json:"details"`
+			MissingPermissions []string `json:"missing_permissions"`
+		} `json:"error"`
+	}
+	if json.Unmarshal(httpErr.RespBody, &respJSON) != nil {
+		return &permErr
+	}
+	permErr.Permissions = respJSON.Error.MissingPermissions
+	if len(permErr.Permissions) > 0 {
+		return &permErr
+	}
+	for _, msg := range []string{respJSON.Error.Details, respJSON.Error.Message} {
+		if match := missingPermissionsPattern.FindStringSubmatch(msg); match != nil {
+			for _, permission := range strings.Split(match[1], ",") {
+				if permission = strings.TrimSpace(permission); permission != "" {
+					permErr.Permissions = append(permErr.Permissions, permission)
+				}
+			}
+			break
+		}
+	}
+	return &permErr
+}
+
+// IsPermissionError returns whether the supplied error is caused by the
+// account of the client missing a permission
+func IsPermissionError(err error) bool {
+	var permErr *PermissionError
+	return errors.As(err, &permErr)
+}
+
+// LookupEnabled returns whether the supplied optional lookup (see Lookups) is
+// performed
+func (cfg ClientConfig) LookupEnabled(lookup string) bool {
+	for _, disabled := range cfg.DisabledLookups {
+		if disabled == lookup {
+			return false
+		}
+	}
+	return true
+}
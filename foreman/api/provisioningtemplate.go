@@ -23,6 +23,8 @@ const (
 type ForemanProvisioningTemplate struct {
 	// Inherits the base object's attributes
 	ForemanObject
+	// Locations and organizations the provisioning template is associated with
+	ForemanTaxonomies
 
 	// The markup and code of the provisioning template
 	Template string
@@ -119,6 +121,15 @@ func (ft ForemanProvisioningTemplate) MarshalJSON() ([]byte, error) {
 		ftMap["template_combinations_attributes"] = ft.TemplateCombinationsAttributes
 	}
 
+	// only include the taxonomies if they are set, leaving the associations
+	// to Foreman otherwise
+	if len(ft.LocationIds) > 0 {
+		ftMap["location_ids"] = ft.LocationIds
+	}
+	if len(ft.OrganizationIds) > 0 {
+		ftMap["organization_ids"] = ft.OrganizationIds
+	}
+
 	log.Debugf("ftMap: [%v]", ftMap)
 
 	return json.Marshal(ftMap)
@@ -146,6 +157,9 @@ func (ft *ForemanProvisioningTemplate) UnmarshalJSON(b []byte) error {
 	}
 	ft.OperatingSystemIds = foremanObjectArrayToIdIntArray(ftJSON.OperatingSystems)
 	ft.TemplateCombinationsAttributes = ftJSON.TemplateCombinationsAttributes
+	if jsonDecErr = ft.decodeTaxonomies(b); jsonDecErr != nil {
+		return jsonDecErr
+	}
 
 	// Unmarshal into mapstructure and set the rest of the struct properties
 	var ftMap map[string]interface{}
@@ -330,33 +344,85 @@ func (c *Client) QueryProvisioningTemplate(t *ForemanProvisioningTemplate) (Quer
 	reqQuery.Set("search", "name="+name)
 
 	req.URL.RawQuery = reqQuery.Encode()
-	sendErr := c.SendAndParse(req, &queryResponse)
-	if sendErr != nil {
-		return queryResponse, sendErr
-	}
+	return sendAndParseQuery[ForemanProvisioningTemplate](c, req)
+}
+
+// -----------------------------------------------------------------------------
+// Preview Implementation
+// -----------------------------------------------------------------------------
 
-	log.Debugf("queryResponse: [%+v]", queryResponse)
+// ProvisioningTemplatePreviewPath : Path of the preview of the template
+// editor.  Previews are not part of the API, unlike the rendering of the
+// stored templates of a host (see RenderHostTemplate).
+const ProvisioningTemplatePreviewPath = "/templates/provisioning_templates/%d/preview"
+
+// TemplateRenderError is returned by PreviewProvisioningTemplate when Foreman
+// could not render the template content (ie: an ERB syntax error or an
+// unknown macro)
+type TemplateRenderError struct {
+	// ID of the previewed template
+	TemplateId int
+	// ID of the host the template was rendered for
+	HostId int
+	// The error Foreman reported
+	Message string
+}
 
-	// Results will be Unmarshaled into a []map[string]interface{}
-	//
-	// Encode back to JSON, then Unmarshal into []ForemanProvisioningTemplate for
-	// the results
-	results := []ForemanProvisioningTemplate{}
-	resultsBytes, jsonEncErr := json.Marshal(queryResponse.Results)
+// Error implements the error interface
+func (e *TemplateRenderError) Error() string {
+	return fmt.Sprintf(
+		"Provisioning template [%d] could not be rendered for host [%d]: %s",
+		e.TemplateId,
+		e.HostId,
+		e.Message,
+	)
+}
+
+// PreviewProvisioningTemplate renders the supplied content of the
+// provisioning template identified by the supplied ID for the host
+// identified by the supplied ID, without saving the content.  Returns the
+// rendered content, or a TemplateRenderError if the content could not be
+// rendered.
+//
+// Example: https://<foreman>/templates/provisioning_templates/<id>/preview
+func (c *Client) PreviewProvisioningTemplate(id int, hostId int, template string) (string, error) {
+	log.Tracef("foreman/api/provisioningtemplate.go#Preview")
+
+	previewJSONBytes, jsonEncErr := json.Marshal(map[string]interface{}{
+		"template":        template,
+		"preview_host_id": hostId,
+	})
 	if jsonEncErr != nil {
-		return queryResponse, jsonEncErr
+		return "", jsonEncErr
 	}
-	jsonDecErr := json.Unmarshal(resultsBytes, &results)
-	if jsonDecErr != nil {
-		return queryResponse, jsonDecErr
-	}
-	// convert the search results from []ForemanProvisioningTemplate to []interface
-	// and set the search results on the query
-	iArr := make([]interface{}, len(results))
-	for idx, val := range results {
-		iArr[idx] = val
+
+	req, reqErr := c.NewRequest(
+		http.MethodPost,
+		"/",
+		bytes.NewBuffer(previewJSONBytes),
+	)
+	if reqErr != nil {
+		return "", reqErr
 	}
-	queryResponse.Results = iArr
+	req.URL.Path = fmt.Sprintf(ProvisioningTemplatePreviewPath, id)
 
-	return queryResponse, nil
+	statusCode, respBody, sendErr := c.Send(req)
+	if sendErr != nil {
+		return "", sendErr
+	}
+	switch {
+	case statusCode == http.StatusNotAcceptable:
+		return "", &TemplateRenderError{
+			TemplateId: id,
+			HostId:     hostId,
+			Message:    string(respBody),
+		}
+	case statusCode < 200 || statusCode > 299:
+		return "", &HTTPError{
+			Endpoint:   req.URL.String(),
+			StatusCode: statusCode,
+			RespBody:   respBody,
+		}
+	}
+	return string(respBody), nil
 }
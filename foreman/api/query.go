@@ -0,0 +1,181 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/wayfair/terraform-provider-utils/log"
+)
+
+// -----------------------------------------------------------------------------
+// Query Implementation
+// -----------------------------------------------------------------------------
+
+// Query performs a search against an arbitrary index endpoint of the API and
+// returns a QueryResponse struct containing query/response metadata.  Unlike
+// the typed Query* functions, the results are left as the generic
+// map[string]interface{} structures produced by the JSON decoder.
+//
+// endpoint
+//   The collection endpoint relative to the API prefix (ie: "architectures"
+//   or "/compute_resources/1/images")
+// search
+//   A Foreman search string.  When empty, no search filter is applied.
+func (c *Client) Query(endpoint string, search string) (QueryResponse, error) {
+	log.Tracef("foreman/api/query.go#Query")
+
+	queryResponse := QueryResponse{}
+
+	reqEndpoint := "/" + strings.TrimPrefix(endpoint, "/")
+	req, reqErr := c.NewRequest(
+		http.MethodGet,
+		reqEndpoint,
+		nil,
+	)
+	if reqErr != nil {
+		return queryResponse, reqErr
+	}
+
+	reqQuery := req.URL.Query()
+	if search != "" {
+		reqQuery.Set("search", search)
+	}
+	reqQuery.Set("per_page", "all")
+
+	req.URL.RawQuery = reqQuery.Encode()
+	sendErr := c.SendAndParse(req, &queryResponse)
+	if sendErr != nil {
+		return queryResponse, sendErr
+	}
+
+	log.Debugf("queryResponse: [%+v]", queryResponse)
+
+	if queryResponse.Results == nil {
+		return queryResponse, fmt.Errorf(
+			"Endpoint [%s] did not return a collection of results",
+			reqEndpoint,
+		)
+	}
+
+	return queryResponse, nil
+}
+
+// typedQueryResponse is a QueryResponse whose results are decoded directly
+// into the Foreman API object T.  The Results field shadows the generic
+// results of the embedded QueryResponse when decoding.
+type typedQueryResponse[T any] struct {
+	QueryResponse
+	Results []T `json:"results"`
+}
+
+// sendAndParseQuery sends the search request and decodes the results into
+// a []T in a single pass, instead of decoding them into the generic
+// map[string]interface{} structures and encoding those back to JSON.  The
+// returned QueryResponse holds the query/response metadata and the typed
+// results converted to []interface{}.
+func sendAndParseQuery[T any](c *Client, req *http.Request) (QueryResponse, error) {
+	var typedResponse typedQueryResponse[T]
+	sendErr := c.SendAndParse(req, &typedResponse)
+	queryResponse := typedResponse.QueryResponse
+	if sendErr != nil {
+		return queryResponse, sendErr
+	}
+
+	log.Debugf("queryResponse: [%+v]", typedResponse)
+
+	// convert the search results from []T to []interface and set the search
+	// results on the query
+	iArr := make([]interface{}, len(typedResponse.Results))
+	for idx, val := range typedResponse.Results {
+		iArr[idx] = val
+	}
+	queryResponse.Results = iArr
+
+	return queryResponse, nil
+}
+
+// QuoteSearchTerm quotes the supplied field name or value for use in a
+// Foreman search string.  Quotes and backslashes within the term are escaped,
+// so a term containing spaces, quotes or the keywords "and" / "or" cannot
+// change the query.
+func QuoteSearchTerm(term string) string {
+	escaped := strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(term)
+	return `"` + escaped + `"`
+}
+
+// queryPageSize is the number of results requested per page when paging
+// through a collection with ForEachResult
+const queryPageSize = 1000
+
+// queryResponsePage is a single page of an index endpoint.  The results are
+// kept as raw JSON so they are only decoded by the caller.
+type queryResponsePage struct {
+	Subtotal int               `json:"subtotal"`
+	Results  []json.RawMessage `json:"results"`
+}
+
+// ForEachResult pages through the results of a search against an arbitrary
+// index endpoint of the API and calls fn with the raw JSON of every result.
+// Unlike Query, only a single page of results is held in memory at a time,
+// so arbitrarily large collections (ie: hosts) can be walked.  Paging stops
+// at the first error returned by fn, which is then returned.
+//
+// endpoint
+//   The collection endpoint relative to the API prefix (ie: "hosts" or
+//   "/compute_resources/1/images")
+// search
+//   A Foreman search string.  When empty, no search filter is applied.
+func (c *Client) ForEachResult(endpoint string, search string, fn func(result json.RawMessage) error) error {
+	log.Tracef("foreman/api/query.go#ForEachResult")
+
+	reqEndpoint := "/" + strings.TrimPrefix(endpoint, "/")
+	seen := 0
+	for page := 1; ; page++ {
+		req, reqErr := c.NewRequest(
+			http.MethodGet,
+			reqEndpoint,
+			nil,
+		)
+		if reqErr != nil {
+			return reqErr
+		}
+
+		reqQuery := req.URL.Query()
+		if search != "" {
+			reqQuery.Set("search", search)
+		}
+		reqQuery.Set("page", strconv.Itoa(page))
+		reqQuery.Set("per_page", strconv.Itoa(queryPageSize))
+		req.URL.RawQuery = reqQuery.Encode()
+
+		var queryPage queryResponsePage
+		sendErr := c.SendAndParse(req, &queryPage)
+		if sendErr != nil {
+			return sendErr
+		}
+		if queryPage.Results == nil {
+			return fmt.Errorf(
+				"Endpoint [%s] did not return a collection of results",
+				reqEndpoint,
+			)
+		}
+
+		log.Debugf("page: [%d], results: [%d], subtotal: [%d]", page, len(queryPage.Results), queryPage.Subtotal)
+
+		for _, result := range queryPage.Results {
+			if fnErr := fn(result); fnErr != nil {
+				return fnErr
+			}
+		}
+		seen += len(queryPage.Results)
+
+		// NOTE(ALL): stop on a short page as well as on the subtotal, the
+		//   collection may change while it is paged through
+		if len(queryPage.Results) < queryPageSize || seen >= queryPage.Subtotal {
+			return nil
+		}
+	}
+}
@@ -0,0 +1,120 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"testing"
+)
+
+// ----------------------------------------------------------------------------
+// sendAndParseQuery
+// ----------------------------------------------------------------------------
+
+// Ensures the typed Query* functions decode the results straight into the
+// Foreman API object and keep the query/response metadata.
+func TestSendAndParseQuery_TypedResults(t *testing.T) {
+	urlMux, server, client := NewForemanAPIAndClient(ClientCredentials{}, ClientConfig{})
+	defer server.Close()
+
+	urlMux.HandleFunc(FOREMAN_API_URL_PREFIX+"/"+ArchitectureEndpointPrefix, func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"total": 3, "subtotal": 1, "search": "name=\"x86_64\"", "results": [{"id": 1, "name": "x86_64"}]}`)
+	})
+
+	queryResponse, err := client.QueryArchitecture(&ForemanArchitecture{ForemanObject: ForemanObject{Name: "x86_64"}})
+	if err != nil {
+		t.Fatalf("QueryArchitecture returned an error: %s", err)
+	}
+	if queryResponse.Total != 3 || queryResponse.Subtotal != 1 || queryResponse.Search != `name="x86_64"` {
+		t.Errorf("Unexpected query metadata [%+v]", queryResponse)
+	}
+	if len(queryResponse.Results) != 1 {
+		t.Fatalf("Expected [1] result, got [%d]", len(queryResponse.Results))
+	}
+	arch, ok := queryResponse.Results[0].(ForemanArchitecture)
+	if !ok {
+		t.Fatalf("Expected a [ForemanArchitecture] result, got [%T]", queryResponse.Results[0])
+	}
+	if arch.Id != 1 || arch.Name != "x86_64" {
+		t.Errorf("Unexpected result [%+v]", arch)
+	}
+}
+
+// ----------------------------------------------------------------------------
+// ForEachResult
+// ----------------------------------------------------------------------------
+
+// Ensures ForEachResult pages through the whole collection, one page per
+// request, and stops at the first error returned by the callback.
+func TestForEachResult_Pages(t *testing.T) {
+	urlMux, server, client := NewForemanAPIAndClient(ClientCredentials{}, ClientConfig{})
+	defer server.Close()
+
+	total := 2*queryPageSize + 5
+	requests := 0
+	urlMux.HandleFunc(FOREMAN_API_URL_PREFIX+"/hosts", func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		query := r.URL.Query()
+		if query.Get("search") != "os = Debian" {
+			t.Errorf("Expected search [os = Debian], got [%s]", query.Get("search"))
+		}
+		page, _ := strconv.Atoi(query.Get("page"))
+		perPage, _ := strconv.Atoi(query.Get("per_page"))
+		results := []map[string]int{}
+		for id := (page-1)*perPage + 1; id <= page*perPage && id <= total; id++ {
+			results = append(results, map[string]int{"id": id})
+		}
+		resultsBytes, _ := json.Marshal(results)
+		fmt.Fprintf(w, `{"subtotal": %d, "page": %d, "results": %s}`, total, page, resultsBytes)
+	})
+
+	seen := 0
+	err := client.ForEachResult("hosts", "os = Debian", func(result json.RawMessage) error {
+		var obj ForemanObject
+		if jsonDecErr := json.Unmarshal(result, &obj); jsonDecErr != nil {
+			return jsonDecErr
+		}
+		seen++
+		if obj.Id != seen {
+			return fmt.Errorf("Expected result [%d], got [%d]", seen, obj.Id)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("ForEachResult returned an error: %s", err)
+	}
+	if seen != total || requests != 3 {
+		t.Errorf("Expected [%d] results in [3] requests, got [%d] in [%d]", total, seen, requests)
+	}
+
+	requests = 0
+	stopErr := fmt.Errorf("stop")
+	err = client.ForEachResult("hosts", "os = Debian", func(result json.RawMessage) error {
+		return stopErr
+	})
+	if err != stopErr || requests != 1 {
+		t.Errorf("Expected the callback error after [1] request, got [%v] after [%d]", err, requests)
+	}
+}
+
+// ----------------------------------------------------------------------------
+// QuoteSearchTerm
+// ----------------------------------------------------------------------------
+
+// Ensures quotes and backslashes are escaped so the term stays a single
+// quoted token of the search
+func TestQuoteSearchTerm(t *testing.T) {
+	cases := map[string]string{
+		"el8":                `"el8"`,
+		"Red Hat and CentOS": `"Red Hat and CentOS"`,
+		`x" or name ~ "`:     `"x\" or name ~ \""`,
+		`C:\Windows`:         `"C:\\Windows"`,
+		`\" or name ~ "\`:    `"\\\" or name ~ \"\\"`,
+	}
+	for term, expected := range cases {
+		if quoted := QuoteSearchTerm(term); quoted != expected {
+			t.Errorf("QuoteSearchTerm(%q): expected [%s], got [%s]", term, expected, quoted)
+		}
+	}
+}
@@ -10,4 +10,10 @@ import (
 type Server struct {
 	// The URL of the API gateway
 	URL url.URL
+	// URLs of the replicas of the API gateway (ie: the other nodes of a
+	// Foreman HA setup).  The client fails over to the first healthy
+	// replica when it cannot reach the server, in the order given.  Only
+	// the scheme and host of a replica are used, the API is expected under
+	// the same path on every node.
+	Replicas []url.URL
 }
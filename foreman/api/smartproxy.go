@@ -5,12 +5,17 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"strings"
 
 	"github.com/wayfair/terraform-provider-utils/log"
 )
 
 const (
 	SmartProxyEndpointPrefix = "smart_proxies"
+
+	// SmartProxyFeatureBMC : Name of the feature of smart proxies performing
+	// BMC operations
+	SmartProxyFeatureBMC = "BMC"
 )
 
 // -----------------------------------------------------------------------------
@@ -30,9 +35,60 @@ const (
 type ForemanSmartProxy struct {
 	// Inherits the base object's attributes
 	ForemanObject
+	// Locations and organizations the smart proxy is associated with
+	ForemanTaxonomies
 
 	// Uniform resource locator of the proxy (ie: https://server:8008)
 	URL string `json:"url"`
+	// Features the proxy provides (ie: DHCP, BMC).  Reported by Foreman, the
+	// features are detected by the proxy itself.
+	Features []ForemanSmartProxyFeature `json:"features,omitempty"`
+}
+
+// Custom JSON unmarshal function.  The locations and organizations are
+// decoded to their IDs.
+func (fsp *ForemanSmartProxy) UnmarshalJSON(b []byte) error {
+	type plainSmartProxy ForemanSmartProxy
+	if jsonDecErr := json.Unmarshal(b, (*plainSmartProxy)(fsp)); jsonDecErr != nil {
+		return jsonDecErr
+	}
+	return fsp.decodeTaxonomies(b)
+}
+
+// ForemanSmartProxyFeature is a feature provided by a smart proxy
+type ForemanSmartProxyFeature struct {
+	Id   int    `json:"id"`
+	Name string `json:"name"`
+	// Capabilities of the feature, ie: the providers a BMC feature supports
+	Capabilities []string `json:"capabilities,omitempty"`
+}
+
+// HasFeature returns whether or not the smart proxy provides the feature
+// with the supplied name (ie: "BMC")
+func (s ForemanSmartProxy) HasFeature(name string) bool {
+	for _, feature := range s.Features {
+		if feature.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// HasCapability returns whether or not the feature of the smart proxy with
+// the supplied name has the supplied capability (ie: the "redfish" provider
+// of the "BMC" feature).  Capabilities are compared case insensitively.
+func (s ForemanSmartProxy) HasCapability(feature string, capability string) bool {
+	for _, f := range s.Features {
+		if f.Name != feature {
+			continue
+		}
+		for _, c := range f.Capabilities {
+			if strings.EqualFold(c, capability) {
+				return true
+			}
+		}
+	}
+	return false
 }
 
 // -----------------------------------------------------------------------------
@@ -138,6 +194,37 @@ func (c *Client) UpdateSmartProxy(s *ForemanSmartProxy) (*ForemanSmartProxy, err
 	return &updatedSmartProxy, nil
 }
 
+// RefreshSmartProxy makes Foreman detect the features of the smart proxy
+// identified by the supplied ID again and returns the ForemanSmartProxy
+// reference with the refreshed features.  Features enabled on the proxy after
+// it was registered are only usable once they are refreshed.
+//
+// Example: https://<foreman>/api/smart_proxies/<id>/refresh
+func (c *Client) RefreshSmartProxy(id int) (*ForemanSmartProxy, error) {
+	log.Tracef("foreman/api/smartproxy.go#Refresh")
+
+	reqEndpoint := fmt.Sprintf("/%s/%d/refresh", SmartProxyEndpointPrefix, id)
+
+	req, reqErr := c.NewRequest(
+		http.MethodPut,
+		reqEndpoint,
+		nil,
+	)
+	if reqErr != nil {
+		return nil, reqErr
+	}
+
+	var refreshedSmartProxy ForemanSmartProxy
+	sendErr := c.SendAndParse(req, &refreshedSmartProxy)
+	if sendErr != nil {
+		return nil, sendErr
+	}
+
+	log.Debugf("refreshedSmartProxy: [%+v]", refreshedSmartProxy)
+
+	return &refreshedSmartProxy, nil
+}
+
 // DeleteSmartProxy deletes the ForemanSmartProxy identified by the supplied ID
 func (c *Client) DeleteSmartProxy(id int) error {
 	log.Tracef("foreman/api/smartproxy.go#Delete")
@@ -184,33 +271,5 @@ func (c *Client) QuerySmartProxy(s *ForemanSmartProxy) (QueryResponse, error) {
 	reqQuery.Set("search", "name="+name)
 
 	req.URL.RawQuery = reqQuery.Encode()
-	sendErr := c.SendAndParse(req, &queryResponse)
-	if sendErr != nil {
-		return queryResponse, sendErr
-	}
-
-	log.Debugf("queryResponse: [%+v]", queryResponse)
-
-	// Results will be Unmarshaled into a []map[string]interface{}
-	//
-	// Encode back to JSON, then Unmarshal into []ForemanSmartProxy for
-	// the results
-	results := []ForemanSmartProxy{}
-	resultsBytes, jsonEncErr := json.Marshal(queryResponse.Results)
-	if jsonEncErr != nil {
-		return queryResponse, jsonEncErr
-	}
-	jsonDecErr := json.Unmarshal(resultsBytes, &results)
-	if jsonDecErr != nil {
-		return queryResponse, jsonDecErr
-	}
-	// convert the search results from []ForemanSmartProxy to []interface
-	// and set the search results on the query
-	iArr := make([]interface{}, len(results))
-	for idx, val := range results {
-		iArr[idx] = val
-	}
-	queryResponse.Results = iArr
-
-	return queryResponse, nil
+	return sendAndParseQuery[ForemanSmartProxy](c, req)
 }
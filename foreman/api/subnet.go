@@ -21,6 +21,8 @@ const (
 type ForemanSubnet struct {
 	// Inherits the base object's attributes
 	ForemanObject
+	// Locations and organizations the subnet is associated with
+	ForemanTaxonomies
 
 	// Subnet network (ie: 192.168.100.0)
 	Network string `json:"network"`
@@ -43,6 +45,46 @@ type ForemanSubnet struct {
 	// Default boot mode for instances assigned to this subnet.  If set, valid
 	// values are "Static" and "DHCP".
 	BootMode string `json:"boot_mode"`
+	// ID of the smart proxy with the BMC feature performing the BMC operations
+	// (ie: power, boot device) of the hosts with a BMC interface in this
+	// subnet
+	BMCId int `json:"bmc_id,omitempty"`
+	// Parameters of the subnet consumed by templates.  Nil leaves the
+	// parameters of the subnet unchanged, an empty slice removes them all.
+	SubnetParameters []ForemanKVParameter `json:"-"`
+}
+
+// Custom JSON marshal function.  The parameters are only sent when they are
+// managed, Foreman replaces all parameters of the subnet with the ones sent.
+func (fs ForemanSubnet) MarshalJSON() ([]byte, error) {
+	type plainSubnet ForemanSubnet
+	fsJSON := struct {
+		plainSubnet
+		SubnetParameters *[]ForemanKVParameter `json:"subnet_parameters_attributes,omitempty"`
+	}{
+		plainSubnet: plainSubnet(fs),
+	}
+	if fs.SubnetParameters != nil {
+		fsJSON.SubnetParameters = &fs.SubnetParameters
+	}
+	return json.Marshal(fsJSON)
+}
+
+// Custom JSON unmarshal function.  The locations and organizations are
+// decoded to their IDs, the parameters are reported as "parameters".
+func (fs *ForemanSubnet) UnmarshalJSON(b []byte) error {
+	type plainSubnet ForemanSubnet
+	if jsonDecErr := json.Unmarshal(b, (*plainSubnet)(fs)); jsonDecErr != nil {
+		return jsonDecErr
+	}
+	var paramsJSON struct {
+		Parameters []ForemanKVParameter `json:"parameters"`
+	}
+	if jsonDecErr := json.Unmarshal(b, &paramsJSON); jsonDecErr != nil {
+		return jsonDecErr
+	}
+	fs.SubnetParameters = paramsJSON.Parameters
+	return fs.decodeTaxonomies(b)
 }
 
 // -----------------------------------------------------------------------------
@@ -80,6 +122,8 @@ func (c *Client) CreateSubnet(s *ForemanSubnet) (*ForemanSubnet, error) {
 		return nil, sendErr
 	}
 
+	restoreHiddenParameters(s.SubnetParameters, createdSubnet.SubnetParameters)
+
 	log.Debugf("createdSubnet: [%+v]", createdSubnet)
 
 	return &createdSubnet, nil
@@ -100,6 +144,11 @@ func (c *Client) ReadSubnet(id int) (*ForemanSubnet, error) {
 	if reqErr != nil {
 		return nil, reqErr
 	}
+	// NOTE(ALL): read the values of hidden parameters, they are masked
+	//   otherwise
+	reqQuery := req.URL.Query()
+	reqQuery.Set("show_hidden_parameters", "true")
+	req.URL.RawQuery = reqQuery.Encode()
 
 	var readSubnet ForemanSubnet
 	sendErr := c.SendAndParse(req, &readSubnet)
@@ -142,6 +191,8 @@ func (c *Client) UpdateSubnet(s *ForemanSubnet) (*ForemanSubnet, error) {
 		return nil, sendErr
 	}
 
+	restoreHiddenParameters(s.SubnetParameters, updatedSubnet.SubnetParameters)
+
 	log.Debugf("updatedSubnet: [%+v]", updatedSubnet)
 
 	return &updatedSubnet, nil
@@ -198,33 +249,5 @@ func (c *Client) QuerySubnet(s *ForemanSubnet) (QueryResponse, error) {
 	}
 
 	req.URL.RawQuery = reqQuery.Encode()
-	sendErr := c.SendAndParse(req, &queryResponse)
-	if sendErr != nil {
-		return queryResponse, sendErr
-	}
-
-	log.Debugf("queryResponse: [%+v]", queryResponse)
-
-	// Results will be Unmarshaled into a []map[string]interface{}
-	//
-	// Encode back to JSON, then Unmarshal into []ForemanSubnet for
-	// the results
-	results := []ForemanSubnet{}
-	resultsBytes, jsonEncErr := json.Marshal(queryResponse.Results)
-	if jsonEncErr != nil {
-		return queryResponse, jsonEncErr
-	}
-	jsonDecErr := json.Unmarshal(resultsBytes, &results)
-	if jsonDecErr != nil {
-		return queryResponse, jsonDecErr
-	}
-	// convert the search results from []ForemanSubnet to []interface
-	// and set the search results on the query
-	iArr := make([]interface{}, len(results))
-	for idx, val := range results {
-		iArr[idx] = val
-	}
-	queryResponse.Results = iArr
-
-	return queryResponse, nil
+	return sendAndParseQuery[ForemanSubnet](c, req)
 }
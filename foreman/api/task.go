@@ -0,0 +1,196 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/wayfair/terraform-provider-utils/log"
+)
+
+const (
+	// FOREMAN_TASKS_API_URL_PREFIX : Prefix of the API of the foreman-tasks
+	// plugin.  The tasks API is not served underneath FOREMAN_API_URL_PREFIX.
+	FOREMAN_TASKS_API_URL_PREFIX = "/foreman_tasks/api"
+	// TaskEndpointPrefix : Prefix appended to the foreman-tasks API url for
+	// tasks
+	TaskEndpointPrefix = "tasks"
+
+	// TaskStateStopped : State of a task which is no longer executed
+	TaskStateStopped = "stopped"
+	// TaskStatePaused : State of a task halted by an error, waiting to be
+	// resumed or cancelled
+	TaskStatePaused = "paused"
+	// TaskResultSuccess : Result of a task which succeeded
+	TaskResultSuccess = "success"
+	// TaskResultWarning : Result of a task which succeeded with warnings (ie:
+	// a repository synchronization skipping some packages)
+	TaskResultWarning = "warning"
+)
+
+// taskWaitMinInterval is the time between two polls of a task, when no
+// interval is configured
+var taskWaitMinInterval = time.Second
+
+// -----------------------------------------------------------------------------
+// Struct Definition and Helpers
+// -----------------------------------------------------------------------------
+
+// ForemanTask API model represents an asynchronous task of the foreman-tasks
+// plugin (ie: a Katello repository synchronization, a content view publish,
+// a remote execution job)
+type ForemanTask struct {
+	// UUID of the task
+	Id string `json:"id"`
+	// Class of the action executed by the task (ie:
+	// "Actions::Katello::Repository::Sync")
+	Label string `json:"label"`
+	// Description of the action executed by the task
+	Action string `json:"action"`
+	// Execution state of the task (ie: planning, running, paused, stopped)
+	State string `json:"state"`
+	// Result of the task (ie: pending, success, warning, error)
+	Result string `json:"result"`
+	// Progress of the task between 0 and 1
+	Progress float64 `json:"progress"`
+	// Time the task started and ended at, empty if it did not
+	StartedAt string `json:"started_at"`
+	EndedAt   string `json:"ended_at"`
+	// Human readable summary of the task
+	Humanized struct {
+		Output string   `json:"output"`
+		Errors []string `json:"errors"`
+	} `json:"humanized"`
+}
+
+// Done returns whether or not the task stopped executing.  A paused task
+// halted on an error and does not continue without intervention.
+func (t *ForemanTask) Done() bool {
+	return t.State == TaskStateStopped || t.State == TaskStatePaused
+}
+
+// TaskError is returned when a task a client waited for did not succeed
+type TaskError struct {
+	// The task in the state it ended in
+	Task *ForemanTask
+}
+
+// Error implements the error interface
+func (e *TaskError) Error() string {
+	msg := fmt.Sprintf(
+		"Task [%s] (%s) ended in state [%s] with result [%s]",
+		e.Task.Id,
+		e.Task.Label,
+		e.Task.State,
+		e.Task.Result,
+	)
+	if len(e.Task.Humanized.Errors) > 0 {
+		msg += ": " + strings.Join(e.Task.Humanized.Errors, "; ")
+	}
+	return msg
+}
+
+// TaskWaitConfig configures how WaitForTask polls a task
+type TaskWaitConfig struct {
+	// Time between two polls of the task
+	Interval time.Duration
+	// Time after which the task is no longer polled.  0 disables the
+	// timeout.
+	Timeout time.Duration
+	// Whether or not a task with result TaskResultWarning succeeded
+	AllowWarning bool
+}
+
+// newForemanTasksRequest constructs a request to the API of the
+// foreman-tasks plugin the same way NewRequest does for the Foreman API
+func (c *Client) newForemanTasksRequest(method string, endpoint string, body io.Reader) (*http.Request, error) {
+	req, reqErr := c.NewRequest(method, endpoint, body)
+	if reqErr != nil {
+		return nil, reqErr
+	}
+	req.URL.Path = FOREMAN_TASKS_API_URL_PREFIX + strings.TrimPrefix(req.URL.Path, FOREMAN_API_URL_PREFIX)
+	return req, nil
+}
+
+// -----------------------------------------------------------------------------
+// Tasks
+// -----------------------------------------------------------------------------
+
+// ReadTask reads the attributes of the ForemanTask identified by the supplied
+// UUID and returns a ForemanTask reference.
+func (c *Client) ReadTask(id string) (*ForemanTask, error) {
+	log.Tracef("foreman/api/task.go#Read")
+
+	reqEndpoint := fmt.Sprintf("/%s/%s", TaskEndpointPrefix, id)
+
+	req, reqErr := c.newForemanTasksRequest(
+		http.MethodGet,
+		reqEndpoint,
+		nil,
+	)
+	if reqErr != nil {
+		return nil, reqErr
+	}
+
+	var readTask ForemanTask
+	sendErr := c.SendAndParse(req, &readTask)
+	if sendErr != nil {
+		return nil, sendErr
+	}
+
+	log.Debugf("readTask: [%+v]", readTask)
+
+	return &readTask, nil
+}
+
+// WaitForTask polls the ForemanTask identified by the supplied UUID until it
+// is done, the timeout of the supplied TaskWaitConfig expires or the supplied
+// context is cancelled.  The supplied progress function, if any, is called
+// with the task after each poll.  The task is returned in the state it ended
+// in, a *TaskError is returned with it if the task did not succeed.
+func (c *Client) WaitForTask(ctx context.Context, id string, wait TaskWaitConfig, progress func(*ForemanTask)) (*ForemanTask, error) {
+	log.Tracef("foreman/api/task.go#Wait")
+
+	interval := wait.Interval
+	if interval <= 0 {
+		interval = taskWaitMinInterval
+	}
+	var deadline time.Time
+	if wait.Timeout > 0 {
+		deadline = time.Now().Add(wait.Timeout)
+	}
+
+	for {
+		task, readErr := c.ReadTask(id)
+		if readErr != nil {
+			return nil, readErr
+		}
+		if progress != nil {
+			progress(task)
+		}
+		if task.Done() {
+			if task.Result == TaskResultSuccess || (wait.AllowWarning && task.Result == TaskResultWarning) {
+				return task, nil
+			}
+			return task, &TaskError{Task: task}
+		}
+		if !deadline.IsZero() && time.Now().Add(interval).After(deadline) {
+			return task, fmt.Errorf(
+				"Task [%s] (%s) did not finish within [%s], it is [%s] at [%.0f%%]",
+				task.Id,
+				task.Label,
+				wait.Timeout,
+				task.State,
+				task.Progress*100,
+			)
+		}
+		select {
+		case <-ctx.Done():
+			return task, fmt.Errorf("Waiting for task [%s] was interrupted: %s", id, ctx.Err())
+		case <-time.After(interval):
+		}
+	}
+}
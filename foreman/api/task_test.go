@@ -0,0 +1,98 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+// ----------------------------------------------------------------------------
+// WaitForTask
+// ----------------------------------------------------------------------------
+
+// Ensures the task is polled on the foreman-tasks API until it is done,
+// reporting its progress
+func TestWaitForTask(t *testing.T) {
+	urlMux, server, client := NewForemanAPIAndClient(ClientCredentials{}, ClientConfig{})
+	defer server.Close()
+
+	polls := 0
+	urlMux.HandleFunc(FOREMAN_TASKS_API_URL_PREFIX+"/tasks/a1b2", func(w http.ResponseWriter, r *http.Request) {
+		polls++
+		if polls < 3 {
+			fmt.Fprintf(w, `{"id": "a1b2", "state": "running", "result": "pending", "progress": 0.%d}`, polls*3)
+			return
+		}
+		fmt.Fprint(w, `{"id": "a1b2", "state": "stopped", "result": "success", "progress": 1.0}`)
+	})
+
+	var progress []float64
+	task, waitErr := client.WaitForTask(
+		context.Background(),
+		"a1b2",
+		TaskWaitConfig{Interval: time.Millisecond},
+		func(task *ForemanTask) { progress = append(progress, task.Progress) },
+	)
+	if waitErr != nil {
+		t.Fatalf("Expected no error, got [%s]", waitErr)
+	}
+	if task.Result != TaskResultSuccess || polls != 3 {
+		t.Fatalf("Expected the task to succeed after [3] polls, got [%+v] after [%d]", task, polls)
+	}
+	if len(progress) != 3 || progress[0] != 0.3 || progress[2] != 1 {
+		t.Fatalf("Expected the progress of each poll to be reported, got %v", progress)
+	}
+}
+
+// Ensures a task which did not succeed fails the wait with its errors,
+// unless warnings are allowed
+func TestWaitForTask_failed(t *testing.T) {
+	urlMux, server, client := NewForemanAPIAndClient(ClientCredentials{}, ClientConfig{})
+	defer server.Close()
+
+	result := "error"
+	urlMux.HandleFunc(FOREMAN_TASKS_API_URL_PREFIX+"/tasks/a1b2", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{
+			"id": "a1b2",
+			"label": "Actions::Katello::Repository::Sync",
+			"state": "paused",
+			"result": "%s",
+			"humanized": {"errors": ["404: Not Found"]}
+		}`, result)
+	})
+
+	_, waitErr := client.WaitForTask(context.Background(), "a1b2", TaskWaitConfig{}, nil)
+	var taskErr *TaskError
+	if !errors.As(waitErr, &taskErr) || !strings.Contains(waitErr.Error(), "404: Not Found") {
+		t.Fatalf("Expected a TaskError with the task's errors, got [%v]", waitErr)
+	}
+
+	result = TaskResultWarning
+	if _, waitErr = client.WaitForTask(context.Background(), "a1b2", TaskWaitConfig{AllowWarning: true}, nil); waitErr != nil {
+		t.Fatalf("Expected warnings to be allowed, got [%s]", waitErr)
+	}
+}
+
+// Ensures a task which does not finish within the timeout fails the wait
+func TestWaitForTask_timeout(t *testing.T) {
+	urlMux, server, client := NewForemanAPIAndClient(ClientCredentials{}, ClientConfig{})
+	defer server.Close()
+
+	urlMux.HandleFunc(FOREMAN_TASKS_API_URL_PREFIX+"/tasks/a1b2", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id": "a1b2", "state": "running", "result": "pending", "progress": 0.5}`)
+	})
+
+	_, waitErr := client.WaitForTask(
+		context.Background(),
+		"a1b2",
+		TaskWaitConfig{Interval: 10 * time.Millisecond, Timeout: 50 * time.Millisecond},
+		nil,
+	)
+	if waitErr == nil || !strings.Contains(waitErr.Error(), "[50%]") {
+		t.Fatalf("Expected the wait to time out, got [%v]", waitErr)
+	}
+}
@@ -0,0 +1,48 @@
+package api
+
+import (
+	"encoding/json"
+)
+
+// -----------------------------------------------------------------------------
+// Struct Definition and Helpers
+// -----------------------------------------------------------------------------
+
+// ForemanTaxonomies are the locations and organizations a Foreman object is
+// associated with.  Multi-organization Foreman installations only show an
+// object within the locations and organizations it is associated with.
+type ForemanTaxonomies struct {
+	// IDs of the locations the object is associated with.  Unset IDs are not
+	// sent, leaving the associations to Foreman.
+	LocationIds []int `json:"location_ids,omitempty"`
+	// IDs of the organizations the object is associated with.  Unset IDs are
+	// not sent, leaving the associations to Foreman.
+	OrganizationIds []int `json:"organization_ids,omitempty"`
+}
+
+// foremanTaxonomiesJSON struct used for JSON decode.  Foreman API returns the
+// locations and organizations as a list of ForemanObjects instead of their
+// IDs.
+type foremanTaxonomiesJSON struct {
+	Locations     *[]ForemanObject `json:"locations"`
+	Organizations *[]ForemanObject `json:"organizations"`
+}
+
+// decodeTaxonomies sets the location and organization IDs from the locations
+// and organizations of the supplied JSON object.  IDs missing from the object
+// (ie: in search results) are left unchanged.  This is deliberately not an
+// UnmarshalJSON method, which would be promoted to the structs embedding
+// ForemanTaxonomies and replace their decoding.
+func (ft *ForemanTaxonomies) decodeTaxonomies(b []byte) error {
+	var ftJSON foremanTaxonomiesJSON
+	if jsonDecErr := json.Unmarshal(b, &ftJSON); jsonDecErr != nil {
+		return jsonDecErr
+	}
+	if ftJSON.Locations != nil {
+		ft.LocationIds = foremanObjectArrayToIdIntArray(*ftJSON.Locations)
+	}
+	if ftJSON.Organizations != nil {
+		ft.OrganizationIds = foremanObjectArrayToIdIntArray(*ftJSON.Organizations)
+	}
+	return nil
+}
@@ -0,0 +1,128 @@
+package api
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+
+	"github.com/wayfair/terraform-provider-utils/log"
+)
+
+const (
+	// TemplateCombinationEndpointPrefix : Prefix appended to the API url for
+	// template combinations.  Template combinations are created underneath
+	// their provisioning template, but read and deleted by their ID only.
+	TemplateCombinationEndpointPrefix = "template_combinations"
+)
+
+// -----------------------------------------------------------------------------
+// Struct Definition and Helpers
+// -----------------------------------------------------------------------------
+
+// The ForemanTemplateCombination API model represents the combination of a
+// provisioning template with a hostgroup and/or an environment.  Hosts of the
+// hostgroup and environment are rendered with the provisioning template
+// instead of the default template of their operating system.
+type ForemanTemplateCombination struct {
+	// Inherits the base object's attributes
+	ForemanObject
+
+	// ID of the provisioning template of the combination
+	ProvisioningTemplateId int `json:"provisioning_template_id"`
+	// ID of the hostgroup of the combination, 0 for any hostgroup
+	HostgroupId int `json:"hostgroup_id,omitempty"`
+	// ID of the environment of the combination, 0 for any environment
+	EnvironmentId int `json:"environment_id,omitempty"`
+}
+
+// -----------------------------------------------------------------------------
+// CRUD Implementation
+// -----------------------------------------------------------------------------
+
+// CreateTemplateCombination creates a new ForemanTemplateCombination with the
+// attributes of the supplied ForemanTemplateCombination reference and returns
+// the created ForemanTemplateCombination reference.  The returned reference
+// will have its ID and other API default values set by this function.
+func (c *Client) CreateTemplateCombination(t *ForemanTemplateCombination) (*ForemanTemplateCombination, error) {
+	log.Tracef("foreman/api/templatecombination.go#Create")
+
+	reqEndpoint := fmt.Sprintf(
+		"/%s/%d/%s",
+		ProvisioningTemplateEndpointPrefix,
+		t.ProvisioningTemplateId,
+		TemplateCombinationEndpointPrefix,
+	)
+
+	tJSONBytes, jsonEncErr := WrapJson("template_combination", t)
+	if jsonEncErr != nil {
+		return nil, jsonEncErr
+	}
+
+	log.Debugf("templateCombinationJSONBytes: [%s]", tJSONBytes)
+
+	req, reqErr := c.NewRequest(
+		http.MethodPost,
+		reqEndpoint,
+		bytes.NewBuffer(tJSONBytes),
+	)
+	if reqErr != nil {
+		return nil, reqErr
+	}
+
+	var createdTemplateCombination ForemanTemplateCombination
+	sendErr := c.SendAndParse(req, &createdTemplateCombination)
+	if sendErr != nil {
+		return nil, sendErr
+	}
+
+	log.Debugf("createdTemplateCombination: [%+v]", createdTemplateCombination)
+
+	return &createdTemplateCombination, nil
+}
+
+// ReadTemplateCombination reads the attributes of a
+// ForemanTemplateCombination identified by the supplied ID and returns a
+// ForemanTemplateCombination reference.
+func (c *Client) ReadTemplateCombination(id int) (*ForemanTemplateCombination, error) {
+	log.Tracef("foreman/api/templatecombination.go#Read")
+
+	reqEndpoint := fmt.Sprintf("/%s/%d", TemplateCombinationEndpointPrefix, id)
+
+	req, reqErr := c.NewRequest(
+		http.MethodGet,
+		reqEndpoint,
+		nil,
+	)
+	if reqErr != nil {
+		return nil, reqErr
+	}
+
+	var readTemplateCombination ForemanTemplateCombination
+	sendErr := c.SendAndParse(req, &readTemplateCombination)
+	if sendErr != nil {
+		return nil, sendErr
+	}
+
+	log.Debugf("readTemplateCombination: [%+v]", readTemplateCombination)
+
+	return &readTemplateCombination, nil
+}
+
+// DeleteTemplateCombination deletes the ForemanTemplateCombination identified
+// by the supplied ID
+func (c *Client) DeleteTemplateCombination(id int) error {
+	log.Tracef("foreman/api/templatecombination.go#Delete")
+
+	reqEndpoint := fmt.Sprintf("/%s/%d", TemplateCombinationEndpointPrefix, id)
+
+	req, reqErr := c.NewRequest(
+		http.MethodDelete,
+		reqEndpoint,
+		nil,
+	)
+	if reqErr != nil {
+		return reqErr
+	}
+
+	return c.SendAndParse(req, nil)
+}
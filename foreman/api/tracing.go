@@ -0,0 +1,64 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+// ----------------------------------------------------------------------------
+// Tracing
+// ----------------------------------------------------------------------------
+
+// tracerName is the name of the instrumentation scope of the client's spans
+const tracerName = "github.com/HanseMerkur/terraform-provider-foreman/foreman/api"
+
+// newTracer returns the tracer of the client.  Without a tracer provider, the
+// spans are discarded.
+func newTracer(tp trace.TracerProvider) trace.Tracer {
+	if tp == nil {
+		tp = noop.NewTracerProvider()
+	}
+	return tp.Tracer(tracerName)
+}
+
+// startSpan starts the span of an API call and returns the request carrying
+// the span's context.  The trace context is propagated to the server in the
+// request headers, so the call can be followed into Foreman.
+func (client *Client) startSpan(request *http.Request) (*http.Request, trace.Span) {
+	// NOTE(ALL): the span name only carries the API collection, the full path
+	//   contains IDs and would make the names unbounded
+	ctx, span := client.tracer.Start(
+		request.Context(),
+		fmt.Sprintf("%s %s", request.Method, apiCollection(request.URL.Path)),
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("http.request.method", request.Method),
+			attribute.String("url.path", request.URL.Path),
+			attribute.String("server.address", request.URL.Hostname()),
+		),
+	)
+	request = request.WithContext(ctx)
+	propagation.TraceContext{}.Inject(ctx, propagation.HeaderCarrier(request.Header))
+	return request, span
+}
+
+// endSpan records the outcome of an API call on its span and ends it.  The
+// latency of the call is the duration of the span.
+func endSpan(span trace.Span, sent sentResponse, sendErr error) {
+	if sent.statusCode > 0 {
+		span.SetAttributes(attribute.Int("http.response.status_code", sent.statusCode))
+	}
+	if sendErr != nil {
+		span.RecordError(sendErr)
+		span.SetStatus(codes.Error, sendErr.Error())
+	} else if sent.statusCode >= 400 {
+		span.SetStatus(codes.Error, http.StatusText(sent.statusCode))
+	}
+	span.End()
+}
@@ -0,0 +1,68 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+// ----------------------------------------------------------------------------
+// Tracing
+// ----------------------------------------------------------------------------
+
+// Ensure every API call is recorded as a span carrying its method, endpoint
+// and status, and the trace context is propagated to the server
+func TestSend_Tracing(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	cred := ClientCredentials{}
+	conf := ClientConfig{
+		TracerProvider: sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)),
+	}
+	mux, server, client := NewForemanAPIAndClient(cred, conf)
+	defer server.Close()
+
+	// dummy '/hosts/1' endpoint - the host does not exist
+	mux.HandleFunc(FOREMAN_API_URL_PREFIX+"/hosts/1", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("traceparent") == "" {
+			t.Errorf("Expected the trace context in the request headers")
+		}
+		w.WriteHeader(http.StatusNotFound)
+	})
+
+	req, _ := client.NewRequest(http.MethodGet, "/hosts/1", nil)
+	if _, _, sendErr := client.Send(req); sendErr != nil {
+		t.Fatalf("Client.Send() returned an error: %s", sendErr)
+	}
+
+	spans := recorder.Ended()
+	if len(spans) != 1 {
+		t.Fatalf("Expected [1] span, got [%d]", len(spans))
+	}
+	span := spans[0]
+	if span.Name() != "GET hosts" {
+		t.Errorf("Expected span [GET hosts], got [%s]", span.Name())
+	}
+	expected := map[attribute.Key]attribute.Value{
+		"http.request.method":       attribute.StringValue(http.MethodGet),
+		"url.path":                  attribute.StringValue(FOREMAN_API_URL_PREFIX + "/hosts/1"),
+		"http.response.status_code": attribute.IntValue(http.StatusNotFound),
+	}
+	for _, attr := range span.Attributes() {
+		if value, ok := expected[attr.Key]; ok {
+			if attr.Value != value {
+				t.Errorf("Expected attribute [%s] to be [%v], got [%v]", attr.Key, value.Emit(), attr.Value.Emit())
+			}
+			delete(expected, attr.Key)
+		}
+	}
+	if len(expected) > 0 {
+		t.Errorf("Expected the span to carry the attributes [%v]", expected)
+	}
+	if span.Status().Code != codes.Error {
+		t.Errorf("Expected the span of a failed call to have an error status")
+	}
+}
@@ -0,0 +1,232 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+
+	"github.com/wayfair/terraform-provider-utils/log"
+)
+
+const (
+	UsergroupEndpointPrefix = "usergroups"
+)
+
+// -----------------------------------------------------------------------------
+// Struct Definition and Helpers
+// -----------------------------------------------------------------------------
+
+// The ForemanUsergroup API model represents a group of users.  Usergroups can
+// own hosts and have roles assigned, and can be mapped to groups of an
+// external authentication source (ie: LDAP).
+type ForemanUsergroup struct {
+	// Inherits the base object's attributes
+	ForemanObject
+
+	// Whether or not the members of the usergroup are administrators
+	Admin bool `json:"admin"`
+	// Groups of external authentication sources mapped to this usergroup
+	ExternalUsergroups []ForemanExternalUsergroup `json:"external_usergroups,omitempty"`
+	// IDs of the roles assigned to the usergroup
+	RoleIds []int `json:"-"`
+}
+
+// Custom JSON unmarshal function.  The roles are decoded to their IDs.
+func (fu *ForemanUsergroup) UnmarshalJSON(b []byte) error {
+	type plainUsergroup ForemanUsergroup
+	if jsonDecErr := json.Unmarshal(b, (*plainUsergroup)(fu)); jsonDecErr != nil {
+		return jsonDecErr
+	}
+	var rolesJSON struct {
+		Roles []ForemanObject `json:"roles"`
+	}
+	if jsonDecErr := json.Unmarshal(b, &rolesJSON); jsonDecErr != nil {
+		return jsonDecErr
+	}
+	fu.RoleIds = foremanObjectArrayToIdIntArray(rolesJSON.Roles)
+	return nil
+}
+
+// usergroupRoleLocks serializes the role changes of each usergroup.  Foreman
+// replaces the roles of a usergroup with the ones sent, concurrent changes of
+// the same usergroup would drop each other's roles.
+var usergroupRoleLocks sync.Map
+
+// ForemanExternalUsergroup maps a group of an external authentication
+// source onto a usergroup
+type ForemanExternalUsergroup struct {
+	// Unique identifier of the external usergroup mapping
+	Id int `json:"id,omitempty"`
+	// Name of the group in the external authentication source
+	Name string `json:"name"`
+	// ID of the external authentication source
+	AuthSourceId int `json:"auth_source_id"`
+}
+
+// -----------------------------------------------------------------------------
+// CRUD Implementation
+// -----------------------------------------------------------------------------
+
+// ReadUsergroup reads the attributes of a ForemanUsergroup identified by the
+// supplied ID and returns a ForemanUsergroup reference.
+func (c *Client) ReadUsergroup(id int) (*ForemanUsergroup, error) {
+	log.Tracef("foreman/api/usergroup.go#Read")
+
+	reqEndpoint := fmt.Sprintf("/%s/%d", UsergroupEndpointPrefix, id)
+
+	req, reqErr := c.NewRequest(
+		http.MethodGet,
+		reqEndpoint,
+		nil,
+	)
+	if reqErr != nil {
+		return nil, reqErr
+	}
+
+	var readUsergroup ForemanUsergroup
+	sendErr := c.SendAndParse(req, &readUsergroup)
+	if sendErr != nil {
+		return nil, sendErr
+	}
+
+	log.Debugf("readUsergroup: [%+v]", readUsergroup)
+
+	return &readUsergroup, nil
+}
+
+// AddUsergroupRole assigns the role identified by the supplied role ID to the
+// usergroup identified by the supplied ID.  Other roles of the usergroup are
+// kept.
+func (c *Client) AddUsergroupRole(id int, roleId int) error {
+	log.Tracef("foreman/api/usergroup.go#AddRole")
+
+	return c.changeUsergroupRoles(id, func(roleIds []int) []int {
+		for _, assigned := range roleIds {
+			if assigned == roleId {
+				return nil
+			}
+		}
+		return append(roleIds, roleId)
+	})
+}
+
+// RemoveUsergroupRole removes the role identified by the supplied role ID
+// from the usergroup identified by the supplied ID.  Other roles of the
+// usergroup are kept.
+func (c *Client) RemoveUsergroupRole(id int, roleId int) error {
+	log.Tracef("foreman/api/usergroup.go#RemoveRole")
+
+	return c.changeUsergroupRoles(id, func(roleIds []int) []int {
+		kept := []int{}
+		for _, assigned := range roleIds {
+			if assigned != roleId {
+				kept = append(kept, assigned)
+			}
+		}
+		if len(kept) == len(roleIds) {
+			return nil
+		}
+		return kept
+	})
+}
+
+// changeUsergroupRoles reads the roles of the usergroup identified by the
+// supplied ID and sends the roles returned by the supplied change function.
+// Nothing is sent if the change function returns nil.
+func (c *Client) changeUsergroupRoles(id int, change func(roleIds []int) []int) error {
+	lock, _ := usergroupRoleLocks.LoadOrStore(id, &sync.Mutex{})
+	lock.(*sync.Mutex).Lock()
+	defer lock.(*sync.Mutex).Unlock()
+
+	readUsergroup, readErr := c.ReadUsergroup(id)
+	if readErr != nil {
+		return readErr
+	}
+	roleIds := change(readUsergroup.RoleIds)
+	if roleIds == nil {
+		return nil
+	}
+
+	reqEndpoint := fmt.Sprintf("/%s/%d", UsergroupEndpointPrefix, id)
+
+	rolesJSONBytes, jsonEncErr := WrapJson("usergroup", map[string][]int{
+		"role_ids": roleIds,
+	})
+	if jsonEncErr != nil {
+		return jsonEncErr
+	}
+
+	log.Debugf("rolesJSONBytes: [%s]", rolesJSONBytes)
+
+	req, reqErr := c.NewRequest(
+		http.MethodPut,
+		reqEndpoint,
+		bytes.NewBuffer(rolesJSONBytes),
+	)
+	if reqErr != nil {
+		return reqErr
+	}
+
+	return c.SendAndParse(req, nil)
+}
+
+// RefreshExternalUsergroup makes Foreman synchronize the members of the
+// usergroup identified by the supplied ID with the group of the external
+// usergroup identified by the supplied external ID.  Foreman otherwise only
+// synchronizes the members when the users log in or on a schedule.
+//
+// Example: https://<foreman>/api/usergroups/<id>/external_usergroups/<external id>/refresh
+func (c *Client) RefreshExternalUsergroup(id int, externalId int) error {
+	log.Tracef("foreman/api/usergroup.go#RefreshExternalUsergroup")
+
+	reqEndpoint := fmt.Sprintf(
+		"/%s/%d/external_usergroups/%d/refresh",
+		UsergroupEndpointPrefix,
+		id,
+		externalId,
+	)
+
+	req, reqErr := c.NewRequest(
+		http.MethodPut,
+		reqEndpoint,
+		nil,
+	)
+	if reqErr != nil {
+		return reqErr
+	}
+
+	return c.SendAndParse(req, nil)
+}
+
+// -----------------------------------------------------------------------------
+// Query Implementation
+// -----------------------------------------------------------------------------
+
+// QueryUsergroup queries for a ForemanUsergroup based on the attributes of the
+// supplied ForemanUsergroup reference and returns a QueryResponse struct
+// containing query/response metadata and the matching usergroups.
+func (c *Client) QueryUsergroup(u *ForemanUsergroup) (QueryResponse, error) {
+	log.Tracef("foreman/api/usergroup.go#Search")
+
+	queryResponse := QueryResponse{}
+
+	reqEndpoint := fmt.Sprintf("/%s", UsergroupEndpointPrefix)
+	req, reqErr := c.NewRequest(
+		http.MethodGet,
+		reqEndpoint,
+		nil,
+	)
+	if reqErr != nil {
+		return queryResponse, reqErr
+	}
+
+	// dynamically build the query based on the attributes
+	reqQuery := req.URL.Query()
+	name := `"` + u.Name + `"`
+	reqQuery.Set("search", "name="+name)
+
+	req.URL.RawQuery = reqQuery.Encode()
+	return sendAndParseQuery[ForemanUsergroup](c, req)
+}
@@ -1,8 +1,18 @@
 package foreman
 
 import (
+	"context"
+	"sync/atomic"
+	"time"
+
 	"github.com/HanseMerkur/terraform-provider-foreman/foreman/api"
 	"github.com/wayfair/terraform-provider-utils/log"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
 )
 
 // Config struct defines the necessary information needed to configure the
@@ -19,20 +29,97 @@ type Config struct {
 	ClientTLSInsecure bool
 	// Set of credentials needed to authenticate against Foreman
 	ClientCredentials api.ClientCredentials
+	// Whether or not to verify referenced objects exist in Foreman while
+	// planning
+	ValidateReferences bool
+	// Whether or not BMC operations are skipped for every host
+	DisableBMC bool
+	// Whether or not the Foreman server has the Katello plugin installed
+	KatelloEnabled bool
+	// Optional lookups skipped for accounts not permitted to perform them
+	DisabledLookups []string
+	// Comment recorded in the audit log with the changes of the provider
+	AuditComment string
+	// Headers added to write requests to attribute the changes
+	AuditHeaders map[string]string
+	// Parameters added to every host unless the host overrides them
+	DefaultHostParameters map[string]string
+	// Deadline of a single API request
+	APITimeout time.Duration
+	// Deadline of a single long running API request (ie: host creation)
+	APIHostTimeout time.Duration
+	// Directory the API responses are cached in across runs
+	APICacheDir string
+	// Duration cached reference data is used without contacting the server
+	APIReferenceCacheTTL time.Duration
+	// Maximum number of simultaneous API requests
+	Parallelism int
+	// Whether or not the API calls are traced with OpenTelemetry
+	OTelTracing bool
+	// Whether or not a summary of the API calls is logged once the plugin
+	// stops
+	APICallSummary bool
+}
+
+// apiCallMetrics counts the API calls of every provider instance of the
+// plugin process with the API call summary enabled.  The summary is logged by
+// LogAPICallSummary.
+var (
+	apiCallMetrics        = api.NewMetrics()
+	apiCallSummaryEnabled atomic.Bool
+)
+
+// LogAPICallSummary logs the counts, total time, retries and failures of the
+// API calls per endpoint, if any provider instance enabled the summary.  It
+// is called once the plugin stops serving, at the end of a plan or apply.
+func LogAPICallSummary() {
+	if !apiCallSummaryEnabled.Load() {
+		return
+	}
+	log.Infof("API call summary: %s", apiCallMetrics.Summary())
 }
 
 // Client creates a client reference for the Foreman REST API given the
-// provider configuration options.  After creating a client reference, the
-// client is then authenticated with the credentials supplied to the provider
-// configuration.
+// provider configuration options.  The credentials supplied to the provider
+// configuration are sent with every request.  No request is sent while
+// configuring, so accounts restricted by roles are only checked for the
+// permissions the managed resources need.
 func (c *Config) Client() (*api.Client, error) {
 	log.Tracef("config.go#Client")
 
+	var tracerProvider trace.TracerProvider
+	if c.OTelTracing {
+		var tpErr error
+		if tracerProvider, tpErr = newTracerProvider(); tpErr != nil {
+			return nil, tpErr
+		}
+	}
+
+	var metrics *api.Metrics
+	if c.APICallSummary {
+		metrics = apiCallMetrics
+		apiCallSummaryEnabled.Store(true)
+	}
+
 	client := api.NewClient(
 		c.Server,
 		c.ClientCredentials,
 		api.ClientConfig{
-			TLSInsecureEnabled: c.ClientTLSInsecure,
+			TLSInsecureEnabled:    c.ClientTLSInsecure,
+			ValidateReferences:    c.ValidateReferences,
+			DisableBMC:            c.DisableBMC,
+			KatelloEnabled:        c.KatelloEnabled,
+			DisabledLookups:       c.DisabledLookups,
+			AuditComment:          c.AuditComment,
+			AuditHeaders:          c.AuditHeaders,
+			DefaultHostParameters: c.DefaultHostParameters,
+			Timeout:               c.APITimeout,
+			LongRunningTimeout:    c.APIHostTimeout,
+			CacheDir:              c.APICacheDir,
+			ReferenceCacheTTL:     c.APIReferenceCacheTTL,
+			Parallelism:           c.Parallelism,
+			TracerProvider:        tracerProvider,
+			Metrics:               metrics,
 		},
 	)
 
@@ -40,3 +127,31 @@ func (c *Config) Client() (*api.Client, error) {
 
 	return client, nil
 }
+
+// newTracerProvider creates the tracer provider the spans of the API calls are
+// exported with.  Spans are sent with OTLP over HTTP, the exporter is
+// configured through the standard OTEL_EXPORTER_OTLP_* and OTEL_SERVICE_NAME
+// environment variables.
+func newTracerProvider() (trace.TracerProvider, error) {
+	ctx := context.Background()
+
+	exporter, exporterErr := otlptracehttp.New(ctx)
+	if exporterErr != nil {
+		return nil, exporterErr
+	}
+	res, resErr := resource.New(
+		ctx,
+		resource.WithAttributes(attribute.String("service.name", "terraform-provider-foreman")),
+		resource.WithFromEnv(),
+	)
+	if resErr != nil {
+		return nil, resErr
+	}
+
+	// NOTE(ALL): Terraform stops the plugin without notice once it is done,
+	//   export every span as it ends since batched spans would be lost
+	return sdktrace.NewTracerProvider(
+		sdktrace.WithSyncer(exporter),
+		sdktrace.WithResource(res),
+	), nil
+}
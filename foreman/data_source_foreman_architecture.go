@@ -5,16 +5,15 @@ import (
 
 	"github.com/HanseMerkur/terraform-provider-foreman/foreman/api"
 	"github.com/wayfair/terraform-provider-utils/autodoc"
-	"github.com/wayfair/terraform-provider-utils/helper"
 	"github.com/wayfair/terraform-provider-utils/log"
 
-	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func dataSourceForemanArchitecture() *schema.Resource {
 	// copy attributes from resource definition
 	r := resourceForemanArchitecture()
-	ds := helper.DataSourceSchemaFromResourceSchema(r.Schema)
+	ds := dataSourceSchemaFromResourceSchema(r.Schema)
 
 	// define searchable attributes for the data source
 	ds["name"] = &schema.Schema{
@@ -27,6 +26,8 @@ func dataSourceForemanArchitecture() *schema.Resource {
 		),
 	}
 
+	ds["exact_match"] = exactMatchSchema()
+
 	return &schema.Resource{
 
 		Read: dataSourceForemanArchitectureRead,
@@ -39,7 +40,7 @@ func dataSourceForemanArchitecture() *schema.Resource {
 func dataSourceForemanArchitectureRead(d *schema.ResourceData, meta interface{}) error {
 	log.Tracef("data_source_foreman_architecture.go#Read")
 
-	client := meta.(*api.Client)
+	client := meta.(*api.Client).Memoized()
 	arch := buildForemanArchitecture(d)
 
 	log.Debugf("ForemanArchitecture: [%+v]", arch)
@@ -49,6 +50,11 @@ func dataSourceForemanArchitectureRead(d *schema.ResourceData, meta interface{})
 		return queryErr
 	}
 
+	filterQueryResults(d, &queryResponse, arch.Name, func(r interface{}) string {
+		result, _ := r.(api.ForemanArchitecture)
+		return result.Name
+	})
+
 	if queryResponse.Subtotal == 0 {
 		return fmt.Errorf("Data source architecture returned no results")
 	} else if queryResponse.Subtotal > 1 {
@@ -82,6 +82,12 @@ func DataSourceForemanArchitectureMockResponseTestCases(t *testing.T) []TestCase
 	obj := RandForemanArchitecture()
 	s := ForemanArchitectureToInstanceState(obj)
 
+	// NOTE(ALL): the results are filtered by the searched value, so search
+	//   for the object in the single result mock response
+	single := obj
+	single.Name = "x86_64"
+	singleState := ForemanArchitectureToInstanceState(single)
+
 	return []TestCaseMockResponse{
 		// If the server responds with more than one search result for the data
 		// source read, then the operation should return an error
@@ -112,7 +118,7 @@ func DataSourceForemanArchitectureMockResponseTestCases(t *testing.T) []TestCase
 			TestCase: TestCase{
 				funcName:     "dataSourceForemanArchitectureRead",
 				crudFunc:     dataSourceForemanArchitectureRead,
-				resourceData: MockForemanArchitectureResourceData(s),
+				resourceData: MockForemanArchitectureResourceData(singleState),
 			},
 			responseFile: ArchitecturesTestDataPath + "/query_response_single.json",
 			returnError:  false,
@@ -8,7 +8,7 @@ import (
 	"github.com/wayfair/terraform-provider-utils/autodoc"
 	"github.com/wayfair/terraform-provider-utils/log"
 
-	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func dataSourceForemanComputeProfile() *schema.Resource {
@@ -22,10 +22,15 @@ func dataSourceForemanComputeProfile() *schema.Resource {
 				Type:     schema.TypeString,
 				Required: true,
 				Description: fmt.Sprintf(
-					"Compute Profile",
+					"Compute Profile. %s \"compute-profile\"",
 					autodoc.MetaExample,
 				),
 			},
+
+			"exact_match": exactMatchSchema(),
+
+			"created_at": createdAtSchema(),
+			"updated_at": updatedAtSchema(),
 		},
 	}
 }
@@ -49,6 +54,7 @@ func buildForemanComputeProfile(d *schema.ResourceData) *api.ForemanComputeProfi
 // the attributes of the supplied ForemanComputeProfile reference
 func setResourceDataFromForemanComputeProfile(d *schema.ResourceData, fk *api.ForemanComputeProfile) {
 	d.SetId(strconv.Itoa(fk.Id))
+	setResourceDataFromForemanObjectTimestamps(d, &fk.ForemanObject)
 	d.Set("name", fk.Name)
 }
 
@@ -59,7 +65,7 @@ func setResourceDataFromForemanComputeProfile(d *schema.ResourceData, fk *api.Fo
 func dataSourceForemanComputeProfileRead(d *schema.ResourceData, meta interface{}) error {
 	log.Tracef("data_source_foreman_architecture.go#Read")
 
-	client := meta.(*api.Client)
+	client := meta.(*api.Client).Memoized()
 	t := buildForemanComputeProfile(d)
 
 	log.Debugf("ForemanComputeProfile: [%+v]", t)
@@ -69,6 +75,11 @@ func dataSourceForemanComputeProfileRead(d *schema.ResourceData, meta interface{
 		return queryErr
 	}
 
+	filterQueryResults(d, &queryResponse, t.Name, func(r interface{}) string {
+		result, _ := r.(api.ForemanComputeProfile)
+		return result.Name
+	})
+
 	if queryResponse.Subtotal == 0 {
 		return fmt.Errorf("Data source template kind returned no results")
 	} else if queryResponse.Subtotal > 1 {
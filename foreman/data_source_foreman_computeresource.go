@@ -5,16 +5,15 @@ import (
 
 	"github.com/HanseMerkur/terraform-provider-foreman/foreman/api"
 	"github.com/wayfair/terraform-provider-utils/autodoc"
-	"github.com/wayfair/terraform-provider-utils/helper"
 	"github.com/wayfair/terraform-provider-utils/log"
 
-	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func dataSourceForemanComputeResource() *schema.Resource {
 	// copy attributes from resource definition
 	r := resourceForemanComputeResource()
-	ds := helper.DataSourceSchemaFromResourceSchema(r.Schema)
+	ds := dataSourceSchemaFromResourceSchema(r.Schema)
 
 	// define searchable attributes for the data source
 	ds["name"] = &schema.Schema{
@@ -23,6 +22,8 @@ func dataSourceForemanComputeResource() *schema.Resource {
 		Description: fmt.Sprintf("The name of the compute resource. %s", autodoc.MetaExample),
 	}
 
+	ds["exact_match"] = exactMatchSchema()
+
 	return &schema.Resource{
 
 		Read: dataSourceForemanComputeResourceRead,
@@ -35,7 +36,7 @@ func dataSourceForemanComputeResource() *schema.Resource {
 func dataSourceForemanComputeResourceRead(d *schema.ResourceData, meta interface{}) error {
 	log.Tracef("data_source_foreman_computeresource.go#Read")
 
-	client := meta.(*api.Client)
+	client := meta.(*api.Client).Memoized()
 	computeresource := buildForemanComputeResource(d)
 
 	log.Debugf("ForemanComputeResource: [%+v]", computeresource)
@@ -45,6 +46,11 @@ func dataSourceForemanComputeResourceRead(d *schema.ResourceData, meta interface
 		return queryErr
 	}
 
+	filterQueryResults(d, &queryResponse, computeresource.Name, func(r interface{}) string {
+		result, _ := r.(api.ForemanComputeResource)
+		return result.Name
+	})
+
 	if queryResponse.Subtotal == 0 {
 		return fmt.Errorf("Data source computeresource returned no results")
 	} else if queryResponse.Subtotal > 1 {
@@ -0,0 +1,143 @@
+package foreman
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/HanseMerkur/terraform-provider-foreman/foreman/api"
+	"github.com/wayfair/terraform-provider-utils/autodoc"
+	"github.com/wayfair/terraform-provider-utils/log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// dataSourceForemanComputeResourceObjects returns a data source listing the
+// objects of the supplied kind (ie: "images", "storage_domains") available on
+// a compute resource.  The objects are listed in the attribute named after
+// the kind, so compute attribute blocks can reference discovered IDs.  Kinds
+// scoped to a cluster take an optional "cluster_id".
+func dataSourceForemanComputeResourceObjects(kind string, noun string, clusterScoped bool) *schema.Resource {
+	s := map[string]*schema.Schema{
+
+		autodoc.MetaAttribute: &schema.Schema{
+			Type:     schema.TypeBool,
+			Computed: true,
+			Description: fmt.Sprintf(
+				"%s Lists the %s available on a compute resource, as reported "+
+					"by the hypervisor.",
+				autodoc.MetaSummary,
+				noun,
+			),
+		},
+
+		"compute_resource_id": &schema.Schema{
+			Type:         schema.TypeInt,
+			Required:     true,
+			ValidateFunc: validation.IntAtLeast(1),
+			Description:  "ID of the compute resource.",
+		},
+
+		"ids": &schema.Schema{
+			Type:        schema.TypeList,
+			Computed:    true,
+			Elem:        &schema.Schema{Type: schema.TypeString},
+			Description: fmt.Sprintf("IDs of the available %s.", noun),
+		},
+
+		"ids_by_name": &schema.Schema{
+			Type:     schema.TypeMap,
+			Computed: true,
+			Elem:     &schema.Schema{Type: schema.TypeString},
+			Description: fmt.Sprintf(
+				"IDs of the available %s keyed by their name. Of %s sharing a "+
+					"name, the first one listed is kept.",
+				noun,
+				noun,
+			),
+		},
+
+		kind: &schema.Schema{
+			Type:        schema.TypeList,
+			Computed:    true,
+			Elem:        dataSourceForemanComputeResourceObjectsElem(),
+			Description: fmt.Sprintf("The available %s.", noun),
+		},
+	}
+	if clusterScoped {
+		s["cluster_id"] = &schema.Schema{
+			Type:     schema.TypeString,
+			Optional: true,
+			Description: fmt.Sprintf(
+				"ID of the cluster to list the %s of. Required by compute "+
+					"resources scoping their %s to a cluster (ie: oVirt).",
+				noun,
+				noun,
+			),
+		}
+	}
+
+	return &schema.Resource{
+		Read:   dataSourceForemanComputeResourceObjectsRead(kind),
+		Schema: s,
+	}
+}
+
+// dataSourceForemanComputeResourceObjectsElem is the nested resource
+// describing a single available object.
+func dataSourceForemanComputeResourceObjectsElem() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"id": &schema.Schema{
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Identifier of the object on the compute resource.",
+			},
+			"name": &schema.Schema{
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The name of the object.",
+			},
+		},
+	}
+}
+
+// dataSourceForemanComputeResourceObjectsRead returns the read function of
+// the data source listing the objects of the supplied kind.
+func dataSourceForemanComputeResourceObjectsRead(kind string) schema.ReadFunc {
+	return func(d *schema.ResourceData, meta interface{}) error {
+		log.Tracef("data_source_foreman_computeresource_objects.go#Read")
+
+		client := meta.(*api.Client).Memoized()
+		computeResourceId := d.Get("compute_resource_id").(int)
+		clusterId, _ := d.Get("cluster_id").(string)
+
+		objects, listErr := client.ListComputeResourceObjects(computeResourceId, kind, clusterId)
+		if listErr != nil {
+			return listErr
+		}
+
+		ids := make([]string, 0, len(objects))
+		idsByName := map[string]string{}
+		objectMaps := make([]map[string]interface{}, 0, len(objects))
+		for _, o := range objects {
+			ids = append(ids, o.Id)
+			if _, ok := idsByName[o.Name]; !ok {
+				idsByName[o.Name] = o.Id
+			}
+			objectMaps = append(objectMaps, map[string]interface{}{
+				"id":   o.Id,
+				"name": o.Name,
+			})
+		}
+
+		log.Debugf("%s: [%+v]", kind, objectMaps)
+
+		d.SetId(fmt.Sprintf("%s/%s", strconv.Itoa(computeResourceId), clusterId))
+		d.Set("ids", ids)
+		d.Set("ids_by_name", idsByName)
+		d.Set(kind, objectMaps)
+
+		return nil
+	}
+}
@@ -0,0 +1,77 @@
+package foreman
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/HanseMerkur/terraform-provider-foreman/foreman/api"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// -----------------------------------------------------------------------------
+// dataSourceForemanComputeResourceObjects
+// -----------------------------------------------------------------------------
+
+// Ensures the objects are listed with their IDs, whether the hypervisor
+// reports them as numbers, strings or UUIDs
+func TestDataSourceForemanComputeResourceObjects(t *testing.T) {
+
+	mux, server, client := NewForemanAPIAndClient(api.ClientCredentials{}, api.ClientConfig{})
+	defer server.Close()
+
+	mux.HandleFunc(api.FOREMAN_API_URL_PREFIX+"/compute_resources/2/available_images", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"results": [
+			{"id": 7, "name": "centos-9"},
+			{"id": "ami-0abc", "name": "debian-12"},
+			{"uuid": "4a1b-77", "name": "centos-9"}
+		]}`))
+	})
+
+	r := dataSourceForemanComputeResourceObjects("images", "images", false)
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"compute_resource_id": 2,
+	})
+
+	if readErr := r.Read(d, client); readErr != nil {
+		t.Fatalf("expected no error, got [%s]", readErr)
+	}
+
+	ids := d.Get("ids").([]interface{})
+	if len(ids) != 3 || ids[0] != "7" || ids[1] != "ami-0abc" || ids[2] != "4a1b-77" {
+		t.Fatalf("expected IDs [7 ami-0abc 4a1b-77], got [%v]", ids)
+	}
+	idsByName := d.Get("ids_by_name").(map[string]interface{})
+	if idsByName["centos-9"] != "7" || idsByName["debian-12"] != "ami-0abc" {
+		t.Fatalf("expected the first ID of each name, got [%v]", idsByName)
+	}
+	if name := d.Get("images.1.name").(string); name != "debian-12" {
+		t.Fatalf("expected image [debian-12], got [%s]", name)
+	}
+
+}
+
+// Ensures the networks of a cluster are listed through the cluster
+func TestDataSourceForemanComputeResourceObjects_cluster(t *testing.T) {
+
+	mux, server, client := NewForemanAPIAndClient(api.ClientCredentials{}, api.ClientConfig{})
+	defer server.Close()
+
+	mux.HandleFunc(api.FOREMAN_API_URL_PREFIX+"/compute_resources/2/available_clusters/c-1/available_networks", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"results": [{"id": "n-1", "name": "ovirtmgmt"}]}`))
+	})
+
+	r := dataSourceForemanComputeResourceObjects("networks", "networks", true)
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"compute_resource_id": 2,
+		"cluster_id":          "c-1",
+	})
+
+	if readErr := r.Read(d, client); readErr != nil {
+		t.Fatalf("expected no error, got [%s]", readErr)
+	}
+	if id := d.Get("ids_by_name.ovirtmgmt").(string); id != "n-1" {
+		t.Fatalf("expected network [n-1], got [%s]", id)
+	}
+
+}
@@ -5,16 +5,15 @@ import (
 
 	"github.com/HanseMerkur/terraform-provider-foreman/foreman/api"
 	"github.com/wayfair/terraform-provider-utils/autodoc"
-	"github.com/wayfair/terraform-provider-utils/helper"
 	"github.com/wayfair/terraform-provider-utils/log"
 
-	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func dataSourceForemanDomain() *schema.Resource {
 	// copy attributes from resource definition
 	r := resourceForemanDomain()
-	ds := helper.DataSourceSchemaFromResourceSchema(r.Schema)
+	ds := dataSourceSchemaFromResourceSchema(r.Schema)
 
 	// define searchable attributes for the data source
 	ds["name"] = &schema.Schema{
@@ -27,6 +26,8 @@ func dataSourceForemanDomain() *schema.Resource {
 		),
 	}
 
+	ds["exact_match"] = exactMatchSchema()
+
 	return &schema.Resource{
 
 		Read: dataSourceForemanDomainRead,
@@ -39,7 +40,7 @@ func dataSourceForemanDomain() *schema.Resource {
 func dataSourceForemanDomainRead(d *schema.ResourceData, meta interface{}) error {
 	log.Tracef("data_source_foreman_domain.go#Read")
 
-	client := meta.(*api.Client)
+	client := meta.(*api.Client).Memoized()
 	domain := buildForemanDomain(d)
 
 	log.Debugf("ForemanDomain: [%+v]", domain)
@@ -49,6 +50,11 @@ func dataSourceForemanDomainRead(d *schema.ResourceData, meta interface{}) error
 		return queryErr
 	}
 
+	filterQueryResults(d, &queryResponse, domain.Name, func(r interface{}) string {
+		result, _ := r.(api.ForemanDomain)
+		return result.Name
+	})
+
 	if queryResponse.Subtotal == 0 {
 		return fmt.Errorf("Data source domain returned no results")
 	} else if queryResponse.Subtotal > 1 {
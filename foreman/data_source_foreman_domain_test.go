@@ -3,8 +3,61 @@ package foreman
 import (
 	"net/http"
 	"testing"
+
+	"github.com/HanseMerkur/terraform-provider-foreman/foreman/api"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
+// -----------------------------------------------------------------------------
+// filterQueryResults
+// -----------------------------------------------------------------------------
+
+// Ensures substring matches are dropped from the search results and case is
+// only ignored unless exact_match is set
+func TestFilterQueryResults_ExactMatch(t *testing.T) {
+
+	names := []string{"prod", "preprod", "PROD"}
+	newQueryResponse := func() api.QueryResponse {
+		queryResponse := api.QueryResponse{Subtotal: len(names)}
+		for _, name := range names {
+			domain := api.ForemanDomain{}
+			domain.Name = name
+			queryResponse.Results = append(queryResponse.Results, domain)
+		}
+		return queryResponse
+	}
+	keyOf := func(r interface{}) string {
+		result, _ := r.(api.ForemanDomain)
+		return result.Name
+	}
+
+	testCases := []struct {
+		exactMatch bool
+		expected   int
+	}{
+		{exactMatch: false, expected: 2},
+		{exactMatch: true, expected: 1},
+	}
+	for _, testCase := range testCases {
+		d := schema.TestResourceDataRaw(t, dataSourceForemanDomain().Schema, map[string]interface{}{
+			"name":        "prod",
+			"exact_match": testCase.exactMatch,
+		})
+		queryResponse := newQueryResponse()
+		filterQueryResults(d, &queryResponse, "prod", keyOf)
+		if queryResponse.Subtotal != testCase.expected || len(queryResponse.Results) != testCase.expected {
+			t.Fatalf(
+				"filterQueryResults with exact_match [%t] kept [%d] results, expected [%d]",
+				testCase.exactMatch,
+				queryResponse.Subtotal,
+				testCase.expected,
+			)
+		}
+	}
+
+}
+
 // ----------------------------------------------------------------------------
 // Test Cases for the Unit Test Framework
 // ----------------------------------------------------------------------------
@@ -82,6 +135,12 @@ func DataSourceForemanDomainMockResponseTestCases(t *testing.T) []TestCaseMockRe
 	obj := RandForemanDomain()
 	s := ForemanDomainToInstanceState(obj)
 
+	// NOTE(ALL): the results are filtered by the searched value, so search
+	//   for the object in the single result mock response
+	single := obj
+	single.Name = "dev.dc1.company.com"
+	singleState := ForemanDomainToInstanceState(single)
+
 	return []TestCaseMockResponse{
 		// If the server responds with more than one search result for the data
 		// source read, then the operation should return an error
@@ -112,7 +171,7 @@ func DataSourceForemanDomainMockResponseTestCases(t *testing.T) []TestCaseMockRe
 			TestCase: TestCase{
 				funcName:     "dataSourceForemanDomainRead",
 				crudFunc:     dataSourceForemanDomainRead,
-				resourceData: MockForemanDomainResourceData(s),
+				resourceData: MockForemanDomainResourceData(singleState),
 			},
 			responseFile: DomainsTestDataPath + "/query_response_single.json",
 			returnError:  false,
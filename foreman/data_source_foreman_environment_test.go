@@ -82,6 +82,12 @@ func DataSourceForemanEnvironmentMockResponseTestCases(t *testing.T) []TestCaseM
 	obj := RandForemanEnvironment()
 	s := ForemanEnvironmentToInstanceState(obj)
 
+	// NOTE(ALL): the results are filtered by the searched value, so search
+	//   for the object in the single result mock response
+	single := obj
+	single.Name = "jsmith_test"
+	singleState := ForemanEnvironmentToInstanceState(single)
+
 	return []TestCaseMockResponse{
 		// If the server responds with more than one search result for the data
 		// source read, then the operation should return an error
@@ -112,7 +118,7 @@ func DataSourceForemanEnvironmentMockResponseTestCases(t *testing.T) []TestCaseM
 			TestCase: TestCase{
 				funcName:     "dataSourceForemanEnvironmentRead",
 				crudFunc:     dataSourceForemanEnvironmentRead,
-				resourceData: MockForemanEnvironmentResourceData(s),
+				resourceData: MockForemanEnvironmentResourceData(singleState),
 			},
 			responseFile: EnvironmentsTestDataPath + "/query_response_single.json",
 			returnError:  false,
@@ -0,0 +1,124 @@
+package foreman
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/HanseMerkur/terraform-provider-foreman/foreman/api"
+	"github.com/wayfair/terraform-provider-utils/autodoc"
+	"github.com/wayfair/terraform-provider-utils/log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceForemanFactSearch() *schema.Resource {
+	return &schema.Resource{
+
+		Read: dataSourceForemanFactSearchRead,
+
+		Schema: map[string]*schema.Schema{
+
+			autodoc.MetaAttribute: &schema.Schema{
+				Type:     schema.TypeBool,
+				Computed: true,
+				Description: fmt.Sprintf(
+					"%s Hosts whose reported fact matches a value pattern. Useful "+
+						"to build dynamic groups based on hardware or network facts.",
+					autodoc.MetaSummary,
+				),
+			},
+
+			"fact": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				Description: fmt.Sprintf(
+					"The name of the fact to match. "+
+						"%s \"manufacturer\"",
+					autodoc.MetaExample,
+				),
+			},
+
+			"value": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				Description: fmt.Sprintf(
+					"The value pattern the fact is matched against. Unless "+
+						"`exact` is set, `%%` can be used as a wildcard and the match is "+
+						"a case-insensitive substring match. "+
+						"%s \"Dell%%\"",
+					autodoc.MetaExample,
+				),
+			},
+
+			"exact": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+				Description: "Whether or not the fact value has to match exactly. " +
+					"Defaults to `false`.",
+			},
+
+			"ids": &schema.Schema{
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeInt},
+				Description: "IDs of the matching hosts.",
+			},
+
+			"hosts": &schema.Schema{
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Fully qualified names of the matching hosts.",
+			},
+		},
+	}
+}
+
+func dataSourceForemanFactSearchRead(d *schema.ResourceData, meta interface{}) error {
+	log.Tracef("data_source_foreman_fact_search.go#Read")
+
+	client := meta.(*api.Client)
+
+	operator := "~"
+	if d.Get("exact").(bool) {
+		operator = "="
+	}
+	// NOTE(ALL): quote the whole field, the search parser splits the fact
+	//   name off the "facts." prefix of a quoted field as well
+	search := fmt.Sprintf(
+		"%s %s %s",
+		api.QuoteSearchTerm("facts."+d.Get("fact").(string)),
+		operator,
+		api.QuoteSearchTerm(d.Get("value").(string)),
+	)
+
+	log.Debugf("search: [%s]", search)
+
+	// NOTE(ALL): page through the hosts instead of requesting them all at
+	//   once, fact searches can match tens of thousands of hosts
+	ids := []int{}
+	hosts := []string{}
+	queryErr := client.ForEachResult(api.HostEndpointPrefix, search, func(result json.RawMessage) error {
+		var h api.ForemanHost
+		if jsonDecErr := json.Unmarshal(result, &h); jsonDecErr != nil {
+			return jsonDecErr
+		}
+		ids = append(ids, h.Id)
+		// NOTE(ALL): the host's name has its domain stripped on unmarshal
+		hosts = append(hosts, h.FQDN())
+		return nil
+	})
+	if queryErr != nil {
+		return queryErr
+	}
+
+	log.Debugf("hosts: [%v]", hosts)
+
+	d.SetId(strconv.Itoa(schema.HashString(search)))
+	d.Set("ids", ids)
+	d.Set("hosts", hosts)
+
+	return nil
+}
@@ -0,0 +1,171 @@
+package foreman
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/HanseMerkur/terraform-provider-foreman/foreman/api"
+	"github.com/wayfair/terraform-provider-utils/autodoc"
+	"github.com/wayfair/terraform-provider-utils/log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// hostDetailLoaders fetch the heavy sub-objects of a host on demand.  Each
+// loader reads a single sub-object and sets the attribute of the same name,
+// only the sub-objects listed in the "include" attribute are fetched.
+var hostDetailLoaders = map[string]func(d *schema.ResourceData, client *api.Client, hostId int) error{
+	"facts": func(d *schema.ResourceData, client *api.Client, hostId int) error {
+		facts, readErr := client.ReadHostFacts(hostId)
+		if readErr != nil {
+			return readErr
+		}
+		return d.Set("facts", facts)
+	},
+	"enc": func(d *schema.ResourceData, client *api.Client, hostId int) error {
+		enc, readErr := client.ReadHostENC(hostId)
+		if readErr != nil {
+			return readErr
+		}
+		return d.Set("enc", enc)
+	},
+	"last_report": func(d *schema.ResourceData, client *api.Client, hostId int) error {
+		report, readErr := client.ReadHostLastConfigReport(hostId)
+		// NOTE(ALL): hosts without configuration management have no reports
+		if api.IsNotFound(readErr) {
+			return d.Set("last_report", []interface{}{})
+		}
+		if readErr != nil {
+			return readErr
+		}
+		return d.Set("last_report", []interface{}{
+			map[string]interface{}{
+				"id":          report.Id,
+				"reported_at": report.ReportedAt,
+				"status":      report.Status,
+			},
+		})
+	},
+}
+
+// hostDetailNames returns the names of the sub-objects of a host that can be
+// included, sorted
+func hostDetailNames() []string {
+	names := make([]string, 0, len(hostDetailLoaders))
+	for name := range hostDetailLoaders {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func dataSourceForemanHostDetails() *schema.Resource {
+	return &schema.Resource{
+
+		Read: dataSourceForemanHostDetailsRead,
+
+		Schema: map[string]*schema.Schema{
+
+			autodoc.MetaAttribute: &schema.Schema{
+				Type:     schema.TypeBool,
+				Computed: true,
+				Description: fmt.Sprintf(
+					"%s The facts, ENC data and last configuration report of a "+
+						"host. Only the included details are fetched from Foreman.",
+					autodoc.MetaSummary,
+				),
+			},
+
+			"host_id": &schema.Schema{
+				Type:         schema.TypeInt,
+				Required:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+				Description:  "ID of the host whose details are read.",
+			},
+
+			"include": &schema.Schema{
+				Type:     schema.TypeSet,
+				Required: true,
+				MinItems: 1,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringInSlice(hostDetailNames(), false),
+				},
+				Set: schema.HashString,
+				Description: "The details to fetch. Details which are not included " +
+					"are left empty and cost no API calls. Valid values: " +
+					"`" + strings.Join(hostDetailNames(), "`, `") + "`.",
+			},
+
+			"facts": &schema.Schema{
+				Type:     schema.TypeMap,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Description: "The facts reported for the host, keyed by fact name.",
+			},
+
+			"enc": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+				Description: "The external node classifier data (classes, " +
+					"parameters, environment) of the host as JSON.",
+			},
+
+			"last_report": &schema.Schema{
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": &schema.Schema{
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "Unique identifier of the report.",
+						},
+						"reported_at": &schema.Schema{
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "When the host generated the report.",
+						},
+						"status": &schema.Schema{
+							Type:     schema.TypeMap,
+							Computed: true,
+							Elem: &schema.Schema{
+								Type: schema.TypeInt,
+							},
+							Description: "Number of resources per status (ie: " +
+								"applied, failed, skipped).",
+						},
+					},
+				},
+				Description: "The last configuration management report of the " +
+					"host. Empty if the host never reported.",
+			},
+		},
+	}
+}
+
+func dataSourceForemanHostDetailsRead(d *schema.ResourceData, meta interface{}) error {
+	log.Tracef("data_source_foreman_host_details.go#Read")
+
+	client := meta.(*api.Client)
+	hostId := d.Get("host_id").(int)
+
+	for _, name := range hostDetailNames() {
+		if !d.Get("include").(*schema.Set).Contains(name) {
+			continue
+		}
+		log.Debugf("Fetching [%s] of host [%d]", name, hostId)
+		if loadErr := hostDetailLoaders[name](d, client, hostId); loadErr != nil {
+			return loadErr
+		}
+	}
+
+	d.SetId(strconv.Itoa(hostId))
+
+	return nil
+}
@@ -0,0 +1,51 @@
+package foreman
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/HanseMerkur/terraform-provider-foreman/foreman/api"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// TestDataSourceForemanHostDetailsRead_Include ensures only the included
+// details of a host are fetched from Foreman.  The test will fail if any
+// endpoint other than the facts of the host is requested.
+func TestDataSourceForemanHostDetailsRead_Include(t *testing.T) {
+	cred := api.ClientCredentials{}
+	conf := api.ClientConfig{}
+
+	mux, server, client := NewForemanAPIAndClient(cred, conf)
+	defer server.Close()
+
+	mux.HandleFunc("/api/hosts/3/facts", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"results":{"host01.example.com":{"kernel":"Linux","processorcount":"4"}}}`))
+	})
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("Unexpected request to [%s]", r.URL.Path)
+		w.WriteHeader(http.StatusNotFound)
+	})
+
+	d := schema.TestResourceDataRaw(t, dataSourceForemanHostDetails().Schema, map[string]interface{}{
+		"host_id": 3,
+		"include": []interface{}{"facts"},
+	})
+
+	if readErr := dataSourceForemanHostDetailsRead(d, client); readErr != nil {
+		t.Fatalf("Read returned an error. Expected [nil] got [%s]", readErr)
+	}
+
+	if d.Id() != "3" {
+		t.Errorf("Expected ID [3] got [%s]", d.Id())
+	}
+	if kernel := d.Get("facts.kernel"); kernel != "Linux" {
+		t.Errorf("Expected fact [kernel] to be [Linux] got [%v]", kernel)
+	}
+	if enc := d.Get("enc"); enc != "" {
+		t.Errorf("Expected [enc] to be empty got [%v]", enc)
+	}
+	if reports := d.Get("last_report").([]interface{}); len(reports) != 0 {
+		t.Errorf("Expected [last_report] to be empty got [%v]", reports)
+	}
+}
@@ -0,0 +1,141 @@
+package foreman
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/HanseMerkur/terraform-provider-foreman/foreman/api"
+	"github.com/wayfair/terraform-provider-utils/autodoc"
+	"github.com/wayfair/terraform-provider-utils/log"
+	"gopkg.in/yaml.v3"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// Formats a host can be exported in
+const (
+	hostExportFormatJSON = "json"
+	hostExportFormatYAML = "yaml"
+)
+
+func dataSourceForemanHostExport() *schema.Resource {
+	return &schema.Resource{
+
+		Read: dataSourceForemanHostExportRead,
+
+		Schema: map[string]*schema.Schema{
+
+			autodoc.MetaAttribute: &schema.Schema{
+				Type:     schema.TypeBool,
+				Computed: true,
+				Description: fmt.Sprintf(
+					"%s Exports the full Foreman representation of a host, "+
+						"including its parameters and interfaces, as a JSON or YAML "+
+						"document for external inventory tools and CMDBs.",
+					autodoc.MetaSummary,
+				),
+			},
+
+			"host_id": &schema.Schema{
+				Type:         schema.TypeInt,
+				Required:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+				Description:  "ID of the host to export.",
+			},
+
+			"format": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  hostExportFormatJSON,
+				ValidateFunc: validation.StringInSlice([]string{
+					hostExportFormatJSON,
+					hostExportFormatYAML,
+					// NOTE(ALL): false - do not ignore case when comparing values
+				}, false),
+				Description: "Format of the document. Values include: `\"json\"`, " +
+					"`\"yaml\"`. Defaults to `\"json\"`.",
+			},
+
+			"facts": &schema.Schema{
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Description: fmt.Sprintf(
+					"Names of the facts exported under the `facts` key of the "+
+						"document. Facts the host did not report are left out. The "+
+						"facts are only read when names are given. "+
+						"%s [\"os::release::full\", \"memorysize_mb\", \"processorcount\"]",
+					autodoc.MetaExample,
+				),
+			},
+
+			"document": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+				Description: "The exported host. Values of hidden parameters stay " +
+					"masked.",
+			},
+		},
+	}
+}
+
+// buildForemanHostExport adds the supplied facts to the representation of a
+// host and serializes it in the supplied format.  Only the facts named in
+// the supplied list are exported.
+func buildForemanHostExport(representation map[string]interface{}, facts map[string]string, names []interface{}, format string) (string, error) {
+	if len(names) > 0 {
+		summary := map[string]string{}
+		for _, name := range names {
+			if value, ok := facts[name.(string)]; ok {
+				summary[name.(string)] = value
+			}
+		}
+		representation["facts"] = summary
+	}
+
+	var document []byte
+	var marshalErr error
+	switch format {
+	case hostExportFormatYAML:
+		document, marshalErr = yaml.Marshal(representation)
+	default:
+		document, marshalErr = json.MarshalIndent(representation, "", "  ")
+	}
+	if marshalErr != nil {
+		return "", marshalErr
+	}
+	return string(document), nil
+}
+
+func dataSourceForemanHostExportRead(d *schema.ResourceData, meta interface{}) error {
+	log.Tracef("data_source_foreman_host_export.go#Read")
+
+	client := meta.(*api.Client)
+	hostId := d.Get("host_id").(int)
+
+	representation, readErr := client.ReadHostRepresentation(hostId)
+	if readErr != nil {
+		return readErr
+	}
+
+	names := d.Get("facts").([]interface{})
+	var facts map[string]string
+	if len(names) > 0 {
+		facts, readErr = client.ReadHostFacts(hostId)
+		if readErr != nil {
+			return readErr
+		}
+	}
+
+	document, exportErr := buildForemanHostExport(representation, facts, names, d.Get("format").(string))
+	if exportErr != nil {
+		return exportErr
+	}
+
+	d.SetId(strconv.Itoa(hostId))
+	d.Set("document", document)
+
+	return nil
+}
@@ -0,0 +1,76 @@
+package foreman
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/HanseMerkur/terraform-provider-foreman/foreman/api"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// -----------------------------------------------------------------------------
+// dataSourceForemanHostExport
+// -----------------------------------------------------------------------------
+
+// Ensures the host is exported with the summary of the requested facts, in
+// the requested format
+func TestDataSourceForemanHostExport(t *testing.T) {
+
+	mux, server, client := NewForemanAPIAndClient(api.ClientCredentials{}, api.ClientConfig{})
+	defer server.Close()
+
+	mux.HandleFunc(api.FOREMAN_API_URL_PREFIX+"/hosts/3", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{
+			"id": 3,
+			"name": "host01.example.com",
+			"parameters": [{"name": "role", "value": "web"}],
+			"interfaces": [{"id": 5, "identifier": "eth0", "ip": "10.0.0.5"}]
+		}`)
+	})
+	factReads := 0
+	mux.HandleFunc(api.FOREMAN_API_URL_PREFIX+"/hosts/3/facts", func(w http.ResponseWriter, r *http.Request) {
+		factReads++
+		fmt.Fprint(w, `{"results": {"host01.example.com": {"processorcount": "4", "uptime": "3 days"}}}`)
+	})
+
+	r := dataSourceForemanHostExport()
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"host_id": 3,
+		"facts":   []interface{}{"processorcount", "memorysize_mb"},
+	})
+	if readErr := r.Read(d, client); readErr != nil {
+		t.Fatalf("expected no error, got [%s]", readErr)
+	}
+
+	var exported map[string]interface{}
+	if jsonErr := json.Unmarshal([]byte(d.Get("document").(string)), &exported); jsonErr != nil {
+		t.Fatalf("expected a JSON document, got [%s]", jsonErr)
+	}
+	if exported["name"] != "host01.example.com" || len(exported["interfaces"].([]interface{})) != 1 {
+		t.Fatalf("expected the host's representation, got [%v]", exported)
+	}
+	facts := exported["facts"].(map[string]interface{})
+	if len(facts) != 1 || facts["processorcount"] != "4" {
+		t.Fatalf("expected only the reported requested facts, got [%v]", facts)
+	}
+
+	d = schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"host_id": 3,
+		"format":  "yaml",
+	})
+	if readErr := r.Read(d, client); readErr != nil {
+		t.Fatalf("expected no error, got [%s]", readErr)
+	}
+	document := d.Get("document").(string)
+	if !strings.Contains(document, "name: host01.example.com\n") || strings.Contains(document, "facts:") {
+		t.Fatalf("expected a YAML document without facts, got [%s]", document)
+	}
+	if factReads != 1 {
+		t.Fatalf("expected the facts to be read once, got [%d]", factReads)
+	}
+
+}
@@ -0,0 +1,155 @@
+package foreman
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/HanseMerkur/terraform-provider-foreman/foreman/api"
+	"github.com/wayfair/terraform-provider-utils/autodoc"
+	"github.com/wayfair/terraform-provider-utils/log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func dataSourceForemanHostInterfaces() *schema.Resource {
+	return &schema.Resource{
+
+		Read: dataSourceForemanHostInterfacesRead,
+
+		Schema: map[string]*schema.Schema{
+
+			autodoc.MetaAttribute: &schema.Schema{
+				Type:     schema.TypeBool,
+				Computed: true,
+				Description: fmt.Sprintf(
+					"%s The network interfaces of a host, including their types, "+
+						"IP and MAC addresses.",
+					autodoc.MetaSummary,
+				),
+			},
+
+			"host_id": &schema.Schema{
+				Type:         schema.TypeInt,
+				Required:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+				Description:  "ID of the host whose interfaces are listed.",
+			},
+
+			"interfaces": &schema.Schema{
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        dataSourceForemanHostInterfacesElem(),
+				Description: "The host's interfaces.",
+			},
+		},
+	}
+}
+
+// dataSourceForemanHostInterfacesElem is the nested resource describing a
+// single entry of the "interfaces" list.
+func dataSourceForemanHostInterfacesElem() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"id": &schema.Schema{
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Unique identifier for the interface.",
+			},
+			"name": &schema.Schema{
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "DNS name associated with the interface.",
+			},
+			"identifier": &schema.Schema{
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Identifier of this interface local to the host.",
+			},
+			"type": &schema.Schema{
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The type of interface.",
+			},
+			"ip": &schema.Schema{
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "IP address associated with the interface.",
+			},
+			"mac": &schema.Schema{
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "MAC address associated with the interface.",
+			},
+			"subnet_id": &schema.Schema{
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "ID of the subnet associated with the interface.",
+			},
+			"primary": &schema.Schema{
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether or not this is the primary interface.",
+			},
+			"provision": &schema.Schema{
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether or not this interface is used to provision the host.",
+			},
+			"managed": &schema.Schema{
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether or not this interface is managed by Foreman.",
+			},
+			"virtual": &schema.Schema{
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether or not this is a virtual interface.",
+			},
+		},
+	}
+}
+
+func dataSourceForemanHostInterfacesRead(d *schema.ResourceData, meta interface{}) error {
+	log.Tracef("data_source_foreman_host_interfaces.go#Read")
+
+	client := meta.(*api.Client)
+	hostId := d.Get("host_id").(int)
+
+	queryResponse, queryErr := client.QueryHostInterfaces(hostId)
+	if queryErr != nil {
+		return queryErr
+	}
+
+	ifaces := make([]map[string]interface{}, 0, len(queryResponse.Results))
+	for _, result := range queryResponse.Results {
+		iface, ok := result.(api.ForemanInterfacesAttribute)
+		if !ok {
+			return fmt.Errorf(
+				"Data source results contain unexpected type. Expected "+
+					"[api.ForemanInterfacesAttribute], got [%T]",
+				result,
+			)
+		}
+		ifaces = append(ifaces, map[string]interface{}{
+			"id":         iface.Id,
+			"name":       iface.Name,
+			"identifier": iface.Identifier,
+			"type":       iface.Type,
+			"ip":         iface.IP,
+			"mac":        iface.MAC,
+			"subnet_id":  iface.SubnetId,
+			"primary":    iface.Primary,
+			"provision":  iface.Provision,
+			"managed":    iface.Managed,
+			"virtual":    iface.Virtual,
+		})
+	}
+
+	log.Debugf("interfaces: [%+v]", ifaces)
+
+	d.SetId(strconv.Itoa(hostId))
+	d.Set("interfaces", ifaces)
+
+	return nil
+}
@@ -0,0 +1,76 @@
+package foreman
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/HanseMerkur/terraform-provider-foreman/foreman/api"
+	"github.com/wayfair/terraform-provider-utils/autodoc"
+	"github.com/wayfair/terraform-provider-utils/log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func dataSourceForemanHostPower() *schema.Resource {
+	return &schema.Resource{
+
+		Read: dataSourceForemanHostPowerRead,
+
+		Schema: map[string]*schema.Schema{
+
+			autodoc.MetaAttribute: &schema.Schema{
+				Type:     schema.TypeBool,
+				Computed: true,
+				Description: fmt.Sprintf(
+					"%s The current power state of a host, as reported by its "+
+						"BMC through Foreman. Reading the state does not change it.",
+					autodoc.MetaSummary,
+				),
+			},
+
+			"host_id": &schema.Schema{
+				Type:         schema.TypeInt,
+				Required:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+				Description:  "ID of the host to query.",
+			},
+
+			"state": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+				Description: fmt.Sprintf(
+					"The power state reported for the host. "+
+						"%s \"on\"",
+					autodoc.MetaExample,
+				),
+			},
+
+			"on": &schema.Schema{
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether or not the host is powered on.",
+			},
+		},
+	}
+}
+
+func dataSourceForemanHostPowerRead(d *schema.ResourceData, meta interface{}) error {
+	log.Tracef("data_source_foreman_host_power.go#Read")
+
+	client := meta.(*api.Client)
+	hostId := d.Get("host_id").(int)
+
+	state, stateErr := client.ReadPowerState(hostId)
+	if stateErr != nil {
+		return stateErr
+	}
+
+	log.Debugf("host_id: [%d], state: [%s]", hostId, state)
+
+	d.SetId(strconv.Itoa(hostId))
+	d.Set("state", state)
+	d.Set("on", state == api.PowerOn)
+
+	return nil
+}
@@ -5,16 +5,15 @@ import (
 
 	"github.com/HanseMerkur/terraform-provider-foreman/foreman/api"
 	"github.com/wayfair/terraform-provider-utils/autodoc"
-	"github.com/wayfair/terraform-provider-utils/helper"
 	"github.com/wayfair/terraform-provider-utils/log"
 
-	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func dataSourceForemanHostgroup() *schema.Resource {
 	// copy attributes from resource definition
 	r := resourceForemanHostgroup()
-	ds := helper.DataSourceSchemaFromResourceSchema(r.Schema)
+	ds := dataSourceSchemaFromResourceSchema(r.Schema)
 
 	// define searchable attributes for the data source
 
@@ -31,6 +30,8 @@ func dataSourceForemanHostgroup() *schema.Resource {
 		),
 	}
 
+	ds["exact_match"] = exactMatchSchema()
+
 	return &schema.Resource{
 
 		Read: dataSourceForemanHostgroupRead,
@@ -43,7 +44,7 @@ func dataSourceForemanHostgroup() *schema.Resource {
 func dataSourceForemanHostgroupRead(d *schema.ResourceData, meta interface{}) error {
 	log.Tracef("data_source_foreman_hostgroup.go#Read")
 
-	client := meta.(*api.Client)
+	client := meta.(*api.Client).Memoized()
 	h := buildForemanHostgroup(d)
 
 	log.Debugf("ForemanHostgroup: [%+v]", h)
@@ -53,6 +54,11 @@ func dataSourceForemanHostgroupRead(d *schema.ResourceData, meta interface{}) er
 		return queryErr
 	}
 
+	filterQueryResults(d, &queryResponse, h.Title, func(r interface{}) string {
+		result, _ := r.(api.ForemanHostgroup)
+		return result.Title
+	})
+
 	if queryResponse.Subtotal == 0 {
 		return fmt.Errorf("Data source hostgroup returned no results")
 	} else if queryResponse.Subtotal > 1 {
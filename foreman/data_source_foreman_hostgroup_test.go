@@ -82,6 +82,12 @@ func DataSourceForemanHostgroupMockResponseTestCases(t *testing.T) []TestCaseMoc
 	obj := RandForemanHostgroup()
 	s := ForemanHostgroupToInstanceState(obj)
 
+	// NOTE(ALL): the results are filtered by the searched value, so search
+	//   for the object in the single result mock response
+	single := obj
+	single.Title = "DC1/terraformtesthostgroup"
+	singleState := ForemanHostgroupToInstanceState(single)
+
 	return []TestCaseMockResponse{
 		// If the server responds with more than one search result for the data
 		// source read, then the operation should return an error
@@ -112,7 +118,7 @@ func DataSourceForemanHostgroupMockResponseTestCases(t *testing.T) []TestCaseMoc
 			TestCase: TestCase{
 				funcName:     "dataSourceForemanHostgroupRead",
 				crudFunc:     dataSourceForemanHostgroupRead,
-				resourceData: MockForemanHostgroupResourceData(s),
+				resourceData: MockForemanHostgroupResourceData(singleState),
 			},
 			responseFile: HostgroupsTestDataPath + "/query_response_single.json",
 			returnError:  false,
@@ -5,16 +5,15 @@ import (
 
 	"github.com/HanseMerkur/terraform-provider-foreman/foreman/api"
 	"github.com/wayfair/terraform-provider-utils/autodoc"
-	"github.com/wayfair/terraform-provider-utils/helper"
 	"github.com/wayfair/terraform-provider-utils/log"
 
-	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func dataSourceForemanImage() *schema.Resource {
 	// copy attributes from resource definition
 	r := resourceForemanImage()
-	ds := helper.DataSourceSchemaFromResourceSchema(r.Schema)
+	ds := dataSourceSchemaFromResourceSchema(r.Schema)
 
 	// define searchable attributes for the data source
 	ds["name"] = &schema.Schema{
@@ -28,6 +27,8 @@ func dataSourceForemanImage() *schema.Resource {
 		Description: fmt.Sprintf("The id of the Compute Resource the image is associated with"),
 	}
 
+	ds["exact_match"] = exactMatchSchema()
+
 	return &schema.Resource{
 
 		Read: dataSourceForemanImageRead,
@@ -40,7 +41,7 @@ func dataSourceForemanImage() *schema.Resource {
 func dataSourceForemanImageRead(d *schema.ResourceData, meta interface{}) error {
 	log.Tracef("data_source_foreman_image.go#Read")
 
-	client := meta.(*api.Client)
+	client := meta.(*api.Client).Memoized()
 	image := buildForemanImage(d)
 
 	log.Debugf("ForemanImage: [%+v]", image)
@@ -50,6 +51,11 @@ func dataSourceForemanImageRead(d *schema.ResourceData, meta interface{}) error
 		return queryErr
 	}
 
+	filterQueryResults(d, &queryResponse, image.Name, func(r interface{}) string {
+		result, _ := r.(api.ForemanImage)
+		return result.Name
+	})
+
 	if queryResponse.Subtotal == 0 {
 		return fmt.Errorf("Data source image returned no results")
 	} else if queryResponse.Subtotal > 1 {
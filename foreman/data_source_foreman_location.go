@@ -0,0 +1,173 @@
+package foreman
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/HanseMerkur/terraform-provider-foreman/foreman/api"
+	"github.com/wayfair/terraform-provider-utils/autodoc"
+	"github.com/wayfair/terraform-provider-utils/log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceForemanLocation() *schema.Resource {
+	return &schema.Resource{
+
+		Read: dataSourceForemanLocationRead,
+
+		Schema: map[string]*schema.Schema{
+
+			autodoc.MetaAttribute: &schema.Schema{
+				Type:     schema.TypeBool,
+				Computed: true,
+				Description: fmt.Sprintf(
+					"%s Foreman representation of a location. Locations can be "+
+						"nested and are identified by their title.",
+					autodoc.MetaSummary,
+				),
+			},
+
+			"title": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				Description: fmt.Sprintf(
+					"The title is the fullname of the location.  A "+
+						"location's title is a path-like string from the head "+
+						"of the location tree down to this location.  The title will be "+
+						"in the form of: \"<parent 1>/<parent 2>/.../<name>\". Either "+
+						"`title` or `name` must be supplied, `title` takes precedence. "+
+						"%s \"EU/DE/Berlin\"",
+					autodoc.MetaExample,
+				),
+			},
+
+			"name": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				Description: fmt.Sprintf(
+					"The name of the location. Only unique for top level "+
+						"locations - use `title` for nested locations. "+
+						"%s \"Berlin\"",
+					autodoc.MetaExample,
+				),
+			},
+
+			"description": &schema.Schema{
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Description of the location.",
+			},
+
+			"parent_id": &schema.Schema{
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "ID of the parent location.",
+			},
+
+			"exact_match": exactMatchSchema(),
+
+			"created_at": createdAtSchema(),
+			"updated_at": updatedAtSchema(),
+		},
+	}
+}
+
+// -----------------------------------------------------------------------------
+// Conversion Helpers
+// -----------------------------------------------------------------------------
+
+// buildForemanLocation constructs a ForemanLocation reference from a resource
+// data reference.  The struct's  members are populated from the data populated
+// in the resource data.  Missing members will be left to the zero value for
+// that member's type.
+func buildForemanLocation(d *schema.ResourceData) *api.ForemanLocation {
+	log.Tracef("data_source_foreman_location.go#buildForemanLocation")
+
+	location := api.ForemanLocation{}
+
+	obj := buildForemanObject(d)
+	location.ForemanObject = *obj
+
+	var attr interface{}
+	var ok bool
+
+	if attr, ok = d.GetOk("title"); ok {
+		location.Title = attr.(string)
+	}
+
+	return &location
+}
+
+// setResourceDataFromForemanLocation sets a ResourceData's attributes from the
+// attributes of the supplied ForemanLocation reference
+func setResourceDataFromForemanLocation(d *schema.ResourceData, fl *api.ForemanLocation) {
+	log.Tracef("data_source_foreman_location.go#setResourceDataFromForemanLocation")
+
+	d.SetId(strconv.Itoa(fl.Id))
+	setResourceDataFromForemanObjectTimestamps(d, &fl.ForemanObject)
+	d.Set("name", fl.Name)
+	d.Set("title", fl.Title)
+	d.Set("description", fl.Description)
+	d.Set("parent_id", fl.ParentId)
+}
+
+// -----------------------------------------------------------------------------
+// Data Source Read Operation
+// -----------------------------------------------------------------------------
+
+func dataSourceForemanLocationRead(d *schema.ResourceData, meta interface{}) error {
+	log.Tracef("data_source_foreman_location.go#Read")
+
+	client := meta.(*api.Client).Memoized()
+	l := buildForemanLocation(d)
+
+	log.Debugf("ForemanLocation: [%+v]", l)
+
+	if l.Title == "" && l.Name == "" {
+		return fmt.Errorf("Data source location requires either a title or a name")
+	}
+
+	queryResponse, queryErr := client.QueryLocation(l)
+	if queryErr != nil {
+		return queryErr
+	}
+
+	// NOTE(ALL): the location is searched by title if one is given
+	if l.Title != "" {
+		filterQueryResults(d, &queryResponse, l.Title, func(r interface{}) string {
+			result, _ := r.(api.ForemanLocation)
+			return result.Title
+		})
+	} else {
+		filterQueryResults(d, &queryResponse, l.Name, func(r interface{}) string {
+			result, _ := r.(api.ForemanLocation)
+			return result.Name
+		})
+	}
+
+	if queryResponse.Subtotal == 0 {
+		return fmt.Errorf("Data source location returned no results")
+	} else if queryResponse.Subtotal > 1 {
+		return fmt.Errorf("Data source location returned more than 1 result")
+	}
+
+	var queryLocation api.ForemanLocation
+	var ok bool
+	if queryLocation, ok = queryResponse.Results[0].(api.ForemanLocation); !ok {
+		return fmt.Errorf(
+			"Data source results contain unexpected type. Expected "+
+				"[api.ForemanLocation], got [%T]",
+			queryResponse.Results[0],
+		)
+	}
+	l = &queryLocation
+
+	log.Debugf("ForemanLocation: [%+v]", l)
+
+	setResourceDataFromForemanLocation(d, l)
+
+	return nil
+}
@@ -0,0 +1,133 @@
+package foreman
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/HanseMerkur/terraform-provider-foreman/foreman/api"
+	"github.com/wayfair/terraform-provider-utils/autodoc"
+	"github.com/wayfair/terraform-provider-utils/log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceForemanLocations() *schema.Resource {
+	return &schema.Resource{
+
+		Read: dataSourceForemanLocationsRead,
+
+		Schema: map[string]*schema.Schema{
+
+			autodoc.MetaAttribute: &schema.Schema{
+				Type:     schema.TypeBool,
+				Computed: true,
+				Description: fmt.Sprintf(
+					"%s Lists the Foreman locations matching a search filter.",
+					autodoc.MetaSummary,
+				),
+			},
+
+			"search": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Description: fmt.Sprintf(
+					"Foreman search filter applied to the locations. When "+
+						"omitted, every location visible to the provider is returned. "+
+						"%s \"title ~ EU/DE\"",
+					autodoc.MetaExample,
+				),
+			},
+
+			"ids": &schema.Schema{
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeInt},
+				Description: "IDs of the matching locations.",
+			},
+
+			"locations": &schema.Schema{
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        dataSourceForemanLocationsElem(),
+				Description: "The matching locations.",
+			},
+		},
+	}
+}
+
+// dataSourceForemanLocationsElem is the nested resource describing a single
+// entry of the "locations" list.
+func dataSourceForemanLocationsElem() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"id": &schema.Schema{
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Unique identifier for the location.",
+			},
+			"name": &schema.Schema{
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The name of the location.",
+			},
+			"title": &schema.Schema{
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The full title of the location.",
+			},
+			"description": &schema.Schema{
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Description of the location.",
+			},
+			"parent_id": &schema.Schema{
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "ID of the parent location.",
+			},
+		},
+	}
+}
+
+func dataSourceForemanLocationsRead(d *schema.ResourceData, meta interface{}) error {
+	log.Tracef("data_source_foreman_locations.go#Read")
+
+	client := meta.(*api.Client).Memoized()
+	search := d.Get("search").(string)
+
+	log.Debugf("search: [%s]", search)
+
+	queryResponse, queryErr := client.SearchLocations(search)
+	if queryErr != nil {
+		return queryErr
+	}
+
+	ids := make([]int, 0, len(queryResponse.Results))
+	locations := make([]map[string]interface{}, 0, len(queryResponse.Results))
+	for _, result := range queryResponse.Results {
+		l, ok := result.(api.ForemanLocation)
+		if !ok {
+			return fmt.Errorf(
+				"Data source results contain unexpected type. Expected "+
+					"[api.ForemanLocation], got [%T]",
+				result,
+			)
+		}
+		ids = append(ids, l.Id)
+		locations = append(locations, map[string]interface{}{
+			"id":          l.Id,
+			"name":        l.Name,
+			"title":       l.Title,
+			"description": l.Description,
+			"parent_id":   l.ParentId,
+		})
+	}
+
+	log.Debugf("locations: [%+v]", locations)
+
+	d.SetId(strconv.Itoa(schema.HashString(search)))
+	d.Set("ids", ids)
+	d.Set("locations", locations)
+
+	return nil
+}
@@ -5,16 +5,15 @@ import (
 
 	"github.com/HanseMerkur/terraform-provider-foreman/foreman/api"
 	"github.com/wayfair/terraform-provider-utils/autodoc"
-	"github.com/wayfair/terraform-provider-utils/helper"
 	"github.com/wayfair/terraform-provider-utils/log"
 
-	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func dataSourceForemanMedia() *schema.Resource {
 	// copy attributes from resource definition
 	r := resourceForemanMedia()
-	ds := helper.DataSourceSchemaFromResourceSchema(r.Schema)
+	ds := dataSourceSchemaFromResourceSchema(r.Schema)
 
 	// define searchable attributes for the data source
 	ds["name"] = &schema.Schema{
@@ -27,6 +26,8 @@ func dataSourceForemanMedia() *schema.Resource {
 		),
 	}
 
+	ds["exact_match"] = exactMatchSchema()
+
 	return &schema.Resource{
 
 		Read: dataSourceForemanMediaRead,
@@ -39,7 +40,7 @@ func dataSourceForemanMedia() *schema.Resource {
 func dataSourceForemanMediaRead(d *schema.ResourceData, meta interface{}) error {
 	log.Tracef("data_source_foreman_media.go#Read")
 
-	client := meta.(*api.Client)
+	client := meta.(*api.Client).Memoized()
 	m := buildForemanMedia(d)
 
 	log.Debugf("ForemanMedia: [%+v]", m)
@@ -49,6 +50,11 @@ func dataSourceForemanMediaRead(d *schema.ResourceData, meta interface{}) error
 		return queryErr
 	}
 
+	filterQueryResults(d, &queryResponse, m.Name, func(r interface{}) string {
+		result, _ := r.(api.ForemanMedia)
+		return result.Name
+	})
+
 	if queryResponse.Subtotal == 0 {
 		return fmt.Errorf("Data source media returned no results")
 	} else if queryResponse.Subtotal > 1 {
@@ -82,6 +82,12 @@ func DataSourceForemanMediaMockResponseTestCases(t *testing.T) []TestCaseMockRes
 	obj := RandForemanMedia()
 	s := ForemanMediaToInstanceState(obj)
 
+	// NOTE(ALL): the results are filtered by the searched value, so search
+	//   for the object in the single result mock response
+	single := obj
+	single.Name = "Terraform Test Mirror"
+	singleState := ForemanMediaToInstanceState(single)
+
 	return []TestCaseMockResponse{
 		// If the server responds with more than one search result for the data
 		// source read, then the operation should return an error
@@ -112,7 +118,7 @@ func DataSourceForemanMediaMockResponseTestCases(t *testing.T) []TestCaseMockRes
 			TestCase: TestCase{
 				funcName:     "dataSourceForemanMediaRead",
 				crudFunc:     dataSourceForemanMediaRead,
-				resourceData: MockForemanMediaResourceData(s),
+				resourceData: MockForemanMediaResourceData(singleState),
 			},
 			responseFile: MediasTestDataPath + "/query_response_single.json",
 			returnError:  false,
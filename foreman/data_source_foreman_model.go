@@ -5,16 +5,15 @@ import (
 
 	"github.com/HanseMerkur/terraform-provider-foreman/foreman/api"
 	"github.com/wayfair/terraform-provider-utils/autodoc"
-	"github.com/wayfair/terraform-provider-utils/helper"
 	"github.com/wayfair/terraform-provider-utils/log"
 
-	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func dataSourceForemanModel() *schema.Resource {
 	// copy attributes from resource definition
 	r := resourceForemanModel()
-	ds := helper.DataSourceSchemaFromResourceSchema(r.Schema)
+	ds := dataSourceSchemaFromResourceSchema(r.Schema)
 
 	// define searchable attributes for the data source
 	ds["name"] = &schema.Schema{
@@ -27,6 +26,8 @@ func dataSourceForemanModel() *schema.Resource {
 		),
 	}
 
+	ds["exact_match"] = exactMatchSchema()
+
 	return &schema.Resource{
 
 		Read: dataSourceForemanModelRead,
@@ -39,7 +40,7 @@ func dataSourceForemanModel() *schema.Resource {
 func dataSourceForemanModelRead(d *schema.ResourceData, meta interface{}) error {
 	log.Tracef("data_source_foreman_model.go#Read")
 
-	client := meta.(*api.Client)
+	client := meta.(*api.Client).Memoized()
 	m := buildForemanModel(d)
 
 	log.Debugf("ForemanModel: [%+v]", m)
@@ -49,6 +50,11 @@ func dataSourceForemanModelRead(d *schema.ResourceData, meta interface{}) error
 		return queryErr
 	}
 
+	filterQueryResults(d, &queryResponse, m.Name, func(r interface{}) string {
+		result, _ := r.(api.ForemanModel)
+		return result.Name
+	})
+
 	if queryResponse.Subtotal == 0 {
 		return fmt.Errorf("Data source model returned no results")
 	} else if queryResponse.Subtotal > 1 {
@@ -82,6 +82,12 @@ func DataSourceForemanModelMockResponseTestCases(t *testing.T) []TestCaseMockRes
 	obj := RandForemanModel()
 	s := ForemanModelToInstanceState(obj)
 
+	// NOTE(ALL): the results are filtered by the searched value, so search
+	//   for the object in the single result mock response
+	single := obj
+	single.Name = "Terraform Test"
+	singleState := ForemanModelToInstanceState(single)
+
 	return []TestCaseMockResponse{
 		// If the server responds with more than one search result for the data
 		// source read, then the operation should return an error
@@ -112,7 +118,7 @@ func DataSourceForemanModelMockResponseTestCases(t *testing.T) []TestCaseMockRes
 			TestCase: TestCase{
 				funcName:     "dataSourceForemanModelRead",
 				crudFunc:     dataSourceForemanModelRead,
-				resourceData: MockForemanModelResourceData(s),
+				resourceData: MockForemanModelResourceData(singleState),
 			},
 			responseFile: ModelsTestDataPath + "/query_response_single.json",
 			returnError:  false,
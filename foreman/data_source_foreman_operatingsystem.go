@@ -5,29 +5,84 @@ import (
 
 	"github.com/HanseMerkur/terraform-provider-foreman/foreman/api"
 	"github.com/wayfair/terraform-provider-utils/autodoc"
-	"github.com/wayfair/terraform-provider-utils/helper"
 	"github.com/wayfair/terraform-provider-utils/log"
 
-	"github.com/hashicorp/terraform/helper/schema"
+	version "github.com/hashicorp/go-version"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func dataSourceForemanOperatingSystem() *schema.Resource {
 	// copy attributes from resource definition
 	r := resourceForemanOperatingSystem()
-	ds := helper.DataSourceSchemaFromResourceSchema(r.Schema)
+	ds := dataSourceSchemaFromResourceSchema(r.Schema)
 
 	// define searchable attributes for the data source
 	ds["title"] = &schema.Schema{
 		Type:     schema.TypeString,
-		Required: true,
+		Optional: true,
+		Computed: true,
 		Description: fmt.Sprintf(
 			"Title is a Foreman computed property that combines the operating "+
 				"system's name, major, and minor versioning information into a single "+
-				"string. "+
+				"string. When set, the operating system is matched by its exact title "+
+				"and the other search attributes are ignored. "+
 				"%s \"CentOS 7.5\"",
 			autodoc.MetaExample,
 		),
 	}
+	ds["name"] = &schema.Schema{
+		Type:     schema.TypeString,
+		Optional: true,
+		Computed: true,
+		Description: fmt.Sprintf(
+			"Operating system name to match when no title is given. "+
+				"%s \"CentOS\"",
+			autodoc.MetaExample,
+		),
+	}
+	ds["family"] = &schema.Schema{
+		Type:         schema.TypeString,
+		Optional:     true,
+		Computed:     true,
+		ValidateFunc: validation.StringInSlice(operatingSystemFamilies, false),
+		Description: fmt.Sprintf(
+			"Operating system family to match when no title is given. "+
+				"%s \"Redhat\"",
+			autodoc.MetaExample,
+		),
+	}
+	ds["major"] = &schema.Schema{
+		Type:     schema.TypeString,
+		Optional: true,
+		Computed: true,
+		Description: fmt.Sprintf(
+			"Major release version to match when no title is given. "+
+				"%s \"9\"",
+			autodoc.MetaExample,
+		),
+	}
+	ds["version_constraint"] = &schema.Schema{
+		Type:     schema.TypeString,
+		Optional: true,
+		Description: fmt.Sprintf(
+			"Version constraint the operating system's \"<major>.<minor>\" "+
+				"version has to satisfy. Operating systems whose version can not be "+
+				"parsed are skipped. "+
+				"%s \">= 9.2, < 10\"",
+			autodoc.MetaExample,
+		),
+	}
+	ds["most_recent"] = &schema.Schema{
+		Type:     schema.TypeBool,
+		Optional: true,
+		Default:  false,
+		Description: "If more than one operating system matches, use the one " +
+			"with the highest version instead of returning an error. " +
+			"Defaults to `false`.",
+	}
+
+	ds["exact_match"] = exactMatchSchema()
 
 	return &schema.Resource{
 
@@ -38,35 +93,121 @@ func dataSourceForemanOperatingSystem() *schema.Resource {
 	}
 }
 
+// operatingSystemVersion parses the "<major>.<minor>" version of the
+// supplied ForemanOperatingSystem.  An empty minor version is treated as 0.
+func operatingSystemVersion(o api.ForemanOperatingSystem) (*version.Version, error) {
+	minor := o.Minor
+	if minor == "" {
+		minor = "0"
+	}
+	return version.NewVersion(o.Major + "." + minor)
+}
+
+// filterOperatingSystems reduces the supplied query results to the operating
+// systems satisfying the version constraint.  If mostRecent is set, only the
+// operating system with the highest version is kept.  An empty constraint
+// matches every result.
+func filterOperatingSystems(results []interface{}, constraint string, mostRecent bool) ([]api.ForemanOperatingSystem, error) {
+	log.Tracef("data_source_foreman_operatingsystem.go#filterOperatingSystems")
+
+	var constraints version.Constraints
+	if constraint != "" {
+		var constraintErr error
+		if constraints, constraintErr = version.NewConstraint(constraint); constraintErr != nil {
+			return nil, constraintErr
+		}
+	}
+
+	filtered := []api.ForemanOperatingSystem{}
+	var newest *version.Version
+	for _, result := range results {
+		os, ok := result.(api.ForemanOperatingSystem)
+		if !ok {
+			return nil, fmt.Errorf(
+				"Data source results contain unexpected type. Expected "+
+					"[api.ForemanOperatingSystem], got [%T]",
+				result,
+			)
+		}
+
+		if constraints == nil && !mostRecent {
+			filtered = append(filtered, os)
+			continue
+		}
+
+		osVersion, versionErr := operatingSystemVersion(os)
+		if versionErr != nil {
+			log.Debugf("skipping [%s]: [%s]", os.Title, versionErr.Error())
+			continue
+		}
+		if constraints != nil && !constraints.Check(osVersion) {
+			continue
+		}
+		if !mostRecent {
+			filtered = append(filtered, os)
+		} else if newest == nil || osVersion.GreaterThan(newest) {
+			newest = osVersion
+			filtered = []api.ForemanOperatingSystem{os}
+		}
+	}
+
+	return filtered, nil
+}
+
 func dataSourceForemanOperatingSystemRead(d *schema.ResourceData, meta interface{}) error {
 	log.Tracef("data_source_foreman_operatingsystem.go#Read")
 
-	client := meta.(*api.Client)
+	client := meta.(*api.Client).Memoized()
 	o := buildForemanOperatingSystem(d)
 
 	log.Debugf("ForemanOperatingSystem: [%+v]", o)
 
+	if o.Title == "" && o.Name == "" && o.Family == "" && o.Major == "" {
+		return fmt.Errorf(
+			"Data source operating system requires a title or at least one of " +
+				"name, family or major",
+		)
+	}
+
 	queryResponse, queryErr := client.QueryOperatingSystem(o)
 	if queryErr != nil {
 		return queryErr
 	}
 
-	if queryResponse.Subtotal == 0 {
+	// NOTE(ALL): the operating system is searched by title if one is given
+	if o.Title != "" {
+		filterQueryResults(d, &queryResponse, o.Title, func(r interface{}) string {
+			result, _ := r.(api.ForemanOperatingSystem)
+			return result.Title
+		})
+	} else {
+		filterQueryResults(d, &queryResponse, o.Name, func(r interface{}) string {
+			result, _ := r.(api.ForemanOperatingSystem)
+			return result.Name
+		})
+	}
+
+	// NOTE(ALL): use the comma-ok form, the search attributes only exist in
+	//   the data source schema
+	constraint, _ := d.Get("version_constraint").(string)
+	mostRecent, _ := d.Get("most_recent").(bool)
+
+	results, filterErr := filterOperatingSystems(
+		queryResponse.Results,
+		constraint,
+		mostRecent,
+	)
+	if filterErr != nil {
+		return filterErr
+	}
+
+	if len(results) == 0 {
 		return fmt.Errorf("Data source operating system returned no results")
-	} else if queryResponse.Subtotal > 1 {
+	} else if len(results) > 1 {
 		return fmt.Errorf("Data source operating system returned more than 1 result")
 	}
 
-	var queryOS api.ForemanOperatingSystem
-	var ok bool
-	if queryOS, ok = queryResponse.Results[0].(api.ForemanOperatingSystem); !ok {
-		return fmt.Errorf(
-			"Data source results contain unexpected type. Expected "+
-				"[api.ForemanArchitecture], got [%T]",
-			queryResponse.Results[0],
-		)
-	}
-	o = &queryOS
+	o = &results[0]
 
 	log.Debugf("ForemanOperatingSystem: [%+v]", o)
 
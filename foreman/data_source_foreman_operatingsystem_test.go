@@ -3,6 +3,8 @@ package foreman
 import (
 	"net/http"
 	"testing"
+
+	"github.com/HanseMerkur/terraform-provider-foreman/foreman/api"
 )
 
 // ----------------------------------------------------------------------------
@@ -82,6 +84,12 @@ func DataSourceForemanOperatingSystemMockResponseTestCases(t *testing.T) []TestC
 	obj := RandForemanOperatingSystem()
 	s := ForemanOperatingSystemToInstanceState(obj)
 
+	// NOTE(ALL): the results are filtered by the searched value, so search
+	//   for the object in the single result mock response
+	single := obj
+	single.Title = "Centos_SRE_7.3"
+	singleState := ForemanOperatingSystemToInstanceState(single)
+
 	return []TestCaseMockResponse{
 		// If the server responds with more than one search result for the data
 		// source read, then the operation should return an error
@@ -112,7 +120,7 @@ func DataSourceForemanOperatingSystemMockResponseTestCases(t *testing.T) []TestC
 			TestCase: TestCase{
 				funcName:     "dataSourceForemanOperatingSystemRead",
 				crudFunc:     dataSourceForemanOperatingSystemRead,
-				resourceData: MockForemanOperatingSystemResourceData(s),
+				resourceData: MockForemanOperatingSystemResourceData(singleState),
 			},
 			responseFile: OperatingSystemsTestDataPath + "/query_response_single.json",
 			returnError:  false,
@@ -125,3 +133,44 @@ func DataSourceForemanOperatingSystemMockResponseTestCases(t *testing.T) []TestC
 	}
 
 }
+
+// ----------------------------------------------------------------------------
+// filterOperatingSystems
+// ----------------------------------------------------------------------------
+
+// Ensures the version constraint and most_recent selection pick the operating
+// system with the highest matching version
+func TestFilterOperatingSystems_MostRecent(t *testing.T) {
+	results := []interface{}{}
+	for _, v := range [][]string{{"9", "0"}, {"9", "2"}, {"9", "10"}, {"10", ""}, {"9", "beta"}} {
+		os := api.ForemanOperatingSystem{}
+		os.Major = v[0]
+		os.Minor = v[1]
+		results = append(results, os)
+	}
+
+	filtered, err := filterOperatingSystems(results, ">= 9.0, < 10", true)
+	if err != nil {
+		t.Fatalf("filterOperatingSystems returned an error: [%s]", err.Error())
+	}
+	if len(filtered) != 1 || filtered[0].Minor != "10" {
+		t.Fatalf(
+			"filterOperatingSystems did not return the most recent operating "+
+				"system. Expected minor [10], got [%+v]",
+			filtered,
+		)
+	}
+
+	filtered, err = filterOperatingSystems(results, "", false)
+	if err != nil {
+		t.Fatalf("filterOperatingSystems returned an error: [%s]", err.Error())
+	}
+	if len(filtered) != len(results) {
+		t.Fatalf(
+			"filterOperatingSystems filtered without a constraint. Expected "+
+				"[%d] results, got [%d]",
+			len(results),
+			len(filtered),
+		)
+	}
+}
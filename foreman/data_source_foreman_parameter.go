@@ -5,16 +5,15 @@ import (
 
 	"github.com/HanseMerkur/terraform-provider-foreman/foreman/api"
 	"github.com/wayfair/terraform-provider-utils/autodoc"
-	"github.com/wayfair/terraform-provider-utils/helper"
 	"github.com/wayfair/terraform-provider-utils/log"
 
-	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func dataSourceForemanParameter() *schema.Resource {
 	// copy attributes from resource definition
 	r := resourceForemanParameter()
-	ds := helper.DataSourceSchemaFromResourceSchema(r.Schema)
+	ds := dataSourceSchemaFromResourceSchema(r.Schema)
 
 	// define searchable attributes for the data source
 	ds["name"] = &schema.Schema{
@@ -27,6 +26,8 @@ func dataSourceForemanParameter() *schema.Resource {
 		),
 	}
 
+	ds["exact_match"] = exactMatchSchema()
+
 	return &schema.Resource{
 
 		Read: dataSourceForemanParameterRead,
@@ -39,7 +40,7 @@ func dataSourceForemanParameter() *schema.Resource {
 func dataSourceForemanParameterRead(d *schema.ResourceData, meta interface{}) error {
 	log.Tracef("data_source_foreman_parameter.go#Read")
 
-	client := meta.(*api.Client)
+	client := meta.(*api.Client).Memoized()
 	parameter := buildForemanParameter(d)
 
 	log.Debugf("ForemanParameter: [%+v]", parameter)
@@ -49,6 +50,11 @@ func dataSourceForemanParameterRead(d *schema.ResourceData, meta interface{}) er
 		return queryErr
 	}
 
+	filterQueryResults(d, &queryResponse, parameter.Name, func(r interface{}) string {
+		result, _ := r.(api.ForemanParameter)
+		return result.Name
+	})
+
 	if queryResponse.Subtotal == 0 {
 		return fmt.Errorf("Data source parameter returned no results")
 	} else if queryResponse.Subtotal > 1 {
@@ -5,16 +5,15 @@ import (
 
 	"github.com/HanseMerkur/terraform-provider-foreman/foreman/api"
 	"github.com/wayfair/terraform-provider-utils/autodoc"
-	"github.com/wayfair/terraform-provider-utils/helper"
 	"github.com/wayfair/terraform-provider-utils/log"
 
-	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func dataSourceForemanPartitionTable() *schema.Resource {
 	// copy attributes from resource definition
 	r := resourceForemanPartitionTable()
-	ds := helper.DataSourceSchemaFromResourceSchema(r.Schema)
+	ds := dataSourceSchemaFromResourceSchema(r.Schema)
 
 	// define searchable attributes for the data source
 	ds["name"] = &schema.Schema{
@@ -27,6 +26,8 @@ func dataSourceForemanPartitionTable() *schema.Resource {
 		),
 	}
 
+	ds["exact_match"] = exactMatchSchema()
+
 	return &schema.Resource{
 
 		Read: dataSourceForemanPartitionTableRead,
@@ -39,7 +40,7 @@ func dataSourceForemanPartitionTable() *schema.Resource {
 func dataSourceForemanPartitionTableRead(d *schema.ResourceData, meta interface{}) error {
 	log.Tracef("data_source_foreman_partitiontable.go#Read")
 
-	client := meta.(*api.Client)
+	client := meta.(*api.Client).Memoized()
 	t := buildForemanPartitionTable(d)
 
 	log.Debugf("ForemanPartitionTable: [%+v]", t)
@@ -49,6 +50,11 @@ func dataSourceForemanPartitionTableRead(d *schema.ResourceData, meta interface{
 		return queryErr
 	}
 
+	filterQueryResults(d, &queryResponse, t.Name, func(r interface{}) string {
+		result, _ := r.(api.ForemanPartitionTable)
+		return result.Name
+	})
+
 	if queryResponse.Subtotal == 0 {
 		return fmt.Errorf("Data source partition table returned no results")
 	} else if queryResponse.Subtotal > 1 {
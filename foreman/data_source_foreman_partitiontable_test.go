@@ -6,8 +6,8 @@ import (
 
 	"github.com/HanseMerkur/terraform-provider-foreman/foreman/api"
 
-	"github.com/hashicorp/terraform/helper/schema"
-	"github.com/hashicorp/terraform/terraform"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
 
 // ----------------------------------------------------------------------------
@@ -87,6 +87,12 @@ func DataSourceForemanPartitionTableMockResponseTestCases(t *testing.T) []TestCa
 	obj := RandForemanPartitionTable()
 	s := ForemanPartitionTableToInstanceState(obj)
 
+	// NOTE(ALL): the results are filtered by the searched value, so search
+	//   for the object in the single result mock response
+	single := obj
+	single.Name = "Terraform Test Partition Table"
+	singleState := ForemanPartitionTableToInstanceState(single)
+
 	testCases := []TestCaseMockResponse{}
 
 	var expectedObj api.ForemanPartitionTable
@@ -141,7 +147,7 @@ func DataSourceForemanPartitionTableMockResponseTestCases(t *testing.T) []TestCa
 			TestCase: TestCase{
 				funcName:     "dataSourceForemanPartitionTableRead",
 				crudFunc:     dataSourceForemanPartitionTableRead,
-				resourceData: MockForemanPartitionTableResourceData(s),
+				resourceData: MockForemanPartitionTableResourceData(singleState),
 			},
 			responseFile:         PartitionTablesTestDataPath + "/query_response_single.json",
 			returnError:          false,
@@ -5,16 +5,15 @@ import (
 
 	"github.com/HanseMerkur/terraform-provider-foreman/foreman/api"
 	"github.com/wayfair/terraform-provider-utils/autodoc"
-	"github.com/wayfair/terraform-provider-utils/helper"
 	"github.com/wayfair/terraform-provider-utils/log"
 
-	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func dataSourceForemanProvisioningTemplate() *schema.Resource {
 	// copy attributes from resource definition
 	r := resourceForemanProvisioningTemplate()
-	ds := helper.DataSourceSchemaFromResourceSchema(r.Schema)
+	ds := dataSourceSchemaFromResourceSchema(r.Schema)
 
 	// define searchable attributes for the data source
 	ds["name"] = &schema.Schema{
@@ -27,6 +26,8 @@ func dataSourceForemanProvisioningTemplate() *schema.Resource {
 		),
 	}
 
+	ds["exact_match"] = exactMatchSchema()
+
 	return &schema.Resource{
 
 		Read: dataSourceForemanProvisioningTemplateRead,
@@ -39,7 +40,7 @@ func dataSourceForemanProvisioningTemplate() *schema.Resource {
 func dataSourceForemanProvisioningTemplateRead(d *schema.ResourceData, meta interface{}) error {
 	log.Tracef("data_source_foreman_provisioningtemplate.go#Read")
 
-	client := meta.(*api.Client)
+	client := meta.(*api.Client).Memoized()
 	t := buildForemanProvisioningTemplate(d)
 
 	log.Debugf("ForemanProvisioningTemplate: [%+v]", t)
@@ -49,6 +50,11 @@ func dataSourceForemanProvisioningTemplateRead(d *schema.ResourceData, meta inte
 		return queryErr
 	}
 
+	filterQueryResults(d, &queryResponse, t.Name, func(r interface{}) string {
+		result, _ := r.(api.ForemanProvisioningTemplate)
+		return result.Name
+	})
+
 	if queryResponse.Subtotal == 0 {
 		return fmt.Errorf("Data source provisioning template returned no results")
 	} else if queryResponse.Subtotal > 1 {
@@ -82,6 +82,12 @@ func DataSourceForemanProvisioningTemplateMockResponseTestCases(t *testing.T) []
 	obj := RandForemanProvisioningTemplate()
 	s := ForemanProvisioningTemplateToInstanceState(obj)
 
+	// NOTE(ALL): the results are filtered by the searched value, so search
+	//   for the object in the single result mock response
+	single := obj
+	single.Name = "CentOS 6.7 - BO1 BIOS BOOT"
+	singleState := ForemanProvisioningTemplateToInstanceState(single)
+
 	return []TestCaseMockResponse{
 		// If the server responds with more than one search result for the data
 		// source read, then the operation should return an error
@@ -112,7 +118,7 @@ func DataSourceForemanProvisioningTemplateMockResponseTestCases(t *testing.T) []
 			TestCase: TestCase{
 				funcName:     "dataSourceForemanProvisioningTemplateRead",
 				crudFunc:     dataSourceForemanProvisioningTemplateRead,
-				resourceData: MockForemanProvisioningTemplateResourceData(s),
+				resourceData: MockForemanProvisioningTemplateResourceData(singleState),
 			},
 			responseFile: ProvisioningTemplatesTestDataPath + "/query_response_single.json",
 			returnError:  false,
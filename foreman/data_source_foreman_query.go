@@ -0,0 +1,130 @@
+package foreman
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/HanseMerkur/terraform-provider-foreman/foreman/api"
+	"github.com/wayfair/terraform-provider-utils/autodoc"
+	"github.com/wayfair/terraform-provider-utils/log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceForemanQuery() *schema.Resource {
+	return &schema.Resource{
+
+		Read: dataSourceForemanQueryRead,
+
+		Schema: map[string]*schema.Schema{
+
+			autodoc.MetaAttribute: &schema.Schema{
+				Type:     schema.TypeBool,
+				Computed: true,
+				Description: fmt.Sprintf(
+					"%s Generic search against any Foreman API collection. Use this "+
+						"to consume API objects the provider does not model yet.",
+					autodoc.MetaSummary,
+				),
+			},
+
+			"endpoint": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				Description: fmt.Sprintf(
+					"The collection endpoint, relative to `/api`. "+
+						"%s \"realms\"",
+					autodoc.MetaExample,
+				),
+			},
+
+			"search": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Description: fmt.Sprintf(
+					"Foreman search filter applied to the collection. "+
+						"%s \"name ~ dc1\"",
+					autodoc.MetaExample,
+				),
+			},
+
+			"results_json": &schema.Schema{
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The raw results returned by Foreman, encoded as JSON.",
+			},
+
+			"results": &schema.Schema{
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        dataSourceForemanQueryElem(),
+				Description: "ID and name pairs of the returned objects.",
+			},
+		},
+	}
+}
+
+// dataSourceForemanQueryElem is the nested resource describing a single
+// entry of the "results" list.
+func dataSourceForemanQueryElem() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"id": &schema.Schema{
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Unique identifier of the object.",
+			},
+			"name": &schema.Schema{
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Name of the object.",
+			},
+		},
+	}
+}
+
+func dataSourceForemanQueryRead(d *schema.ResourceData, meta interface{}) error {
+	log.Tracef("data_source_foreman_query.go#Read")
+
+	client := meta.(*api.Client)
+	endpoint := d.Get("endpoint").(string)
+	search := d.Get("search").(string)
+
+	log.Debugf("endpoint: [%s], search: [%s]", endpoint, search)
+
+	queryResponse, queryErr := client.Query(endpoint, search)
+	if queryErr != nil {
+		return queryErr
+	}
+
+	resultsBytes, jsonEncErr := json.Marshal(queryResponse.Results)
+	if jsonEncErr != nil {
+		return jsonEncErr
+	}
+
+	results := make([]map[string]interface{}, 0, len(queryResponse.Results))
+	for _, result := range queryResponse.Results {
+		resultMap, ok := result.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf(
+				"Data source results contain unexpected type. Expected "+
+					"[map[string]interface{}], got [%T]",
+				result,
+			)
+		}
+		// NOTE(ALL): JSON numbers are decoded as float64
+		id, _ := resultMap["id"].(float64)
+		name, _ := resultMap["name"].(string)
+		results = append(results, map[string]interface{}{
+			"id":   int(id),
+			"name": name,
+		})
+	}
+
+	d.SetId(strconv.Itoa(schema.HashString(endpoint + "?" + search)))
+	d.Set("results_json", string(resultsBytes))
+	d.Set("results", results)
+
+	return nil
+}
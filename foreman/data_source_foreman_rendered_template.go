@@ -0,0 +1,78 @@
+package foreman
+
+import (
+	"fmt"
+
+	"github.com/HanseMerkur/terraform-provider-foreman/foreman/api"
+	"github.com/wayfair/terraform-provider-utils/autodoc"
+	"github.com/wayfair/terraform-provider-utils/log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func dataSourceForemanRenderedTemplate() *schema.Resource {
+	return &schema.Resource{
+
+		Read: dataSourceForemanRenderedTemplateRead,
+
+		Schema: map[string]*schema.Schema{
+
+			autodoc.MetaAttribute: &schema.Schema{
+				Type:     schema.TypeBool,
+				Computed: true,
+				Description: fmt.Sprintf(
+					"%s The provisioning template Foreman renders for a host. The "+
+						"rendered output can be reused as cloud-init user data or "+
+						"verified as part of CI.",
+					autodoc.MetaSummary,
+				),
+			},
+
+			"host_id": &schema.Schema{
+				Type:         schema.TypeInt,
+				Required:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+				Description:  "ID of the host the template is rendered for.",
+			},
+
+			"template_kind": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				Description: fmt.Sprintf(
+					"The kind of the template to render. Foreman selects the "+
+						"template of this kind associated with the host's operating "+
+						"system, hostgroup and environment. "+
+						"%s \"provision\"",
+					autodoc.MetaExample,
+				),
+			},
+
+			"rendered": &schema.Schema{
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The rendered template content.",
+			},
+		},
+	}
+}
+
+func dataSourceForemanRenderedTemplateRead(d *schema.ResourceData, meta interface{}) error {
+	log.Tracef("data_source_foreman_rendered_template.go#Read")
+
+	client := meta.(*api.Client)
+	hostId := d.Get("host_id").(int)
+	kind := d.Get("template_kind").(string)
+
+	log.Debugf("host_id: [%d], template_kind: [%s]", hostId, kind)
+
+	rendered, renderErr := client.RenderHostTemplate(hostId, kind)
+	if renderErr != nil {
+		return renderErr
+	}
+
+	d.SetId(fmt.Sprintf("%d/%s", hostId, kind))
+	d.Set("rendered", rendered)
+
+	return nil
+}
@@ -5,16 +5,15 @@ import (
 
 	"github.com/HanseMerkur/terraform-provider-foreman/foreman/api"
 	"github.com/wayfair/terraform-provider-utils/autodoc"
-	"github.com/wayfair/terraform-provider-utils/helper"
 	"github.com/wayfair/terraform-provider-utils/log"
 
-	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func dataSourceForemanSmartProxy() *schema.Resource {
 	// copy attributes from resource definition
 	r := resourceForemanSmartProxy()
-	ds := helper.DataSourceSchemaFromResourceSchema(r.Schema)
+	ds := dataSourceSchemaFromResourceSchema(r.Schema)
 
 	// define searchable attributes for the data source
 	ds["name"] = &schema.Schema{
@@ -27,6 +26,8 @@ func dataSourceForemanSmartProxy() *schema.Resource {
 		),
 	}
 
+	ds["exact_match"] = exactMatchSchema()
+
 	return &schema.Resource{
 
 		Read: dataSourceForemanSmartProxyRead,
@@ -39,7 +40,7 @@ func dataSourceForemanSmartProxy() *schema.Resource {
 func dataSourceForemanSmartProxyRead(d *schema.ResourceData, meta interface{}) error {
 	log.Tracef("data_source_foreman_smartproxy.go#Read")
 
-	client := meta.(*api.Client)
+	client := meta.(*api.Client).Memoized()
 	s := buildForemanSmartProxy(d)
 
 	log.Debugf("ForemanSmartProxy: [%+v]", s)
@@ -49,6 +50,11 @@ func dataSourceForemanSmartProxyRead(d *schema.ResourceData, meta interface{}) e
 		return queryErr
 	}
 
+	filterQueryResults(d, &queryResponse, s.Name, func(r interface{}) string {
+		result, _ := r.(api.ForemanSmartProxy)
+		return result.Name
+	})
+
 	if queryResponse.Subtotal == 0 {
 		return fmt.Errorf("Data source smart proxy returned no results")
 	} else if queryResponse.Subtotal > 1 {
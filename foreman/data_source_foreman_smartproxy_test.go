@@ -82,6 +82,12 @@ func DataSourceForemanSmartProxyMockResponseTestCases(t *testing.T) []TestCaseMo
 	obj := RandForemanSmartProxy()
 	s := ForemanSmartProxyToInstanceState(obj)
 
+	// NOTE(ALL): the results are filtered by the searched value, so search
+	//   for the object in the single result mock response
+	single := obj
+	single.Name = "smartproxy01.dev.dc1.company.com"
+	singleState := ForemanSmartProxyToInstanceState(single)
+
 	return []TestCaseMockResponse{
 		// If the server responds with more than one search result for the data
 		// source read, then the operation should return an error
@@ -112,7 +118,7 @@ func DataSourceForemanSmartProxyMockResponseTestCases(t *testing.T) []TestCaseMo
 			TestCase: TestCase{
 				funcName:     "dataSourceForemanSmartProxyRead",
 				crudFunc:     dataSourceForemanSmartProxyRead,
-				resourceData: MockForemanSmartProxyResourceData(s),
+				resourceData: MockForemanSmartProxyResourceData(singleState),
 			},
 			responseFile: SmartProxiesTestDataPath + "/query_response_single.json",
 			returnError:  false,
@@ -5,16 +5,15 @@ import (
 
 	"github.com/HanseMerkur/terraform-provider-foreman/foreman/api"
 	"github.com/wayfair/terraform-provider-utils/autodoc"
-	"github.com/wayfair/terraform-provider-utils/helper"
 	"github.com/wayfair/terraform-provider-utils/log"
 
-	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func dataSourceForemanSubnet() *schema.Resource {
 	// copy attributes from resource definition
 	r := resourceForemanSubnet()
-	ds := helper.DataSourceSchemaFromResourceSchema(r.Schema)
+	ds := dataSourceSchemaFromResourceSchema(r.Schema)
 
 	// define searchable attributes for the data source
 	ds["network"] = &schema.Schema{
@@ -37,6 +36,8 @@ func dataSourceForemanSubnet() *schema.Resource {
 		),
 	}
 
+	ds["exact_match"] = exactMatchSchema()
+
 	return &schema.Resource{
 
 		Read: dataSourceForemanSubnetRead,
@@ -49,7 +50,7 @@ func dataSourceForemanSubnet() *schema.Resource {
 func dataSourceForemanSubnetRead(d *schema.ResourceData, meta interface{}) error {
 	log.Tracef("data_source_foreman_subnet.go#Read")
 
-	client := meta.(*api.Client)
+	client := meta.(*api.Client).Memoized()
 	s := buildForemanSubnet(d)
 
 	log.Debugf("ForemanSubnet: [%+v]", s)
@@ -59,6 +60,19 @@ func dataSourceForemanSubnetRead(d *schema.ResourceData, meta interface{}) error
 		return queryErr
 	}
 
+	// NOTE(ALL): the subnet is searched by name if one is given
+	if s.Name != "" {
+		filterQueryResults(d, &queryResponse, s.Name, func(r interface{}) string {
+			result, _ := r.(api.ForemanSubnet)
+			return result.Name
+		})
+	} else {
+		filterQueryResults(d, &queryResponse, s.Network, func(r interface{}) string {
+			result, _ := r.(api.ForemanSubnet)
+			return result.Network
+		})
+	}
+
 	if queryResponse.Subtotal == 0 {
 		return fmt.Errorf("Data source subnet returned no results")
 	} else if queryResponse.Subtotal > 1 {
@@ -82,6 +82,12 @@ func DataSourceForemanSubnetMockResponseTestCases(t *testing.T) []TestCaseMockRe
 	obj := RandForemanSubnet()
 	s := ForemanSubnetToInstanceState(obj)
 
+	// NOTE(ALL): the results are filtered by the searched value, so search
+	//   for the object in the single result mock response
+	single := obj
+	single.Name = "10.228.192.0 DC1"
+	singleState := ForemanSubnetToInstanceState(single)
+
 	return []TestCaseMockResponse{
 		// If the server responds with more than one search result for the data
 		// source read, then the operation should return an error
@@ -112,7 +118,7 @@ func DataSourceForemanSubnetMockResponseTestCases(t *testing.T) []TestCaseMockRe
 			TestCase: TestCase{
 				funcName:     "dataSourceForemanSubnetRead",
 				crudFunc:     dataSourceForemanSubnetRead,
-				resourceData: MockForemanSubnetResourceData(s),
+				resourceData: MockForemanSubnetResourceData(singleState),
 			},
 			responseFile: SubnetsTestDataPath + "/query_response_single.json",
 			returnError:  false,
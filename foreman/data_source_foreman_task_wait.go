@@ -0,0 +1,37 @@
+package foreman
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/wayfair/terraform-provider-utils/autodoc"
+	"github.com/wayfair/terraform-provider-utils/log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceForemanTaskWait() *schema.Resource {
+	r := &schema.Resource{
+
+		ReadContext: withDiagnostics(dataSourceForemanTaskWaitRead),
+
+		Schema: taskWaitSchema(false),
+	}
+	r.Schema[autodoc.MetaAttribute] = &schema.Schema{
+		Type:     schema.TypeBool,
+		Computed: true,
+		Description: fmt.Sprintf(
+			"%s Waits until a Foreman or Katello task succeeds, each time the "+
+				"data source is read. A task which fails or does not finish "+
+				"within the timeout fails the plan or apply.",
+			autodoc.MetaSummary,
+		),
+	}
+	return r
+}
+
+func dataSourceForemanTaskWaitRead(ctx context.Context, d *schema.ResourceData, meta interface{}) error {
+	log.Tracef("data_source_foreman_task_wait.go#Read")
+
+	return waitForForemanTask(ctx, d, meta)
+}
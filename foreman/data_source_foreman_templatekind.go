@@ -8,7 +8,7 @@ import (
 	"github.com/wayfair/terraform-provider-utils/autodoc"
 	"github.com/wayfair/terraform-provider-utils/log"
 
-	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func dataSourceForemanTemplateKind() *schema.Resource {
@@ -27,6 +27,11 @@ func dataSourceForemanTemplateKind() *schema.Resource {
 					autodoc.MetaExample,
 				),
 			},
+
+			"exact_match": exactMatchSchema(),
+
+			"created_at": createdAtSchema(),
+			"updated_at": updatedAtSchema(),
 		},
 	}
 }
@@ -50,6 +55,7 @@ func buildForemanTemplateKind(d *schema.ResourceData) *api.ForemanTemplateKind {
 // the attributes of the supplied ForemanTemplateKind reference
 func setResourceDataFromForemanTemplateKind(d *schema.ResourceData, fk *api.ForemanTemplateKind) {
 	d.SetId(strconv.Itoa(fk.Id))
+	setResourceDataFromForemanObjectTimestamps(d, &fk.ForemanObject)
 	d.Set("name", fk.Name)
 }
 
@@ -60,7 +66,7 @@ func setResourceDataFromForemanTemplateKind(d *schema.ResourceData, fk *api.Fore
 func dataSourceForemanTemplateKindRead(d *schema.ResourceData, meta interface{}) error {
 	log.Tracef("data_source_foreman_architecture.go#Read")
 
-	client := meta.(*api.Client)
+	client := meta.(*api.Client).Memoized()
 	t := buildForemanTemplateKind(d)
 
 	log.Debugf("ForemanTemplateKind: [%+v]", t)
@@ -70,6 +76,11 @@ func dataSourceForemanTemplateKindRead(d *schema.ResourceData, meta interface{})
 		return queryErr
 	}
 
+	filterQueryResults(d, &queryResponse, t.Name, func(r interface{}) string {
+		result, _ := r.(api.ForemanTemplateKind)
+		return result.Name
+	})
+
 	if queryResponse.Subtotal == 0 {
 		return fmt.Errorf("Data source template kind returned no results")
 	} else if queryResponse.Subtotal > 1 {
@@ -7,8 +7,8 @@ import (
 
 	"github.com/HanseMerkur/terraform-provider-foreman/foreman/api"
 
-	"github.com/hashicorp/terraform/helper/schema"
-	"github.com/hashicorp/terraform/terraform"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
 
 // -----------------------------------------------------------------------------
@@ -24,6 +24,8 @@ func ForemanTemplateKindToInstanceState(obj api.ForemanTemplateKind) *terraform.
 	state.ID = strconv.Itoa(obj.Id)
 	// Build the attribute map from ForemanTemplateKind
 	attr := map[string]string{}
+	attr["created_at"] = obj.CreatedAt
+	attr["updated_at"] = obj.UpdatedAt
 	attr["name"] = obj.Name
 	state.Attributes = attr
 	return &state
@@ -158,6 +160,12 @@ func DataSourceForemanTemplateKindMockResponseTestCases(t *testing.T) []TestCase
 	obj := RandForemanTemplateKind()
 	s := ForemanTemplateKindToInstanceState(obj)
 
+	// NOTE(ALL): the results are filtered by the searched value, so search
+	//   for the object in the single result mock response
+	single := obj
+	single.Name = "provision"
+	singleState := ForemanTemplateKindToInstanceState(single)
+
 	return []TestCaseMockResponse{
 		// If the server responds with more than one search result for the data
 		// source read, then the operation should return an error
@@ -188,7 +196,7 @@ func DataSourceForemanTemplateKindMockResponseTestCases(t *testing.T) []TestCase
 			TestCase: TestCase{
 				funcName:     "dataSourceForemanTemplateKindRead",
 				crudFunc:     dataSourceForemanTemplateKindRead,
-				resourceData: MockForemanTemplateKindResourceData(s),
+				resourceData: MockForemanTemplateKindResourceData(singleState),
 			},
 			responseFile: TemplateKindsTestDataPath + "/query_response_single.json",
 			returnError:  false,
@@ -0,0 +1,88 @@
+package foreman
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/HanseMerkur/terraform-provider-foreman/foreman/api"
+	"github.com/wayfair/terraform-provider-utils/autodoc"
+	"github.com/wayfair/terraform-provider-utils/log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func dataSourceForemanUnattendedPreview() *schema.Resource {
+	return &schema.Resource{
+
+		Read: dataSourceForemanUnattendedPreviewRead,
+
+		Schema: map[string]*schema.Schema{
+
+			autodoc.MetaAttribute: &schema.Schema{
+				Type:     schema.TypeBool,
+				Computed: true,
+				Description: fmt.Sprintf(
+					"%s The output of Foreman's unattended endpoint for a host in "+
+						"build mode, ie: the exact kickstart or preseed the host "+
+						"receives once it is powered on. Unlike "+
+						"`foreman_rendered_template`, the host is identified by its "+
+						"build token, the same way the installer requests it. Use it "+
+						"to assert the provisioning output in CI before powering the "+
+						"host on.",
+					autodoc.MetaSummary,
+				),
+			},
+
+			"token": &schema.Schema{
+				Type:         schema.TypeString,
+				Required:     true,
+				Sensitive:    true,
+				ValidateFunc: validation.StringIsNotWhiteSpace,
+				Description: "Build token of the host. Foreman issues the token " +
+					"when the host enters build mode and passes it to the host in " +
+					"the URLs of its templates.",
+			},
+
+			"template_kind": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "provision",
+				Description: fmt.Sprintf(
+					"The kind of the template served. Defaults to "+
+						"`\"provision\"`. %s \"finish\"",
+					autodoc.MetaExample,
+				),
+			},
+
+			"rendered": &schema.Schema{
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The content served to the host.",
+			},
+		},
+	}
+}
+
+func dataSourceForemanUnattendedPreviewRead(d *schema.ResourceData, meta interface{}) error {
+	log.Tracef("data_source_foreman_unattended_preview.go#Read")
+
+	client := meta.(*api.Client)
+	token := d.Get("token").(string)
+	kind := d.Get("template_kind").(string)
+
+	log.Debugf("template_kind: [%s]", kind)
+
+	rendered, renderErr := client.RenderUnattendedTemplate(token, kind)
+	if renderErr != nil {
+		return renderErr
+	}
+
+	// NOTE(ALL): the ID must not reveal the token
+	sum := sha256.Sum256([]byte(token))
+	d.SetId(fmt.Sprintf("%s/%s", hex.EncodeToString(sum[:8]), kind))
+	d.Set("rendered", rendered)
+
+	return nil
+}
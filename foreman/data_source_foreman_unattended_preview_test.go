@@ -0,0 +1,56 @@
+package foreman
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/HanseMerkur/terraform-provider-foreman/foreman/api"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// -----------------------------------------------------------------------------
+// dataSourceForemanUnattendedPreview
+// -----------------------------------------------------------------------------
+
+// Ensures the template is fetched from the unattended endpoint with the build
+// token, without the token leaking into the ID or errors
+func TestDataSourceForemanUnattendedPreview(t *testing.T) {
+
+	mux, server, client := NewForemanAPIAndClient(api.ClientCredentials{}, api.ClientConfig{})
+	defer server.Close()
+
+	mux.HandleFunc("/unattended/provision", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("token") != "aaaa-1111" {
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, "unable to find host")
+			return
+		}
+		fmt.Fprint(w, "# kickstart\ninstall\nreboot\n")
+	})
+
+	r := dataSourceForemanUnattendedPreview()
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"token": "aaaa-1111",
+	})
+	if readErr := r.Read(d, client); readErr != nil {
+		t.Fatalf("expected no error, got [%s]", readErr)
+	}
+	if rendered := d.Get("rendered").(string); rendered != "# kickstart\ninstall\nreboot\n" {
+		t.Fatalf("expected the kickstart of the host, got [%s]", rendered)
+	}
+	if strings.Contains(d.Id(), "aaaa-1111") {
+		t.Fatalf("expected the ID not to contain the token, got [%s]", d.Id())
+	}
+
+	d = schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"token": "bbbb-2222",
+	})
+	readErr := r.Read(d, client)
+	if !api.IsNotFound(readErr) || strings.Contains(readErr.Error(), "bbbb-2222") {
+		t.Fatalf("expected a not found error without the token, got [%v]", readErr)
+	}
+
+}
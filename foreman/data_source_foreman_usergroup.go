@@ -0,0 +1,177 @@
+package foreman
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/HanseMerkur/terraform-provider-foreman/foreman/api"
+	"github.com/wayfair/terraform-provider-utils/autodoc"
+	"github.com/wayfair/terraform-provider-utils/log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceForemanUsergroup() *schema.Resource {
+	return &schema.Resource{
+
+		Read: dataSourceForemanUsergroupRead,
+
+		Schema: map[string]*schema.Schema{
+
+			autodoc.MetaAttribute: &schema.Schema{
+				Type:     schema.TypeBool,
+				Computed: true,
+				Description: fmt.Sprintf(
+					"%s Foreman representation of a usergroup. Usergroups can own "+
+						"hosts and have roles assigned.",
+					autodoc.MetaSummary,
+				),
+			},
+
+			"name": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				Description: fmt.Sprintf(
+					"The name of the usergroup. "+
+						"%s \"sre\"",
+					autodoc.MetaExample,
+				),
+			},
+
+			"admin": &schema.Schema{
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether or not the members of the usergroup are administrators.",
+			},
+
+			"external_usergroups": &schema.Schema{
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        dataSourceForemanExternalUsergroupElem(),
+				Description: "Groups of external authentication sources mapped to the usergroup.",
+			},
+
+			"exact_match": exactMatchSchema(),
+
+			"created_at": createdAtSchema(),
+			"updated_at": updatedAtSchema(),
+		},
+	}
+}
+
+// dataSourceForemanExternalUsergroupElem is the nested resource describing a
+// single entry of the "external_usergroups" list.
+func dataSourceForemanExternalUsergroupElem() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"id": &schema.Schema{
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Unique identifier of the external usergroup mapping.",
+			},
+			"name": &schema.Schema{
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Name of the group in the external authentication source.",
+			},
+			"auth_source_id": &schema.Schema{
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "ID of the external authentication source.",
+			},
+		},
+	}
+}
+
+// -----------------------------------------------------------------------------
+// Conversion Helpers
+// -----------------------------------------------------------------------------
+
+// buildForemanUsergroup constructs a ForemanUsergroup reference from a
+// resource data reference.  The struct's  members are populated from the data
+// populated in the resource data.  Missing members will be left to the zero
+// value for that member's type.
+func buildForemanUsergroup(d *schema.ResourceData) *api.ForemanUsergroup {
+	log.Tracef("data_source_foreman_usergroup.go#buildForemanUsergroup")
+
+	usergroup := api.ForemanUsergroup{}
+
+	obj := buildForemanObject(d)
+	usergroup.ForemanObject = *obj
+
+	return &usergroup
+}
+
+// setResourceDataFromForemanUsergroup sets a ResourceData's attributes from
+// the attributes of the supplied ForemanUsergroup reference
+func setResourceDataFromForemanUsergroup(d *schema.ResourceData, fu *api.ForemanUsergroup) {
+	log.Tracef("data_source_foreman_usergroup.go#setResourceDataFromForemanUsergroup")
+
+	d.SetId(strconv.Itoa(fu.Id))
+	setResourceDataFromForemanObjectTimestamps(d, &fu.ForemanObject)
+	d.Set("name", fu.Name)
+	d.Set("admin", fu.Admin)
+
+	extArr := make([]map[string]interface{}, len(fu.ExternalUsergroups))
+	for idx, val := range fu.ExternalUsergroups {
+		extArr[idx] = map[string]interface{}{
+			"id":             val.Id,
+			"name":           val.Name,
+			"auth_source_id": val.AuthSourceId,
+		}
+	}
+	d.Set("external_usergroups", extArr)
+}
+
+// -----------------------------------------------------------------------------
+// Data Source Read Operation
+// -----------------------------------------------------------------------------
+
+func dataSourceForemanUsergroupRead(d *schema.ResourceData, meta interface{}) error {
+	log.Tracef("data_source_foreman_usergroup.go#Read")
+
+	client := meta.(*api.Client).Memoized()
+	u := buildForemanUsergroup(d)
+
+	log.Debugf("ForemanUsergroup: [%+v]", u)
+
+	queryResponse, queryErr := client.QueryUsergroup(u)
+	if queryErr != nil {
+		return queryErr
+	}
+
+	filterQueryResults(d, &queryResponse, u.Name, func(r interface{}) string {
+		result, _ := r.(api.ForemanUsergroup)
+		return result.Name
+	})
+
+	if queryResponse.Subtotal == 0 {
+		return fmt.Errorf("Data source usergroup returned no results")
+	} else if queryResponse.Subtotal > 1 {
+		return fmt.Errorf("Data source usergroup returned more than 1 result")
+	}
+
+	var queryUsergroup api.ForemanUsergroup
+	var ok bool
+	if queryUsergroup, ok = queryResponse.Results[0].(api.ForemanUsergroup); !ok {
+		return fmt.Errorf(
+			"Data source results contain unexpected type. Expected "+
+				"[api.ForemanUsergroup], got [%T]",
+			queryResponse.Results[0],
+		)
+	}
+
+	// NOTE(ALL): the search results do not include the external usergroup
+	//   mappings - read the usergroup itself to get them
+	readUsergroup, readErr := client.ReadUsergroup(queryUsergroup.Id)
+	if readErr != nil {
+		return readErr
+	}
+	u = readUsergroup
+
+	log.Debugf("ForemanUsergroup: [%+v]", u)
+
+	setResourceDataFromForemanUsergroup(d, u)
+
+	return nil
+}
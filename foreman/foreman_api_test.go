@@ -14,7 +14,7 @@ import (
 	"github.com/HanseMerkur/terraform-provider-foreman/foreman/api"
 	tfrand "github.com/wayfair/terraform-provider-utils/rand"
 
-	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 // ----------------------------------------------------------------------------
@@ -695,3 +695,54 @@ func TestCRUDFunction_MockResponse(t *testing.T) {
 
 	} //end for
 }
+
+// TestCRUDFunction_ReadNotFound ensures a resource that no longer exists in
+// Foreman is removed from the state instead of failing the refresh.  The mock
+// server will respond with a http.StatusNotFound.  The test will fail if the
+// read function returns an error or does not clear the resource's ID.
+func TestCRUDFunction_ReadNotFound(t *testing.T) {
+	obj := api.ForemanArchitecture{}
+	obj.Id = rand.Intn(100) + 1
+	s := ForemanArchitectureToInstanceState(obj)
+
+	testCases := []TestCase{
+		TestCase{
+			funcName:     "resourceForemanArchitectureRead",
+			crudFunc:     resourceForemanArchitectureRead,
+			resourceData: MockForemanArchitectureResourceData(s),
+		},
+	}
+
+	cred := api.ClientCredentials{}
+	conf := api.ClientConfig{}
+
+	mux, server, client := NewForemanAPIAndClient(cred, conf)
+	defer server.Close()
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	})
+
+	for _, testCase := range testCases {
+		t.Logf("test case: [%+v]", testCase)
+
+		err := testCase.crudFunc(testCase.resourceData, client)
+		if err != nil {
+			t.Fatalf(
+				"[%s] returned an error when the server responded with a 404 "+
+					"status code.  Expected [nil] got [%s]",
+				testCase.funcName,
+				err,
+			)
+		}
+		if testCase.resourceData.Id() != "" {
+			t.Fatalf(
+				"[%s] did not remove the resource from the state.  Expected ID [] "+
+					"got [%s]",
+				testCase.funcName,
+				testCase.resourceData.Id(),
+			)
+		}
+
+	} //end for
+}
@@ -4,13 +4,13 @@ import (
 	"log"
 	"net/url"
 	"os"
+	"time"
 
 	"github.com/HanseMerkur/terraform-provider-foreman/foreman/api"
 	logger "github.com/wayfair/terraform-provider-utils/log"
 
-	"github.com/hashicorp/terraform/helper/schema"
-	"github.com/hashicorp/terraform/helper/validation"
-	"github.com/hashicorp/terraform/terraform"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 // Environment variables the provider recognizes for configuration
@@ -23,6 +23,10 @@ const (
 	ClientUsernameEnv string = "FOREMAN_CLIENT_USERNAME"
 	// Environment variable to configure the client_password attribute
 	ClientPasswordEnv string = "FOREMAN_CLIENT_PASSWORD"
+	// Environment variable to configure the audit_comment attribute
+	AuditCommentEnv string = "FOREMAN_AUDIT_COMMENT"
+	// Environment variable to configure the state_encryption_key attribute
+	StateEncryptionKeyEnv string = "FOREMAN_STATE_ENCRYPTION_KEY"
 )
 
 // Provider configuration default values
@@ -49,7 +53,7 @@ type LoggingConfig struct {
 }
 
 // Provider : Defines params for provider in terraform and available resources
-func Provider() terraform.ResourceProvider {
+func Provider() *schema.Provider {
 	return &schema.Provider{
 
 		Schema: map[string]*schema.Schema{
@@ -111,6 +115,20 @@ func Provider() terraform.ResourceProvider {
 				Description: "The protocol the Foreman REST API server is using for " +
 					"communication. Defaults to `\"https\"`.",
 			},
+			"server_replicas": &schema.Schema{
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringIsNotWhiteSpace,
+				},
+				Description: "Hostnames / IP addresses of replicas of the Foreman " +
+					"REST API server (ie: the other nodes of a Foreman HA setup), " +
+					"using the same protocol. When the server cannot be reached, " +
+					"the provider fails over to the first replica answering its " +
+					"health check, in the order given, and keeps using it for the " +
+					"rest of the run.",
+			},
 
 			// -- REST client configuration --
 
@@ -122,6 +140,184 @@ func Provider() terraform.ResourceProvider {
 					"Defaults to `false`.",
 			},
 
+			"validate_references": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+				Deprecated: "Use `validate_references` in the `features` block " +
+					"instead.",
+				Description: "Whether or not to verify during the plan that the " +
+					"objects referenced by a host (hostgroup, subnets, image, compute " +
+					"profile) exist in Foreman and are compatible with each other, " +
+					"and that the interface compute attributes suit the compute " +
+					"resource. " +
+					"This turns most provisioning failures into plan errors at the " +
+					"cost of additional API calls. Defaults to `false`.",
+			},
+
+			"features": &schema.Schema{
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"bmc": &schema.Schema{
+							Type:     schema.TypeBool,
+							Optional: true,
+							Default:  true,
+							Description: "Whether or not BMC operations are performed " +
+								"for hosts with `enable_bmc`. When disabled, hosts are " +
+								"powered through Foreman's default behaviour instead. " +
+								"Defaults to `true`.",
+						},
+						"katello": &schema.Schema{
+							Type:     schema.TypeBool,
+							Optional: true,
+							Default:  false,
+							Description: "Whether or not the Foreman server has the " +
+								"Katello plugin installed. Defaults to `false`.",
+						},
+						"validate_references": &schema.Schema{
+							Type:     schema.TypeBool,
+							Optional: true,
+							Default:  false,
+							Description: "Whether or not to verify during the plan that " +
+								"the objects referenced by a host exist in Foreman and are " +
+								"compatible with each other. Defaults to `false`.",
+						},
+						"disabled_lookups": &schema.Schema{
+							Type:     schema.TypeSet,
+							Optional: true,
+							Elem: &schema.Schema{
+								Type:         schema.TypeString,
+								ValidateFunc: validation.StringInSlice(api.Lookups, false),
+							},
+							Description: "Optional lookups skipped for accounts " +
+								"restricted by roles which are not permitted to perform " +
+								"them. `\"build_status\"` skips the build status and " +
+								"orchestration tasks of created hosts, `\"power_state\"` " +
+								"the verification of BMCs, `\"boot_device\"` reading back " +
+								"the boot device of hosts and `\"smart_proxy\"` the " +
+								"verification of Redfish BMC proxies while planning.",
+						},
+					},
+				},
+				Description: "Toggles provider behaviours for every resource " +
+					"managed by this provider.",
+			},
+
+			"api_timeout": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      60,
+				ValidateFunc: validation.IntAtLeast(0),
+				Description: "Number of seconds to wait for the response of a single " +
+					"API request. A value of `0` disables the timeout. Defaults to `60`.",
+			},
+			"api_host_timeout": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      1800,
+				ValidateFunc: validation.IntAtLeast(0),
+				Description: "Number of seconds to wait for the response of a long " +
+					"running API request, such as creating a host (including its VM on " +
+					"the compute resource) or a power operation. A value of `0` " +
+					"disables the timeout. Defaults to `1800`.",
+			},
+			"api_cache_dir": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "",
+				Description: "Directory to cache the responses of read requests for " +
+					"reference data (architectures, template kinds and operating " +
+					"systems) in. Cached responses are revalidated with conditional requests " +
+					"(ETag / Last-Modified) and reused when Foreman reports them " +
+					"unchanged, which speeds up refreshing large workspaces. " +
+					"The most recently used responses are cached in memory for the " +
+					"duration of a run either way, the pages of large collections " +
+					"are never cached. Defaults to `\"\"`.",
+			},
+			"api_reference_cache_ttl": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      0,
+				ValidateFunc: validation.IntAtLeast(0),
+				Description: "Number of seconds cached reference data (architectures, " +
+					"template kinds and operating systems) is used without contacting " +
+					"Foreman. Together with `api_cache_dir`, repeated plans against the " +
+					"same Foreman skip these lookups entirely. A value of `0` always " +
+					"revalidates the cached responses. Defaults to `0`.",
+			},
+			"parallelism": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      0,
+				ValidateFunc: validation.IntAtLeast(0),
+				Description: "Maximum number of requests sent to Foreman " +
+					"simultaneously, independent of Terraform's own `-parallelism`. " +
+					"A value of `0` does not limit the number of requests. " +
+					"Defaults to `0`.",
+			},
+			"otel_tracing": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+				Description: "Whether or not to trace the API calls with OpenTelemetry. " +
+					"A span is recorded for every request (method, endpoint, status " +
+					"and latency) and exported with OTLP over HTTP, configured " +
+					"through the standard `OTEL_EXPORTER_OTLP_*` environment " +
+					"variables. The trace context is propagated to Foreman. " +
+					"Defaults to `false`.",
+			},
+			"api_call_summary": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+				Description: "Whether or not to log a summary of the API calls (count " +
+					"and time per endpoint, retries and failures) at the end of a plan " +
+					"or apply, at the `INFO` log level. Helps to identify the resources " +
+					"generating excessive Foreman traffic. Defaults to `false`.",
+			},
+
+			"audit_comment": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				DefaultFunc: schema.EnvDefaultFunc(
+					AuditCommentEnv,
+					"",
+				),
+				Description: "Comment recorded in Foreman's audit log with every " +
+					"change made by the provider, so the audits show the Terraform " +
+					"run instead of the shared account alone (ie: " +
+					"`\"changed by Terraform run ${var.run_id}\"`). This can also be " +
+					"set through the environment variable `FOREMAN_AUDIT_COMMENT`. " +
+					"Defaults to `\"\"`.",
+			},
+			"default_host_parameters": &schema.Schema{
+				Type:     schema.TypeMap,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Description: "Parameters added to the parameters of every " +
+					"`foreman_host` (ie: `puppet_environment`, `cost_center`). " +
+					"Parameters of the same name set in `parameters`, " +
+					"`hidden_parameters` or `user_data_parameters` of a host win " +
+					"over the defaults. The defaults are not recorded in the state " +
+					"of the hosts.",
+			},
+			"audit_headers": &schema.Schema{
+				Type:     schema.TypeMap,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Description: "HTTP headers added to every request changing objects " +
+					"in Foreman. Use them where an authenticating proxy in front of " +
+					"Foreman permits impersonation or records the headers of the " +
+					"changes.",
+			},
+
 			// -- client credentials --
 
 			"client_username": &schema.Schema{
@@ -136,57 +332,106 @@ func Provider() terraform.ResourceProvider {
 					"Defaults to `\"\"`.",
 			},
 			"client_password": &schema.Schema{
-				Type:     schema.TypeString,
-				Optional: true,
+				Type:      schema.TypeString,
+				Optional:  true,
+				Sensitive: true,
 				DefaultFunc: schema.EnvDefaultFunc(
 					ClientPasswordEnv,
 					"",
 				),
-				Description: "The username to authenticate against Foreman. This can " +
+				Description: "The password to authenticate against Foreman. This can " +
 					"also be set through the environment variable `FOREMAN_CLIENT_PASSWORD`. " +
 					"Defaults to `\"\"`.",
 			},
+
+			// -- state --
+
+			"state_encryption_key": &schema.Schema{
+				Type:      schema.TypeString,
+				Optional:  true,
+				Sensitive: true,
+				DefaultFunc: schema.EnvDefaultFunc(
+					StateEncryptionKeyEnv,
+					"",
+				),
+				Description: "Passphrase the BMC passwords of hosts and the passwords " +
+					"of compute resources are encrypted with before they are written " +
+					"to the state. Root passwords are only stored as a hash, which " +
+					"does not depend on the passphrase. The keys are derived from the " +
+					"passphrase with PBKDF2 and a random salt stored with every " +
+					"value. Changing the passphrase shows the encrypted values as " +
+					"changed. All configurations of the provider must use the same " +
+					"passphrase, provider aliases configured with another passphrase " +
+					"fail. " +
+					"This can also be set through the environment variable " +
+					"`FOREMAN_STATE_ENCRYPTION_KEY`. Defaults to `\"\"`, which " +
+					"stores the values unencrypted.",
+			},
 		},
 
 		ResourcesMap: map[string]*schema.Resource{
-			"foreman_architecture":         resourceForemanArchitecture(),
-			"foreman_host":                 resourceForemanHost(),
-			"foreman_hostgroup":            resourceForemanHostgroup(),
-			"foreman_media":                resourceForemanMedia(),
-			"foreman_model":                resourceForemanModel(),
-			"foreman_operatingsystem":      resourceForemanOperatingSystem(),
-			"foreman_partitiontable":       resourceForemanPartitionTable(),
-			"foreman_provisioningtemplate": resourceForemanProvisioningTemplate(),
-			"foreman_smartproxy":           resourceForemanSmartProxy(),
-			"foreman_computeresource":      resourceForemanComputeResource(),
-			"foreman_image":                resourceForemanImage(),
-			"foreman_environment":          resourceForemanEnvironment(),
-			"foreman_parameter":            resourceForemanParameter(),
-			"foreman_global_parameter":     resourceForemanCommonParameter(),
-			"foreman_subnet":               resourceForemanSubnet(),
-			"foreman_domain":               resourceForemanDomain(),
-			"foreman_defaulttemplate":      resourceForemanDefaultTemplate(),
+			"foreman_architecture":                   resourceForemanArchitecture(),
+			"foreman_host":                           resourceForemanHost(),
+			"foreman_host_interface":                 resourceForemanHostInterface(),
+			"foreman_host_set":                       resourceForemanHostSet(),
+			"foreman_hostgroup":                      resourceForemanHostgroup(),
+			"foreman_media":                          resourceForemanMedia(),
+			"foreman_model":                          resourceForemanModel(),
+			"foreman_operatingsystem":                resourceForemanOperatingSystem(),
+			"foreman_partitiontable":                 resourceForemanPartitionTable(),
+			"foreman_provisioningtemplate":           resourceForemanProvisioningTemplate(),
+			"foreman_smartproxy":                     resourceForemanSmartProxy(),
+			"foreman_computeresource":                resourceForemanComputeResource(),
+			"foreman_image":                          resourceForemanImage(),
+			"foreman_environment":                    resourceForemanEnvironment(),
+			"foreman_parameter":                      resourceForemanParameter(),
+			"foreman_global_parameter":               resourceForemanCommonParameter(),
+			"foreman_subnet":                         resourceForemanSubnet(),
+			"foreman_domain":                         resourceForemanDomain(),
+			"foreman_defaulttemplate":                resourceForemanDefaultTemplate(),
+			"foreman_katello_host_collection_host":   resourceForemanKatelloHostCollectionHost(),
+			"foreman_template_combination":           resourceForemanTemplateCombination(),
+			"foreman_task_wait":                      resourceForemanTaskWait(),
+			"foreman_usergroup_role":                 resourceForemanUsergroupRole(),
+			"foreman_usergroup_refresh":              resourceForemanUsergroupRefresh(),
+			"foreman_smart_class_parameter_override": resourceForemanSmartClassParameterOverride(),
 		},
 
 		DataSourcesMap: map[string]*schema.Resource{
-			"foreman_architecture":         dataSourceForemanArchitecture(),
-			"foreman_domain":               dataSourceForemanDomain(),
-			"foreman_environment":          dataSourceForemanEnvironment(),
-			"foreman_hostgroup":            dataSourceForemanHostgroup(),
-			"foreman_media":                dataSourceForemanMedia(),
-			"foreman_model":                dataSourceForemanModel(),
-			"foreman_operatingsystem":      dataSourceForemanOperatingSystem(),
-			"foreman_partitiontable":       dataSourceForemanPartitionTable(),
-			"foreman_provisioningtemplate": dataSourceForemanProvisioningTemplate(),
-			"foreman_smartproxy":           dataSourceForemanSmartProxy(),
-			"foreman_subnet":               dataSourceForemanSubnet(),
-			"foreman_templatekind":         dataSourceForemanTemplateKind(),
-			"foreman_computeprofile":       dataSourceForemanComputeProfile(),
-			"foreman_computeresource":      dataSourceForemanComputeResource(),
-			"foreman_image":                dataSourceForemanImage(),
-			"foreman_parameter":            dataSourceForemanParameter(),
-			"foreman_global_parameter":     dataSourceForemanCommonParameter(),
-			"foreman_defaulttemplate":      dataSourceForemanDefaultTemplate(),
+			"foreman_architecture":                    dataSourceForemanArchitecture(),
+			"foreman_domain":                          dataSourceForemanDomain(),
+			"foreman_environment":                     dataSourceForemanEnvironment(),
+			"foreman_hostgroup":                       dataSourceForemanHostgroup(),
+			"foreman_media":                           dataSourceForemanMedia(),
+			"foreman_model":                           dataSourceForemanModel(),
+			"foreman_operatingsystem":                 dataSourceForemanOperatingSystem(),
+			"foreman_partitiontable":                  dataSourceForemanPartitionTable(),
+			"foreman_provisioningtemplate":            dataSourceForemanProvisioningTemplate(),
+			"foreman_smartproxy":                      dataSourceForemanSmartProxy(),
+			"foreman_subnet":                          dataSourceForemanSubnet(),
+			"foreman_templatekind":                    dataSourceForemanTemplateKind(),
+			"foreman_computeprofile":                  dataSourceForemanComputeProfile(),
+			"foreman_computeresource":                 dataSourceForemanComputeResource(),
+			"foreman_image":                           dataSourceForemanImage(),
+			"foreman_parameter":                       dataSourceForemanParameter(),
+			"foreman_global_parameter":                dataSourceForemanCommonParameter(),
+			"foreman_defaulttemplate":                 dataSourceForemanDefaultTemplate(),
+			"foreman_location":                        dataSourceForemanLocation(),
+			"foreman_locations":                       dataSourceForemanLocations(),
+			"foreman_rendered_template":               dataSourceForemanRenderedTemplate(),
+			"foreman_unattended_preview":              dataSourceForemanUnattendedPreview(),
+			"foreman_host_power":                      dataSourceForemanHostPower(),
+			"foreman_fact_search":                     dataSourceForemanFactSearch(),
+			"foreman_host_interfaces":                 dataSourceForemanHostInterfaces(),
+			"foreman_host_details":                    dataSourceForemanHostDetails(),
+			"foreman_host_export":                     dataSourceForemanHostExport(),
+			"foreman_task_wait":                       dataSourceForemanTaskWait(),
+			"foreman_usergroup":                       dataSourceForemanUsergroup(),
+			"foreman_query":                           dataSourceForemanQuery(),
+			"foreman_computeresource_images":          dataSourceForemanComputeResourceObjects("images", "images", false),
+			"foreman_computeresource_flavors":         dataSourceForemanComputeResourceObjects("flavors", "flavors", false),
+			"foreman_computeresource_networks":        dataSourceForemanComputeResourceObjects("networks", "networks", true),
+			"foreman_computeresource_storage_domains": dataSourceForemanComputeResourceObjects("storage_domains", "storage domains", false),
 		},
 		ConfigureFunc: providerConfigure,
 	}
@@ -248,6 +493,48 @@ func providerConfigure(d *schema.ResourceData) (interface{}, error) {
 		logConfig.LogLevel.String(),
 	)
 
+	// Provider features.  The defaults apply when the block is omitted.
+	features := map[string]interface{}{
+		"bmc":                 true,
+		"katello":             false,
+		"validate_references": false,
+	}
+	if featureList, ok := d.Get("features").([]interface{}); ok && len(featureList) > 0 && featureList[0] != nil {
+		features = featureList[0].(map[string]interface{})
+	}
+	// NOTE(ALL): the deprecated top-level attribute keeps working alongside
+	//   the features block
+	validateReferences := d.Get("validate_references").(bool) ||
+		features["validate_references"].(bool)
+	disabledLookups := []string{}
+	if lookupSet, ok := features["disabled_lookups"].(*schema.Set); ok {
+		for _, lookup := range lookupSet.List() {
+			disabledLookups = append(disabledLookups, lookup.(string))
+		}
+	}
+
+	if encryptionErr := configureStateEncryption(d.Get("state_encryption_key").(string)); encryptionErr != nil {
+		return nil, encryptionErr
+	}
+
+	defaultHostParameters := map[string]string{}
+	for name, value := range d.Get("default_host_parameters").(map[string]interface{}) {
+		defaultHostParameters[name] = value.(string)
+	}
+
+	auditHeaders := map[string]string{}
+	for name, value := range d.Get("audit_headers").(map[string]interface{}) {
+		auditHeaders[name] = value.(string)
+	}
+
+	var replicas []url.URL
+	for _, replica := range d.Get("server_replicas").([]interface{}) {
+		replicas = append(replicas, url.URL{
+			Scheme: d.Get("server_protocol").(string),
+			Host:   replica.(string),
+		})
+	}
+
 	config := Config{
 		// -- server configuration --
 		Server: api.Server{
@@ -255,9 +542,24 @@ func providerConfigure(d *schema.ResourceData) (interface{}, error) {
 				Scheme: d.Get("server_protocol").(string),
 				Host:   d.Get("server_hostname").(string),
 			},
+			Replicas: replicas,
 		},
 		// -- client configuration --
-		ClientTLSInsecure: d.Get("client_tls_insecure").(bool),
+		ClientTLSInsecure:     d.Get("client_tls_insecure").(bool),
+		ValidateReferences:    validateReferences,
+		DisableBMC:            !features["bmc"].(bool),
+		KatelloEnabled:        features["katello"].(bool),
+		DisabledLookups:       disabledLookups,
+		AuditComment:          d.Get("audit_comment").(string),
+		AuditHeaders:          auditHeaders,
+		DefaultHostParameters: defaultHostParameters,
+		APITimeout:            time.Duration(d.Get("api_timeout").(int)) * time.Second,
+		APIHostTimeout:        time.Duration(d.Get("api_host_timeout").(int)) * time.Second,
+		APICacheDir:           d.Get("api_cache_dir").(string),
+		APIReferenceCacheTTL:  time.Duration(d.Get("api_reference_cache_ttl").(int)) * time.Second,
+		Parallelism:           d.Get("parallelism").(int),
+		OTelTracing:           d.Get("otel_tracing").(bool),
+		APICallSummary:        d.Get("api_call_summary").(bool),
 		ClientCredentials: api.ClientCredentials{
 			Username: d.Get("client_username").(string),
 			Password: d.Get("client_password").(string),
@@ -10,8 +10,8 @@ import (
 	"github.com/wayfair/terraform-provider-utils/conv"
 	"github.com/wayfair/terraform-provider-utils/log"
 
-	"github.com/hashicorp/terraform/helper/schema"
-	"github.com/hashicorp/terraform/helper/validation"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 const (
@@ -72,6 +72,9 @@ func resourceForemanArchitecture() *schema.Resource {
 				Description: "IDs of the operating systems associated with this " +
 					"architecture",
 			},
+
+			"created_at": createdAtSchema(),
+			"updated_at": updatedAtSchema(),
 		},
 	}
 }
@@ -109,6 +112,7 @@ func setResourceDataFromForemanArchitecture(d *schema.ResourceData, fa *api.Fore
 	log.Tracef("resource_foreman_architecture.go#setResourceDataFromForemanArchitecture")
 
 	d.SetId(strconv.Itoa(fa.Id))
+	setResourceDataFromForemanObjectTimestamps(d, &fa.ForemanObject)
 	d.Set("name", fa.Name)
 	d.Set("operatingsystem_ids", fa.OperatingSystemIds)
 }
@@ -147,7 +151,7 @@ func resourceForemanArchitectureRead(d *schema.ResourceData, meta interface{}) e
 
 	readArch, readErr := client.ReadArchitecture(a.Id)
 	if readErr != nil {
-		return readErr
+		return handleReadError(d, readErr)
 	}
 
 	log.Debugf("Read ForemanArchitecture: [%+v]", readArch)
@@ -12,8 +12,8 @@ import (
 	"github.com/HanseMerkur/terraform-provider-foreman/foreman/api"
 	tfrand "github.com/wayfair/terraform-provider-utils/rand"
 
-	"github.com/hashicorp/terraform/helper/schema"
-	"github.com/hashicorp/terraform/terraform"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
 
 // -----------------------------------------------------------------------------
@@ -29,6 +29,8 @@ func ForemanArchitectureToInstanceState(obj api.ForemanArchitecture) *terraform.
 	state.ID = strconv.Itoa(obj.Id)
 	// Build the attribute map from ForemanArchitecture
 	attr := map[string]string{}
+	attr["created_at"] = obj.CreatedAt
+	attr["updated_at"] = obj.UpdatedAt
 	attr["name"] = obj.Name
 	attr["operatingsystem_ids.#"] = strconv.Itoa(len(obj.OperatingSystemIds))
 	for idx, val := range obj.OperatingSystemIds {
@@ -275,7 +277,7 @@ func ResourceForemanArchitectureRequestDataTestCases(t *testing.T) []TestCaseReq
 
 	rd := MockForemanArchitectureResourceData(s)
 	obj = *buildForemanArchitecture(rd)
-	reqData, _ := json.Marshal(obj)
+	reqData, _ := api.WrapJson("architecture", obj)
 
 	return []TestCaseRequestData{
 		TestCaseRequestData{
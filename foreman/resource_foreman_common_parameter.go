@@ -8,7 +8,7 @@ import (
 	"github.com/wayfair/terraform-provider-utils/autodoc"
 	"github.com/wayfair/terraform-provider-utils/log"
 
-	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func resourceForemanCommonParameter() *schema.Resource {
@@ -40,9 +40,20 @@ func resourceForemanCommonParameter() *schema.Resource {
 				Required: true,
 			},
 			"value": &schema.Schema{
-				Type:     schema.TypeString,
-				Required: true,
+				Type:      schema.TypeString,
+				Required:  true,
+				Sensitive: true,
 			},
+			"hidden_value": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+				Description: "Whether the value of the parameter is masked in the " +
+					"Foreman UI.",
+			},
+
+			"created_at": createdAtSchema(),
+			"updated_at": updatedAtSchema(),
 		},
 	}
 }
@@ -72,6 +83,7 @@ func buildForemanCommonParameter(d *schema.ResourceData) *api.ForemanCommonParam
 	if attr, ok = d.GetOk("value"); ok {
 		common_parameter.Value = attr.(string)
 	}
+	common_parameter.HiddenValue = d.Get("hidden_value").(bool)
 	return &common_parameter
 }
 
@@ -81,8 +93,10 @@ func setResourceDataFromForemanCommonParameter(d *schema.ResourceData, fd *api.F
 	log.Tracef("resource_foreman_common_parameter.go#setResourceDataFromForemanCommonParameter")
 
 	d.SetId(strconv.Itoa(fd.Id))
+	setResourceDataFromForemanObjectTimestamps(d, &fd.ForemanObject)
 	d.Set("name", fd.Name)
 	d.Set("value", fd.Value)
+	d.Set("hidden_value", fd.HiddenValue)
 }
 
 // -----------------------------------------------------------------------------
@@ -119,7 +133,7 @@ func resourceForemanCommonParameterRead(d *schema.ResourceData, meta interface{}
 
 	readCommonParameter, readErr := client.ReadCommonParameter(common_parameter, common_parameter.Id)
 	if readErr != nil {
-		return readErr
+		return handleReadError(d, readErr)
 	}
 
 	log.Debugf("Read ForemanCommonParameter: [%+v]", readCommonParameter)
@@ -8,7 +8,8 @@ import (
 	"github.com/wayfair/terraform-provider-utils/autodoc"
 	"github.com/wayfair/terraform-provider-utils/log"
 
-	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceForemanComputeResource() *schema.Resource {
@@ -49,13 +50,28 @@ func resourceForemanComputeResource() *schema.Resource {
 			"hypervisor": &schema.Schema{
 				Type:     schema.TypeString,
 				Required: true,
+				ValidateFunc: validation.StringInSlice([]string{
+					"Libvirt",
+					"Ovirt",
+					"EC2",
+					"Vmware",
+					"Openstack",
+					"Rackspace",
+					"GCE",
+					// NOTE(ALL): false - do not ignore case when comparing values
+				}, false),
 				Description: "The HyperVisor/Cloud Provider for this Compute Resource:" +
 					"supported providers include \"Libvirt\", \"Ovirt\", \"EC2\"," +
 					"\"Vmware\", \"Openstack\", \"Rackspace\", \"GCE\"",
 			},
 			"displaytype": &schema.Schema{
-				Type:        schema.TypeString,
-				Optional:    true,
+				Type:     schema.TypeString,
+				Optional: true,
+				ValidateFunc: validation.StringInSlice([]string{
+					"VNC",
+					"SPICE",
+					"VMRC",
+				}, true),
 				Description: "For Libvirt: \"VNC\" or \"SPICE\". For VMWare: \"VNC\" or \"VMRC\"",
 			},
 			"user": &schema.Schema{
@@ -64,10 +80,13 @@ func resourceForemanComputeResource() *schema.Resource {
 				Description: "Username for oVirt, EC2, VMware, OpenStack. Access Key for EC2.",
 			},
 			"password": &schema.Schema{
-				Type:        schema.TypeString,
-				Sensitive:   true,
-				Optional:    true,
-				Description: "Password for oVirt, EC2, VMware, OpenStack. Secret key for EC2",
+				Type:             schema.TypeString,
+				Sensitive:        true,
+				Optional:         true,
+				StateFunc:        encryptSensitiveValue,
+				DiffSuppressFunc: suppressSensitiveValueDiff,
+				Description: "Password for oVirt, EC2, VMware, OpenStack. Secret key for EC2. " +
+					"Encrypted in the state with the provider's `state_encryption_key`, if set.",
 			},
 			"datacenter": &schema.Schema{
 				Type:        schema.TypeString,
@@ -89,6 +108,12 @@ func resourceForemanComputeResource() *schema.Resource {
 				Optional:    true,
 				Description: "For VMware only",
 			},
+
+			"location_ids":     locationIdsSchema("compute resource"),
+			"organization_ids": organizationIdsSchema("compute resource"),
+
+			"created_at": createdAtSchema(),
+			"updated_at": updatedAtSchema(),
 		},
 	}
 }
@@ -108,6 +133,7 @@ func buildForemanComputeResource(d *schema.ResourceData) *api.ForemanComputeReso
 
 	obj := buildForemanObject(d)
 	computeresource.ForemanObject = *obj
+	computeresource.ForemanTaxonomies = buildForemanTaxonomies(d)
 
 	var attr interface{}
 	var ok bool
@@ -128,7 +154,13 @@ func buildForemanComputeResource(d *schema.ResourceData) *api.ForemanComputeReso
 		computeresource.User = attr.(string)
 	}
 	if attr, ok = d.GetOk("password"); ok {
-		computeresource.Password = attr.(string)
+		// NOTE(ALL): unchanged passwords are read from the state, where they
+		//   may be encrypted
+		password, decryptErr := decryptSensitiveValue(attr.(string))
+		if decryptErr != nil {
+			log.Errorf("Could not decrypt the password of compute resource [%d]: %s", computeresource.Id, decryptErr)
+		}
+		computeresource.Password = password
 	}
 	if attr, ok = d.GetOk("datacenter"); ok {
 		computeresource.Datacenter = attr.(string)
@@ -152,12 +184,14 @@ func setResourceDataFromForemanComputeResource(d *schema.ResourceData, fd *api.F
 	log.Tracef("resource_foreman_computeresource.go#setResourceDataFromForemanComputeResource")
 
 	d.SetId(strconv.Itoa(fd.Id))
+	setResourceDataFromForemanObjectTimestamps(d, &fd.ForemanObject)
+	setResourceDataFromForemanTaxonomies(d, &fd.ForemanTaxonomies)
 	d.Set("name", fd.Name)
 	d.Set("url", fd.URL)
 	d.Set("hypervisor", fd.Provider)
 	d.Set("displaytype", fd.DisplayType)
 	d.Set("user", fd.User)
-	d.Set("password", fd.Password)
+	d.Set("password", encryptSensitiveValue(fd.Password))
 	d.Set("datacenter", fd.Datacenter)
 	d.Set("server", fd.Server)
 	d.Set("setconsolepassword", fd.SetConsolePassword)
@@ -183,7 +217,7 @@ func resourceForemanComputeResourceRead(d *schema.ResourceData, meta interface{}
 
 	readComputeResource, readErr := client.ReadComputeResource(computeresource.Id)
 	if readErr != nil {
-		return readErr
+		return handleReadError(d, readErr)
 	}
 
 	log.Debugf("Read ForemanComputeResource: [%+v]", readComputeResource)
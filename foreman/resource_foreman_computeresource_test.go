@@ -1,6 +1,8 @@
 package foreman
 
 import (
+	"context"
+	"encoding/base64"
 	"encoding/json"
 	"math/rand"
 	"net/http"
@@ -11,8 +13,8 @@ import (
 	"github.com/HanseMerkur/terraform-provider-foreman/foreman/api"
 	tfrand "github.com/wayfair/terraform-provider-utils/rand"
 
-	"github.com/hashicorp/terraform/helper/schema"
-	"github.com/hashicorp/terraform/terraform"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
 
 // -----------------------------------------------------------------------------
@@ -29,6 +31,8 @@ func ForemanComputeResourceToInstanceState(obj api.ForemanComputeResource) *terr
 	// Build the attribute map from ForemanComputeResource
 	state.Attributes = map[string]string{
 		"name":               obj.Name,
+		"created_at":         obj.CreatedAt,
+		"updated_at":         obj.UpdatedAt,
 		"url":                obj.URL,
 		"hypervisor":         obj.Provider,
 		"displaytype":        obj.DisplayType,
@@ -255,3 +259,103 @@ func ResourceForemanComputeResourceMockResponseTestCases(t *testing.T) []TestCas
 	}
 
 }
+
+// setTestStateEncryptionKey configures the state encryption with the
+// supplied passphrase for the duration of the test
+func setTestStateEncryptionKey(t *testing.T, passphrase string) {
+	reset := func() {
+		stateEncryption.Lock()
+		stateEncryption.configured = false
+		stateEncryption.Unlock()
+	}
+	reset()
+	t.Cleanup(func() {
+		reset()
+		configureStateEncryption("")
+		reset()
+	})
+	if configErr := configureStateEncryption(passphrase); configErr != nil {
+		t.Fatalf("configureStateEncryption returned an error: %s", configErr)
+	}
+}
+
+// Ensures the password is encrypted in the state without showing up as a
+// change, and decrypted when sent to Foreman
+func TestResourceForemanComputeResource_StateEncryption(t *testing.T) {
+	setTestStateEncryptionKey(t, "correct horse battery staple")
+
+	encrypted := encryptSensitiveValue("secret")
+	if encrypted == "secret" || encrypted != encryptSensitiveValue("secret") {
+		t.Fatalf("Expected the password to be encrypted deterministically, got [%s]", encrypted)
+	}
+	if configErr := configureStateEncryption("another passphrase"); configErr == nil {
+		t.Fatalf("Expected configurations with another passphrase to be refused")
+	}
+
+	r := resourceForemanComputeResource()
+	state := &terraform.InstanceState{
+		ID: "1",
+		Attributes: map[string]string{
+			"id":       "1",
+			"name":     "vcenter",
+			"password": encrypted,
+		},
+	}
+	diff, diffErr := r.Diff(context.Background(), state, terraform.NewResourceConfigRaw(map[string]interface{}{
+		"name":     "vcenter",
+		"password": "secret",
+	}), nil)
+	if diffErr != nil {
+		t.Fatalf("Diff returned an error: %s", diffErr)
+	}
+	if diff != nil && diff.Attributes["password"] != nil {
+		t.Fatalf("Expected the unchanged password not to show up as a change, got [%+v]", diff.Attributes["password"])
+	}
+
+	diff, diffErr = r.Diff(context.Background(), state, terraform.NewResourceConfigRaw(map[string]interface{}{
+		"name":     "vcenter",
+		"password": "changed",
+	}), nil)
+	if diffErr != nil || diff.Attributes["password"] == nil || diff.Attributes["password"].New != encryptSensitiveValue("changed") {
+		t.Fatalf("Expected the changed password to be planned encrypted, got [%+v], [%v]", diff, diffErr)
+	}
+
+	d := r.Data(state)
+	if password := buildForemanComputeResource(d).Password; password != "secret" {
+		t.Fatalf("Expected the decrypted password to be sent, got [%s]", password)
+	}
+
+	// NOTE(ALL): the next run of the provider encrypts with another salt
+	setTestStateEncryptionKey(t, "correct horse battery staple")
+	if reencrypted := encryptSensitiveValue("secret"); reencrypted == encrypted {
+		t.Fatalf("Expected every run to encrypt with a new salt, got [%s]", reencrypted)
+	}
+	diff, diffErr = r.Diff(context.Background(), state, terraform.NewResourceConfigRaw(map[string]interface{}{
+		"name":     "vcenter",
+		"password": "secret",
+	}), nil)
+	if diffErr != nil {
+		t.Fatalf("Diff returned an error: %s", diffErr)
+	}
+	if diff != nil && diff.Attributes["password"] != nil {
+		t.Fatalf("Expected the password encrypted by another run not to show up as a change, got [%+v]", diff.Attributes["password"])
+	}
+
+	// Values encrypted without a salt by earlier versions are still decrypted
+	legacyKeys, keysErr := newStateEncryptionKeys([]byte("correct horse battery staple"))
+	if keysErr != nil {
+		t.Fatalf("newStateEncryptionKeys returned an error: %s", keysErr)
+	}
+	nonce := make([]byte, legacyKeys.aead.NonceSize())
+	legacy := legacyStateEncryptionPrefix + base64.StdEncoding.EncodeToString(
+		legacyKeys.aead.Seal(nonce, nonce, []byte("secret"), nil),
+	)
+	if plain, decryptErr := decryptSensitiveValue(legacy); decryptErr != nil || plain != "secret" {
+		t.Fatalf("Expected the legacy password to be decrypted, got [%s], [%v]", plain, decryptErr)
+	}
+
+	setTestStateEncryptionKey(t, "another passphrase")
+	if _, decryptErr := decryptSensitiveValue(encrypted); decryptErr == nil {
+		t.Fatalf("Expected the password not to decrypt with another passphrase")
+	}
+}
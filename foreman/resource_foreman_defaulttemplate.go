@@ -8,8 +8,8 @@ import (
 	"github.com/wayfair/terraform-provider-utils/autodoc"
 	"github.com/wayfair/terraform-provider-utils/log"
 
-	"github.com/hashicorp/terraform/helper/schema"
-	"github.com/hashicorp/terraform/helper/validation"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceForemanDefaultTemplate() *schema.Resource {
@@ -20,8 +20,10 @@ func resourceForemanDefaultTemplate() *schema.Resource {
 		Update: resourceForemanDefaultTemplateUpdate,
 		Delete: resourceForemanDefaultTemplateDelete,
 
+		// NOTE(ALL): Default template objects are nested underneath their parent. The
+		//   import ID has the form "<operatingsystem_id>/<id>"
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			State: importStateCompositeId("operatingsystem_id"),
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -55,6 +57,9 @@ func resourceForemanDefaultTemplate() *schema.Resource {
 				Description:  "Template Kind Id to define the Default Template",
 				ValidateFunc: validation.IntAtLeast(1),
 			},
+
+			"created_at": createdAtSchema(),
+			"updated_at": updatedAtSchema(),
 		},
 	}
 }
@@ -96,6 +101,7 @@ func setResourceDataFromForemanDefaultTemplate(d *schema.ResourceData, fd *api.F
 	log.Tracef("resource_foreman_defaultTemplate.go#setResourceDataFromForemanDefaultTemplate")
 
 	d.SetId(strconv.Itoa(fd.Id))
+	setResourceDataFromForemanObjectTimestamps(d, &fd.ForemanObject)
 	d.Set("provisioningtemplate_id", fd.ProvisioningTemplateId)
 	d.Set("templatekind_id", fd.TemplateKindId)
 	d.Set("operatingsystem_id", fd.OperatingSystemId)
@@ -135,7 +141,7 @@ func resourceForemanDefaultTemplateRead(d *schema.ResourceData, meta interface{}
 
 	readDefaultTemplate, readErr := client.ReadDefaultTemplate(defaultTemplate, defaultTemplate.Id)
 	if readErr != nil {
-		return readErr
+		return handleReadError(d, readErr)
 	}
 
 	log.Debugf("Read ForemanDefaultTemplate: [%+v]", readDefaultTemplate)
@@ -8,7 +8,8 @@ import (
 	"github.com/wayfair/terraform-provider-utils/autodoc"
 	"github.com/wayfair/terraform-provider-utils/log"
 
-	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceForemanDomain() *schema.Resource {
@@ -51,6 +52,25 @@ func resourceForemanDomain() *schema.Resource {
 				Optional:    true,
 				Description: "Description of the domain",
 			},
+
+			"dns_id": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntAtLeast(0),
+				Description: "ID of the smart proxy managing the DNS records of " +
+					"the domain. Hosts in the domain get their DNS records created " +
+					"through this proxy.",
+			},
+
+			"parameters":        parametersSchema("domain"),
+			"hidden_parameters": hiddenParametersSchema("domain"),
+			"parameter_types":   parameterTypesSchema("domain"),
+
+			"location_ids":     locationIdsSchema("domain"),
+			"organization_ids": organizationIdsSchema("domain"),
+
+			"created_at": createdAtSchema(),
+			"updated_at": updatedAtSchema(),
 		},
 	}
 }
@@ -70,6 +90,7 @@ func buildForemanDomain(d *schema.ResourceData) *api.ForemanDomain {
 
 	obj := buildForemanObject(d)
 	domain.ForemanObject = *obj
+	domain.ForemanTaxonomies = buildForemanTaxonomies(d)
 
 	var attr interface{}
 	var ok bool
@@ -77,6 +98,10 @@ func buildForemanDomain(d *schema.ResourceData) *api.ForemanDomain {
 	if attr, ok = d.GetOk("fullname"); ok {
 		domain.Fullname = attr.(string)
 	}
+	if attr, ok = d.GetOk("dns_id"); ok {
+		domain.DnsId = attr.(int)
+	}
+	domain.DomainParameters = buildForemanKVParameters(d)
 
 	return &domain
 }
@@ -87,8 +112,12 @@ func setResourceDataFromForemanDomain(d *schema.ResourceData, fd *api.ForemanDom
 	log.Tracef("resource_foreman_domain.go#setResourceDataFromForemanDomain")
 
 	d.SetId(strconv.Itoa(fd.Id))
+	setResourceDataFromForemanObjectTimestamps(d, &fd.ForemanObject)
+	setResourceDataFromForemanTaxonomies(d, &fd.ForemanTaxonomies)
 	d.Set("name", fd.Name)
 	d.Set("fullname", fd.Fullname)
+	d.Set("dns_id", fd.DnsId)
+	setResourceDataFromForemanKVParameters(d, fd.DomainParameters)
 }
 
 // -----------------------------------------------------------------------------
@@ -97,6 +126,21 @@ func setResourceDataFromForemanDomain(d *schema.ResourceData, fd *api.ForemanDom
 
 func resourceForemanDomainCreate(d *schema.ResourceData, meta interface{}) error {
 	log.Tracef("resource_foreman_domain.go#Create")
+
+	client := meta.(*api.Client)
+	domain := buildForemanDomain(d)
+
+	log.Debugf("ForemanDomain: [%+v]", domain)
+
+	createdDomain, createErr := client.CreateDomain(domain)
+	if createErr != nil {
+		return createErr
+	}
+
+	log.Debugf("Created ForemanDomain: [%+v]", createdDomain)
+
+	setResourceDataFromForemanDomain(d, createdDomain)
+
 	return nil
 }
 
@@ -110,7 +154,7 @@ func resourceForemanDomainRead(d *schema.ResourceData, meta interface{}) error {
 
 	readDomain, readErr := client.ReadDomain(domain.Id)
 	if readErr != nil {
-		return readErr
+		return handleReadError(d, readErr)
 	}
 
 	log.Debugf("Read ForemanDomain: [%+v]", readDomain)
@@ -122,14 +166,32 @@ func resourceForemanDomainRead(d *schema.ResourceData, meta interface{}) error {
 
 func resourceForemanDomainUpdate(d *schema.ResourceData, meta interface{}) error {
 	log.Tracef("resource_foreman_domain.go#Update")
+
+	client := meta.(*api.Client)
+	domain := buildForemanDomain(d)
+
+	log.Debugf("ForemanDomain: [%+v]", domain)
+
+	updatedDomain, updateErr := client.UpdateDomain(domain)
+	if updateErr != nil {
+		return updateErr
+	}
+
+	log.Debugf("Updated ForemanDomain: [%+v]", updatedDomain)
+
+	setResourceDataFromForemanDomain(d, updatedDomain)
+
 	return nil
 }
 
 func resourceForemanDomainDelete(d *schema.ResourceData, meta interface{}) error {
 	log.Tracef("resource_foreman_domain.go#Delete")
 
+	client := meta.(*api.Client)
+	domain := buildForemanDomain(d)
+
 	// NOTE(ALL): d.SetId("") is automatically called by terraform assuming delete
 	//   returns no errors
 
-	return nil
+	return client.DeleteDomain(domain.Id)
 }
@@ -5,14 +5,15 @@ import (
 	"math/rand"
 	"net/http"
 	"reflect"
+	"sort"
 	"strconv"
 	"testing"
 
 	"github.com/HanseMerkur/terraform-provider-foreman/foreman/api"
 	tfrand "github.com/wayfair/terraform-provider-utils/rand"
 
-	"github.com/hashicorp/terraform/helper/schema"
-	"github.com/hashicorp/terraform/terraform"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
 
 // -----------------------------------------------------------------------------
@@ -28,8 +29,11 @@ func ForemanDomainToInstanceState(obj api.ForemanDomain) *terraform.InstanceStat
 	state.ID = strconv.Itoa(obj.Id)
 	// Build the attribute map from ForemanDomain
 	attr := map[string]string{}
+	attr["created_at"] = obj.CreatedAt
+	attr["updated_at"] = obj.UpdatedAt
 	attr["name"] = obj.Name
 	attr["fullname"] = obj.Fullname
+	attr["dns_id"] = strconv.Itoa(obj.DnsId)
 	state.Attributes = attr
 	return &state
 }
@@ -58,6 +62,7 @@ func RandForemanDomain() api.ForemanDomain {
 	obj.ForemanObject = fo
 
 	obj.Fullname = tfrand.String(20, tfrand.Lower+".")
+	obj.DnsId = rand.Intn(100)
 
 	return obj
 }
@@ -120,6 +125,66 @@ func TestDomainUnmarshalJSON_ForemanObject(t *testing.T) {
 
 }
 
+// Ensures the JSON unmarshal decodes the locations and organizations to their
+// IDs and the IDs are set on the ResourceData
+func TestDomainUnmarshalJSON_Taxonomies(t *testing.T) {
+
+	domainJSON := []byte(`{
+		"id": 3,
+		"name": "dev.dc1.company.com",
+		"fullname": "dev",
+		"locations": [{"id": 2, "name": "dc1"}],
+		"organizations": [{"id": 1, "name": "acme"}, {"id": 4, "name": "corp"}]
+	}`)
+
+	var obj api.ForemanDomain
+	if jsonDecErr := json.Unmarshal(domainJSON, &obj); jsonDecErr != nil {
+		t.Fatalf("ForemanDomain UnmarshalJSON failed: [%s]", jsonDecErr)
+	}
+	expected := api.ForemanTaxonomies{
+		LocationIds:     []int{2},
+		OrganizationIds: []int{1, 4},
+	}
+	if !reflect.DeepEqual(obj.ForemanTaxonomies, expected) || obj.Fullname != "dev" {
+		t.Fatalf(
+			"ForemanDomain UnmarshalJSON did not properly decode the taxonomies. "+
+				"Expected [%+v], got [%+v]",
+			expected,
+			obj,
+		)
+	}
+
+	d := MockForemanDomainResourceData(ForemanDomainToInstanceState(api.ForemanDomain{}))
+	setResourceDataFromForemanDomain(d, &obj)
+	actual := buildForemanDomain(d).ForemanTaxonomies
+	sort.Ints(actual.OrganizationIds)
+	if !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("expected the taxonomies [%+v] to be set, got [%+v]", expected, actual)
+	}
+
+}
+
+// -----------------------------------------------------------------------------
+// MarshalJSON
+// -----------------------------------------------------------------------------
+
+// Ensures the DNS proxy is sent by its ID and removed when unset
+func TestDomainMarshalJSON_DnsId(t *testing.T) {
+
+	for dnsId, expected := range map[int]interface{}{0: nil, 5: "5"} {
+		domainJSON, jsonEncErr := json.Marshal(api.ForemanDomain{DnsId: dnsId})
+		if jsonEncErr != nil {
+			t.Fatalf("ForemanDomain MarshalJSON failed: [%s]", jsonEncErr)
+		}
+		var sent map[string]interface{}
+		json.Unmarshal(domainJSON, &sent)
+		if actual, ok := sent["dns_id"]; !ok || actual != expected {
+			t.Fatalf("expected dns_id [%v] to be sent, got [%s]", expected, domainJSON)
+		}
+	}
+
+}
+
 // -----------------------------------------------------------------------------
 // setResourceDataFromForemanDomain
 // -----------------------------------------------------------------------------
@@ -154,6 +219,15 @@ func ResourceForemanDomainCorrectURLAndMethodTestCases(t *testing.T) []TestCaseC
 	domainsURIById := DomainsURI + "/" + strconv.Itoa(obj.Id)
 
 	return []TestCaseCorrectURLAndMethod{
+		TestCaseCorrectURLAndMethod{
+			TestCase: TestCase{
+				funcName:     "resourceForemanDomainCreate",
+				crudFunc:     resourceForemanDomainCreate,
+				resourceData: MockForemanDomainResourceData(s),
+			},
+			expectedURI:    DomainsURI,
+			expectedMethod: http.MethodPost,
+		},
 		TestCaseCorrectURLAndMethod{
 			TestCase: TestCase{
 				funcName:     "resourceForemanDomainRead",
@@ -163,6 +237,24 @@ func ResourceForemanDomainCorrectURLAndMethodTestCases(t *testing.T) []TestCaseC
 			expectedURI:    domainsURIById,
 			expectedMethod: http.MethodGet,
 		},
+		TestCaseCorrectURLAndMethod{
+			TestCase: TestCase{
+				funcName:     "resourceForemanDomainUpdate",
+				crudFunc:     resourceForemanDomainUpdate,
+				resourceData: MockForemanDomainResourceData(s),
+			},
+			expectedURI:    domainsURIById,
+			expectedMethod: http.MethodPut,
+		},
+		TestCaseCorrectURLAndMethod{
+			TestCase: TestCase{
+				funcName:     "resourceForemanDomainDelete",
+				crudFunc:     resourceForemanDomainDelete,
+				resourceData: MockForemanDomainResourceData(s),
+			},
+			expectedURI:    domainsURIById,
+			expectedMethod: http.MethodDelete,
+		},
 	}
 
 }
@@ -180,6 +272,11 @@ func ResourceForemanDomainRequestDataEmptyTestCases(t *testing.T) []TestCase {
 			crudFunc:     resourceForemanDomainRead,
 			resourceData: MockForemanDomainResourceData(s),
 		},
+		TestCase{
+			funcName:     "resourceForemanDomainDelete",
+			crudFunc:     resourceForemanDomainDelete,
+			resourceData: MockForemanDomainResourceData(s),
+		},
 	}
 }
 
@@ -191,11 +288,26 @@ func ResourceForemanDomainStatusCodeTestCases(t *testing.T) []TestCase {
 	s := ForemanDomainToInstanceState(obj)
 
 	return []TestCase{
+		TestCase{
+			funcName:     "resourceForemanDomainCreate",
+			crudFunc:     resourceForemanDomainCreate,
+			resourceData: MockForemanDomainResourceData(s),
+		},
 		TestCase{
 			funcName:     "resourceForemanDomainRead",
 			crudFunc:     resourceForemanDomainRead,
 			resourceData: MockForemanDomainResourceData(s),
 		},
+		TestCase{
+			funcName:     "resourceForemanDomainUpdate",
+			crudFunc:     resourceForemanDomainUpdate,
+			resourceData: MockForemanDomainResourceData(s),
+		},
+		TestCase{
+			funcName:     "resourceForemanDomainDelete",
+			crudFunc:     resourceForemanDomainDelete,
+			resourceData: MockForemanDomainResourceData(s),
+		},
 	}
 }
 
@@ -206,11 +318,21 @@ func ResourceForemanDomainEmptyResponseTestCases(t *testing.T) []TestCase {
 	s := ForemanDomainToInstanceState(obj)
 
 	return []TestCase{
+		TestCase{
+			funcName:     "resourceForemanDomainCreate",
+			crudFunc:     resourceForemanDomainCreate,
+			resourceData: MockForemanDomainResourceData(s),
+		},
 		TestCase{
 			funcName:     "resourceForemanDomainRead",
 			crudFunc:     resourceForemanDomainRead,
 			resourceData: MockForemanDomainResourceData(s),
 		},
+		TestCase{
+			funcName:     "resourceForemanDomainUpdate",
+			crudFunc:     resourceForemanDomainUpdate,
+			resourceData: MockForemanDomainResourceData(s),
+		},
 	}
 }
 
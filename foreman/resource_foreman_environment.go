@@ -8,7 +8,7 @@ import (
 	"github.com/wayfair/terraform-provider-utils/autodoc"
 	"github.com/wayfair/terraform-provider-utils/log"
 
-	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func resourceForemanEnvironment() *schema.Resource {
@@ -44,6 +44,12 @@ func resourceForemanEnvironment() *schema.Resource {
 					autodoc.MetaExample,
 				),
 			},
+
+			"location_ids":     locationIdsSchema("environment"),
+			"organization_ids": organizationIdsSchema("environment"),
+
+			"created_at": createdAtSchema(),
+			"updated_at": updatedAtSchema(),
 		},
 	}
 }
@@ -63,6 +69,7 @@ func buildForemanEnvironment(d *schema.ResourceData) *api.ForemanEnvironment {
 
 	obj := buildForemanObject(d)
 	environment.ForemanObject = *obj
+	environment.ForemanTaxonomies = buildForemanTaxonomies(d)
 
 	var attr interface{}
 	var ok bool
@@ -80,6 +87,8 @@ func setResourceDataFromForemanEnvironment(d *schema.ResourceData, fe *api.Forem
 	log.Tracef("resource_foreman_environment.go#setResourceDataFromForemanEnvironment")
 
 	d.SetId(strconv.Itoa(fe.Id))
+	setResourceDataFromForemanObjectTimestamps(d, &fe.ForemanObject)
+	setResourceDataFromForemanTaxonomies(d, &fe.ForemanTaxonomies)
 	d.Set("name", fe.Name)
 }
 
@@ -117,7 +126,7 @@ func resourceForemanEnvironmentRead(d *schema.ResourceData, meta interface{}) er
 
 	readEnvironment, readErr := client.ReadEnvironment(e.Id)
 	if readErr != nil {
-		return readErr
+		return handleReadError(d, readErr)
 	}
 
 	log.Debugf("Read ForemanEnvironment: [%+v]", readEnvironment)
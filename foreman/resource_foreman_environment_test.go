@@ -5,13 +5,14 @@ import (
 	"math/rand"
 	"net/http"
 	"reflect"
+	"sort"
 	"strconv"
 	"testing"
 
 	"github.com/HanseMerkur/terraform-provider-foreman/foreman/api"
 
-	"github.com/hashicorp/terraform/helper/schema"
-	"github.com/hashicorp/terraform/terraform"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
 
 // -----------------------------------------------------------------------------
@@ -27,6 +28,8 @@ func ForemanEnvironmentToInstanceState(obj api.ForemanEnvironment) *terraform.In
 	state.ID = strconv.Itoa(obj.Id)
 	// Build the attribute map from ForemanEnvironment
 	attr := map[string]string{}
+	attr["created_at"] = obj.CreatedAt
+	attr["updated_at"] = obj.UpdatedAt
 	attr["name"] = obj.Name
 	state.Attributes = attr
 	return &state
@@ -116,6 +119,44 @@ func TestEnvironmentUnmarshalJSON_ForemanObject(t *testing.T) {
 
 }
 
+// Ensures the JSON unmarshal decodes the locations and organizations to their
+// IDs and the IDs are sent back when the environment is built
+func TestEnvironmentUnmarshalJSON_Taxonomies(t *testing.T) {
+
+	environmentJSON := []byte(`{
+		"id": 7,
+		"name": "production",
+		"locations": [{"id": 2, "name": "dc1"}],
+		"organizations": [{"id": 1, "name": "acme"}, {"id": 4, "name": "corp"}]
+	}`)
+
+	var obj api.ForemanEnvironment
+	if jsonDecErr := json.Unmarshal(environmentJSON, &obj); jsonDecErr != nil {
+		t.Fatalf("ForemanEnvironment UnmarshalJSON failed: [%s]", jsonDecErr)
+	}
+	expected := api.ForemanTaxonomies{
+		LocationIds:     []int{2},
+		OrganizationIds: []int{1, 4},
+	}
+	if !reflect.DeepEqual(obj.ForemanTaxonomies, expected) {
+		t.Fatalf(
+			"ForemanEnvironment UnmarshalJSON did not properly decode the "+
+				"taxonomies. Expected [%+v], got [%+v]",
+			expected,
+			obj.ForemanTaxonomies,
+		)
+	}
+
+	d := MockForemanEnvironmentResourceData(ForemanEnvironmentToInstanceState(api.ForemanEnvironment{}))
+	setResourceDataFromForemanEnvironment(d, &obj)
+	actual := buildForemanEnvironment(d).ForemanTaxonomies
+	sort.Ints(actual.OrganizationIds)
+	if !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("expected the taxonomies [%+v] to be set, got [%+v]", expected, actual)
+	}
+
+}
+
 // -----------------------------------------------------------------------------
 // setResourceDataFromForemanEnvironment
 // -----------------------------------------------------------------------------
@@ -1,30 +1,74 @@
 package foreman
 
 import (
+	"context"
 	"fmt"
+	"net"
+	"sort"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/HanseMerkur/terraform-provider-foreman/foreman/api"
 	"github.com/wayfair/terraform-provider-utils/autodoc"
 	"github.com/wayfair/terraform-provider-utils/log"
 
-	"github.com/hashicorp/terraform/helper/schema"
-	"github.com/hashicorp/terraform/helper/validation"
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
+// hostInPlaceUpdates lists the changes which recreate a host unless they are
+// listed in the host's update_in_place attribute.
+var hostInPlaceUpdates = []string{
+	"operatingsystem",
+	"hostgroup",
+	"compute_resource",
+	"compute_attributes",
+}
+
+// hostInPlaceUpdateAttributes maps each of the hostInPlaceUpdates to the
+// attributes making up that change.
+var hostInPlaceUpdateAttributes = map[string][]string{
+	"operatingsystem":    []string{"operatingsystem_id", "operatingsystem_title"},
+	"hostgroup":          []string{"hostgroup_id", "hostgroup_title"},
+	"compute_resource":   []string{"compute_resource_id"},
+	"compute_attributes": []string{"compute_profile_id", "vmware", "libvirt", "ovirt", "ec2", "gce", "azure", "openstack"},
+}
+
+// Behaviors of a host on destroy
+const (
+	// hostDestroyDelete : the host is deleted from Foreman
+	hostDestroyDelete = "delete"
+	// hostDestroyUnmanage : the host is kept in Foreman, unmanaged
+	hostDestroyUnmanage = "unmanage"
+)
+
+// newProgressReportId returns the UUID tracking the orchestration tasks of a
+// host creation.  See api.ForemanHost.ProgressReportId.
+var newProgressReportId = func() string {
+	return uuid.New().String()
+}
+
 func resourceForemanHost() *schema.Resource {
-	return &schema.Resource{
+	r := &schema.Resource{
 
-		Create: resourceForemanHostCreate,
-		Read:   resourceForemanHostRead,
-		Update: resourceForemanHostUpdate,
-		Delete: resourceForemanHostDelete,
+		// NOTE(ALL): create and update wait for the host, which is cancelled
+		//   when the apply is interrupted
+		CreateContext: resourceForemanHostCreate,
+		Read:          resourceForemanHostRead,
+		UpdateContext: withDiagnostics(resourceForemanHostUpdate),
+		Delete:        resourceForemanHostDelete,
 
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
 		},
 
+		SchemaVersion: 1,
+
+		CustomizeDiff: resourceForemanHostCustomizeDiff,
+
 		Schema: map[string]*schema.Schema{
 
 			autodoc.MetaAttribute: &schema.Schema{
@@ -36,19 +80,28 @@ func resourceForemanHost() *schema.Resource {
 				),
 			},
 
-			// -- Required --
+			// -- Computed --
 
 			"name": &schema.Schema{
 				Type:     schema.TypeString,
 				ForceNew: true,
-				Required: true,
+				Optional: true,
+				Computed: true,
 				Description: fmt.Sprintf(
-					"Host fully qualified domain name. "+
+					"Host fully qualified domain name. When omitted, Foreman's "+
+						"name generator assigns a name to the host on creation. "+
 						"%s \"compute01.dc1.company.com\"",
 					autodoc.MetaExample,
 				),
 			},
 
+			"fqdn": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+				Description: "Fully qualified domain name of the host, the name " +
+					"joined with the name of the host's domain.",
+			},
+
 			// -- Optional --
 
 			"method": &schema.Schema{
@@ -60,10 +113,137 @@ func resourceForemanHost() *schema.Resource {
 					"build",
 					"image",
 				}, false),
-				Description: "Chooses a method with which to provision the Host" +
+				Description: "Chooses a method with which to provision the Host. " +
 					"Options are \"build\" and \"image\"",
 			},
 
+			"build": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+				Description: "Marks an existing host for rebuild when switched from " +
+					"`false` to `true`. Other updates leave the build state of the " +
+					"host untouched. Defaults to `false`.",
+			},
+			"reboot_on_rebuild": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+				Description: "Power cycles the host after marking it for rebuild so " +
+					"the rebuild starts right away. With `enable_bmc`, the next " +
+					"boot is set to PXE first. Defaults to `false`.",
+			},
+
+			"provision_boot": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+				Description: "Boots the host from PXE on create and on rebuild. The " +
+					"next boot device is set to PXE through the BMC and the host is " +
+					"power cycled, a rebuild does not require `reboot_on_rebuild`. " +
+					"Virtual machines without a BMC interface are powered through " +
+					"their compute resource and boot as configured by their compute " +
+					"profile. " +
+					"Implied by `enable_bmc`. Ignored when `bmc` is disabled in the " +
+					"provider's `features` block. Defaults to `false`.",
+			},
+
+			"readiness_check": readinessCheckSchema(),
+
+			"rebuild_power_action": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  api.PowerCycle,
+				ValidateFunc: validation.StringInSlice([]string{
+					api.PowerSoft,
+					api.PowerCycle,
+					// NOTE(ALL): false - do not ignore case when comparing values
+				}, false),
+				Description: "How `reboot_on_rebuild` reboots the host. `\"cycle\"` " +
+					"resets the host hard. `\"soft\"` reboots it through ACPI, which " +
+					"is gentler on fragile hardware. BMCs can not reboot a host " +
+					"softly, with `enable_bmc` the host is shut down gracefully " +
+					"within `shutdown_grace_period` and powered back on instead. " +
+					"Defaults to `\"cycle\"`.",
+			},
+
+			"destroy_behavior": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  hostDestroyDelete,
+				ValidateFunc: validation.StringInSlice([]string{
+					hostDestroyDelete,
+					hostDestroyUnmanage,
+					// NOTE(ALL): false - do not ignore case when comparing values
+				}, false),
+				Description: "What happens to the host on destroy. `\"delete\"` " +
+					"deletes the host from Foreman. `\"unmanage\"` keeps the host " +
+					"and its history in Foreman: its build is cancelled, Foreman " +
+					"no longer manages it and it is only removed from the state. " +
+					"The host is not shut down or powered off when unmanaged. " +
+					"Defaults to `\"delete\"`.",
+			},
+
+			"shutdown_on_destroy": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+				Description: "Shuts the host down before it is deleted. The host is " +
+					"asked to shut down gracefully first and powered off once " +
+					"`shutdown_grace_period` expires. The host is not deleted if it " +
+					"can not be powered off. Defaults to `false`.",
+			},
+
+			"power_off_on_destroy": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+				Description: "Powers the host off before it is deleted, through its " +
+					"BMC or its compute resource. Foreman refuses to delete some " +
+					"running virtual machines. Unlike `shutdown_on_destroy`, the " +
+					"host is not asked to shut down gracefully. The host is not " +
+					"deleted if it can not be powered off. Ignored with " +
+					"`shutdown_on_destroy`. Defaults to `false`.",
+			},
+
+			"shutdown_grace_period": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      120,
+				ValidateFunc: validation.IntAtLeast(0),
+				Description: "Number of seconds the host has to shut down gracefully " +
+					"with `shutdown_on_destroy` or a `\"soft\"` " +
+					"`rebuild_power_action`, before it is powered off. A value of " +
+					"`0` powers the host off right away. Defaults to `120`.",
+			},
+
+			"update_in_place": &schema.Schema{
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringInSlice(hostInPlaceUpdates, false),
+				},
+				Set: schema.HashString,
+				Description: "Changes applied to the existing host instead of " +
+					"recreating it. Any of \"operatingsystem\", \"hostgroup\", " +
+					"\"compute_resource\" and \"compute_attributes\". By default, " +
+					"changing the operating system, hostgroup, compute resource, " +
+					"compute profile or the compute resource specific block " +
+					"(ie: `vmware`) recreates the host. With \"compute_attributes\" " +
+					"the virtual machine is resized by its compute resource instead, " +
+					"see `reboot_on_resize`.",
+			},
+			"reboot_on_resize": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+				Description: "Reboots the virtual machine through its compute " +
+					"resource after its compute profile or compute attributes were " +
+					"changed in place, for hypervisors which apply a new CPU or " +
+					"memory size on the next boot only. Defaults to `false`.",
+			},
+
 			"comment": &schema.Schema{
 				Type:         schema.TypeString,
 				ForceNew:     true,
@@ -73,6 +253,16 @@ func resourceForemanHost() *schema.Resource {
 					"Note: Changes to this attribute will trigger a host rebuild.",
 				),
 			},
+			"root_password": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Sensitive:    true,
+				StateFunc:    hashSensitiveValue,
+				ValidateFunc: validation.StringLenBetween(8, 256),
+				Description: "Root password of the host. Overrides the root password " +
+					"inherited from the hostgroup. Only a hash of the password is " +
+					"stored in the state.",
+			},
 			"parameters": &schema.Schema{
 				Type:     schema.TypeMap,
 				ForceNew: false,
@@ -80,6 +270,23 @@ func resourceForemanHost() *schema.Resource {
 				Description: "A map of parameters that will be saved as host parameters " +
 					"in the machine config.",
 			},
+			"hidden_parameters": &schema.Schema{
+				Type:      schema.TypeMap,
+				ForceNew:  false,
+				Optional:  true,
+				Sensitive: true,
+				Description: "A map of parameters that will be saved as host parameters " +
+					"with their values masked in the Foreman UI.",
+			},
+			"user_data_parameters": &schema.Schema{
+				Type:     schema.TypeMap,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Description: "A map of parameters injected into the `user_data` " +
+					"template the host is bootstrapped with when cloned from an " +
+					"image with user data. They are saved as host parameters and " +
+					"must not overlap with `parameters`.",
+			},
 
 			"enable_bmc": &schema.Schema{
 				Type:     schema.TypeBool,
@@ -87,30 +294,122 @@ func resourceForemanHost() *schema.Resource {
 				Default:  false,
 				Description: "Enables PMI/BMC functionality. On create and update " +
 					"calls, having this enabled will force a host to poweroff, set next " +
-					"boot to PXE and power on. Defaults to `false`.",
+					"boot to PXE and power on. Ignored when `bmc` is disabled in the " +
+					"provider's `features` block. Defaults to `false`.",
+			},
+
+			"build_status": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+				Description: "Build status of the host reported by Foreman at the " +
+					"end of create (ie: `\"Pending installation\"`, " +
+					"`\"Installed\"`).",
+			},
+
+			"orchestration_errors": &schema.Schema{
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Description: "Orchestration tasks of the host creation which failed " +
+					"(ie: DNS or DHCP records), along with their status.",
+			},
+
+			"build_duration": &schema.Schema{
+				Type:     schema.TypeInt,
+				Computed: true,
+				Description: "Number of seconds the creation of the host took, " +
+					"including the power operations and the `readiness_check`.",
+			},
+
+			"boot_device": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringInSlice(api.BootDevices, false),
+				Description: "Boot device the host keeps booting from, one of " +
+					"`\"disk\"`, `\"pxe\"`, `\"cdrom\"` or `\"bios\"`. Unlike the " +
+					"PXE boot of `provision_boot`, it applies to every boot. The " +
+					"boot device is set and read back through the BMC smart proxy " +
+					"of the subnet of the `bmc` interface, a boot device changed " +
+					"outside of Terraform is set again. Ignored when `bmc` is " +
+					"disabled in the provider's `features` block.",
+			},
+
+			"bmc_action": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringInSlice(api.BMCActions, false),
+				Description: "Sends an action to the BMC of the host whenever the " +
+					"attribute changes to a new value: `\"identify_on\"` and " +
+					"`\"identify_off\"` switch the chassis identify LED, " +
+					"`\"cold_reset\"` and `\"warm_reset\"` reset the BMC itself. " +
+					"The action is sent to the BMC smart proxy of the subnet of the " +
+					"`bmc` interface, which has to trust the provider's client. Set " +
+					"it to `\"\"` and back to repeat an action. A failed action is " +
+					"retried on the next apply. Ignored when `bmc` is disabled in " +
+					"the provider's `features` block.",
 			},
 
 			"retry_count": &schema.Schema{
+				Type:     schema.TypeInt,
+				Optional: true,
+				Default:  2,
+				Description: "Number of attempts made to create, update or power the host in Foreman before giving up. " +
+					"Power operations rejected by the BMC for its credentials, or failing for a missing " +
+					"BMC smart proxy, are not retried.",
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+
+			"retry_delay": &schema.Schema{
 				Type:         schema.TypeInt,
 				Optional:     true,
-				Default:      2,
-				Description:  "Number of times to retry on a failed attempt to register a new host in foreman.",
-				ValidateFunc: validation.IntAtLeast(1),
+				Default:      0,
+				ValidateFunc: validation.IntAtLeast(0),
+				Description: "Number of seconds to wait between two attempts to create, " +
+					"update or power the host. Defaults to `0`.",
+			},
+
+			"power_verify_count": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      0,
+				ValidateFunc: validation.IntAtLeast(0),
+				Description: "Number of times the power state of the host is queried " +
+					"after a power action to confirm it took effect. BMCs frequently " +
+					"acknowledge an action before the host changes its state. A value " +
+					"of `0` disables the verification. Defaults to `0`.",
+			},
+
+			"power_verify_delay": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      5,
+				ValidateFunc: validation.IntAtLeast(0),
+				Description: "Number of seconds to wait before each query of the power " +
+					"state. Defaults to `5`.",
+			},
+
+			"power_verify_timeout": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      0,
+				ValidateFunc: validation.IntAtLeast(0),
+				Description: "Number of seconds after a power action within which the " +
+					"host has to report the desired power state. The power state is " +
+					"queried until the host reports it or the timeout expires, " +
+					"limited to `power_verify_count` queries if that is set. A value " +
+					"of `0` disables the timeout. Defaults to `0`.",
 			},
 
 			"bmc_success": &schema.Schema{
 				Type:     schema.TypeBool,
-				Optional: true,
-				Default:  true,
-				Description: fmt.Sprintf(
-					"Tracks the partial state of BMC operations on host "+
-						"creation. If these operations fail, the host will be created in "+
-						"Foreman and this boolean will remain `false`. On the next "+
-						"`terraform apply` will trigger the host update to pick back up "+
-						"with the BMC operations. "+
-						"%s",
-					autodoc.MetaUnexported,
-				),
+				Computed: true,
+				Description: "Tracks the partial state of BMC operations on host " +
+					"creation. If these operations fail, the host is kept in Foreman " +
+					"and in the state and this boolean is set to `false`. The next " +
+					"`terraform apply` updates the host to pick back up with the BMC " +
+					"operations instead of recreating it.",
 			},
 
 			// -- Foreign Key Relationships --
@@ -135,7 +434,6 @@ func resourceForemanHost() *schema.Resource {
 			"operatingsystem_id": &schema.Schema{
 				Type:         schema.TypeInt,
 				Optional:     true,
-				ForceNew:     true,
 				Computed:     true,
 				ValidateFunc: validation.IntAtLeast(0),
 				Description:  "ID of the operating system to put on the host.",
@@ -148,10 +446,20 @@ func resourceForemanHost() *schema.Resource {
 				ValidateFunc: validation.IntAtLeast(0),
 				Description:  "ID of the medium mounted on the host.",
 			},
+			"ptable_id": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.IntAtLeast(0),
+				Description: "ID of the partition table of the host. Overrides " +
+					"the partition table inherited from the hostgroup. With " +
+					"`validate_references`, the OS family of the partition table " +
+					"is checked against the operating system of the host at plan time.",
+			},
 			"hostgroup_id": &schema.Schema{
 				Type:         schema.TypeInt,
 				Optional:     true,
-				ForceNew:     true,
+				Computed:     true,
 				ValidateFunc: validation.IntAtLeast(0),
 				Description:  "ID of the hostgroup to assign to the host.",
 			},
@@ -166,28 +474,117 @@ func resourceForemanHost() *schema.Resource {
 			"compute_resource_id": &schema.Schema{
 				Type:         schema.TypeInt,
 				Optional:     true,
-				ForceNew:     true,
 				ValidateFunc: validation.IntAtLeast(0),
 			},
 			"compute_profile_id": &schema.Schema{
 				Type:         schema.TypeInt,
 				Optional:     true,
-				ForceNew:     true,
 				Computed:     true,
 				ValidateFunc: validation.IntAtLeast(0),
 			},
+			"vmware":    vmwareSchema(),
+			"libvirt":   libvirtSchema(),
+			"ovirt":     ovirtSchema(),
+			"ec2":       ec2Schema(),
+			"gce":       gceSchema(),
+			"azure":     azureSchema(),
+			"openstack": openstackSchema(),
+
+			// -- Name-based Foreign Key Alternatives --
+
+			"domain_name": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+				Description: fmt.Sprintf(
+					"Name of the domain to assign to the host. Resolved to "+
+						"`domain_id` by the provider and takes precedence over it. "+
+						"%s \"dev.dc1.company.com\"",
+					autodoc.MetaExample,
+				),
+			},
+			"hostgroup_title": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Description: fmt.Sprintf(
+					"Title of the hostgroup to assign to the host. Resolved to "+
+						"`hostgroup_id` by the provider and takes precedence over it. "+
+						"%s \"BO1/VM/DEVP4\"",
+					autodoc.MetaExample,
+				),
+			},
+			"operatingsystem_title": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Description: fmt.Sprintf(
+					"Title of the operating system to put on the host. Resolved "+
+						"to `operatingsystem_id` by the provider and takes precedence "+
+						"over it. "+
+						"%s \"CentOS 7.5\"",
+					autodoc.MetaExample,
+				),
+			},
 
 			// -- Key Components --
 			"interfaces_attributes": &schema.Schema{
-				Type:        schema.TypeSet,
-				Optional:    true,
-				ForceNew:    true,
-				Elem:        resourceForemanInterfacesAttributes(),
-				Set:         schema.HashResource(resourceForemanInterfacesAttributes()),
-				Description: "Host interface information.",
+				Type:     schema.TypeSet,
+				Optional: true,
+				ForceNew: true,
+				Elem:     resourceForemanInterfacesAttributes(),
+				Set:      resourceForemanInterfacesAttributesHash,
+				Description: "Host interface information. Interfaces are " +
+					"identified by their `identifier`, or their MAC address if no " +
+					"identifier is given, so the order Foreman returns them in does " +
+					"not matter.",
+			},
+			"exclusive_interfaces": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+				Description: "Whether `interfaces_attributes` holds all of the " +
+					"host's interfaces. Set to false when additional interfaces " +
+					"are attached to the host with `foreman_host_interface`, so " +
+					"interfaces not configured here are left out of the state.",
 			},
+
+			"created_at": createdAtSchema(),
+			"updated_at": updatedAtSchema(),
+		},
+	}
+
+	// NOTE(ALL): the schema kept its shape in version 1, only the root
+	//   password is stored as a hash instead of plain text
+	r.StateUpgraders = []schema.StateUpgrader{
+		{
+			Version: 0,
+			Type:    r.CoreConfigSchema().ImpliedType(),
+			Upgrade: resourceForemanHostStateUpgradeV0,
 		},
 	}
+
+	return r
+}
+
+// resourceForemanInterfacesAttributesHash is the hash function of the
+// "interfaces_attributes" set.  Interfaces are keyed by their identifier,
+// falling back to the MAC address and then to the IP address and type.
+//
+// NOTE(ALL): Foreman returns the interfaces in arbitrary order and populates
+//   computed attributes (ie: "id", "mac") the configuration does not contain.
+//   Hashing every attribute of the interface leads to perpetual diffs and
+//   needless "_destroy" churn, so only the identifying attributes are hashed.
+//   Changes to any other attribute show up as a change of the element.
+func resourceForemanInterfacesAttributesHash(v interface{}) int {
+	m := v.(map[string]interface{})
+	if identifier, _ := m["identifier"].(string); identifier != "" {
+		return schema.HashString("identifier:" + identifier)
+	}
+	if mac, _ := m["mac"].(string); mac != "" {
+		return schema.HashString("mac:" + strings.ToLower(mac))
+	}
+	ip, _ := m["ip"].(string)
+	ifaceType, _ := m["type"].(string)
+	return schema.HashString("ip:" + ip + "/" + ifaceType)
 }
 
 // resourceForemanInterfacesAttributes is a nested resource that represents a
@@ -218,7 +615,7 @@ func resourceForemanInterfacesAttributes() *schema.Resource {
 				Type:         schema.TypeString,
 				Optional:     true,
 				ForceNew:     true,
-				ValidateFunc: validation.SingleIP(),
+				ValidateFunc: validation.IsIPAddress,
 				Description:  "IP address associated with the interface.",
 			},
 			"mac": &schema.Schema{
@@ -242,6 +639,13 @@ func resourceForemanInterfacesAttributes() *schema.Resource {
 				ForceNew:    true,
 				Description: "Identifier of this interface local to the host.",
 			},
+			"name": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Computed:    true,
+				Description: "DNS name associated with the interface.",
+			},
 			"managed": &schema.Schema{
 				Type:        schema.TypeBool,
 				Optional:    true,
@@ -275,18 +679,24 @@ func resourceForemanInterfacesAttributes() *schema.Resource {
 				Optional:    true,
 				Description: "Identifiers of attached interfaces, e.g. 'eth1', 'eth2' as comma-separated list",
 			},
+			// NOTE(ALL): the BMC credentials are updated in place, so they can be
+			//   rotated without replacing the host
 			"username": &schema.Schema{
-				Type:        schema.TypeString,
-				ForceNew:    true,
-				Optional:    true,
-				Description: "Username used for BMC/IPMI functionality.",
+				Type:     schema.TypeString,
+				Optional: true,
+				Description: "Username used for BMC/IPMI functionality. Changing " +
+					"the credentials of a BMC interface verifies the BMC is " +
+					"reachable with them.",
 			},
 			"password": &schema.Schema{
-				Type:        schema.TypeString,
-				Sensitive:   true,
-				ForceNew:    true,
-				Optional:    true,
-				Description: "Associated password used for BMC/IPMI functionality.",
+				Type:             schema.TypeString,
+				Sensitive:        true,
+				Optional:         true,
+				StateFunc:        encryptSensitiveValue,
+				DiffSuppressFunc: suppressSensitiveValueDiff,
+				Description: "Associated password used for BMC/IPMI functionality. " +
+					"Encrypted in the state with the provider's " +
+					"`state_encryption_key`, if set.",
 			},
 			"type": &schema.Schema{
 				Type:     schema.TypeString,
@@ -307,17 +717,18 @@ func resourceForemanInterfacesAttributes() *schema.Resource {
 				Type:     schema.TypeString,
 				Optional: true,
 				ForceNew: true,
-				ValidateFunc: validation.StringInSlice([]string{
-					"IPMI",
-					// NOTE(ALL): false - do not ignore case when comparing values
-				}, false),
+				// NOTE(ALL): false - do not ignore case when comparing values
+				ValidateFunc: validation.StringInSlice(api.BMCProviders, false),
 				Description: "Provider used for BMC/IMPI functionality. Values include: " +
-					"`\"IPMI\"`",
+					"`\"IPMI\"`, `\"Redfish\"`. Redfish interfaces require the BMC " +
+					"proxy of their subnet to support the Redfish provider.",
 			},
 			"compute_attributes": &schema.Schema{
-				Type:        schema.TypeMap,
-				Optional:    true,
-				Description: "Hypervisor specific interface options",
+				Type:     schema.TypeMap,
+				Optional: true,
+				Description: "Hypervisor specific interface options. On VMware " +
+					"compute resources, `nic_type` of the host's `vmware` block " +
+					"sets the adapter type of the interfaces not setting a `type`.",
 			},
 		},
 	}
@@ -345,6 +756,7 @@ func buildForemanHost(d *schema.ResourceData) *api.ForemanHost {
 	host.Name = d.Get("name").(string)
 	host.Comment = d.Get("comment").(string)
 	host.Method = d.Get("method").(string)
+	host.RootPassword = d.Get("root_password").(string)
 
 	if attr, ok = d.GetOk("domain_id"); ok {
 		host.DomainId = attr.(int)
@@ -364,6 +776,9 @@ func buildForemanHost(d *schema.ResourceData) *api.ForemanHost {
 	if attr, ok = d.GetOk("image_id"); ok {
 		host.ImageId = attr.(int)
 	}
+	if attr, ok = d.GetOk("ptable_id"); ok {
+		host.PtableId = attr.(int)
+	}
 	if attr, ok = d.GetOk("compute_resource_id"); ok {
 		host.ComputeResourceId = attr.(int)
 	}
@@ -379,8 +794,41 @@ func buildForemanHost(d *schema.ResourceData) *api.ForemanHost {
 			})
 		}
 	}
+	if attr, ok = d.GetOk("hidden_parameters"); ok {
+		hiddenTags := attr.(map[string]interface{})
+		for key, value := range hiddenTags {
+			host.HostParameters = append(host.HostParameters, api.ForemanKVParameter{
+				Name:        key,
+				Value:       value.(string),
+				HiddenValue: true,
+			})
+		}
+	}
+	if attr, ok = d.GetOk("user_data_parameters"); ok {
+		for key, value := range attr.(map[string]interface{}) {
+			host.HostParameters = append(host.HostParameters, api.ForemanKVParameter{
+				Name:  key,
+				Value: value.(string),
+			})
+		}
+	}
+
+	for _, buildComputeAttributes := range []func(*schema.ResourceData) map[string]interface{}{
+		buildForemanHostVMwareComputeAttributes,
+		buildForemanHostLibvirtComputeAttributes,
+		buildForemanHostEC2ComputeAttributes,
+		buildForemanHostGCEComputeAttributes,
+		buildForemanHostAzureComputeAttributes,
+	} {
+		if attrs := buildComputeAttributes(d); attrs != nil {
+			host.ComputeAttributes = attrs
+		}
+	}
 
 	host.InterfacesAttributes = buildForemanInterfacesAttributes(d)
+	applyForemanHostVMwareNICType(d, host.InterfacesAttributes)
+	applyForemanHostLibvirtNetwork(d, host.InterfacesAttributes)
+	applyForemanHostAzureNetwork(d, host.InterfacesAttributes)
 
 	return &host
 }
@@ -489,6 +937,14 @@ func mapToForemanInterfacesAttribute(m map[string]interface{}) api.ForemanInterf
 	if tempIntAttr.Password, ok = m["password"].(string); !ok {
 		tempIntAttr.Password = ""
 	}
+	// NOTE(ALL): unchanged passwords are read from the state, where they may
+	//   be encrypted
+	if password, decryptErr := decryptSensitiveValue(tempIntAttr.Password); decryptErr != nil {
+		log.Errorf("Could not decrypt the password of interface [%d]: %s", tempIntAttr.Id, decryptErr)
+		tempIntAttr.Password = ""
+	} else {
+		tempIntAttr.Password = password
+	}
 
 	if tempIntAttr.Identifier, ok = m["identifier"].(string); !ok {
 		tempIntAttr.Identifier = ""
@@ -518,20 +974,127 @@ func mapToForemanInterfacesAttribute(m map[string]interface{}) api.ForemanInterf
 		tempIntAttr.Destroy = false
 	}
 
-	log.Debugf("m: [%v], tempIntAttr: [%+v]", m, tempIntAttr)
+	log.Debugf("tempIntAttr: [%+v]", tempIntAttr)
 	return tempIntAttr
 }
 
+// buildForemanHostRetryConfig constructs the api.RetryConfig used for the
+// host's API calls from the "retry_count" and "retry_delay" attributes.
+func buildForemanHostRetryConfig(d *schema.ResourceData) api.RetryConfig {
+	return api.RetryConfig{
+		Count: d.Get("retry_count").(int),
+		Delay: time.Duration(d.Get("retry_delay").(int)) * time.Second,
+	}
+}
+
+// buildForemanHostPowerVerifyConfig constructs the api.PowerVerifyConfig used
+// to confirm the host's power actions from the "power_verify_count",
+// "power_verify_delay" and "power_verify_timeout" attributes.
+func buildForemanHostPowerVerifyConfig(d *schema.ResourceData) api.PowerVerifyConfig {
+	return api.PowerVerifyConfig{
+		Count:   d.Get("power_verify_count").(int),
+		Delay:   time.Duration(d.Get("power_verify_delay").(int)) * time.Second,
+		Timeout: time.Duration(d.Get("power_verify_timeout").(int)) * time.Second,
+	}
+}
+
+// resolveForemanHostForeignKeyNames resolves the name-based alternatives of
+// the host's foreign key attributes and sets the IDs on the supplied
+// ForemanHost reference.
+func resolveForemanHostForeignKeyNames(d *schema.ResourceData, client *api.Client, h *api.ForemanHost) error {
+	log.Tracef("resource_foreman_host.go#resolveForemanHostForeignKeyNames")
+
+	var resolveErr error
+	if h.DomainId, resolveErr = resolveForeignKeyName(d, client, "domain_name", h.DomainId, lookupDomainId); resolveErr != nil {
+		return resolveErr
+	}
+	if h.HostgroupId, resolveErr = resolveForeignKeyName(d, client, "hostgroup_title", h.HostgroupId, lookupHostgroupId); resolveErr != nil {
+		return resolveErr
+	}
+	if h.OperatingSystemId, resolveErr = resolveForeignKeyName(d, client, "operatingsystem_title", h.OperatingSystemId, lookupOperatingSystemId); resolveErr != nil {
+		return resolveErr
+	}
+	if resolveErr = resolveForemanHostOvirtComputeAttributes(d, client, h); resolveErr != nil {
+		return resolveErr
+	}
+	return resolveForemanHostOpenstackComputeAttributes(d, client, h)
+}
+
+// applyForemanHostDefaultParameters adds the provider's default host
+// parameters to the parameters of the supplied ForemanHost.  Parameters the
+// host sets itself win over the defaults.
+func applyForemanHostDefaultParameters(h *api.ForemanHost, defaults map[string]string) {
+	names := make([]string, 0, len(defaults))
+	for name := range defaults {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	set := map[string]bool{}
+	for _, param := range h.HostParameters {
+		set[param.Name] = true
+	}
+	for _, name := range names {
+		if !set[name] {
+			h.HostParameters = append(h.HostParameters, api.ForemanKVParameter{
+				Name:  name,
+				Value: defaults[name],
+			})
+		}
+	}
+}
+
+// omitForemanHostDefaultParameters returns the supplied host parameters
+// without the provider's default host parameters, so the defaults do not show
+// up in the parameters of the host.  A parameter is kept if the host sets it
+// itself or its value differs from the default (ie: the default changed).
+func omitForemanHostDefaultParameters(d *schema.ResourceData, params []api.ForemanKVParameter, defaults map[string]string) []api.ForemanKVParameter {
+	if len(defaults) == 0 {
+		return params
+	}
+	hostParameters, _ := d.Get("parameters").(map[string]interface{})
+
+	kept := []api.ForemanKVParameter{}
+	for _, param := range params {
+		value, isDefault := defaults[param.Name]
+		if _, isSet := hostParameters[param.Name]; isDefault && !isSet && !param.HiddenValue && param.Value == value {
+			continue
+		}
+		kept = append(kept, param)
+	}
+	return kept
+}
+
 // setResourceDataFromForemanHost sets a ResourceData's attributes from the
 // attributes of the supplied ForemanHost struct
 func setResourceDataFromForemanHost(d *schema.ResourceData, fh *api.ForemanHost) {
 	log.Tracef("resource_foreman_host.go#setResourceDataFromForemanHost")
 
 	d.SetId(strconv.Itoa(fh.Id))
+	setResourceDataFromForemanObjectTimestamps(d, &fh.ForemanObject)
 
 	d.Set("name", fh.Name)
+	d.Set("fqdn", fh.FQDN())
 	d.Set("comment", fh.Comment)
-	d.Set("parameters", fh.HostParameters)
+	parameters := map[string]string{}
+	hiddenParameters := map[string]string{}
+	// NOTE(ALL): user data parameters are plain host parameters in Foreman.
+	//   The parameters known to the state as user data parameters are kept
+	//   apart from the other parameters.
+	stateUserDataParameters, _ := d.Get("user_data_parameters").(map[string]interface{})
+	userDataParameters := map[string]string{}
+	for _, param := range fh.HostParameters {
+		if _, ok := stateUserDataParameters[param.Name]; ok && !param.HiddenValue {
+			userDataParameters[param.Name] = param.Value
+		} else if param.HiddenValue {
+			hiddenParameters[param.Name] = param.Value
+		} else {
+			parameters[param.Name] = param.Value
+		}
+	}
+	d.Set("parameters", parameters)
+	d.Set("hidden_parameters", hiddenParameters)
+	d.Set("user_data_parameters", userDataParameters)
 	d.Set("domain_id", fh.DomainId)
 	d.Set("environment_id", fh.EnvironmentId)
 	d.Set("hostgroup_id", fh.HostgroupId)
@@ -540,20 +1103,7 @@ func setResourceDataFromForemanHost(d *schema.ResourceData, fh *api.ForemanHost)
 	d.Set("operatingsystem_id", fh.OperatingSystemId)
 	d.Set("medium_id", fh.MediumId)
 	d.Set("image_id", fh.ImageId)
-
-	// In partial mode, flag keys below as completed successfully
-	d.SetPartial("name")
-	d.SetPartial("comment")
-	d.SetPartial("parameters")
-	d.SetPartial("domain_id")
-	d.SetPartial("environment_id")
-	d.SetPartial("hostgroup_id")
-	d.SetPartial("compute_resource_id")
-	d.SetPartial("compute_profile_id")
-	d.SetPartial("operatingsystem_id")
-	d.SetPartial("medium_id")
-	d.SetPartial("image_id")
-	d.SetPartial("enable_bmc")
+	d.Set("ptable_id", fh.PtableId)
 
 	setResourceDataFromForemanInterfacesAttributes(d, fh.InterfacesAttributes)
 }
@@ -564,13 +1114,53 @@ func setResourceDataFromForemanHost(d *schema.ResourceData, fh *api.ForemanHost)
 func setResourceDataFromForemanInterfacesAttributes(d *schema.ResourceData, fhia []api.ForemanInterfacesAttribute) {
 	// this attribute is a *schema.Set.  In order to construct a set, we need to
 	// supply a hash function so the set can differentiate for uniqueness of
-	// entries.  The hash function will be based on the resource definition
-	hashFunc := schema.HashResource(resourceForemanInterfacesAttributes())
+	// entries.  The hash function keys the interfaces by their identifier
+	hashFunc := resourceForemanInterfacesAttributesHash
 	// underneath, a *schema.Set stores an array of map[string]interface{} entries.
 	// convert each ForemanInterfaces struct in the supplied array to a
 	// mapstructure and then add it to the set
-	ifaceArr := make([]interface{}, len(fhia))
-	for idx, val := range fhia {
+	var stateIfaces []map[string]interface{}
+	if stateSet, ok := d.Get("interfaces_attributes").(*schema.Set); ok {
+		for _, iface := range stateSet.List() {
+			stateIfaces = append(stateIfaces, iface.(map[string]interface{}))
+		}
+	}
+	exclusive := d.Get("exclusive_interfaces").(bool)
+	ifaceArr := make([]interface{}, 0, len(fhia))
+	for _, val := range fhia {
+		stateIface := matchForemanInterfacesAttribute(stateIfaces, val)
+		// NOTE(ALL): Interfaces managed through foreman_host_interface are not
+		//   part of the host's configuration.  Skip the interfaces unknown to
+		//   the state unless there are none yet, ie: on import.
+		if !exclusive && stateIface == nil && len(stateIfaces) > 0 {
+			continue
+		}
+		// NOTE(ALL): Map the interface back onto the one known to the state so
+		//   it keeps the key it was configured with.  Foreman fills in the
+		//   identifier of interfaces configured by MAC address, IP address or
+		//   subnet only, as well as the MAC address and the IP address (IPAM)
+		//   of interfaces configured without them.  These would otherwise
+		//   change the element's hash and replace the interface.
+		if stateIface != nil {
+			identifier, _ := stateIface["identifier"].(string)
+			mac, _ := stateIface["mac"].(string)
+			ip, _ := stateIface["ip"].(string)
+			if identifier == "" {
+				val.Identifier = ""
+				if mac == "" {
+					val.MAC = ""
+					if ip == "" {
+						val.IP = ""
+					}
+				}
+			}
+			// NOTE(ALL): Foreman does not return the BMC password.  Carry over
+			//   the password known to the state for the interface so it does
+			//   not show up as a change.
+			if val.Password == "" {
+				val.Password, _ = stateIface["password"].(string)
+			}
+		}
 		// NOTE(ALL): we ommit the "_destroy" property here - this does not need
 		//   to be stored by terraform in the state file. That is a hidden key that
 		//   is only used in updates.  Anything that exists will always have it
@@ -579,6 +1169,7 @@ func setResourceDataFromForemanInterfacesAttributes(d *schema.ResourceData, fhia
 			"id":           val.Id,
 			"ip":           val.IP,
 			"mac":          val.MAC,
+			"identifier":   val.Identifier,
 			"name":         val.Name,
 			"subnet_id":    val.SubnetId,
 			"primary":      val.Primary,
@@ -588,7 +1179,7 @@ func setResourceDataFromForemanInterfacesAttributes(d *schema.ResourceData, fhia
 			"type":         val.Type,
 			"bmc_provider": val.Provider,
 			"username":     val.Username,
-			"password":     val.Password,
+			"password":     encryptSensitiveValue(val.Password),
 
 			"attached_devices": val.AttachedDevices,
 			"attached_to":      val.AttachedTo,
@@ -596,93 +1187,1006 @@ func setResourceDataFromForemanInterfacesAttributes(d *schema.ResourceData, fhia
 			// NOTE(ALL): These settings only apply to virtual machines
 			"compute_attributes": val.ComputeAttributes,
 		}
-		ifaceArr[idx] = ifaceMap
+		ifaceArr = append(ifaceArr, ifaceMap)
 	}
 	// with the array set up, create the *schema.Set and set the ResourceData's
 	// "interfaces_attributes" property
 	tempIntAttrSet := schema.NewSet(hashFunc, ifaceArr)
 	d.Set("interfaces_attributes", tempIntAttrSet)
-
-	// For partial state, passing a prefix will flag all nested keys as successful
-	d.SetPartial("interfaces_attributes")
 }
 
-// -----------------------------------------------------------------------------
-// Resource CRUD Operations
-// -----------------------------------------------------------------------------
-
-func resourceForemanHostCreate(d *schema.ResourceData, meta interface{}) error {
-	log.Tracef("resource_foreman_host.go#Create")
-
-	client := meta.(*api.Client)
-	h := buildForemanHost(d)
-
-	// NOTE(ALL): Set the build flag to true on host create
+// matchForemanInterfacesAttribute returns the interface of the supplied list
+// that corresponds to the interface read from Foreman.  Interfaces are matched
+// by their ID, then by their identifier and then by their MAC address.
+// Interfaces configured without either are matched by their IP address, or by
+// their subnet when configured without an IP address.  If no interface
+// matches, nil is returned.
+func matchForemanInterfacesAttribute(ifaces []map[string]interface{}, fia api.ForemanInterfacesAttribute) map[string]interface{} {
+	if fia.Id > 0 {
+		for _, iface := range ifaces {
+			if id, _ := iface["id"].(int); id == fia.Id {
+				return iface
+			}
+		}
+	}
+	if fia.Identifier != "" {
+		for _, iface := range ifaces {
+			if identifier, _ := iface["identifier"].(string); identifier == fia.Identifier {
+				return iface
+			}
+		}
+	}
+	if fia.MAC != "" {
+		for _, iface := range ifaces {
+			if mac, _ := iface["mac"].(string); strings.EqualFold(mac, fia.MAC) {
+				return iface
+			}
+		}
+	}
+	// NOTE(ALL): interfaces configured by IP address or subnet only are
+	//   matched by the attributes they were configured with
+	for _, iface := range ifaces {
+		identifier, _ := iface["identifier"].(string)
+		mac, _ := iface["mac"].(string)
+		ip, _ := iface["ip"].(string)
+		ifaceType, _ := iface["type"].(string)
+		subnetId, _ := iface["subnet_id"].(int)
+		if identifier != "" || mac != "" || ifaceType != fia.Type {
+			continue
+		}
+		if ip != "" && ip == fia.IP {
+			return iface
+		}
+		if ip == "" && subnetId > 0 && subnetId == fia.SubnetId {
+			return iface
+		}
+	}
+	return nil
+}
+
+// -----------------------------------------------------------------------------
+// Plan-time Validation
+// -----------------------------------------------------------------------------
+
+// resourceForemanHostCustomizeDiff plans the completion of BMC operations
+// that failed during a previous apply, recreates the host for destructive
+// changes and verifies the host's references.
+func resourceForemanHostCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	log.Tracef("resource_foreman_host.go#CustomizeDiff")
+
+	if d.Id() != "" && !d.Get("bmc_success").(bool) {
+		if setErr := d.SetNew("bmc_success", true); setErr != nil {
+			return setErr
+		}
+	}
+
+	if forceNewErr := forceNewForemanHostChanges(d); forceNewErr != nil {
+		return forceNewErr
+	}
+
+	if overlapErr := validateForemanHostUserDataParameters(d); overlapErr != nil {
+		return overlapErr
+	}
+
+	if vmwareErr := validateForemanHostVMware(d); vmwareErr != nil {
+		return vmwareErr
+	}
+
+	if libvirtErr := validateForemanHostLibvirt(d); libvirtErr != nil {
+		return libvirtErr
+	}
+
+	return validateForemanHostReferences(d, meta)
+}
+
+// validateForemanHostUserDataParameters verifies the user data parameters
+// do not overlap with the other host parameters.  Both are saved as host
+// parameters, so a parameter set in both would flip between them.
+func validateForemanHostUserDataParameters(d *schema.ResourceDiff) error {
+	if !d.NewValueKnown("user_data_parameters") {
+		return nil
+	}
+	userDataParameters, _ := d.Get("user_data_parameters").(map[string]interface{})
+	for _, attr := range []string{"parameters", "hidden_parameters"} {
+		other, _ := d.Get(attr).(map[string]interface{})
+		for name := range userDataParameters {
+			if _, ok := other[name]; ok {
+				return fmt.Errorf(
+					"user_data_parameters [%s] is also set in %s",
+					name,
+					attr,
+				)
+			}
+		}
+	}
+	return nil
+}
+
+// forceNewForemanHostChanges recreates the host when its operating system,
+// hostgroup, compute resource or compute attributes change, unless the change
+// is listed in update_in_place.  Changing these in place can leave a host
+// which does not match its new configuration, so recreating it stays the
+// default.
+func forceNewForemanHostChanges(d *schema.ResourceDiff) error {
+	if d.Id() == "" {
+		return nil
+	}
+
+	inPlace := d.Get("update_in_place").(*schema.Set)
+	for update, attrs := range hostInPlaceUpdateAttributes {
+		if inPlace.Contains(update) {
+			log.Debugf("Updating [%s] of host [%s] in place", update, d.Id())
+			continue
+		}
+		for _, attr := range attrs {
+			if forceNewErr := forceNewForemanHostAttribute(d, attr); forceNewErr != nil {
+				return forceNewErr
+			}
+		}
+	}
+	return nil
+}
+
+// forceNewForemanHostAttribute recreates the host when the supplied
+// attribute changed.
+//
+// NOTE(ALL): ForceNew of a block only applies to its number of items, a
+//   changed attribute within the block does not recreate the host.  The
+//   changed attributes of blocks (ie: `vmware`) are forced new one by one.
+func forceNewForemanHostAttribute(d *schema.ResourceDiff, key string) error {
+	if !d.HasChange(key) {
+		return nil
+	}
+	if forceNewErr := d.ForceNew(key); forceNewErr != nil {
+		return forceNewErr
+	}
+
+	o, n := d.GetChange(key)
+	oItems, _ := o.([]interface{})
+	nItems, _ := n.([]interface{})
+	for idx := 0; idx < len(oItems) || idx < len(nItems); idx++ {
+		attrs := map[string]bool{}
+		for _, items := range [][]interface{}{oItems, nItems} {
+			if idx >= len(items) {
+				continue
+			}
+			item, _ := items[idx].(map[string]interface{})
+			for attr := range item {
+				attrs[attr] = true
+			}
+		}
+		for attr := range attrs {
+			forceNewErr := forceNewForemanHostAttribute(d, fmt.Sprintf("%s.%d.%s", key, idx, attr))
+			if forceNewErr != nil {
+				return forceNewErr
+			}
+		}
+	}
+	return nil
+}
+
+// foremanHostComputeAttributesChanged returns whether or not the compute
+// profile or the compute resource specific block of the host changed, which
+// resizes its virtual machine when updated in place.
+func foremanHostComputeAttributesChanged(d *schema.ResourceData) bool {
+	for _, attr := range hostInPlaceUpdateAttributes["compute_attributes"] {
+		if d.HasChange(attr) {
+			return true
+		}
+	}
+	return false
+}
+
+// foremanHostBMCEnabled returns whether or not BMC operations are performed
+// for the host.  The provider's "features" block can disable BMC operations
+// for every host, in which case Foreman's default power behaviour is used.
+func foremanHostBMCEnabled(d *schema.ResourceData, client *api.Client) bool {
+	if !d.Get("enable_bmc").(bool) {
+		return false
+	}
+	if client.Config().DisableBMC {
+		log.Debugf("BMC operations are disabled by the provider features")
+		return false
+	}
+	return true
+}
+
+// foremanHostBMCCredentials returns the credentials of the BMC interfaces in
+// the supplied "interfaces_attributes" set, keyed by the hash of the interface
+func foremanHostBMCCredentials(v interface{}) map[int]string {
+	credentials := map[int]string{}
+	ifaceSet, ok := v.(*schema.Set)
+	if !ok {
+		return credentials
+	}
+	for _, iface := range ifaceSet.List() {
+		ifaceMap := iface.(map[string]interface{})
+		if ifaceMap["type"] != "bmc" {
+			continue
+		}
+		username, _ := ifaceMap["username"].(string)
+		password, _ := ifaceMap["password"].(string)
+		// NOTE(ALL): compare the secrets, the old one may be encrypted
+		if decrypted, decryptErr := decryptSensitiveValue(password); decryptErr == nil {
+			password = decrypted
+		}
+		credentials[resourceForemanInterfacesAttributesHash(ifaceMap)] = username + "\x00" + password
+	}
+	return credentials
+}
+
+// foremanHostBMCCredentialsChanged returns whether or not the credentials of
+// any of the BMC interfaces of the host changed
+func foremanHostBMCCredentialsChanged(d *schema.ResourceData) bool {
+	if !d.HasChange("interfaces_attributes") {
+		return false
+	}
+	oldVal, newVal := d.GetChange("interfaces_attributes")
+	oldCredentials := foremanHostBMCCredentials(oldVal)
+	for hash, credentials := range foremanHostBMCCredentials(newVal) {
+		if oldCredentials[hash] != credentials {
+			return true
+		}
+	}
+	return false
+}
+
+// foremanHostProvisionBoot returns whether or not the host is booted from PXE
+// and power cycled through its BMC on create and rebuild.  This is the case
+// with "provision_boot" as well as with "enable_bmc", unless the provider's
+// "features" block disables BMC operations.
+func foremanHostProvisionBoot(d *schema.ResourceData, client *api.Client) bool {
+	if !d.Get("provision_boot").(bool) {
+		return foremanHostBMCEnabled(d, client)
+	}
+	if client.Config().DisableBMC {
+		log.Debugf("BMC operations are disabled by the provider features")
+		return false
+	}
+	return true
+}
+
+// foremanHostHasBMCInterface returns whether or not the host has a BMC
+// interface
+func foremanHostHasBMCInterface(d *schema.ResourceData) bool {
+	return len(foremanHostBMCCredentials(d.Get("interfaces_attributes"))) > 0
+}
+
+// shutdownForemanHost shuts the host down before it is deleted.  Hosts with
+// BMC operations enabled are shut down through their BMC with a soft power
+// off, other hosts through their compute resource.  With
+// "power_off_on_destroy" instead of "shutdown_on_destroy" the host is powered
+// off right away.
+func shutdownForemanHost(d *schema.ResourceData, client *api.Client, h *api.ForemanHost, retry api.RetryConfig) error {
+	gracefulAction := api.PowerStop
+	if foremanHostBMCEnabled(d, client) && foremanHostHasBMCInterface(d) {
+		gracefulAction = api.PowerSoft
+	}
+	attr := "power_off_on_destroy"
+	grace := time.Duration(0)
+	if d.Get("shutdown_on_destroy").(bool) {
+		attr = "shutdown_on_destroy"
+		grace = time.Duration(d.Get("shutdown_grace_period").(int)) * time.Second
+	}
+
+	log.Debugf("Shutting down host [%d] with [%s] within [%s]", h.Id, gracefulAction, grace)
+
+	shutdownErr := client.ShutdownHost(h, gracefulAction, grace, retry, buildForemanHostPowerVerifyConfig(d))
+	if shutdownErr != nil {
+		return fmt.Errorf(
+			"Host [%d] could not be shut down, it is not deleted. Disable "+
+				"%s to delete it regardless: %s",
+			h.Id,
+			attr,
+			shutdownErr,
+		)
+	}
+	return nil
+}
+
+// validateForemanRedfishProxy returns an error if the BMC proxy identified by
+// the supplied ID does not support the Redfish provider
+func validateForemanRedfishProxy(client *api.Client, id int) error {
+	if !client.Config().LookupEnabled(api.LookupSmartProxy) {
+		log.Debugf("Smart proxy lookups are disabled, not verifying BMC proxy [%d]", id)
+		return nil
+	}
+	proxy, readErr := client.ReadSmartProxy(id)
+	if readErr != nil {
+		return fmt.Errorf("BMC proxy [%d] could not be verified: %s", id, readErr)
+	}
+	if !proxy.HasCapability(api.SmartProxyFeatureBMC, api.BMCProviderRedfish) {
+		return fmt.Errorf(
+			"BMC proxy [%s] does not support the [%s] provider",
+			proxy.Name,
+			api.BMCProviderRedfish,
+		)
+	}
+	return nil
+}
+
+// verifyForemanHostBMC verifies the BMC of the host identified by the
+// supplied ID is reachable by querying its power state through Foreman
+func verifyForemanHostBMC(client *api.Client, id int) error {
+	if !client.Config().LookupEnabled(api.LookupPowerState) {
+		log.Debugf("Power state lookups are disabled, not verifying the BMC of host [%d]", id)
+		return nil
+	}
+	state, stateErr := client.ReadPowerState(id)
+	if stateErr != nil {
+		return fmt.Errorf("BMC of host [%d] could not be verified: %s", id, stateErr)
+	}
+	log.Debugf("BMC of host [%d] reports power state [%s]", id, state)
+	return nil
+}
+
+// withDiagnostics adapts a CRUD function returning an error to the context
+// aware CRUD functions of the SDK
+func withDiagnostics(f func(context.Context, *schema.ResourceData, interface{}) error) func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics {
+	return func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+		return diag.FromErr(f(ctx, d, meta))
+	}
+}
+
+// waitForForemanHost waits until the host passes its readiness check.  When
+// the wait is interrupted (ie: the apply is aborted) while the host builds,
+// the build is cancelled so the host is not left in a reinstall loop.
+func waitForForemanHost(ctx context.Context, client *api.Client, check *foremanHostReadinessCheck, id int, building bool) error {
+	waitErr := check.wait(ctx)
+	if waitErr == nil || ctx.Err() == nil || !building {
+		return waitErr
+	}
+	log.Debugf("Cancelling the build of host [%d]", id)
+	if cancelErr := client.CancelHostBuild(id); cancelErr != nil {
+		return fmt.Errorf("%s, cancelling the build of the host failed: %s", waitErr, cancelErr)
+	}
+	return fmt.Errorf("%s, the build of the host was cancelled", waitErr)
+}
+
+// setForemanHostBuildStatus sets the "build_status", "orchestration_errors"
+// and "build_duration" attributes at the end of the creation of the host.  The
+// statuses are informational, errors reading them are logged only.
+func setForemanHostBuildStatus(d *schema.ResourceData, client *api.Client, id int, progressReportId string, started time.Time) {
+	d.Set("build_duration", int(time.Since(started).Seconds()))
+	if !client.Config().LookupEnabled(api.LookupBuildStatus) {
+		return
+	}
+
+	status, statusErr := client.ReadHostStatus(id, api.HostStatusBuild)
+	if statusErr != nil {
+		log.Errorf("Build status of host [%d] could not be read: %s", id, statusErr)
+	} else {
+		d.Set("build_status", status.StatusLabel)
+	}
+
+	orchestrationErrors := []string{}
+	tasks, tasksErr := client.ReadOrchestrationTasks(progressReportId)
+	if tasksErr != nil {
+		log.Errorf("Orchestration tasks of host [%d] could not be read: %s", id, tasksErr)
+	}
+	for _, task := range tasks {
+		if task.Status == api.OrchestrationTaskFailed || task.Status == api.OrchestrationTaskConflict {
+			orchestrationErrors = append(orchestrationErrors, fmt.Sprintf("%s: %s", task.Name, task.Status))
+		}
+	}
+	d.Set("orchestration_errors", orchestrationErrors)
+}
+
+// foremanHostBMCProxy returns the BMC interface of the host and the BMC
+// smart proxy of the subnet of the interface
+func foremanHostBMCProxy(d *schema.ResourceData, client *api.Client, id int) (*api.ForemanSmartProxy, api.BMCInterface, error) {
+	bmc := api.BMCInterface{}
+
+	var bmcIface map[string]interface{}
+	if ifaceSet, ok := d.Get("interfaces_attributes").(*schema.Set); ok {
+		for _, iface := range ifaceSet.List() {
+			if ifaceMap := iface.(map[string]interface{}); ifaceMap["type"] == "bmc" {
+				bmcIface = ifaceMap
+			}
+		}
+	}
+	if bmcIface == nil {
+		return nil, bmc, fmt.Errorf("Host [%d] has no bmc interface", id)
+	}
+
+	subnetId, _ := bmcIface["subnet_id"].(int)
+	if subnetId == 0 {
+		return nil, bmc, fmt.Errorf("The bmc interface of host [%d] has no subnet", id)
+	}
+	subnet, subnetErr := client.ReadSubnet(subnetId)
+	if subnetErr != nil {
+		return nil, bmc, subnetErr
+	}
+	if subnet.BMCId == 0 {
+		return nil, bmc, fmt.Errorf("Subnet [%d] of the bmc interface of host [%d] has no BMC proxy", subnetId, id)
+	}
+	proxy, proxyErr := client.ReadSmartProxy(subnet.BMCId)
+	if proxyErr != nil {
+		return nil, bmc, proxyErr
+	}
+
+	bmc.Address, _ = bmcIface["ip"].(string)
+	if bmc.Address == "" {
+		bmc.Address, _ = bmcIface["name"].(string)
+	}
+	bmc.Provider, _ = bmcIface["bmc_provider"].(string)
+	bmc.Username, _ = bmcIface["username"].(string)
+	bmc.Password, _ = bmcIface["password"].(string)
+	password, decryptErr := decryptSensitiveValue(bmc.Password)
+	if decryptErr != nil {
+		return nil, bmc, decryptErr
+	}
+	bmc.Password = password
+	return proxy, bmc, nil
+}
+
+// sendForemanHostBMCAction sends the "bmc_action" of the host to its BMC
+// through the BMC smart proxy of the subnet of its BMC interface
+func sendForemanHostBMCAction(d *schema.ResourceData, client *api.Client, id int, retry api.RetryConfig) error {
+	action := d.Get("bmc_action").(string)
+	if client.Config().DisableBMC {
+		log.Debugf("BMC operations are disabled by the provider features, not sending [%s]", action)
+		return nil
+	}
+	proxy, bmc, proxyErr := foremanHostBMCProxy(d, client, id)
+	if proxyErr != nil {
+		return proxyErr
+	}
+	log.Debugf("Sending BMC action [%s] to host [%d] through proxy [%s]", action, id, proxy.Name)
+	return client.SendBMCAction(id, proxy, bmc, action, retry)
+}
+
+// setForemanHostBootDevice sets the "boot_device" of the host through the BMC
+// smart proxy of the subnet of its BMC interface
+func setForemanHostBootDevice(d *schema.ResourceData, client *api.Client, id int, retry api.RetryConfig) error {
+	device := d.Get("boot_device").(string)
+	if client.Config().DisableBMC {
+		log.Debugf("BMC operations are disabled by the provider features, not setting [%s]", device)
+		return nil
+	}
+	proxy, bmc, proxyErr := foremanHostBMCProxy(d, client, id)
+	if proxyErr != nil {
+		return proxyErr
+	}
+	log.Debugf("Setting boot device [%s] of host [%d] through proxy [%s]", device, id, proxy.Name)
+	return client.SetBMCBootDevice(id, proxy, bmc, device, retry)
+}
+
+// readForemanHostBootDevice reads the boot device of the host into the
+// "boot_device" attribute, so a boot device changed outside of Terraform is
+// set again.  The boot device is only read when "boot_device" is set, errors
+// reading it are logged only and keep the attribute unchanged.
+func readForemanHostBootDevice(d *schema.ResourceData, client *api.Client, id int) {
+	if d.Get("boot_device").(string) == "" || client.Config().DisableBMC ||
+		!client.Config().LookupEnabled(api.LookupBootDevice) {
+		return
+	}
+	proxy, bmc, proxyErr := foremanHostBMCProxy(d, client, id)
+	if proxyErr != nil {
+		log.Errorf("Boot device of host [%d] could not be read: %s", id, proxyErr)
+		return
+	}
+	device, readErr := client.ReadBMCBootDevice(id, proxy, bmc)
+	if readErr != nil {
+		log.Errorf("Boot device of host [%d] could not be read: %s", id, readErr)
+		return
+	}
+	if device != "" {
+		d.Set("boot_device", device)
+	}
+}
+
+// validateForemanHostReferences verifies the objects referenced by the host
+// exist in Foreman and are compatible with each other when the provider is
+// configured with "validate_references".  Only references that are known and
+// changed in the plan are verified.
+func validateForemanHostReferences(d *schema.ResourceDiff, meta interface{}) error {
+	log.Tracef("resource_foreman_host.go#validateForemanHostReferences")
+
+	client, ok := meta.(*api.Client)
+	if !ok || client == nil || !client.Config().ValidateReferences {
+		return nil
+	}
+	// NOTE(ALL): the referenced objects are usually shared by many hosts
+	client = client.Memoized()
+
+	// changedReference returns the ID of the reference if it is set, known and
+	// changed in the plan.  Otherwise 0 is returned.
+	changedReference := func(attr string) int {
+		if !d.NewValueKnown(attr) || !d.HasChange(attr) {
+			return 0
+		}
+		id, _ := d.Get(attr).(int)
+		return id
+	}
+
+	if id := changedReference("hostgroup_id"); id > 0 {
+		if _, readErr := client.ReadHostgroup(id); readErr != nil {
+			return fmt.Errorf("hostgroup_id [%d] could not be verified: %s", id, readErr)
+		}
+	}
+
+	if id := changedReference("compute_profile_id"); id > 0 {
+		if _, readErr := client.ReadComputeProfile(id); readErr != nil {
+			return fmt.Errorf("compute_profile_id [%d] could not be verified: %s", id, readErr)
+		}
+	}
+
+	if id := changedReference("image_id"); id > 0 {
+		computeResourceId, _ := d.Get("compute_resource_id").(int)
+		if computeResourceId == 0 {
+			return fmt.Errorf("image_id [%d] requires compute_resource_id to be set", id)
+		}
+		// NOTE(ALL): images are nested under their compute resource - reading
+		//   the image through another compute resource fails
+		readImage, readErr := client.ReadImage(computeResourceId, id)
+		if readErr != nil {
+			return fmt.Errorf(
+				"image_id [%d] could not be verified on compute resource [%d]: %s",
+				id,
+				computeResourceId,
+				readErr,
+			)
+		}
+		osId, _ := d.Get("operatingsystem_id").(int)
+		if osId > 0 && readImage.OperatingSystemID > 0 && osId != readImage.OperatingSystemID {
+			return fmt.Errorf(
+				"image_id [%d] belongs to operating system [%d], the host uses "+
+					"operating system [%d]",
+				id,
+				readImage.OperatingSystemID,
+				osId,
+			)
+		}
+	}
+
+	if validateErr := validateForemanHostPartitionTable(d, client); validateErr != nil {
+		return validateErr
+	}
+
+	// NOTE(ALL): the typed compute attributes are silently ignored by other
+	//   types of compute resources
+	for block, provider := range foremanHostComputeBlocks {
+		if _, ok := foremanHostComputeBlock(d.Get(block)); !ok || !d.NewValueKnown("compute_resource_id") ||
+			!(d.HasChange("compute_resource_id") || d.HasChange(block)) {
+			continue
+		}
+		computeResourceId, _ := d.Get("compute_resource_id").(int)
+		if computeResourceId == 0 {
+			return fmt.Errorf("%s requires compute_resource_id to be set", block)
+		}
+		readComputeResource, readErr := client.ReadComputeResource(computeResourceId)
+		if readErr != nil {
+			return fmt.Errorf(
+				"compute_resource_id [%d] could not be verified: %s",
+				computeResourceId,
+				readErr,
+			)
+		}
+		if readComputeResource.Provider != provider {
+			return fmt.Errorf(
+				"%s requires a %s compute resource, compute resource [%d] is of type [%s]",
+				block,
+				provider,
+				computeResourceId,
+				readComputeResource.Provider,
+			)
+		}
+	}
+
+	if ovirtErr := validateForemanHostOvirtReferences(d, client); ovirtErr != nil {
+		return ovirtErr
+	}
+
+	if openstackErr := validateForemanHostOpenstackReferences(d, client); openstackErr != nil {
+		return openstackErr
+	}
+
+	if !d.NewValueKnown("interfaces_attributes") {
+		return nil
+	}
+	ifaceSet, ok := d.Get("interfaces_attributes").(*schema.Set)
+	if !ok {
+		return nil
+	}
+
+	// NOTE(ALL): the hypervisors reject unknown interface compute attributes
+	//   with errors that hardly point at the offending key.  Check the keys
+	//   against the type of the compute resource instead.
+	computeResourceId, _ := d.Get("compute_resource_id").(int)
+	if computeResourceId > 0 && d.NewValueKnown("compute_resource_id") &&
+		(d.HasChange("compute_resource_id") || d.HasChange("interfaces_attributes")) {
+		readComputeResource, readErr := client.ReadComputeResource(computeResourceId)
+		if readErr != nil {
+			return fmt.Errorf(
+				"compute_resource_id [%d] could not be verified: %s",
+				computeResourceId,
+				readErr,
+			)
+		}
+		for _, iface := range ifaceSet.List() {
+			ifaceMap := iface.(map[string]interface{})
+			computeAttributes, _ := ifaceMap["compute_attributes"].(map[string]interface{})
+			if validateErr := validateForemanInterfaceComputeAttributes(readComputeResource.Provider, computeAttributes); validateErr != nil {
+				return fmt.Errorf(
+					"compute_attributes of interface [%s] are invalid for compute "+
+						"resource [%d]: %s",
+					ifaceMap["identifier"],
+					computeResourceId,
+					validateErr,
+				)
+			}
+		}
+	}
+
+	if !d.HasChange("interfaces_attributes") {
+		return nil
+	}
+	for _, iface := range ifaceSet.List() {
+		ifaceMap := iface.(map[string]interface{})
+		subnetId, _ := ifaceMap["subnet_id"].(int)
+		if subnetId == 0 {
+			continue
+		}
+		subnet, readErr := client.ReadSubnet(subnetId)
+		if readErr != nil {
+			return fmt.Errorf("subnet_id [%d] could not be verified: %s", subnetId, readErr)
+		}
+		if ifaceMap["type"] == "bmc" && ifaceMap["bmc_provider"] == api.BMCProviderRedfish && subnet.BMCId > 0 {
+			if validateErr := validateForemanRedfishProxy(client, subnet.BMCId); validateErr != nil {
+				return fmt.Errorf(
+					"interface [%s] uses the Redfish provider: %s",
+					ifaceMap["identifier"],
+					validateErr,
+				)
+			}
+		}
+		// NOTE(ALL): only IPv4 subnets are checked for the interface address
+		ip := net.ParseIP(ifaceMap["ip"].(string))
+		mask := net.ParseIP(subnet.Mask).To4()
+		if ip == nil || mask == nil {
+			continue
+		}
+		network := net.IPNet{
+			IP:   net.ParseIP(subnet.Network),
+			Mask: net.IPMask(mask),
+		}
+		if !network.Contains(ip) {
+			return fmt.Errorf(
+				"ip [%s] is not part of subnet [%d] (%s/%s)",
+				ip,
+				subnetId,
+				subnet.Network,
+				subnet.Mask,
+			)
+		}
+	}
+
+	return nil
+}
+
+// validateForemanHostPartitionTable verifies the partition table of the host
+// is of the OS family of the host's operating system.  Foreman only offers
+// the partition tables of the operating system's family to the host, the
+// build of a host with another partition table fails.
+func validateForemanHostPartitionTable(d *schema.ResourceDiff, client *api.Client) error {
+	if !d.HasChanges("ptable_id", "operatingsystem_id") ||
+		!d.NewValueKnown("ptable_id") ||
+		!d.NewValueKnown("operatingsystem_id") {
+		return nil
+	}
+	ptableId, _ := d.Get("ptable_id").(int)
+	if ptableId == 0 {
+		return nil
+	}
+	readPtable, readErr := client.ReadPartitionTable(ptableId)
+	if readErr != nil {
+		return fmt.Errorf("ptable_id [%d] could not be verified: %s", ptableId, readErr)
+	}
+
+	osId, _ := d.Get("operatingsystem_id").(int)
+	if osId == 0 || readPtable.OSFamily == "" {
+		return nil
+	}
+	readOs, readErr := client.ReadOperatingSystem(osId)
+	if readErr != nil {
+		return fmt.Errorf("operatingsystem_id [%d] could not be verified: %s", osId, readErr)
+	}
+	if readOs.Family != "" && readOs.Family != readPtable.OSFamily {
+		return fmt.Errorf(
+			"ptable_id [%d] is a partition table of the [%s] OS family, the "+
+				"operating system [%d] of the host is of the [%s] family",
+			ptableId,
+			readPtable.OSFamily,
+			osId,
+			readOs.Family,
+		)
+	}
+	return nil
+}
+
+// interfaceComputeAttributes lists the interface compute attributes each
+// compute resource type supports, along with their valid values.  A nil list
+// of values accepts any value.  Compute resource types missing from the map
+// are not validated.
+var interfaceComputeAttributes = map[string]map[string][]string{
+	"Vmware": map[string][]string{
+		"network": nil,
+		"type": []string{
+			"VirtualE1000",
+			"VirtualE1000e",
+			"VirtualPCNet32",
+			"VirtualVmxnet",
+			"VirtualVmxnet3",
+		},
+	},
+	"Libvirt": map[string][]string{
+		"type": []string{
+			"network",
+			"bridge",
+		},
+		"network": nil,
+		"bridge":  nil,
+		"model": []string{
+			"virtio",
+			"rtl8139",
+			"ne2k_pci",
+			"pcnet",
+			"e1000",
+		},
+	},
+	"Ovirt": map[string][]string{
+		"name":         nil,
+		"network":      nil,
+		"interface":    nil,
+		"vnic_profile": nil,
+	},
+}
+
+// applyForemanInterfaceComputeAttributeDefaults sets the supplied compute
+// attributes on the network adapters of the interfaces which do not set the
+// supplied key (ie: "type") in their own compute attributes.  The other
+// compute attributes depend on the key, so an interface setting it keeps its
+// compute attributes as they are.
+func applyForemanInterfaceComputeAttributeDefaults(ifaces []api.ForemanInterfacesAttribute, key string, defaults map[string]interface{}) {
+	for idx, iface := range ifaces {
+		// NOTE(ALL): BMC and virtual interfaces have no network adapter
+		if iface.Type == "bmc" || iface.Virtual || iface.Destroy {
+			continue
+		}
+		if _, ok := iface.ComputeAttributes[key]; ok {
+			continue
+		}
+		computeAttributes := map[string]interface{}{}
+		for attr, value := range defaults {
+			computeAttributes[attr] = value
+		}
+		for attr, value := range iface.ComputeAttributes {
+			computeAttributes[attr] = value
+		}
+		ifaces[idx].ComputeAttributes = computeAttributes
+	}
+}
+
+// foremanHostComputeBlocks maps the typed compute attribute blocks of a host
+// to the provider of the compute resources they apply to
+var foremanHostComputeBlocks = map[string]string{
+	"vmware":    vmwareComputeResourceProvider,
+	"libvirt":   libvirtComputeResourceProvider,
+	"ovirt":     ovirtComputeResourceProvider,
+	"ec2":       ec2ComputeResourceProvider,
+	"gce":       gceComputeResourceProvider,
+	"azure":     azureComputeResourceProvider,
+	"openstack": openstackComputeResourceProvider,
+}
+
+// computeResourceObjectResolver resolves the ID or name of an object
+// available on a compute resource to its ID.  Objects scoped to a cluster are
+// resolved within the supplied cluster ID.
+type computeResourceObjectResolver func(kind string, clusterId string, idOrName string) (string, error)
+
+// newComputeResourceObjectResolver returns a computeResourceObjectResolver
+// resolving objects against the compute resource identified by the supplied
+// ID.
+func newComputeResourceObjectResolver(client *api.Client, computeResourceId int) computeResourceObjectResolver {
+	return func(kind string, clusterId string, idOrName string) (string, error) {
+		object, findErr := client.FindComputeResourceObject(computeResourceId, kind, clusterId, idOrName)
+		if findErr != nil {
+			return "", findErr
+		}
+		return object.Id, nil
+	}
+}
+
+// otherForemanHostComputeBlocks returns the typed compute attribute blocks of
+// a host other than the supplied one.  A host is deployed to a single type of
+// compute resource, so the blocks conflict with each other.
+func otherForemanHostComputeBlocks(block string) []string {
+	others := make([]string, 0, len(foremanHostComputeBlocks))
+	for other := range foremanHostComputeBlocks {
+		if other != block {
+			others = append(others, other)
+		}
+	}
+	sort.Strings(others)
+	return others
+}
+
+// foremanHostComputeBlock returns the typed compute attribute block of a
+// host and whether or not it is set.
+func foremanHostComputeBlock(v interface{}) (map[string]interface{}, bool) {
+	blocks, _ := v.([]interface{})
+	if len(blocks) == 0 || blocks[0] == nil {
+		return nil, false
+	}
+	block, ok := blocks[0].(map[string]interface{})
+	return block, ok
+}
+
+// validateForemanInterfaceComputeAttributes verifies the compute attributes
+// of an interface are supported by the supplied compute resource type.
+func validateForemanInterfaceComputeAttributes(provider string, computeAttributes map[string]interface{}) error {
+	supported, ok := interfaceComputeAttributes[provider]
+	if !ok {
+		return nil
+	}
+
+	for key, value := range computeAttributes {
+		values, ok := supported[key]
+		if !ok {
+			keys := make([]string, 0, len(supported))
+			for supportedKey := range supported {
+				keys = append(keys, supportedKey)
+			}
+			sort.Strings(keys)
+			return fmt.Errorf(
+				"[%s] is not supported by %s compute resources, supported keys "+
+					"are: %s",
+				key,
+				provider,
+				strings.Join(keys, ", "),
+			)
+		}
+		if values == nil {
+			continue
+		}
+		strValue, _ := value.(string)
+		valid := false
+		for _, v := range values {
+			if v == strValue {
+				valid = true
+				break
+			}
+		}
+		if !valid {
+			return fmt.Errorf(
+				"[%s] of %s compute resources must be one of: %s, got [%s]",
+				key,
+				provider,
+				strings.Join(values, ", "),
+				strValue,
+			)
+		}
+	}
+	return nil
+}
+
+// -----------------------------------------------------------------------------
+// State Upgrades
+// -----------------------------------------------------------------------------
+
+// resourceForemanHostStateUpgradeV0 upgrades the state of a host from schema
+// version 0.  The root password used to be stored in plain text, version 1
+// only stores its hash.
+func resourceForemanHostStateUpgradeV0(ctx context.Context, rawState map[string]interface{}, meta interface{}) (map[string]interface{}, error) {
+	log.Tracef("resource_foreman_host.go#StateUpgradeV0")
+
+	upgradeSensitiveStateValue(rawState, "root_password")
+	return rawState, nil
+}
+
+// -----------------------------------------------------------------------------
+// Resource CRUD Operations
+// -----------------------------------------------------------------------------
+
+func resourceForemanHostCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Tracef("resource_foreman_host.go#Create")
+
+	client := meta.(*api.Client)
+	h := buildForemanHost(d)
+	applyForemanHostDefaultParameters(h, client.Config().DefaultHostParameters)
+
+	// NOTE(ALL): Set the build flag to true on host create
 	if h.Method == "build" {
 		h.Build = true
 	}
 
+	if resolveErr := resolveForemanHostForeignKeyNames(d, client, h); resolveErr != nil {
+		return diag.FromErr(resolveErr)
+	}
+
 	log.Debugf("ForemanHost: [%+v]", h)
-	hostRetryCount := d.Get("retry_count").(int)
+	hostRetry := buildForemanHostRetryConfig(d)
+	powerVerify := buildForemanHostPowerVerifyConfig(d)
 
-	createdHost, createErr := client.CreateHost(h, hostRetryCount)
+	started := time.Now()
+	h.ProgressReportId = newProgressReportId()
+	createdHost, createErr := client.CreateHost(h, hostRetry)
 	if createErr != nil {
-		return createErr
+		return diag.FromErr(createErr)
 	}
+	defer setForemanHostBuildStatus(d, client, createdHost.Id, h.ProgressReportId, started)
 
 	log.Debugf("Created ForemanHost: [%+v]", createdHost)
 
 	// Enables partial state mode in the event of failure of one of API calls required for host creation
-	// This requires you to call the SetPartial function for each changed key.
-	// Only changes enabled with SetPartial are merged in.
 	d.Partial(true)
 
+	createdHost.HostParameters = omitForemanHostDefaultParameters(d, createdHost.HostParameters, client.Config().DefaultHostParameters)
 	setResourceDataFromForemanHost(d, createdHost)
 
-	enablebmc := d.Get("enable_bmc").(bool)
+	provisionBoot := foremanHostProvisionBoot(d, client)
+
+	// NOTE(ALL): The host already exists in Foreman at this point.  Returning an
+	//   error would taint the host and recreate it on the next apply, so the
+	//   failure is recorded in `bmc_success` and reported as a warning instead.
+	//   The next plan picks it up and the update finishes the BMC operations.
+	var diags diag.Diagnostics
+	bmcWarning := func(summary string, err error) {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  fmt.Sprintf("%s for host [%d]", summary, createdHost.Id),
+			Detail:   fmt.Sprintf("%s, it is retried on the next apply", err),
+		})
+	}
 
-	var powerCmds []interface{}
-	// If enable_bmc is true, perform required power off, pxe boot and power on BMC functions
-	if enablebmc {
-		log.Debugf("Calling BMC Reboot/PXE Functions")
-		// List of BMC Actions to perform
-		powerCmds = []interface{}{
-			api.BMCBoot{
-				Device: api.BootPxe,
-			},
-			api.Power{
-				PowerAction: api.PowerCycle,
-			},
-		}
-	} else {
-		log.Debugf("Using default Foreman behaviour for startup")
-		powerCmds = []interface{}{
-			api.Power{
-				PowerAction: api.PowerOn,
-			},
+	// NOTE(ALL): verify the BMC is reachable before chaining BMC operations,
+	//   the power status fails with a clearer error than the boot device
+	if provisionBoot && foremanHostHasBMCInterface(d) {
+		if verifyErr := verifyForemanHostBMC(client, createdHost.Id); verifyErr != nil {
+			bmcWarning("The BMC cannot be reached", verifyErr)
+			d.Set("bmc_success", false)
+			d.Partial(false)
+			return diags
 		}
 	}
 
-	// Loop through each of the above BMC Operations and execute.
-	// In the event fo any failure, exit with error
-	for _, cmd := range powerCmds {
-		sendErr := client.SendPowerCommand(createdHost, cmd, hostRetryCount)
-		if sendErr != nil {
-			return sendErr
-		}
-		// Sleep for 3 seconds between chained BMC calls
-		duration := time.Duration(3) * time.Second
-		time.Sleep(duration)
+	if powerErr := startForemanHost(d, client, createdHost, hostRetry, powerVerify); powerErr != nil {
+		bmcWarning("BMC operations failed", powerErr)
+		d.Set("bmc_success", false)
+		d.Partial(false)
+		return diags
 	}
 	// When the BMC Operations succeed, set the `bmc_success` key to true.
 	d.Set("bmc_success", true)
-	// Set the `bmc_success` key as successful in partial mode
-	d.SetPartial("bmc_success")
+
+	// NOTE(ALL): like the power commands, a failed BMC action does not taint
+	//   the host.  Clearing it from the state sends it again on the next apply.
+	if d.Get("boot_device").(string) != "" {
+		if bootErr := setForemanHostBootDevice(d, client, createdHost.Id, hostRetry); bootErr != nil {
+			bmcWarning("Setting the boot device failed", bootErr)
+			d.Set("boot_device", "")
+		}
+	}
+	if d.Get("bmc_action").(string) != "" {
+		if actionErr := sendForemanHostBMCAction(d, client, createdHost.Id, hostRetry); actionErr != nil {
+			bmcWarning("The BMC action failed", actionErr)
+			d.Set("bmc_action", "")
+		}
+	}
 
 	// Disable partial mode
 	d.Partial(false)
 
-	return nil
+	// NOTE(ALL): unlike a failed power command, a host which does not come up
+	//   is tainted, as it is most likely not going to be provisioned
+	if check := buildForemanHostReadinessCheck(d, createdHost); check != nil {
+		if waitErr := waitForForemanHost(ctx, client, check, createdHost.Id, h.Build); waitErr != nil {
+			return append(diags, diag.FromErr(waitErr)...)
+		}
+	}
+
+	return diags
 }
 
 func resourceForemanHostRead(d *schema.ResourceData, meta interface{}) error {
@@ -695,25 +2199,45 @@ func resourceForemanHostRead(d *schema.ResourceData, meta interface{}) error {
 
 	readHost, readErr := client.ReadHost(h.Id)
 	if readErr != nil {
-		return readErr
+		return handleReadError(d, readErr)
 	}
 
 	log.Debugf("Read ForemanHost: [%+v]", readHost)
 
+	readHost.HostParameters = omitForemanHostDefaultParameters(d, readHost.HostParameters, client.Config().DefaultHostParameters)
 	setResourceDataFromForemanHost(d, readHost)
+	readForemanHostBootDevice(d, client, readHost.Id)
 
 	return nil
 }
 
-func resourceForemanHostUpdate(d *schema.ResourceData, meta interface{}) error {
+func resourceForemanHostUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) error {
 	log.Tracef("resource_foreman_host.go#Update")
 
 	client := meta.(*api.Client)
 	h := buildForemanHost(d)
+	applyForemanHostDefaultParameters(h, client.Config().DefaultHostParameters)
 
-	// NOTE(ALL): Set the build flag to true on host create
-	if h.Method == "build" {
-		h.Build = true
+	if resolveErr := resolveForemanHostForeignKeyNames(d, client, h); resolveErr != nil {
+		return resolveErr
+	}
+
+	// NOTE(ALL): Only mark the host for rebuild when the build attribute is
+	//   switched on.  Any other update leaves the build state untouched.
+	rebuild := d.HasChange("build") && d.Get("build").(bool)
+	h.Build = rebuild
+
+	// NOTE(ALL): An unchanged root password only exists as a hash in the
+	//   state - do not send it back to Foreman
+	if !d.HasChange("root_password") {
+		h.RootPassword = ""
+	}
+
+	// NOTE(ALL): Foreman resizes the virtual machine whenever compute
+	//   attributes are sent - only send them when they changed
+	resize := foremanHostComputeAttributesChanged(d)
+	if !resize {
+		h.ComputeAttributes = nil
 	}
 
 	log.Debugf("ForemanHost: [%+v]", h)
@@ -762,80 +2286,153 @@ func resourceForemanHostUpdate(d *schema.ResourceData, meta interface{}) error {
 
 	} // end HasChange("interfaces_attributes")
 
-	hostRetryCount := d.Get("retry_count").(int)
+	hostRetry := buildForemanHostRetryConfig(d)
+	powerVerify := buildForemanHostPowerVerifyConfig(d)
 
 	// We need to test whether a call to update the host is necessary based on what has changed.
 	// Otherwise, a detected update caused by a unsuccessful BMC operation will cause a 422 on update.
-	if d.HasChange("name") ||
+	if rebuild ||
+		d.HasChange("name") ||
 		d.HasChange("comment") ||
+		d.HasChange("root_password") ||
 		d.HasChange("parameters") ||
+		d.HasChange("hidden_parameters") ||
+		d.HasChange("user_data_parameters") ||
 		d.HasChange("domain_id") ||
 		d.HasChange("environment_id") ||
 		d.HasChange("hostgroup_id") ||
+		d.HasChange("hostgroup_title") ||
+		d.HasChange("ptable_id") ||
 		d.HasChange("compute_resource_id") ||
-		d.HasChange("compute_profile_id") ||
+		resize ||
 		d.HasChange("operatingsystem_id") ||
+		d.HasChange("operatingsystem_title") ||
 		d.HasChange("interfaces_attributes") {
 
 		log.Debugf("host: [%+v]", h)
 
-		updatedHost, updateErr := client.UpdateHost(h, hostRetryCount)
+		updatedHost, updateErr := client.UpdateHost(h, hostRetry)
 		if updateErr != nil {
 			return updateErr
 		}
 
 		log.Debugf("Updated FormanHost: [%+v]", updatedHost)
 
+		updatedHost.HostParameters = omitForemanHostDefaultParameters(d, updatedHost.HostParameters, client.Config().DefaultHostParameters)
 		setResourceDataFromForemanHost(d, updatedHost)
 	} // end HasChange("name")
 
-	// Perform BMC operations on update only if the bmc_success boolean has a change
-	if d.HasChange("bmc_success") {
-		enablebmc := d.Get("enable_bmc").(bool)
-
-		var powerCmds []interface{}
-		// If enable_bmc is true, perform required power off, pxe boot and power on BMC functions
-		if enablebmc {
-			log.Debugf("Calling BMC Reboot/PXE Functions")
-			// List of BMC Actions to perform
-			powerCmds = []interface{}{
-				api.Power{
-					PowerAction: api.PowerOff,
-				},
-				api.BMCBoot{
-					Device: api.BootPxe,
-				},
-				api.Power{
-					PowerAction: api.PowerOn,
-				},
-			}
-		} else {
-			powerCmds = []interface{}{
-				api.Power{
-					PowerAction: api.PowerOn,
-				},
-			}
+	// NOTE(ALL): a rebuild reboots the host on its own
+	if resize && !rebuild && d.Get("reboot_on_resize").(bool) {
+		log.Debugf("Rebooting host [%d] after resizing it", h.Id)
+		rebootErr := client.SendPowerCommand(h, api.Power{PowerAction: api.PowerReboot}, hostRetry, powerVerify)
+		if rebootErr != nil {
+			return rebootErr
 		}
+	}
 
-		for _, cmd := range powerCmds {
-			sendErr := client.SendPowerCommand(h, cmd, hostRetryCount)
-			if sendErr != nil {
-				return sendErr
-			}
-			// Sleep for 3 seconds between chained BMC calls
-			duration := time.Duration(3) * time.Second
-			time.Sleep(duration)
+	// Verify the BMC is reachable with rotated credentials
+	if foremanHostBMCCredentialsChanged(d) && !client.Config().DisableBMC {
+		if verifyErr := verifyForemanHostBMC(client, h.Id); verifyErr != nil {
+			return verifyErr
 		}
-		d.Set("bmc_success", true)
-		d.SetPartial("bmc_success")
+	}
 
+	// Perform BMC operations on update only if the bmc_success boolean has a change
+	poweredOn := false
+	if d.HasChange("bmc_success") {
+		if powerErr := startForemanHost(d, client, h, hostRetry, powerVerify); powerErr != nil {
+			return powerErr
+		}
+		d.Set("bmc_success", true)
+		poweredOn = true
+	} else if rebuild && (d.Get("reboot_on_rebuild").(bool) || foremanHostProvisionBoot(d, client)) {
+		if rebootErr := rebootForemanHostForRebuild(d, client, h, hostRetry, powerVerify); rebootErr != nil {
+			return rebootErr
+		}
+		poweredOn = true
 	} // end HasChange("bmc_success")
+
+	if d.HasChange("boot_device") && d.Get("boot_device").(string) != "" {
+		if bootErr := setForemanHostBootDevice(d, client, h.Id, hostRetry); bootErr != nil {
+			return bootErr
+		}
+	}
+	if d.HasChange("bmc_action") && d.Get("bmc_action").(string) != "" {
+		if actionErr := sendForemanHostBMCAction(d, client, h.Id, hostRetry); actionErr != nil {
+			return actionErr
+		}
+	}
 	// Use partial state mode in the event of failure of one of API calls required for host creation
 	d.Partial(false)
 
+	if check := buildForemanHostReadinessCheck(d, h); poweredOn && check != nil {
+		return waitForForemanHost(ctx, client, check, h.Id, rebuild)
+	}
+
 	return nil
 }
 
+// startForemanHost powers a created host on so it starts provisioning.  With
+// a provision boot, the host is booted from PXE through its BMC.  Otherwise
+// Foreman's default behaviour powers the host on.  Create and the retry of
+// failed BMC operations on update both start the host this way.
+func startForemanHost(d *schema.ResourceData, client *api.Client, h *api.ForemanHost, retry api.RetryConfig, verify api.PowerVerifyConfig) error {
+	if foremanHostProvisionBoot(d, client) {
+		log.Debugf("Calling BMC Reboot/PXE Functions")
+		return client.ProvisionBoot(h, retry, verify)
+	}
+	log.Debugf("Using default Foreman behaviour for startup")
+	return client.SendPowerCommand(h, api.Power{PowerAction: api.PowerOn}, retry, verify)
+}
+
+// rebootForemanHostForRebuild reboots the host with the power action of the
+// "rebuild_power_action" attribute so a rebuild starts right away.  With a
+// provision boot, the next boot is set to PXE first.
+//
+// NOTE(ALL): BMCs can not reboot a host softly, their "soft" power action
+//   shuts the host down.  A soft reboot through the BMC waits for the host to
+//   shut down and powers it back on.
+func rebootForemanHostForRebuild(d *schema.ResourceData, client *api.Client, h *api.ForemanHost, retry api.RetryConfig, verify api.PowerVerifyConfig) error {
+	provisionBoot := foremanHostProvisionBoot(d, client)
+	soft := d.Get("rebuild_power_action").(string) == api.PowerSoft
+
+	if provisionBoot && !soft {
+		log.Debugf("Calling BMC Reboot/PXE Functions for rebuild")
+		return client.ProvisionBoot(h, retry, verify)
+	}
+
+	// NOTE(ALL): virtual machines reboot softly through their compute resource
+	if h.PoweredByComputeResource() {
+		provisionBoot = false
+	}
+
+	// Boot from PXE to pick up the rebuild
+	if provisionBoot {
+		log.Debugf("Calling BMC Reboot/PXE Functions for rebuild")
+		bootErr := client.SendPowerCommand(h, api.BMCBoot{Device: api.BootPxe}, retry, verify)
+		if bootErr != nil {
+			return bootErr
+		}
+		// Sleep for 3 seconds between chained BMC calls
+		time.Sleep(time.Duration(3) * time.Second)
+	}
+
+	powerAction := api.PowerCycle
+	switch {
+	case soft && provisionBoot:
+		grace := time.Duration(d.Get("shutdown_grace_period").(int)) * time.Second
+		if shutdownErr := client.ShutdownHost(h, api.PowerSoft, grace, retry, verify); shutdownErr != nil {
+			return shutdownErr
+		}
+		powerAction = api.PowerOn
+	case soft:
+		powerAction = api.PowerReboot
+	}
+
+	return client.SendPowerCommand(h, api.Power{PowerAction: powerAction}, retry, verify)
+}
+
 func resourceForemanHostDelete(d *schema.ResourceData, meta interface{}) error {
 	log.Tracef("resource_foreman_host.go#Delete")
 
@@ -843,7 +2440,20 @@ func resourceForemanHostDelete(d *schema.ResourceData, meta interface{}) error {
 	h := buildForemanHost(d)
 
 	log.Debugf("ForemanHost: [%+v]", h)
-	hostRetryCount := d.Get("retry_count").(int)
+	hostRetry := buildForemanHostRetryConfig(d)
+
+	// NOTE(ALL): an unmanaged host keeps running and keeps its interfaces,
+	//   it is only removed from the state
+	if d.Get("destroy_behavior").(string) == hostDestroyUnmanage {
+		log.Infof("Unmanaging host [%d] instead of deleting it", h.Id)
+		return client.UnmanageHost(h.Id)
+	}
+
+	if d.Get("shutdown_on_destroy").(bool) || d.Get("power_off_on_destroy").(bool) {
+		if shutdownErr := shutdownForemanHost(d, client, h, hostRetry); shutdownErr != nil {
+			return shutdownErr
+		}
+	}
 
 	if len(h.InterfacesAttributes) > 0 {
 		log.Debugf("deleting host that has interfaces set")
@@ -854,7 +2464,7 @@ func resourceForemanHostDelete(d *schema.ResourceData, meta interface{}) error {
 		}
 		log.Debugf("host: [%+v]", h)
 
-		updatedHost, updateErr := client.UpdateHost(h, hostRetryCount)
+		updatedHost, updateErr := client.UpdateHost(h, hostRetry)
 		if updateErr != nil {
 			return updateErr
 		}
@@ -865,6 +2475,7 @@ func resourceForemanHostDelete(d *schema.ResourceData, meta interface{}) error {
 		//   the update call. This allows us to recover from a partial state if
 		//   delete encounters an error after this point - at least the resource's
 		//   state will be saved with the correct interfaces.
+		updatedHost.HostParameters = omitForemanHostDefaultParameters(d, updatedHost.HostParameters, client.Config().DefaultHostParameters)
 		setResourceDataFromForemanHost(d, updatedHost)
 
 		log.Debugf("completed the interface deletion")
@@ -0,0 +1,294 @@
+package foreman
+
+import (
+	"github.com/HanseMerkur/terraform-provider-foreman/foreman/api"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// Providers of the cloud compute resources
+const (
+	ec2ComputeResourceProvider   = "EC2"
+	gceComputeResourceProvider   = "GCE"
+	azureComputeResourceProvider = "AzureRm"
+)
+
+// -----------------------------------------------------------------------------
+// Schema
+// -----------------------------------------------------------------------------
+
+// ec2Schema is the "ec2" block of a host.  It holds the attributes of the
+// instance created on an Amazon EC2 compute resource.  The region is set on
+// the compute resource.  Changing them recreates the host, unless it is
+// updated in place.
+func ec2Schema() *schema.Schema {
+	return &schema.Schema{
+		Type:          schema.TypeList,
+		Optional:      true,
+		MaxItems:      1,
+		ConflictsWith: otherForemanHostComputeBlocks("ec2"),
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"flavor": &schema.Schema{
+					Type:        schema.TypeString,
+					Required:    true,
+					Description: "Instance type of the instance, ie: `\"t3.medium\"`.",
+				},
+				"image": &schema.Schema{
+					Type:     schema.TypeString,
+					Optional: true,
+					Description: "ID of the AMI the instance is launched from. Defaults " +
+						"to the image of `image_id`.",
+				},
+				"availability_zone": &schema.Schema{
+					Type:        schema.TypeString,
+					Optional:    true,
+					Description: "Availability zone the instance is launched in, ie: `\"eu-central-1a\"`.",
+				},
+				"subnet_id": &schema.Schema{
+					Type:        schema.TypeString,
+					Optional:    true,
+					Description: "ID of the VPC subnet the instance is launched in.",
+				},
+				"security_group_ids": &schema.Schema{
+					Type:        schema.TypeList,
+					Optional:    true,
+					Elem:        &schema.Schema{Type: schema.TypeString},
+					Description: "IDs of the security groups of the instance.",
+				},
+				"managed_ip": &schema.Schema{
+					Type:     schema.TypeString,
+					Optional: true,
+					Default:  "public",
+					ValidateFunc: validation.StringInSlice([]string{
+						"public",
+						"private",
+						// NOTE(ALL): false - do not ignore case when comparing values
+					}, false),
+					Description: "IP address of the instance managed by Foreman. " +
+						"Values include: `\"public\"`, `\"private\"`. Defaults to " +
+						"`\"public\"`.",
+				},
+			},
+		},
+	}
+}
+
+// gceSchema is the "gce" block of a host.  It holds the attributes of the
+// instance created on a Google Compute Engine compute resource.  The zone is
+// set on the compute resource.  Changing them recreates the host, unless it
+// is updated in place.
+func gceSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:          schema.TypeList,
+		Optional:      true,
+		MaxItems:      1,
+		ConflictsWith: otherForemanHostComputeBlocks("gce"),
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"machine_type": &schema.Schema{
+					Type:        schema.TypeString,
+					Required:    true,
+					Description: "Machine type of the instance, ie: `\"e2-medium\"`.",
+				},
+				"image": &schema.Schema{
+					Type:     schema.TypeString,
+					Optional: true,
+					Description: "Image the instance is created from. Defaults to the " +
+						"image of `image_id`.",
+				},
+				"network": &schema.Schema{
+					Type:        schema.TypeString,
+					Optional:    true,
+					Description: "VPC network of the instance. Defaults to `\"default\"`.",
+				},
+				"associate_external_ip": &schema.Schema{
+					Type:        schema.TypeBool,
+					Optional:    true,
+					Default:     false,
+					Description: "Whether or not an ephemeral external IP address is associated with the instance.",
+				},
+			},
+		},
+	}
+}
+
+// azureSchema is the "azure" block of a host.  It holds the attributes of the
+// virtual machine created on an Azure Resource Manager compute resource.  The
+// region is set on the compute resource.  Changing them recreates the host,
+// unless it is updated in place.
+func azureSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:          schema.TypeList,
+		Optional:      true,
+		MaxItems:      1,
+		ConflictsWith: otherForemanHostComputeBlocks("azure"),
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"vm_size": &schema.Schema{
+					Type:        schema.TypeString,
+					Required:    true,
+					Description: "Size of the virtual machine, ie: `\"Standard_B2s\"`.",
+				},
+				"resource_group": &schema.Schema{
+					Type:        schema.TypeString,
+					Required:    true,
+					Description: "Resource group the virtual machine is created in.",
+				},
+				"image": &schema.Schema{
+					Type:     schema.TypeString,
+					Optional: true,
+					Description: "Image the virtual machine is created from. Defaults " +
+						"to the image of `image_id`.",
+				},
+				"platform": &schema.Schema{
+					Type:     schema.TypeString,
+					Optional: true,
+					Default:  "Linux",
+					ValidateFunc: validation.StringInSlice([]string{
+						"Linux",
+						"Windows",
+						// NOTE(ALL): false - do not ignore case when comparing values
+					}, false),
+					Description: "Platform of the virtual machine. Values include: " +
+						"`\"Linux\"`, `\"Windows\"`. Defaults to `\"Linux\"`.",
+				},
+				"username": &schema.Schema{
+					Type:        schema.TypeString,
+					Optional:    true,
+					Description: "Name of the administrator account of the virtual machine.",
+				},
+				"ssh_key": &schema.Schema{
+					Type:        schema.TypeString,
+					Optional:    true,
+					Description: "Public SSH key of the administrator account.",
+				},
+				"premium_os_disk": &schema.Schema{
+					Type:        schema.TypeBool,
+					Optional:    true,
+					Default:     false,
+					Description: "Whether or not the OS disk is a premium SSD.",
+				},
+				"subnet": &schema.Schema{
+					Type:     schema.TypeString,
+					Optional: true,
+					Description: "ID of the subnet the network adapters are connected " +
+						"to. Applies to the interfaces which do not set a `network` " +
+						"in their `compute_attributes`.",
+				},
+				"public_ip": &schema.Schema{
+					Type:     schema.TypeString,
+					Optional: true,
+					Default:  "None",
+					ValidateFunc: validation.StringInSlice([]string{
+						"None",
+						"Static",
+						"Dynamic",
+						// NOTE(ALL): false - do not ignore case when comparing values
+					}, false),
+					Description: "Public IP address allocation of the network adapters " +
+						"connected to `subnet`. Values include: `\"None\"`, " +
+						"`\"Static\"`, `\"Dynamic\"`. Defaults to `\"None\"`.",
+				},
+			},
+		},
+	}
+}
+
+// -----------------------------------------------------------------------------
+// Conversion Helpers
+// -----------------------------------------------------------------------------
+
+// ec2ComputeAttributes, gceComputeAttributes and azureComputeAttributes map
+// the attributes of the cloud blocks to the compute attribute names Foreman
+// expects for the compute resource type.
+var (
+	ec2ComputeAttributes = map[string]string{
+		"flavor":             "flavor_id",
+		"image":              "image_id",
+		"availability_zone":  "availability_zone",
+		"subnet_id":          "subnet_id",
+		"security_group_ids": "security_group_ids",
+		"managed_ip":         "managed_ip",
+	}
+	gceComputeAttributes = map[string]string{
+		"machine_type":          "machine_type",
+		"image":                 "image_id",
+		"network":               "network",
+		"associate_external_ip": "associate_external_ip",
+	}
+	azureComputeAttributes = map[string]string{
+		"vm_size":         "vm_size",
+		"resource_group":  "resource_group",
+		"image":           "image_id",
+		"platform":        "platform",
+		"username":        "username",
+		"ssh_key":         "ssh_key_data",
+		"premium_os_disk": "premium_os_disk",
+	}
+)
+
+// buildForemanHostEC2ComputeAttributes translates the "ec2" block of a host
+// to the compute attributes of the EC2 compute resource.  Nil is returned if
+// the block is not set.
+func buildForemanHostEC2ComputeAttributes(d *schema.ResourceData) map[string]interface{} {
+	return buildForemanHostCloudComputeAttributes(d.Get("ec2"), ec2ComputeAttributes)
+}
+
+// buildForemanHostGCEComputeAttributes translates the "gce" block of a host
+// to the compute attributes of the GCE compute resource.  Nil is returned if
+// the block is not set.
+func buildForemanHostGCEComputeAttributes(d *schema.ResourceData) map[string]interface{} {
+	return buildForemanHostCloudComputeAttributes(d.Get("gce"), gceComputeAttributes)
+}
+
+// buildForemanHostAzureComputeAttributes translates the "azure" block of a
+// host to the compute attributes of the Azure compute resource.  Nil is
+// returned if the block is not set.
+func buildForemanHostAzureComputeAttributes(d *schema.ResourceData) map[string]interface{} {
+	return buildForemanHostCloudComputeAttributes(d.Get("azure"), azureComputeAttributes)
+}
+
+// buildForemanHostCloudComputeAttributes copies the set attributes of a cloud
+// block to the compute attribute names of the supplied map.  Empty strings
+// and lists are left out, so Foreman applies its defaults for them.
+func buildForemanHostCloudComputeAttributes(v interface{}, names map[string]string) map[string]interface{} {
+	block, ok := foremanHostComputeBlock(v)
+	if !ok {
+		return nil
+	}
+
+	attrs := map[string]interface{}{}
+	for attr, computeAttr := range names {
+		switch value := block[attr].(type) {
+		case string:
+			if value == "" {
+				continue
+			}
+		case []interface{}:
+			if len(value) == 0 {
+				continue
+			}
+		}
+		attrs[computeAttr] = block[attr]
+	}
+	return attrs
+}
+
+// applyForemanHostAzureNetwork sets the subnet of the "azure" block on the
+// interfaces which do not set a network in their compute attributes.
+func applyForemanHostAzureNetwork(d *schema.ResourceData, ifaces []api.ForemanInterfacesAttribute) {
+	azure, ok := foremanHostComputeBlock(d.Get("azure"))
+	if !ok {
+		return
+	}
+	subnet, _ := azure["subnet"].(string)
+	if subnet == "" {
+		return
+	}
+	applyForemanInterfaceComputeAttributeDefaults(ifaces, "network", map[string]interface{}{
+		"network":   subnet,
+		"public_ip": azure["public_ip"],
+	})
+}
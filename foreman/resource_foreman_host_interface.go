@@ -0,0 +1,251 @@
+package foreman
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/HanseMerkur/terraform-provider-foreman/foreman/api"
+	"github.com/wayfair/terraform-provider-utils/autodoc"
+	"github.com/wayfair/terraform-provider-utils/log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceForemanHostInterface() *schema.Resource {
+	return &schema.Resource{
+
+		Create: resourceForemanHostInterfaceCreate,
+		Read:   resourceForemanHostInterfaceRead,
+		Update: resourceForemanHostInterfaceUpdate,
+		Delete: resourceForemanHostInterfaceDelete,
+
+		// NOTE(ALL): Interfaces are nested underneath their host. The import ID
+		//   has the form "<host_id>/<id>"
+		Importer: &schema.ResourceImporter{
+			State: importStateCompositeId("host_id"),
+		},
+
+		Schema: map[string]*schema.Schema{
+
+			autodoc.MetaAttribute: &schema.Schema{
+				Type:     schema.TypeBool,
+				Computed: true,
+				Description: fmt.Sprintf(
+					"%s Additional network interface attached to an existing "+
+						"host. The interface is managed independently from the "+
+						"host, ie: secondary interfaces managed by another module. "+
+						"Set `exclusive_interfaces` of a `foreman_host` to false "+
+						"when attaching interfaces to it with this resource.",
+					autodoc.MetaSummary,
+				),
+			},
+
+			"host_id": &schema.Schema{
+				Type:         schema.TypeInt,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+				Description:  "ID of the host the interface is attached to.",
+			},
+			"identifier": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				Description: fmt.Sprintf(
+					"Identifier of the interface local to the host. %s \"eth1\"",
+					autodoc.MetaExample,
+				),
+			},
+			"type": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+				Default:  "interface",
+				ValidateFunc: validation.StringInSlice([]string{
+					"interface",
+					"bond",
+					"bridge",
+					// NOTE(ALL): false - do not ignore case when comparing values
+				}, false),
+				Description: "The type of interface. Values include: `\"interface\"`, " +
+					"`\"bond\"`, `\"bridge\"`.",
+			},
+			"name": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "DNS name associated with the interface.",
+			},
+			"ip": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.IsIPAddress,
+				Description:  "IP address associated with the interface.",
+			},
+			"mac": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "MAC address associated with the interface.",
+			},
+			"subnet_id": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "ID of the subnet to associate with the interface.",
+			},
+			"managed": &schema.Schema{
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether or not the interface is managed by Foreman.",
+			},
+			"virtual": &schema.Schema{
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether or not this is a virtual interface.",
+			},
+			"attached_to": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Identifier of the interface to which this interface belongs.",
+			},
+			"attached_devices": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Description: "Identifiers of attached interfaces, e.g. 'eth1', 'eth2' " +
+					"as comma-separated list",
+			},
+		},
+	}
+}
+
+// -----------------------------------------------------------------------------
+// Conversion Helpers
+// -----------------------------------------------------------------------------
+
+// buildForemanHostInterface constructs a ForemanInterfacesAttribute reference
+// from a resource data reference.  The struct's members are populated from
+// the data populated in the resource data.  Missing members will be left to
+// the zero value for that member's type.
+func buildForemanHostInterface(d *schema.ResourceData) *api.ForemanInterfacesAttribute {
+	log.Tracef("resource_foreman_host_interface.go#buildForemanHostInterface")
+
+	iface := api.ForemanInterfacesAttribute{}
+
+	iface.Id, _ = strconv.Atoi(d.Id())
+	iface.Identifier = d.Get("identifier").(string)
+	iface.Type = d.Get("type").(string)
+	iface.Name = d.Get("name").(string)
+	iface.IP = d.Get("ip").(string)
+	iface.MAC = d.Get("mac").(string)
+	iface.SubnetId = d.Get("subnet_id").(int)
+	iface.Managed = d.Get("managed").(bool)
+	iface.Virtual = d.Get("virtual").(bool)
+	iface.AttachedTo = d.Get("attached_to").(string)
+	iface.AttachedDevices = d.Get("attached_devices").(string)
+
+	return &iface
+}
+
+// setResourceDataFromForemanHostInterface sets a ResourceData's attributes
+// from the attributes of the supplied ForemanInterfacesAttribute reference
+func setResourceDataFromForemanHostInterface(d *schema.ResourceData, fi *api.ForemanInterfacesAttribute) {
+	log.Tracef("resource_foreman_host_interface.go#setResourceDataFromForemanHostInterface")
+
+	d.SetId(strconv.Itoa(fi.Id))
+	d.Set("identifier", fi.Identifier)
+	d.Set("type", fi.Type)
+	d.Set("name", fi.Name)
+	d.Set("ip", fi.IP)
+	d.Set("mac", fi.MAC)
+	d.Set("subnet_id", fi.SubnetId)
+	d.Set("managed", fi.Managed)
+	d.Set("virtual", fi.Virtual)
+	d.Set("attached_to", fi.AttachedTo)
+	d.Set("attached_devices", fi.AttachedDevices)
+}
+
+// -----------------------------------------------------------------------------
+// Resource CRUD Operations
+// -----------------------------------------------------------------------------
+
+func resourceForemanHostInterfaceCreate(d *schema.ResourceData, meta interface{}) error {
+	log.Tracef("resource_foreman_host_interface.go#Create")
+
+	client := meta.(*api.Client)
+	hostId := d.Get("host_id").(int)
+	iface := buildForemanHostInterface(d)
+
+	log.Debugf("ForemanInterfacesAttribute: [%s]", iface)
+
+	createdIface, createErr := client.CreateHostInterface(hostId, iface)
+	if createErr != nil {
+		return createErr
+	}
+
+	log.Debugf("Created ForemanInterfacesAttribute: [%s]", createdIface)
+
+	setResourceDataFromForemanHostInterface(d, createdIface)
+
+	return nil
+}
+
+func resourceForemanHostInterfaceRead(d *schema.ResourceData, meta interface{}) error {
+	log.Tracef("resource_foreman_host_interface.go#Read")
+
+	client := meta.(*api.Client)
+	hostId := d.Get("host_id").(int)
+	iface := buildForemanHostInterface(d)
+
+	readIface, readErr := client.ReadHostInterface(hostId, iface.Id)
+	if readErr != nil {
+		return handleReadError(d, readErr)
+	}
+
+	log.Debugf("Read ForemanInterfacesAttribute: [%s]", readIface)
+
+	setResourceDataFromForemanHostInterface(d, readIface)
+
+	return nil
+}
+
+func resourceForemanHostInterfaceUpdate(d *schema.ResourceData, meta interface{}) error {
+	log.Tracef("resource_foreman_host_interface.go#Update")
+
+	client := meta.(*api.Client)
+	hostId := d.Get("host_id").(int)
+	iface := buildForemanHostInterface(d)
+
+	log.Debugf("ForemanInterfacesAttribute: [%s]", iface)
+
+	updatedIface, updateErr := client.UpdateHostInterface(hostId, iface)
+	if updateErr != nil {
+		return updateErr
+	}
+
+	log.Debugf("Updated ForemanInterfacesAttribute: [%s]", updatedIface)
+
+	setResourceDataFromForemanHostInterface(d, updatedIface)
+
+	return nil
+}
+
+func resourceForemanHostInterfaceDelete(d *schema.ResourceData, meta interface{}) error {
+	log.Tracef("resource_foreman_host_interface.go#Delete")
+
+	client := meta.(*api.Client)
+	hostId := d.Get("host_id").(int)
+	iface := buildForemanHostInterface(d)
+
+	// NOTE(ALL): the interfaces are deleted along with their host - the
+	//   interface is gone if the host was destroyed first
+	deleteErr := client.DeleteHostInterface(hostId, iface.Id)
+	if deleteErr != nil && !api.IsNotFound(deleteErr) {
+		return deleteErr
+	}
+
+	return nil
+}
@@ -0,0 +1,77 @@
+package foreman
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/HanseMerkur/terraform-provider-foreman/foreman/api"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// -----------------------------------------------------------------------------
+// resourceForemanHostInterface
+// -----------------------------------------------------------------------------
+
+// Ensures the interface is attached to the host's interfaces endpoint and is
+// removed from the state once it was deleted elsewhere
+func TestResourceForemanHostInterface(t *testing.T) {
+
+	mux, server, client := NewForemanAPIAndClient(api.ClientCredentials{}, api.ClientConfig{})
+	defer server.Close()
+
+	var created map[string]map[string]interface{}
+	mux.HandleFunc(api.FOREMAN_API_URL_PREFIX+"/hosts/4/interfaces", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Fatalf("expected a POST request, got [%s]", r.Method)
+		}
+		body, _ := io.ReadAll(r.Body)
+		json.Unmarshal(body, &created)
+		w.Write([]byte(`{"id": 12, "identifier": "eth1", "type": "interface", "mac": "52:54:00:ab:cd:ef", "subnet_id": 3}`))
+	})
+	deleted := false
+	mux.HandleFunc(api.FOREMAN_API_URL_PREFIX+"/hosts/4/interfaces/12", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodDelete {
+			deleted = true
+			w.Write([]byte(`{}`))
+			return
+		}
+		if deleted {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"error": {"message": "Resource interface not found by id '12'"}}`))
+			return
+		}
+		w.Write([]byte(`{"id": 12, "identifier": "eth1", "type": "interface", "mac": "52:54:00:ab:cd:ef", "subnet_id": 3}`))
+	})
+
+	r := resourceForemanHostInterface()
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"host_id":    4,
+		"identifier": "eth1",
+		"subnet_id":  3,
+	})
+
+	if createErr := r.Create(d, client); createErr != nil {
+		t.Fatalf("expected no error, got [%s]", createErr)
+	}
+	if d.Id() != "12" || d.Get("mac").(string) != "52:54:00:ab:cd:ef" {
+		t.Fatalf("expected the created interface in the state, got ID [%s] and MAC [%s]", d.Id(), d.Get("mac"))
+	}
+	if created["interface"]["identifier"] != "eth1" {
+		t.Fatalf("expected the interface to be sent wrapped in [interface], got [%v]", created)
+	}
+
+	if readErr := r.Read(d, client); readErr != nil || d.Id() != "12" {
+		t.Fatalf("expected the interface to be kept, got ID [%s] and error [%v]", d.Id(), readErr)
+	}
+
+	if deleteErr := r.Delete(d, client); deleteErr != nil {
+		t.Fatalf("expected no error, got [%s]", deleteErr)
+	}
+	if readErr := r.Read(d, client); readErr != nil || d.Id() != "" {
+		t.Fatalf("expected the interface to be removed, got ID [%s] and error [%v]", d.Id(), readErr)
+	}
+
+}
@@ -0,0 +1,216 @@
+package foreman
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/HanseMerkur/terraform-provider-foreman/foreman/api"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// libvirtComputeResourceProvider is the provider of libvirt compute resources
+const libvirtComputeResourceProvider = "Libvirt"
+
+// libvirtBootDevices are the devices of a libvirt virtual machine's boot order
+var libvirtBootDevices = []string{"network", "hd", "cdrom"}
+
+// -----------------------------------------------------------------------------
+// Schema
+// -----------------------------------------------------------------------------
+
+// libvirtSchema is the "libvirt" block of a host.  It holds the attributes of
+// the virtual machine created on a libvirt compute resource, which are
+// translated to the compute attribute names Foreman expects for libvirt.
+// Changing them recreates the host, unless "compute_attributes" is listed in
+// update_in_place.
+func libvirtSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:          schema.TypeList,
+		Optional:      true,
+		MaxItems:      1,
+		ConflictsWith: otherForemanHostComputeBlocks("libvirt"),
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"memory_mb": &schema.Schema{
+					Type:         schema.TypeInt,
+					Optional:     true,
+					ValidateFunc: validation.IntAtLeast(1),
+					Description:  "Memory of the virtual machine in MB.",
+				},
+				"cpus": &schema.Schema{
+					Type:         schema.TypeInt,
+					Optional:     true,
+					ValidateFunc: validation.IntAtLeast(1),
+					Description:  "Number of CPUs of the virtual machine.",
+				},
+				"boot_order": &schema.Schema{
+					Type:     schema.TypeList,
+					Optional: true,
+					Elem: &schema.Schema{
+						Type: schema.TypeString,
+						// NOTE(ALL): false - do not ignore case when comparing values
+						ValidateFunc: validation.StringInSlice(libvirtBootDevices, false),
+					},
+					Description: "Devices the virtual machine boots from, in order. " +
+						"Values include: `\"network\"`, `\"hd\"`, `\"cdrom\"`.",
+				},
+				"network_type": &schema.Schema{
+					Type:     schema.TypeString,
+					Optional: true,
+					// NOTE(ALL): false - do not ignore case when comparing values
+					ValidateFunc: validation.StringInSlice(interfaceComputeAttributes[libvirtComputeResourceProvider]["type"], false),
+					Description: "How the network adapters of the virtual machine are " +
+						"connected. `\"network\"` attaches them to the libvirt virtual " +
+						"network `network` (ie: NAT), `\"bridge\"` to the host bridge " +
+						"`bridge`. Applies to the interfaces which do not set a `type` " +
+						"in their `compute_attributes`.",
+				},
+				"network": &schema.Schema{
+					Type:        schema.TypeString,
+					Optional:    true,
+					Description: "Libvirt virtual network of the `\"network\"` network type.",
+				},
+				"bridge": &schema.Schema{
+					Type:        schema.TypeString,
+					Optional:    true,
+					Description: "Host bridge of the `\"bridge\"` network type.",
+				},
+				"nic_model": &schema.Schema{
+					Type:     schema.TypeString,
+					Optional: true,
+					// NOTE(ALL): false - do not ignore case when comparing values
+					ValidateFunc: validation.StringInSlice(interfaceComputeAttributes[libvirtComputeResourceProvider]["model"], false),
+					Description:  "Model of the network adapters, ie: `\"virtio\"`.",
+				},
+				"volume": &schema.Schema{
+					Type:        schema.TypeList,
+					Optional:    true,
+					Description: "Disks of the virtual machine.",
+					Elem: &schema.Resource{
+						Schema: map[string]*schema.Schema{
+							"pool_name": &schema.Schema{
+								Type:        schema.TypeString,
+								Required:    true,
+								Description: "Storage pool the disk is created in.",
+							},
+							"capacity_gb": &schema.Schema{
+								Type:         schema.TypeInt,
+								Required:     true,
+								ValidateFunc: validation.IntAtLeast(1),
+								Description:  "Capacity of the disk in GB.",
+							},
+							"format_type": &schema.Schema{
+								Type:     schema.TypeString,
+								Optional: true,
+								Default:  "qcow2",
+								ValidateFunc: validation.StringInSlice([]string{
+									"raw",
+									"qcow2",
+									// NOTE(ALL): false - do not ignore case when comparing values
+								}, false),
+								Description: "Format of the disk. Values include: `\"raw\"`, " +
+									"`\"qcow2\"`. Defaults to `\"qcow2\"`.",
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// -----------------------------------------------------------------------------
+// Conversion Helpers
+// -----------------------------------------------------------------------------
+
+// buildForemanHostLibvirtComputeAttributes translates the "libvirt" block of
+// a host to the compute attributes of the libvirt compute resource.  Nil is
+// returned if the block is not set.
+func buildForemanHostLibvirtComputeAttributes(d *schema.ResourceData) map[string]interface{} {
+	libvirt, ok := foremanHostComputeBlock(d.Get("libvirt"))
+	if !ok {
+		return nil
+	}
+
+	attrs := map[string]interface{}{}
+	// NOTE(ALL): libvirt compute resources expect the memory in bytes
+	if memory, _ := libvirt["memory_mb"].(int); memory > 0 {
+		attrs["memory"] = strconv.Itoa(memory * 1024 * 1024)
+	}
+	if cpus, _ := libvirt["cpus"].(int); cpus > 0 {
+		attrs["cpus"] = cpus
+	}
+	if bootOrder, _ := libvirt["boot_order"].([]interface{}); len(bootOrder) > 0 {
+		attrs["boot_order"] = bootOrder
+	}
+
+	// NOTE(ALL): Foreman expects the volumes as a map keyed by their index,
+	//   like the nested attributes of its forms
+	volumes, _ := libvirt["volume"].([]interface{})
+	if len(volumes) > 0 {
+		volumeAttrs := map[string]interface{}{}
+		for idx, volume := range volumes {
+			volumeMap, _ := volume.(map[string]interface{})
+			capacity, _ := volumeMap["capacity_gb"].(int)
+			volumeAttrs[strconv.Itoa(idx)] = map[string]interface{}{
+				"pool_name":   volumeMap["pool_name"],
+				"capacity":    fmt.Sprintf("%dG", capacity),
+				"format_type": volumeMap["format_type"],
+			}
+		}
+		attrs["volumes_attributes"] = volumeAttrs
+	}
+
+	return attrs
+}
+
+// applyForemanHostLibvirtNetwork sets the network of the "libvirt" block on
+// the interfaces which do not set a type in their compute attributes.
+func applyForemanHostLibvirtNetwork(d *schema.ResourceData, ifaces []api.ForemanInterfacesAttribute) {
+	libvirt, ok := foremanHostComputeBlock(d.Get("libvirt"))
+	if !ok {
+		return
+	}
+	networkType, _ := libvirt["network_type"].(string)
+	if networkType == "" {
+		return
+	}
+	defaults := map[string]interface{}{
+		"type":      networkType,
+		networkType: libvirt[networkType],
+	}
+	if model, _ := libvirt["nic_model"].(string); model != "" {
+		defaults["model"] = model
+	}
+	applyForemanInterfaceComputeAttributeDefaults(ifaces, "type", defaults)
+}
+
+// -----------------------------------------------------------------------------
+// Plan-time Validation
+// -----------------------------------------------------------------------------
+
+// validateForemanHostLibvirt verifies the network type of the "libvirt" block
+// names the network or bridge the adapters are connected to.
+func validateForemanHostLibvirt(d *schema.ResourceDiff) error {
+	if !d.NewValueKnown("libvirt") {
+		return nil
+	}
+	libvirt, ok := foremanHostComputeBlock(d.Get("libvirt"))
+	if !ok {
+		return nil
+	}
+	networkType, _ := libvirt["network_type"].(string)
+	if networkType == "" {
+		return nil
+	}
+	if name, _ := libvirt[networkType].(string); name == "" {
+		return fmt.Errorf(
+			"libvirt network_type [%s] requires %s to be set",
+			networkType,
+			networkType,
+		)
+	}
+	return nil
+}
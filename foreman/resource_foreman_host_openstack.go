@@ -0,0 +1,187 @@
+package foreman
+
+import (
+	"fmt"
+
+	"github.com/HanseMerkur/terraform-provider-foreman/foreman/api"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// openstackComputeResourceProvider is the provider of OpenStack compute
+// resources
+const openstackComputeResourceProvider = "Openstack"
+
+// -----------------------------------------------------------------------------
+// Schema
+// -----------------------------------------------------------------------------
+
+// openstackSchema is the "openstack" block of a host.  It holds the
+// attributes of the instance created on an OpenStack compute resource, which
+// are translated to the compute attribute names Foreman expects for
+// OpenStack.  Flavors, networks and security groups are given by ID or name,
+// names are resolved against the objects available on the compute resource.
+// Changing them recreates the host, unless it is updated in place.
+func openstackSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:          schema.TypeList,
+		Optional:      true,
+		MaxItems:      1,
+		ConflictsWith: otherForemanHostComputeBlocks("openstack"),
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"flavor": &schema.Schema{
+					Type:        schema.TypeString,
+					Required:    true,
+					Description: "ID or name of the flavor of the instance, ie: `\"m1.small\"`.",
+				},
+				"tenant": &schema.Schema{
+					Type:        schema.TypeString,
+					Optional:    true,
+					Description: "ID of the tenant (project) the instance is created in.",
+				},
+				"networks": &schema.Schema{
+					Type:        schema.TypeList,
+					Optional:    true,
+					Elem:        &schema.Schema{Type: schema.TypeString},
+					Description: "IDs or names of the networks the instance is connected to, in order.",
+				},
+				"security_groups": &schema.Schema{
+					Type:        schema.TypeList,
+					Optional:    true,
+					Elem:        &schema.Schema{Type: schema.TypeString},
+					Description: "IDs or names of the security groups of the instance.",
+				},
+				"floating_ip_pool": &schema.Schema{
+					Type:     schema.TypeString,
+					Optional: true,
+					Description: "Name of the pool the floating IP address of the " +
+						"instance is allocated from. No floating IP address is " +
+						"allocated if not set.",
+				},
+				"boot_from_volume": &schema.Schema{
+					Type:        schema.TypeBool,
+					Optional:    true,
+					Default:     false,
+					Description: "Whether or not the instance boots from a new volume created from its image.",
+				},
+				"volume_size_gb": &schema.Schema{
+					Type:         schema.TypeInt,
+					Optional:     true,
+					ValidateFunc: validation.IntAtLeast(1),
+					Description: "Size of the boot volume in GB when `boot_from_volume` " +
+						"is set. Defaults to the size of the flavor's disk.",
+				},
+			},
+		},
+	}
+}
+
+// -----------------------------------------------------------------------------
+// Conversion Helpers
+// -----------------------------------------------------------------------------
+
+// buildForemanHostOpenstackComputeAttributes translates the "openstack"
+// block of a host to the compute attributes of the OpenStack compute
+// resource, looking up the objects with the supplied function.
+func buildForemanHostOpenstackComputeAttributes(openstack map[string]interface{}, find func(kind string, idOrName string) (*api.ForemanComputeResourceObject, error)) (map[string]interface{}, error) {
+	flavor, findErr := find("flavors", openstack["flavor"].(string))
+	if findErr != nil {
+		return nil, findErr
+	}
+	attrs := map[string]interface{}{
+		"flavor_ref":       flavor.Id,
+		"boot_from_volume": openstack["boot_from_volume"],
+	}
+	if tenant, _ := openstack["tenant"].(string); tenant != "" {
+		attrs["tenant_id"] = tenant
+	}
+	if pool, _ := openstack["floating_ip_pool"].(string); pool != "" {
+		attrs["network"] = pool
+	}
+	if size, _ := openstack["volume_size_gb"].(int); size > 0 {
+		attrs["size_gb"] = size
+	}
+
+	networks, _ := openstack["networks"].([]interface{})
+	if len(networks) > 0 {
+		nics := make([]interface{}, 0, len(networks))
+		for _, network := range networks {
+			found, findErr := find("networks", network.(string))
+			if findErr != nil {
+				return nil, findErr
+			}
+			nics = append(nics, found.Id)
+		}
+		attrs["nics"] = nics
+	}
+
+	// NOTE(ALL): security groups are assigned by their name
+	groups, _ := openstack["security_groups"].([]interface{})
+	if len(groups) > 0 {
+		names := make([]interface{}, 0, len(groups))
+		for _, group := range groups {
+			found, findErr := find("security_groups", group.(string))
+			if findErr != nil {
+				return nil, findErr
+			}
+			names = append(names, found.Name)
+		}
+		attrs["security_groups"] = names
+	}
+
+	return attrs, nil
+}
+
+// resolveForemanHostOpenstackComputeAttributes sets the compute attributes
+// of the "openstack" block of a host on the supplied ForemanHost reference,
+// resolving the objects through the host's compute resource.
+func resolveForemanHostOpenstackComputeAttributes(d *schema.ResourceData, client *api.Client, h *api.ForemanHost) error {
+	openstack, ok := foremanHostComputeBlock(d.Get("openstack"))
+	if !ok {
+		return nil
+	}
+	if h.ComputeResourceId == 0 {
+		return fmt.Errorf("openstack requires compute_resource_id to be set")
+	}
+
+	attrs, buildErr := buildForemanHostOpenstackComputeAttributes(openstack, openstackObjectFinder(client, h.ComputeResourceId))
+	if buildErr != nil {
+		return buildErr
+	}
+	h.ComputeAttributes = attrs
+	return nil
+}
+
+// openstackObjectFinder returns a function looking up the objects available
+// on the OpenStack compute resource identified by the supplied ID.
+func openstackObjectFinder(client *api.Client, computeResourceId int) func(kind string, idOrName string) (*api.ForemanComputeResourceObject, error) {
+	return func(kind string, idOrName string) (*api.ForemanComputeResourceObject, error) {
+		return client.FindComputeResourceObject(computeResourceId, kind, "", idOrName)
+	}
+}
+
+// -----------------------------------------------------------------------------
+// Plan-time Validation
+// -----------------------------------------------------------------------------
+
+// validateForemanHostOpenstackReferences verifies the flavor, networks and
+// security groups of the "openstack" block are available on the host's
+// compute resource, so misspelled names fail the plan instead of the apply.
+func validateForemanHostOpenstackReferences(d *schema.ResourceDiff, client *api.Client) error {
+	if !d.NewValueKnown("openstack") || !d.NewValueKnown("compute_resource_id") ||
+		!(d.HasChange("openstack") || d.HasChange("compute_resource_id")) {
+		return nil
+	}
+	openstack, ok := foremanHostComputeBlock(d.Get("openstack"))
+	if !ok {
+		return nil
+	}
+	computeResourceId, _ := d.Get("compute_resource_id").(int)
+	if computeResourceId == 0 {
+		return nil
+	}
+	_, buildErr := buildForemanHostOpenstackComputeAttributes(openstack, openstackObjectFinder(client, computeResourceId))
+	return buildErr
+}
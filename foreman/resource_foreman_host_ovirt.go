@@ -0,0 +1,241 @@
+package foreman
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/HanseMerkur/terraform-provider-foreman/foreman/api"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// ovirtComputeResourceProvider is the provider of oVirt/RHV compute resources
+const ovirtComputeResourceProvider = "Ovirt"
+
+// -----------------------------------------------------------------------------
+// Schema
+// -----------------------------------------------------------------------------
+
+// ovirtSchema is the "ovirt" block of a host.  It holds the attributes of the
+// virtual machine created on an oVirt/RHV compute resource, which are
+// translated to the compute attribute names Foreman expects for oVirt.  The
+// objects of the compute resource are given by ID or name, names are
+// resolved against the objects available on the compute resource.  Changing
+// them recreates the host, unless "compute_attributes" is listed in
+// update_in_place.
+func ovirtSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:          schema.TypeList,
+		Optional:      true,
+		MaxItems:      1,
+		ConflictsWith: otherForemanHostComputeBlocks("ovirt"),
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"cluster": &schema.Schema{
+					Type:        schema.TypeString,
+					Required:    true,
+					Description: "ID or name of the cluster the virtual machine is placed in.",
+				},
+				"template": &schema.Schema{
+					Type:        schema.TypeString,
+					Optional:    true,
+					Description: "ID or name of the template the virtual machine is created from.",
+				},
+				"instance_type": &schema.Schema{
+					Type:     schema.TypeString,
+					Optional: true,
+					Description: "ID of the instance type of the virtual machine. " +
+						"Instance types are not resolved by name.",
+				},
+				"cores": &schema.Schema{
+					Type:         schema.TypeInt,
+					Optional:     true,
+					ValidateFunc: validation.IntAtLeast(1),
+					Description:  "Number of cores per socket of the virtual machine.",
+				},
+				"sockets": &schema.Schema{
+					Type:         schema.TypeInt,
+					Optional:     true,
+					ValidateFunc: validation.IntAtLeast(1),
+					Description:  "Number of CPU sockets of the virtual machine.",
+				},
+				"storage_domain": &schema.Schema{
+					Type:     schema.TypeString,
+					Optional: true,
+					Description: "ID or name of the storage domain of the disks which " +
+						"do not set their own.",
+				},
+				"network": &schema.Schema{
+					Type:     schema.TypeString,
+					Optional: true,
+					Description: "ID or name of the network of the cluster the network " +
+						"adapters are connected to. Applies to the interfaces which do " +
+						"not set a `network` in their `compute_attributes`.",
+				},
+				"vnic_profile": &schema.Schema{
+					Type:     schema.TypeString,
+					Optional: true,
+					Description: "ID or name of the network profile of the network " +
+						"adapters. Applies along with `network`.",
+				},
+				"volume": &schema.Schema{
+					Type:        schema.TypeList,
+					Optional:    true,
+					Description: "Disks of the virtual machine.",
+					Elem: &schema.Resource{
+						Schema: map[string]*schema.Schema{
+							"size_gb": &schema.Schema{
+								Type:         schema.TypeInt,
+								Required:     true,
+								ValidateFunc: validation.IntAtLeast(1),
+								Description:  "Size of the disk in GB.",
+							},
+							"storage_domain": &schema.Schema{
+								Type:     schema.TypeString,
+								Optional: true,
+								Description: "ID or name of the storage domain of the disk. " +
+									"Defaults to the `storage_domain` of the block.",
+							},
+							"bootable": &schema.Schema{
+								Type:        schema.TypeBool,
+								Optional:    true,
+								Default:     false,
+								Description: "Whether or not the virtual machine boots from the disk.",
+							},
+							"preallocate": &schema.Schema{
+								Type:        schema.TypeBool,
+								Optional:    true,
+								Default:     false,
+								Description: "Whether or not the disk is preallocated.",
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// -----------------------------------------------------------------------------
+// Conversion Helpers
+// -----------------------------------------------------------------------------
+
+// buildForemanHostOvirtComputeAttributes translates the "ovirt" block of a
+// host to the compute attributes of the oVirt compute resource, resolving
+// the objects of the compute resource with the supplied resolver.  The
+// interface compute attributes applying the block's network are returned
+// along with them, nil if the block sets no network.
+func buildForemanHostOvirtComputeAttributes(ovirt map[string]interface{}, resolve computeResourceObjectResolver) (map[string]interface{}, map[string]interface{}, error) {
+	cluster, resolveErr := resolve("clusters", "", ovirt["cluster"].(string))
+	if resolveErr != nil {
+		return nil, nil, resolveErr
+	}
+	attrs := map[string]interface{}{
+		"cluster": cluster,
+	}
+	if template, _ := ovirt["template"].(string); template != "" {
+		if attrs["template"], resolveErr = resolve("images", "", template); resolveErr != nil {
+			return nil, nil, resolveErr
+		}
+	}
+	if instanceType, _ := ovirt["instance_type"].(string); instanceType != "" {
+		attrs["instance_type"] = instanceType
+	}
+	for _, attr := range []string{"cores", "sockets"} {
+		if value, _ := ovirt[attr].(int); value > 0 {
+			attrs[attr] = strconv.Itoa(value)
+		}
+	}
+
+	// NOTE(ALL): Foreman expects the volumes as a map keyed by their index,
+	//   like the nested attributes of its forms
+	volumes, _ := ovirt["volume"].([]interface{})
+	if len(volumes) > 0 {
+		volumeAttrs := map[string]interface{}{}
+		for idx, volume := range volumes {
+			volumeMap, _ := volume.(map[string]interface{})
+			storageDomain, _ := volumeMap["storage_domain"].(string)
+			if storageDomain == "" {
+				storageDomain, _ = ovirt["storage_domain"].(string)
+			}
+			volumeAttr := map[string]interface{}{
+				"size_gb":     volumeMap["size_gb"],
+				"bootable":    volumeMap["bootable"],
+				"preallocate": "0",
+			}
+			if preallocate, _ := volumeMap["preallocate"].(bool); preallocate {
+				volumeAttr["preallocate"] = "1"
+			}
+			if storageDomain != "" {
+				if volumeAttr["storage_domain"], resolveErr = resolve("storage_domains", "", storageDomain); resolveErr != nil {
+					return nil, nil, resolveErr
+				}
+			}
+			volumeAttrs[strconv.Itoa(idx)] = volumeAttr
+		}
+		attrs["volumes_attributes"] = volumeAttrs
+	}
+
+	var ifaceAttrs map[string]interface{}
+	if network, _ := ovirt["network"].(string); network != "" {
+		networkId, networkErr := resolve("networks", cluster, network)
+		if networkErr != nil {
+			return nil, nil, networkErr
+		}
+		ifaceAttrs = map[string]interface{}{"network": networkId}
+		if profile, _ := ovirt["vnic_profile"].(string); profile != "" {
+			if ifaceAttrs["vnic_profile"], resolveErr = resolve("vnic_profiles", "", profile); resolveErr != nil {
+				return nil, nil, resolveErr
+			}
+		}
+	}
+
+	return attrs, ifaceAttrs, nil
+}
+
+// resolveForemanHostOvirtComputeAttributes sets the compute attributes of the
+// "ovirt" block of a host on the supplied ForemanHost reference, resolving
+// the objects through the host's compute resource.
+func resolveForemanHostOvirtComputeAttributes(d *schema.ResourceData, client *api.Client, h *api.ForemanHost) error {
+	ovirt, ok := foremanHostComputeBlock(d.Get("ovirt"))
+	if !ok {
+		return nil
+	}
+	if h.ComputeResourceId == 0 {
+		return fmt.Errorf("ovirt requires compute_resource_id to be set")
+	}
+
+	attrs, ifaceAttrs, buildErr := buildForemanHostOvirtComputeAttributes(ovirt, newComputeResourceObjectResolver(client, h.ComputeResourceId))
+	if buildErr != nil {
+		return buildErr
+	}
+	h.ComputeAttributes = attrs
+	if ifaceAttrs != nil {
+		applyForemanInterfaceComputeAttributeDefaults(h.InterfacesAttributes, "network", ifaceAttrs)
+	}
+	return nil
+}
+
+// -----------------------------------------------------------------------------
+// Plan-time Validation
+// -----------------------------------------------------------------------------
+
+// validateForemanHostOvirtReferences verifies the objects of the "ovirt"
+// block are available on the host's compute resource.
+func validateForemanHostOvirtReferences(d *schema.ResourceDiff, client *api.Client) error {
+	if !d.NewValueKnown("ovirt") || !d.NewValueKnown("compute_resource_id") ||
+		!(d.HasChange("ovirt") || d.HasChange("compute_resource_id")) {
+		return nil
+	}
+	ovirt, ok := foremanHostComputeBlock(d.Get("ovirt"))
+	if !ok {
+		return nil
+	}
+	computeResourceId, _ := d.Get("compute_resource_id").(int)
+	if computeResourceId == 0 {
+		return nil
+	}
+	_, _, buildErr := buildForemanHostOvirtComputeAttributes(ovirt, newComputeResourceObjectResolver(client, computeResourceId))
+	return buildErr
+}
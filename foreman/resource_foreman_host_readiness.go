@@ -0,0 +1,247 @@
+package foreman
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/HanseMerkur/terraform-provider-foreman/foreman/api"
+	"github.com/wayfair/terraform-provider-utils/log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"golang.org/x/net/icmp"
+	"golang.org/x/net/ipv4"
+)
+
+const (
+	// readinessCheckTCP : the host is ready once it accepts TCP connections
+	readinessCheckTCP = "tcp"
+	// readinessCheckICMP : the host is ready once it answers ICMP echo
+	// requests
+	readinessCheckICMP = "icmp"
+)
+
+// -----------------------------------------------------------------------------
+// Schema
+// -----------------------------------------------------------------------------
+
+// readinessCheckSchema is the "readiness_check" block of a host.  Once the
+// host is powered on after create or rebuild, the provider waits until the
+// host is reachable, so dependent resources and provisioners only run
+// against a machine which is up.
+func readinessCheckSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		Optional: true,
+		MaxItems: 1,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"type": &schema.Schema{
+					Type:     schema.TypeString,
+					Optional: true,
+					Default:  readinessCheckTCP,
+					ValidateFunc: validation.StringInSlice([]string{
+						readinessCheckTCP,
+						readinessCheckICMP,
+						// NOTE(ALL): false - do not ignore case when comparing values
+					}, false),
+					Description: "How the host is checked. `\"tcp\"` connects to " +
+						"`port`, `\"icmp\"` sends echo requests (ping). ICMP requires " +
+						"unprivileged ping sockets or elevated privileges for " +
+						"Terraform. Defaults to `\"tcp\"`.",
+				},
+				"port": &schema.Schema{
+					Type:         schema.TypeInt,
+					Optional:     true,
+					Default:      22,
+					ValidateFunc: validation.IsPortNumber,
+					Description:  "TCP port which has to accept connections. Defaults to `22`.",
+				},
+				"address": &schema.Schema{
+					Type:     schema.TypeString,
+					Optional: true,
+					Description: "Address checked. Defaults to the IP address of the " +
+						"primary interface, or the FQDN of the host.",
+				},
+				"timeout": &schema.Schema{
+					Type:         schema.TypeInt,
+					Optional:     true,
+					Default:      600,
+					ValidateFunc: validation.IntAtLeast(1),
+					Description: "Number of seconds the host has to become reachable. " +
+						"Defaults to `600`.",
+				},
+				"interval": &schema.Schema{
+					Type:         schema.TypeInt,
+					Optional:     true,
+					Default:      10,
+					ValidateFunc: validation.IntAtLeast(1),
+					Description:  "Number of seconds between checks. Defaults to `10`.",
+				},
+			},
+		},
+		Description: "Waits until the host is reachable after it is powered on " +
+			"on create or rebuild. A host which does not become reachable within " +
+			"the timeout fails the apply, on create it is tainted. When the " +
+			"apply is interrupted during the wait, the build of the host is " +
+			"cancelled so it does not keep reinstalling.",
+	}
+}
+
+// -----------------------------------------------------------------------------
+// Readiness Check
+// -----------------------------------------------------------------------------
+
+// foremanHostReadinessCheck is the readiness check configured by the
+// "readiness_check" block of a host
+type foremanHostReadinessCheck struct {
+	Type     string
+	Address  string
+	Port     int
+	Timeout  time.Duration
+	Interval time.Duration
+}
+
+// buildForemanHostReadinessCheck constructs the readiness check of the host
+// from its "readiness_check" block.  The address defaults to the primary
+// interface of the supplied ForemanHost, or its FQDN.  nil is returned if the
+// block is not set.
+func buildForemanHostReadinessCheck(d *schema.ResourceData, h *api.ForemanHost) *foremanHostReadinessCheck {
+	checks := d.Get("readiness_check").([]interface{})
+	if len(checks) == 0 || checks[0] == nil {
+		return nil
+	}
+	m := checks[0].(map[string]interface{})
+
+	check := foremanHostReadinessCheck{
+		Type:     m["type"].(string),
+		Address:  m["address"].(string),
+		Port:     m["port"].(int),
+		Timeout:  time.Duration(m["timeout"].(int)) * time.Second,
+		Interval: time.Duration(m["interval"].(int)) * time.Second,
+	}
+	if check.Address == "" {
+		for _, iface := range h.InterfacesAttributes {
+			if iface.Primary && iface.IP != "" {
+				check.Address = iface.IP
+			}
+		}
+	}
+	if check.Address == "" {
+		check.Address = h.Name
+		if h.DomainName != "" {
+			check.Address = h.Name + "." + h.DomainName
+		}
+	}
+	return &check
+}
+
+// wait checks the host until it is reachable, the timeout expires or the
+// supplied context is cancelled
+func (check foremanHostReadinessCheck) wait(ctx context.Context) error {
+	log.Tracef("resource_foreman_host_readiness.go#wait")
+
+	deadline := time.Now().Add(check.Timeout)
+	var probeErr error
+	for attempt := 0; ; attempt++ {
+		probeErr = check.probe()
+		if probeErr == nil {
+			log.Debugf("Host [%s] is ready after [%d] checks", check.Address, attempt+1)
+			return nil
+		}
+		log.Debugf("Readiness check #[%d] of host [%s] failed: %s", attempt, check.Address, probeErr)
+		if time.Now().Add(check.Interval).After(deadline) {
+			break
+		}
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("Waiting for host [%s] was interrupted: %s", check.Address, ctx.Err())
+		case <-time.After(check.Interval):
+		}
+	}
+	return fmt.Errorf(
+		"Host [%s] is not reachable via [%s] after [%s]: %s",
+		check.Address,
+		check.Type,
+		check.Timeout,
+		probeErr,
+	)
+}
+
+// probe checks the host once.  Each check waits at most one interval for an
+// answer.
+func (check foremanHostReadinessCheck) probe() error {
+	if check.Type == readinessCheckICMP {
+		return pingHost(check.Address, check.Interval)
+	}
+	address := net.JoinHostPort(check.Address, strconv.Itoa(check.Port))
+	conn, dialErr := net.DialTimeout("tcp", address, check.Interval)
+	if dialErr != nil {
+		return dialErr
+	}
+	return conn.Close()
+}
+
+// pingHost sends an ICMP echo request to the supplied address and waits for
+// the reply until the timeout expires.  Unprivileged ping sockets are tried
+// first, raw sockets require elevated privileges.
+func pingHost(address string, timeout time.Duration) error {
+	ipAddr, resolveErr := net.ResolveIPAddr("ip4", address)
+	if resolveErr != nil {
+		return resolveErr
+	}
+
+	var dst net.Addr = &net.UDPAddr{IP: ipAddr.IP}
+	conn, listenErr := icmp.ListenPacket("udp4", "0.0.0.0")
+	if listenErr != nil {
+		dst = ipAddr
+		conn, listenErr = icmp.ListenPacket("ip4:icmp", "0.0.0.0")
+		if listenErr != nil {
+			return listenErr
+		}
+	}
+	defer conn.Close()
+
+	msg := icmp.Message{
+		Type: ipv4.ICMPTypeEcho,
+		Body: &icmp.Echo{
+			ID:   os.Getpid() & 0xffff,
+			Seq:  1,
+			Data: []byte("terraform-provider-foreman"),
+		},
+	}
+	msgBytes, msgErr := msg.Marshal(nil)
+	if msgErr != nil {
+		return msgErr
+	}
+	if _, writeErr := conn.WriteTo(msgBytes, dst); writeErr != nil {
+		return writeErr
+	}
+
+	if deadlineErr := conn.SetReadDeadline(time.Now().Add(timeout)); deadlineErr != nil {
+		return deadlineErr
+	}
+	reply := make([]byte, 1500)
+	for {
+		n, peer, readErr := conn.ReadFrom(reply)
+		if readErr != nil {
+			return readErr
+		}
+		// NOTE(ALL): 1 is the protocol number of ICMP for IPv4
+		replyMsg, parseErr := icmp.ParseMessage(1, reply[:n])
+		if parseErr != nil {
+			continue
+		}
+		peerIP, _, _ := net.SplitHostPort(peer.String())
+		if peerIP == "" {
+			peerIP = peer.String()
+		}
+		if replyMsg.Type == ipv4.ICMPTypeEchoReply && peerIP == ipAddr.IP.String() {
+			return nil
+		}
+	}
+}
@@ -0,0 +1,651 @@
+package foreman
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/HanseMerkur/terraform-provider-foreman/foreman/api"
+	"github.com/wayfair/terraform-provider-utils/autodoc"
+	"github.com/wayfair/terraform-provider-utils/log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const (
+	// Regex validation on the name pattern of a host set.  The pattern must
+	// contain exactly one integer verb which is replaced by the host's index.
+	hostSetNamePatternRegex = `^[^%]*%[-+ 0]*[0-9]*d[^%]*$`
+)
+
+// hostSetSharedAttributes lists the attributes applied to every host of the
+// set.  Changes to any of them update all hosts of the set.
+var hostSetSharedAttributes = []string{
+	"comment",
+	"domain_id",
+	"environment_id",
+	"hostgroup_id",
+	"operatingsystem_id",
+	"medium_id",
+	"image_id",
+	"compute_profile_id",
+	"parameters",
+}
+
+func resourceForemanHostSet() *schema.Resource {
+	return &schema.Resource{
+
+		CreateContext: resourceForemanHostSetCreate,
+		Read:          resourceForemanHostSetRead,
+		Update:        resourceForemanHostSetUpdate,
+		Delete:        resourceForemanHostSetDelete,
+
+		CustomizeDiff: resourceForemanHostSetCustomizeDiff,
+
+		Schema: map[string]*schema.Schema{
+
+			autodoc.MetaAttribute: &schema.Schema{
+				Type:     schema.TypeBool,
+				Computed: true,
+				Description: fmt.Sprintf(
+					"%s A set of hosts sharing the same attributes. The hosts are "+
+						"created, updated and deleted with concurrent API calls, which "+
+						"is considerably faster than using `count` on `foreman_host`.",
+					autodoc.MetaSummary,
+				),
+			},
+
+			"name_pattern": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+				ValidateFunc: validation.StringMatch(
+					regexp.MustCompile(hostSetNamePatternRegex),
+					"Name pattern must contain exactly one integer verb (ie: %d, %02d).",
+				),
+				Description: fmt.Sprintf(
+					"Pattern of the host names. The integer verb is replaced by the "+
+						"index of the host. %s \"web%%02d\"",
+					autodoc.MetaExample,
+				),
+			},
+
+			"host_count": &schema.Schema{
+				Type:         schema.TypeInt,
+				Required:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+				Description: "Number of hosts in the set. Increasing the count " +
+					"creates the additional hosts, decreasing it deletes the hosts " +
+					"with the highest indexes.",
+			},
+
+			"start_index": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ForceNew:     true,
+				Default:      1,
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "Index of the first host of the set. Defaults to `1`.",
+			},
+
+			"parallelism": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      5,
+				ValidateFunc: validation.IntBetween(1, 50),
+				Description: "Maximum number of concurrent API calls. " +
+					"Defaults to `5`.",
+			},
+
+			"method": &schema.Schema{
+				Type:     schema.TypeString,
+				ForceNew: true,
+				Optional: true,
+				Default:  "build",
+				ValidateFunc: validation.StringInSlice([]string{
+					"build",
+					"image",
+				}, false),
+				Description: "Chooses a method with which to provision the hosts. " +
+					"Options are \"build\" and \"image\"",
+			},
+
+			"comment": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Additional information about the hosts.",
+			},
+
+			"parameters": &schema.Schema{
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Description: "A map of parameters that will be saved as host parameters of every host.",
+			},
+
+			"power_state": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				ValidateFunc: validation.StringInSlice([]string{
+					api.PowerOn,
+					api.PowerOff,
+					// NOTE(ALL): false - do not ignore case when comparing values
+				}, false),
+				Description: "Desired power state of the hosts, `\"on\"` or " +
+					"`\"off\"`. All hosts are powered when it changes, created hosts " +
+					"when they are added to the set. The hosts are powered with a " +
+					"single request to the bulk hosts API, or with concurrent API " +
+					"calls on Foreman versions without it. The power state of the " +
+					"hosts is not read back.",
+			},
+
+			"retry_count": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      2,
+				Description:  "Number of attempts made to create or update each host in Foreman before giving up.",
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+
+			"retry_delay": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      0,
+				ValidateFunc: validation.IntAtLeast(0),
+				Description: "Number of seconds to wait between two attempts to create " +
+					"or update a host. Defaults to `0`.",
+			},
+
+			// -- Foreign Key Relationships --
+
+			"domain_id": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "ID of the domain to assign to the hosts.",
+			},
+			"environment_id": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "ID of the environment to assign to the hosts.",
+			},
+			"hostgroup_id": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "ID of the hostgroup to assign to the hosts.",
+			},
+			"operatingsystem_id": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "ID of the operating system to put on the hosts.",
+			},
+			"medium_id": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "ID of the medium mounted on the hosts.",
+			},
+			"image_id": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "ID of the image cloned for the hosts.",
+			},
+			"compute_resource_id": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "ID of the compute resource the hosts are deployed on.",
+			},
+			"compute_profile_id": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "ID of the compute profile applied to the hosts.",
+			},
+
+			// -- Computed --
+
+			"host_ids": &schema.Schema{
+				Type:     schema.TypeMap,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeInt,
+				},
+				Description: "Map of the host names to the IDs of the hosts in Foreman.",
+			},
+		},
+	}
+}
+
+// -----------------------------------------------------------------------------
+// Conversion Helpers
+// -----------------------------------------------------------------------------
+
+// buildForemanHostSetHost constructs the ForemanHost shared by all hosts of
+// the set from a resource data reference.  The host's ID and name are left to
+// the zero value.
+func buildForemanHostSetHost(d *schema.ResourceData) *api.ForemanHost {
+	host := api.ForemanHost{}
+	host.Comment = d.Get("comment").(string)
+	host.Method = d.Get("method").(string)
+	host.DomainId = d.Get("domain_id").(int)
+	host.EnvironmentId = d.Get("environment_id").(int)
+	host.HostgroupId = d.Get("hostgroup_id").(int)
+	host.OperatingSystemId = d.Get("operatingsystem_id").(int)
+	host.MediumId = d.Get("medium_id").(int)
+	host.ImageId = d.Get("image_id").(int)
+	host.ComputeResourceId = d.Get("compute_resource_id").(int)
+	host.ComputeProfileId = d.Get("compute_profile_id").(int)
+
+	for key, value := range d.Get("parameters").(map[string]interface{}) {
+		host.HostParameters = append(host.HostParameters, api.ForemanKVParameter{
+			Name:  key,
+			Value: value.(string),
+		})
+	}
+
+	return &host
+}
+
+// foremanHostSetNames returns the names of the hosts of the set, generated
+// from the name pattern, the start index and the host count.
+func foremanHostSetNames(namePattern string, startIndex int, hostCount int) []string {
+	names := make([]string, hostCount)
+	for idx := range names {
+		names[idx] = fmt.Sprintf(namePattern, startIndex+idx)
+	}
+	return names
+}
+
+// foremanHostSetIds converts the "host_ids" attribute to a map of host names
+// to host IDs.
+func foremanHostSetIds(v interface{}) map[string]int {
+	hostIds := map[string]int{}
+	attr, _ := v.(map[string]interface{})
+	for name, id := range attr {
+		if hostId, ok := id.(int); ok {
+			hostIds[name] = hostId
+		}
+	}
+	return hostIds
+}
+
+// copyForemanHostSetIds returns a copy of the supplied map of host names to
+// host IDs.  Concurrent operations look up the host IDs in the copy while the
+// original is modified.
+func copyForemanHostSetIds(hostIds map[string]int) map[string]int {
+	hostIdsCopy := make(map[string]int, len(hostIds))
+	for name, id := range hostIds {
+		hostIdsCopy[name] = id
+	}
+	return hostIdsCopy
+}
+
+// runForemanHostSetOperations calls the supplied operation for each of the
+// host names, with at most parallelism calls in flight at the same time.  The
+// errors of the failed calls are combined into a single error.
+func runForemanHostSetOperations(names []string, parallelism int, op func(name string) error) error {
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	failures := []string{}
+	sem := make(chan struct{}, parallelism)
+
+	for _, name := range names {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(name string) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			if opErr := op(name); opErr != nil {
+				mu.Lock()
+				failures = append(failures, fmt.Sprintf("[%s]: %s", name, opErr))
+				mu.Unlock()
+			}
+		}(name)
+	}
+	wg.Wait()
+
+	if len(failures) == 0 {
+		return nil
+	}
+	sort.Strings(failures)
+	return fmt.Errorf(
+		"%d of %d host operations failed: %s",
+		len(failures),
+		len(names),
+		strings.Join(failures, "; "),
+	)
+}
+
+// setForemanHostSetPowerState sends the supplied power action to the hosts
+// identified by the supplied map of host names to host IDs.  The bulk hosts
+// API is used when Foreman provides it, individual power commands otherwise.
+func setForemanHostSetPowerState(client *api.Client, hostIds map[string]int, action string, retry api.RetryConfig, parallelism int) error {
+	if len(hostIds) == 0 {
+		return nil
+	}
+	names := make([]string, 0, len(hostIds))
+	ids := make([]int, 0, len(hostIds))
+	for name, id := range hostIds {
+		names = append(names, name)
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+
+	bulkErr := client.SendBulkPowerCommand(ids, action, retry)
+	if !api.IsNotFound(bulkErr) {
+		return bulkErr
+	}
+	log.Debugf("Bulk hosts API is not available, powering [%d] hosts individually", len(ids))
+
+	return runForemanHostSetOperations(names, parallelism, func(name string) error {
+		h := api.ForemanHost{}
+		h.Id = hostIds[name]
+		return client.SendPowerCommand(&h, api.Power{PowerAction: action}, retry, api.PowerVerifyConfig{})
+	})
+}
+
+// -----------------------------------------------------------------------------
+// Plan-time Validation
+// -----------------------------------------------------------------------------
+
+// resourceForemanHostSetCustomizeDiff plans an update of the set when hosts
+// of the set are missing from Foreman, ie: deleted out of band.
+func resourceForemanHostSetCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if d.Id() == "" {
+		return nil
+	}
+	if !d.NewValueKnown("name_pattern") || !d.NewValueKnown("start_index") || !d.NewValueKnown("host_count") {
+		return nil
+	}
+
+	hostIds := foremanHostSetIds(d.Get("host_ids"))
+	names := foremanHostSetNames(
+		d.Get("name_pattern").(string),
+		d.Get("start_index").(int),
+		d.Get("host_count").(int),
+	)
+	if len(hostIds) != len(names) {
+		return d.SetNewComputed("host_ids")
+	}
+	for _, name := range names {
+		if _, ok := hostIds[name]; !ok {
+			return d.SetNewComputed("host_ids")
+		}
+	}
+	return nil
+}
+
+// -----------------------------------------------------------------------------
+// Resource CRUD Operations
+// -----------------------------------------------------------------------------
+
+func resourceForemanHostSetCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Tracef("resource_foreman_host_set.go#Create")
+
+	client := meta.(*api.Client)
+	hostRetry := buildForemanHostRetryConfig(d)
+	// NOTE(ALL): ResourceData is not safe for concurrent use, the host shared
+	//   by the set is built before the concurrent API calls
+	sharedHost := buildForemanHostSetHost(d)
+
+	names := foremanHostSetNames(
+		d.Get("name_pattern").(string),
+		d.Get("start_index").(int),
+		d.Get("host_count").(int),
+	)
+
+	var mu sync.Mutex
+	hostIds := map[string]int{}
+	createErr := runForemanHostSetOperations(names, d.Get("parallelism").(int), func(name string) error {
+		h := *sharedHost
+		h.Name = name
+		// NOTE(ALL): Set the build flag to true on host create
+		h.Build = h.Method == "build"
+		h.ProgressReportId = newProgressReportId()
+		log.Debugf("ForemanHost: [%+v]", h)
+		createdHost, err := client.CreateHost(&h, hostRetry)
+		if err != nil {
+			return err
+		}
+		log.Debugf("Created ForemanHost: [%+v]", createdHost)
+		mu.Lock()
+		hostIds[name] = createdHost.Id
+		mu.Unlock()
+		return nil
+	})
+
+	if len(hostIds) == 0 {
+		return diag.FromErr(createErr)
+	}
+
+	// NOTE(ALL): Keep the hosts that were created in the state even if some of
+	//   them failed.  An error would taint the set and the next apply would
+	//   replace all of its hosts - the failures are reported as a warning
+	//   instead and the next plan creates the missing hosts.
+	d.SetId(d.Get("name_pattern").(string))
+	d.Set("host_ids", hostIds)
+
+	var diags diag.Diagnostics
+	if createErr != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  fmt.Sprintf("Created [%d] of [%d] hosts of host set [%s]", len(hostIds), len(names), d.Id()),
+			Detail:   fmt.Sprintf("The missing hosts are created by the next apply: %s", createErr),
+		})
+	}
+
+	if powerState := d.Get("power_state").(string); powerState != "" {
+		if powerErr := setForemanHostSetPowerState(client, hostIds, powerState, hostRetry, d.Get("parallelism").(int)); powerErr != nil {
+			// NOTE(ALL): clear the power state so the next apply powers the
+			//   hosts again
+			d.Set("power_state", "")
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Warning,
+				Summary:  fmt.Sprintf("Failed to power the hosts of host set [%s] %s", d.Id(), powerState),
+				Detail:   fmt.Sprintf("The hosts are powered by the next apply: %s", powerErr),
+			})
+		}
+	}
+	return diags
+}
+
+func resourceForemanHostSetRead(d *schema.ResourceData, meta interface{}) error {
+	log.Tracef("resource_foreman_host_set.go#Read")
+
+	client := meta.(*api.Client)
+	hostIds := foremanHostSetIds(d.Get("host_ids"))
+
+	names := make([]string, 0, len(hostIds))
+	for name := range hostIds {
+		names = append(names, name)
+	}
+
+	var mu sync.Mutex
+	readIds := copyForemanHostSetIds(hostIds)
+	readErr := runForemanHostSetOperations(names, d.Get("parallelism").(int), func(name string) error {
+		_, err := client.ReadHost(readIds[name])
+		if api.IsNotFound(err) {
+			log.Infof("Host [%s] of the set no longer exists in Foreman, removing it from the state", name)
+			mu.Lock()
+			delete(hostIds, name)
+			mu.Unlock()
+			return nil
+		}
+		return err
+	})
+	if readErr != nil {
+		return readErr
+	}
+
+	if len(hostIds) == 0 {
+		log.Infof("Resource [%s] no longer exists in Foreman, removing it from the state", d.Id())
+		d.SetId("")
+		return nil
+	}
+	d.Set("host_ids", hostIds)
+
+	return nil
+}
+
+func resourceForemanHostSetUpdate(d *schema.ResourceData, meta interface{}) error {
+	log.Tracef("resource_foreman_host_set.go#Update")
+
+	client := meta.(*api.Client)
+	hostRetry := buildForemanHostRetryConfig(d)
+	// NOTE(ALL): ResourceData is not safe for concurrent use, the host shared
+	//   by the set is built before the concurrent API calls
+	sharedHost := buildForemanHostSetHost(d)
+	parallelism := d.Get("parallelism").(int)
+
+	oldHostIds, _ := d.GetChange("host_ids")
+	hostIds := foremanHostSetIds(oldHostIds)
+	names := foremanHostSetNames(
+		d.Get("name_pattern").(string),
+		d.Get("start_index").(int),
+		d.Get("host_count").(int),
+	)
+
+	// Sort the hosts of the set into the ones to create, update and delete
+	wanted := map[string]bool{}
+	createNames, updateNames, deleteNames := []string{}, []string{}, []string{}
+	for _, name := range names {
+		wanted[name] = true
+		if _, ok := hostIds[name]; ok {
+			updateNames = append(updateNames, name)
+		} else {
+			createNames = append(createNames, name)
+		}
+	}
+	for name := range hostIds {
+		if !wanted[name] {
+			deleteNames = append(deleteNames, name)
+		}
+	}
+	if !d.HasChanges(hostSetSharedAttributes...) {
+		updateNames = []string{}
+	}
+
+	var mu sync.Mutex
+	var errs []string
+	existingIds := copyForemanHostSetIds(hostIds)
+	createdIds := map[string]int{}
+
+	deleteErr := runForemanHostSetOperations(deleteNames, parallelism, func(name string) error {
+		if err := client.DeleteHost(existingIds[name]); err != nil && !api.IsNotFound(err) {
+			return err
+		}
+		mu.Lock()
+		delete(hostIds, name)
+		mu.Unlock()
+		return nil
+	})
+	if deleteErr != nil {
+		errs = append(errs, deleteErr.Error())
+	}
+
+	updateErr := runForemanHostSetOperations(updateNames, parallelism, func(name string) error {
+		h := *sharedHost
+		h.Name = name
+		h.Id = existingIds[name]
+		log.Debugf("ForemanHost: [%+v]", h)
+		updatedHost, err := client.UpdateHost(&h, hostRetry)
+		if err != nil {
+			return err
+		}
+		log.Debugf("Updated ForemanHost: [%+v]", updatedHost)
+		return nil
+	})
+	if updateErr != nil {
+		errs = append(errs, updateErr.Error())
+	}
+
+	createErr := runForemanHostSetOperations(createNames, parallelism, func(name string) error {
+		h := *sharedHost
+		h.Name = name
+		// NOTE(ALL): Set the build flag to true on host create
+		h.Build = h.Method == "build"
+		h.ProgressReportId = newProgressReportId()
+		log.Debugf("ForemanHost: [%+v]", h)
+		createdHost, err := client.CreateHost(&h, hostRetry)
+		if err != nil {
+			return err
+		}
+		log.Debugf("Created ForemanHost: [%+v]", createdHost)
+		mu.Lock()
+		hostIds[name] = createdHost.Id
+		createdIds[name] = createdHost.Id
+		mu.Unlock()
+		return nil
+	})
+	if createErr != nil {
+		errs = append(errs, createErr.Error())
+	}
+
+	// NOTE(ALL): A changed power state applies to all hosts of the set, an
+	//   unchanged one to the created hosts only
+	if powerState := d.Get("power_state").(string); powerState != "" {
+		powerIds := createdIds
+		if d.HasChange("power_state") {
+			powerIds = hostIds
+		}
+		if powerErr := setForemanHostSetPowerState(client, powerIds, powerState, hostRetry, parallelism); powerErr != nil {
+			errs = append(errs, powerErr.Error())
+			// NOTE(ALL): keep the previous power state so the next apply
+			//   powers the hosts again
+			oldPowerState, _ := d.GetChange("power_state")
+			d.Set("power_state", oldPowerState)
+		}
+	}
+
+	// NOTE(ALL): Record the hosts that exist after the update even if some of
+	//   the operations failed, so no host is orphaned in Foreman.
+	d.Set("host_ids", hostIds)
+
+	if len(errs) > 0 {
+		return fmt.Errorf("Failed to update host set [%s]: %s", d.Id(), strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+func resourceForemanHostSetDelete(d *schema.ResourceData, meta interface{}) error {
+	log.Tracef("resource_foreman_host_set.go#Delete")
+
+	client := meta.(*api.Client)
+	hostIds := foremanHostSetIds(d.Get("host_ids"))
+
+	names := make([]string, 0, len(hostIds))
+	for name := range hostIds {
+		names = append(names, name)
+	}
+
+	var mu sync.Mutex
+	existingIds := copyForemanHostSetIds(hostIds)
+	deleteErr := runForemanHostSetOperations(names, d.Get("parallelism").(int), func(name string) error {
+		if err := client.DeleteHost(existingIds[name]); err != nil && !api.IsNotFound(err) {
+			return err
+		}
+		mu.Lock()
+		delete(hostIds, name)
+		mu.Unlock()
+		return nil
+	})
+	if deleteErr != nil {
+		// NOTE(ALL): Keep the hosts that could not be deleted in the state
+		d.Set("host_ids", hostIds)
+		return deleteErr
+	}
+
+	// NOTE(ALL): d.SetId("") is automatically called by terraform assuming delete
+	//   returns no errors
+	return nil
+}
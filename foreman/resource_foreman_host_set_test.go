@@ -0,0 +1,201 @@
+package foreman
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"sort"
+	"sync"
+	"testing"
+
+	"github.com/HanseMerkur/terraform-provider-foreman/foreman/api"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// -----------------------------------------------------------------------------
+// foremanHostSetNames
+// -----------------------------------------------------------------------------
+
+// Ensures the host names are generated from the pattern and the start index
+func TestForemanHostSetNames(t *testing.T) {
+
+	expected := []string{"web08", "web09", "web10"}
+	actual := foremanHostSetNames("web%02d", 8, 3)
+	if !reflect.DeepEqual(expected, actual) {
+		t.Fatalf("expected host names [%v], got [%v]", expected, actual)
+	}
+
+}
+
+// -----------------------------------------------------------------------------
+// runForemanHostSetOperations
+// -----------------------------------------------------------------------------
+
+// Ensures no more than parallelism operations run at the same time and the
+// errors of all failed operations are returned
+func TestRunForemanHostSetOperations(t *testing.T) {
+
+	names := foremanHostSetNames("host%d", 1, 20)
+	parallelism := 3
+
+	var mu sync.Mutex
+	running, maxRunning := 0, 0
+	opErr := runForemanHostSetOperations(names, parallelism, func(name string) error {
+		mu.Lock()
+		running++
+		if running > maxRunning {
+			maxRunning = running
+		}
+		mu.Unlock()
+		defer func() {
+			mu.Lock()
+			running--
+			mu.Unlock()
+		}()
+		if name == "host4" || name == "host17" {
+			return fmt.Errorf("failed")
+		}
+		return nil
+	})
+
+	if maxRunning > parallelism {
+		t.Fatalf(
+			"expected at most [%d] concurrent operations, got [%d]",
+			parallelism,
+			maxRunning,
+		)
+	}
+	expected := "2 of 20 host operations failed: [host17]: failed; [host4]: failed"
+	if opErr == nil || opErr.Error() != expected {
+		t.Fatalf("expected error [%s], got [%v]", expected, opErr)
+	}
+
+	if opErr = runForemanHostSetOperations(names, parallelism, func(string) error { return nil }); opErr != nil {
+		t.Fatalf("expected no error, got [%s]", opErr)
+	}
+
+}
+
+// -----------------------------------------------------------------------------
+// setForemanHostSetPowerState
+// -----------------------------------------------------------------------------
+
+// Ensures the hosts are powered with a single bulk request, and individually
+// when Foreman has no bulk hosts API
+func TestSetForemanHostSetPowerState(t *testing.T) {
+
+	mux, server, client := NewForemanAPIAndClient(api.ClientCredentials{}, api.ClientConfig{})
+	defer server.Close()
+
+	bulkStatus := http.StatusOK
+	var mu sync.Mutex
+	requests := []string{}
+	mux.HandleFunc(api.FOREMAN_API_URL_PREFIX+"/"+api.HostBulkPowerEndpoint, func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		mu.Lock()
+		requests = append(requests, string(body))
+		mu.Unlock()
+		w.WriteHeader(bulkStatus)
+		fmt.Fprint(w, `{}`)
+	})
+	for _, id := range []int{3, 7} {
+		mux.HandleFunc(fmt.Sprintf("%s/%d/power", HostsURI, id), func(w http.ResponseWriter, r *http.Request) {
+			mu.Lock()
+			requests = append(requests, r.URL.Path)
+			mu.Unlock()
+			fmt.Fprint(w, `{"power": true}`)
+		})
+	}
+
+	hostIds := map[string]int{"web01": 7, "web02": 3}
+
+	powerErr := setForemanHostSetPowerState(client, hostIds, api.PowerOn, api.RetryConfig{}, 2)
+	if powerErr != nil {
+		t.Fatalf("expected no error, got [%s]", powerErr)
+	}
+	expected := []string{`{"included":{"ids":[3,7]},"power":"on"}`}
+	if !reflect.DeepEqual(expected, requests) {
+		t.Fatalf("expected the requests [%v], got [%v]", expected, requests)
+	}
+
+	bulkStatus = http.StatusNotFound
+	requests = []string{}
+	powerErr = setForemanHostSetPowerState(client, hostIds, api.PowerOff, api.RetryConfig{}, 2)
+	if powerErr != nil {
+		t.Fatalf("expected no error, got [%s]", powerErr)
+	}
+	sort.Strings(requests)
+	expected = []string{
+		HostsURI + "/3/power",
+		HostsURI + "/7/power",
+		`{"included":{"ids":[3,7]},"power":"off"}`,
+	}
+	if !reflect.DeepEqual(expected, requests) {
+		t.Fatalf("expected the requests [%v], got [%v]", expected, requests)
+	}
+
+}
+
+// -----------------------------------------------------------------------------
+// resourceForemanHostSetCreate
+// -----------------------------------------------------------------------------
+
+// Ensures a partially created set keeps the created hosts without failing the
+// create, which would taint the set and replace all of its hosts.  The hosts
+// are created in build mode.
+func TestResourceForemanHostSetCreate_PartialFailure(t *testing.T) {
+
+	mux, server, client := NewForemanAPIAndClient(api.ClientCredentials{}, api.ClientConfig{})
+	defer server.Close()
+
+	var mu sync.Mutex
+	nextId := 10
+	mux.HandleFunc(HostsURI, func(w http.ResponseWriter, r *http.Request) {
+		var sent map[string]map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&sent)
+		name, _ := sent["host"]["name"].(string)
+		if sent["host"]["build"] != true {
+			t.Errorf("expected host [%s] to be created in build mode, got [%v]", name, sent["host"])
+		}
+		if name == "web02" {
+			w.WriteHeader(http.StatusUnprocessableEntity)
+			fmt.Fprint(w, `{"error": {"message": "Name has already been taken"}}`)
+			return
+		}
+		mu.Lock()
+		nextId++
+		id := nextId
+		mu.Unlock()
+		fmt.Fprintf(w, `{"id": %d, "name": "%s"}`, id, name)
+	})
+
+	r := resourceForemanHostSet()
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"name_pattern": "web%02d",
+		"host_count":   3,
+	})
+	diags := r.CreateContext(context.Background(), d, client)
+	if diags.HasError() {
+		t.Fatalf("expected no error, got [%v]", diags)
+	}
+	if len(diags) != 1 {
+		t.Fatalf("expected the failed host to be reported as a warning, got [%v]", diags)
+	}
+	if d.Id() != "web%02d" {
+		t.Fatalf("expected the set to be created, got the ID [%s]", d.Id())
+	}
+	hostIds := foremanHostSetIds(d.Get("host_ids"))
+	names := []string{}
+	for name := range hostIds {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	if !reflect.DeepEqual(names, []string{"web01", "web03"}) {
+		t.Fatalf("expected only the created hosts to be recorded, got [%v]", hostIds)
+	}
+
+}
@@ -1,19 +1,26 @@
 package foreman
 
 import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"math/rand"
+	"net"
 	"net/http"
 	"reflect"
 	"strconv"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/HanseMerkur/terraform-provider-foreman/foreman/api"
 	tfrand "github.com/wayfair/terraform-provider-utils/rand"
 
-	"github.com/hashicorp/terraform/helper/schema"
-	"github.com/hashicorp/terraform/terraform"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
 
 // -----------------------------------------------------------------------------
@@ -23,13 +30,38 @@ import (
 const HostsURI = api.FOREMAN_API_URL_PREFIX + "/hosts"
 const HostsTestDataPath = "testdata/1.11/hosts"
 
+// withoutContext adapts the context aware CRUD functions of the host to the
+// CRUD functions of the unit test framework
+func withoutContext(f func(context.Context, *schema.ResourceData, interface{}) error) CRUDFunc {
+	return func(d *schema.ResourceData, meta interface{}) error {
+		return f(context.Background(), d, meta)
+	}
+}
+
+// withoutDiagnostics adapts a context aware CRUD function returning
+// diagnostics to the CRUD functions of the table driven tests.  Warnings are
+// ignored, the first error is returned.
+func withoutDiagnostics(f func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics) CRUDFunc {
+	return func(d *schema.ResourceData, meta interface{}) error {
+		for _, diagnostic := range f(context.Background(), d, meta) {
+			if diagnostic.Severity == diag.Error {
+				return fmt.Errorf("%s", diagnostic.Summary)
+			}
+		}
+		return nil
+	}
+}
+
 // Given a ForemanHost, create a mock instance state reference
 func ForemanHostToInstanceState(obj api.ForemanHost) *terraform.InstanceState {
 	state := terraform.InstanceState{}
 	state.ID = strconv.Itoa(obj.Id)
 	// Build the attribute map from ForemanHost
 	attr := map[string]string{}
+	attr["created_at"] = obj.CreatedAt
+	attr["updated_at"] = obj.UpdatedAt
 	attr["name"] = obj.Name
+	attr["fqdn"] = obj.FQDN()
 	attr["domain_id"] = strconv.Itoa(obj.DomainId)
 	attr["environment_id"] = strconv.Itoa(obj.EnvironmentId)
 	attr["hostgroup_id"] = strconv.Itoa(obj.HostgroupId)
@@ -243,6 +275,430 @@ func TestSetResourceDataFromForemanHost_Value(t *testing.T) {
 
 }
 
+// -----------------------------------------------------------------------------
+// resourceForemanInterfacesAttributesHash
+// -----------------------------------------------------------------------------
+
+// Ensures interfaces are keyed by their identifier and computed attributes
+// returned by Foreman do not change the hash
+func TestResourceForemanInterfacesAttributesHash_Identifier(t *testing.T) {
+
+	configured := map[string]interface{}{
+		"identifier": "eth0",
+		"ip":         "10.0.0.10",
+		"type":       "interface",
+	}
+	read := map[string]interface{}{
+		"id":         rand.Intn(100) + 1,
+		"identifier": "eth0",
+		"ip":         "10.0.0.10",
+		"mac":        "52:54:00:12:34:56",
+		"type":       "interface",
+	}
+
+	if resourceForemanInterfacesAttributesHash(configured) != resourceForemanInterfacesAttributesHash(read) {
+		t.Fatalf(
+			"resourceForemanInterfacesAttributesHash returned different hashes for " +
+				"the same interface identifier",
+		)
+	}
+
+	read["identifier"] = "eth1"
+	if resourceForemanInterfacesAttributesHash(configured) == resourceForemanInterfacesAttributesHash(read) {
+		t.Fatalf(
+			"resourceForemanInterfacesAttributesHash returned the same hash for " +
+				"different interface identifiers",
+		)
+	}
+
+}
+
+// Ensures interfaces read from Foreman keep the key they were configured with,
+// regardless of the order Foreman returns them in
+func TestSetResourceDataFromForemanInterfacesAttributes_StableKeys(t *testing.T) {
+
+	configured := map[string]interface{}{
+		"mac":  "52:54:00:12:34:56",
+		"type": "interface",
+	}
+	d := schema.TestResourceDataRaw(t, resourceForemanHost().Schema, map[string]interface{}{
+		"name":                  "host01",
+		"interfaces_attributes": []interface{}{configured},
+	})
+
+	setResourceDataFromForemanInterfacesAttributes(d, []api.ForemanInterfacesAttribute{
+		api.ForemanInterfacesAttribute{
+			Id:         2,
+			Identifier: "eth1",
+			MAC:        "52:54:00:AB:CD:EF",
+			Type:       "interface",
+		},
+		api.ForemanInterfacesAttribute{
+			Id:         1,
+			Identifier: "eth0",
+			MAC:        "52:54:00:12:34:56",
+			Type:       "interface",
+		},
+	})
+
+	ifaceSet := d.Get("interfaces_attributes").(*schema.Set)
+	if ifaceSet.Len() != 2 {
+		t.Fatalf("expected 2 interfaces, got [%d]", ifaceSet.Len())
+	}
+	if !ifaceSet.Contains(configured) {
+		t.Fatalf(
+			"interface configured by MAC address is not keyed by its MAC " +
+				"address after the read",
+		)
+	}
+
+}
+
+// Ensures interfaces configured by IP address or subnet only keep their key
+// when Foreman fills in their identifier, MAC address and IP address
+func TestSetResourceDataFromForemanInterfacesAttributes_IPOnly(t *testing.T) {
+
+	byIP := map[string]interface{}{
+		"ip":        "10.0.0.10",
+		"subnet_id": 1,
+		"type":      "interface",
+	}
+	bySubnet := map[string]interface{}{
+		"subnet_id": 2,
+		"type":      "interface",
+	}
+	d := schema.TestResourceDataRaw(t, resourceForemanHost().Schema, map[string]interface{}{
+		"name":                  "host01",
+		"interfaces_attributes": []interface{}{byIP, bySubnet},
+	})
+
+	setResourceDataFromForemanInterfacesAttributes(d, []api.ForemanInterfacesAttribute{
+		api.ForemanInterfacesAttribute{
+			Id:         1,
+			Identifier: "eth0",
+			IP:         "10.0.0.10",
+			MAC:        "52:54:00:12:34:56",
+			SubnetId:   1,
+			Type:       "interface",
+		},
+		api.ForemanInterfacesAttribute{
+			Id:         2,
+			Identifier: "eth1",
+			IP:         "10.0.1.20",
+			MAC:        "52:54:00:AB:CD:EF",
+			SubnetId:   2,
+			Type:       "interface",
+		},
+	})
+
+	ifaceSet := d.Get("interfaces_attributes").(*schema.Set)
+	if ifaceSet.Len() != 2 {
+		t.Fatalf("expected 2 interfaces, got [%d]", ifaceSet.Len())
+	}
+	for _, configured := range []map[string]interface{}{byIP, bySubnet} {
+		hash := resourceForemanInterfacesAttributesHash(configured)
+		found := false
+		for _, iface := range ifaceSet.List() {
+			if resourceForemanInterfacesAttributesHash(iface) == hash {
+				found = true
+			}
+		}
+		if !found {
+			t.Fatalf(
+				"interface configured as [%v] is not keyed the same after the "+
+					"read, got [%v]",
+				configured,
+				ifaceSet.List(),
+			)
+		}
+	}
+
+}
+
+// Ensures interfaces attached with foreman_host_interface are left out of the
+// state of a host without exclusive interfaces
+func TestSetResourceDataFromForemanInterfacesAttributes_NonExclusive(t *testing.T) {
+
+	configured := map[string]interface{}{
+		"identifier": "eth0",
+		"type":       "interface",
+	}
+	d := schema.TestResourceDataRaw(t, resourceForemanHost().Schema, map[string]interface{}{
+		"name":                  "host01",
+		"exclusive_interfaces":  false,
+		"interfaces_attributes": []interface{}{configured},
+	})
+
+	setResourceDataFromForemanInterfacesAttributes(d, []api.ForemanInterfacesAttribute{
+		api.ForemanInterfacesAttribute{
+			Id:         1,
+			Identifier: "eth0",
+			Type:       "interface",
+		},
+		api.ForemanInterfacesAttribute{
+			Id:         2,
+			Identifier: "eth1",
+			Type:       "interface",
+		},
+	})
+
+	ifaceSet := d.Get("interfaces_attributes").(*schema.Set)
+	if ifaceSet.Len() != 1 {
+		t.Fatalf("expected 1 interface, got [%d]", ifaceSet.Len())
+	}
+
+}
+
+// -----------------------------------------------------------------------------
+// validateForemanInterfaceComputeAttributes
+// -----------------------------------------------------------------------------
+
+// Ensures interface compute attributes are checked against the type of the
+// compute resource
+func TestValidateForemanInterfaceComputeAttributes(t *testing.T) {
+
+	testCases := []struct {
+		provider          string
+		computeAttributes map[string]interface{}
+		valid             bool
+	}{
+		{"Vmware", map[string]interface{}{"network": "VLAN 10", "type": "VirtualVmxnet3"}, true},
+		{"Vmware", map[string]interface{}{"type": "virtio"}, false},
+		{"Vmware", map[string]interface{}{"bridge": "br0"}, false},
+		{"Libvirt", map[string]interface{}{"type": "bridge", "bridge": "br0", "model": "virtio"}, true},
+		{"Libvirt", map[string]interface{}{"type": "VirtualVmxnet3"}, false},
+		{"EC2", map[string]interface{}{"anything": "goes"}, true},
+	}
+
+	for _, testCase := range testCases {
+		validateErr := validateForemanInterfaceComputeAttributes(testCase.provider, testCase.computeAttributes)
+		if testCase.valid && validateErr != nil {
+			t.Errorf(
+				"expected compute attributes [%v] to be valid for [%s], got: %s",
+				testCase.computeAttributes,
+				testCase.provider,
+				validateErr,
+			)
+		}
+		if !testCase.valid && validateErr == nil {
+			t.Errorf(
+				"expected compute attributes [%v] to be invalid for [%s]",
+				testCase.computeAttributes,
+				testCase.provider,
+			)
+		}
+	}
+
+}
+
+// -----------------------------------------------------------------------------
+// rebootForemanHostForRebuild
+// -----------------------------------------------------------------------------
+
+// Ensures the rebuild reboot uses the power action of "rebuild_power_action"
+func TestRebootForemanHostForRebuild_PowerAction(t *testing.T) {
+	cred := api.ClientCredentials{}
+	conf := api.ClientConfig{}
+
+	mux, server, client := NewForemanAPIAndClient(cred, conf)
+	defer server.Close()
+
+	actions := []string{}
+	mux.HandleFunc(HostsURI+"/1/power", func(w http.ResponseWriter, r *http.Request) {
+		var power api.Power
+		json.NewDecoder(r.Body).Decode(&power)
+		actions = append(actions, power.PowerAction)
+		fmt.Fprint(w, `{"power": true}`)
+	})
+
+	h := &api.ForemanHost{}
+	h.Id = 1
+
+	testCases := []struct {
+		powerAction string
+		expected    string
+	}{
+		{api.PowerCycle, api.PowerCycle},
+		{api.PowerSoft, api.PowerReboot},
+	}
+
+	for _, testCase := range testCases {
+		actions = []string{}
+		d := schema.TestResourceDataRaw(t, resourceForemanHost().Schema, map[string]interface{}{
+			"name":                 "host01",
+			"rebuild_power_action": testCase.powerAction,
+		})
+		rebootErr := rebootForemanHostForRebuild(d, client, h, api.RetryConfig{}, api.PowerVerifyConfig{})
+		if rebootErr != nil {
+			t.Fatalf("rebootForemanHostForRebuild returned an error: %s", rebootErr)
+		}
+		if len(actions) != 1 || actions[0] != testCase.expected {
+			t.Errorf(
+				"Expected power action [%s] for rebuild_power_action [%s], got %v",
+				testCase.expected,
+				testCase.powerAction,
+				actions,
+			)
+		}
+	}
+}
+
+func TestShutdownForemanHost_PowerOffOnDestroy(t *testing.T) {
+	cred := api.ClientCredentials{}
+	conf := api.ClientConfig{}
+
+	mux, server, client := NewForemanAPIAndClient(cred, conf)
+	defer server.Close()
+
+	actions := []string{}
+	mux.HandleFunc(HostsURI+"/1/power", func(w http.ResponseWriter, r *http.Request) {
+		var power api.Power
+		json.NewDecoder(r.Body).Decode(&power)
+		if power.PowerAction == api.PowerState {
+			fmt.Fprint(w, `{"power": "on"}`)
+			return
+		}
+		actions = append(actions, power.PowerAction)
+		fmt.Fprint(w, `{"power": true}`)
+	})
+
+	h := &api.ForemanHost{}
+	h.Id = 1
+
+	d := schema.TestResourceDataRaw(t, resourceForemanHost().Schema, map[string]interface{}{
+		"name":                 "host01",
+		"power_off_on_destroy": true,
+	})
+	if shutdownErr := shutdownForemanHost(d, client, h, api.RetryConfig{}); shutdownErr != nil {
+		t.Fatalf("shutdownForemanHost returned an error: %s", shutdownErr)
+	}
+	if len(actions) != 1 || actions[0] != api.PowerOff {
+		t.Errorf("Expected the power actions [off], got %v", actions)
+	}
+}
+
+func TestWaitForForemanHost_CancelBuild(t *testing.T) {
+	cred := api.ClientCredentials{}
+	conf := api.ClientConfig{}
+
+	mux, server, client := NewForemanAPIAndClient(cred, conf)
+	defer server.Close()
+
+	requests := []string{}
+	mux.HandleFunc(HostsURI+"/1", func(w http.ResponseWriter, r *http.Request) {
+		body := map[string]map[string]interface{}{}
+		json.NewDecoder(r.Body).Decode(&body)
+		requests = append(requests, fmt.Sprintf("%s %v", r.Method, body["host"]))
+		fmt.Fprint(w, `{"id": 1}`)
+	})
+
+	// NOTE(ALL): nothing listens on the port of a closed listener
+	listener, listenErr := net.Listen("tcp", "127.0.0.1:0")
+	if listenErr != nil {
+		t.Fatalf("net.Listen returned an error: %s", listenErr)
+	}
+	listener.Close()
+
+	check := &foremanHostReadinessCheck{
+		Type:     readinessCheckTCP,
+		Address:  "127.0.0.1",
+		Port:     listener.Addr().(*net.TCPAddr).Port,
+		Timeout:  time.Minute,
+		Interval: 10 * time.Millisecond,
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if waitErr := waitForForemanHost(ctx, client, check, 1, false); waitErr == nil || len(requests) != 0 {
+		t.Fatalf("Expected an interrupted wait without a cancelled build, got %v (%v)", requests, waitErr)
+	}
+	if waitErr := waitForForemanHost(ctx, client, check, 1, true); waitErr == nil {
+		t.Fatalf("Expected an interrupted wait to return an error")
+	}
+	if len(requests) != 1 || requests[0] != "PUT map[build:false]" {
+		t.Errorf("Expected the build to be cancelled, got %v", requests)
+	}
+}
+
+func TestSetForemanHostBuildStatus(t *testing.T) {
+	cred := api.ClientCredentials{}
+	conf := api.ClientConfig{}
+
+	mux, server, client := NewForemanAPIAndClient(cred, conf)
+	defer server.Close()
+
+	mux.HandleFunc(HostsURI+"/1/status/build", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"status": 1, "status_label": "Pending installation"}`)
+	})
+	mux.HandleFunc(api.FOREMAN_API_URL_PREFIX+"/orchestration/report1/tasks", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `[
+			{"name": "Create DHCP Settings for host01", "status": "completed"},
+			{"name": "Create DNS record for host01", "status": "conflict"}
+		]`)
+	})
+
+	d := schema.TestResourceDataRaw(t, resourceForemanHost().Schema, map[string]interface{}{
+		"name": "host01",
+	})
+	setForemanHostBuildStatus(d, client, 1, "report1", time.Now().Add(-time.Minute))
+
+	if status := d.Get("build_status").(string); status != "Pending installation" {
+		t.Errorf("Expected the build status [Pending installation], got [%s]", status)
+	}
+	expected := []interface{}{"Create DNS record for host01: conflict"}
+	if errs := d.Get("orchestration_errors").([]interface{}); !reflect.DeepEqual(errs, expected) {
+		t.Errorf("Expected the orchestration errors %v, got %v", expected, errs)
+	}
+	if duration := d.Get("build_duration").(int); duration < 60 {
+		t.Errorf("Expected a build duration of at least [60] seconds, got [%d]", duration)
+	}
+}
+
+func TestForemanHostReadinessCheck_TCP(t *testing.T) {
+	listener, listenErr := net.Listen("tcp", "127.0.0.1:0")
+	if listenErr != nil {
+		t.Fatalf("net.Listen returned an error: %s", listenErr)
+	}
+	port := listener.Addr().(*net.TCPAddr).Port
+
+	h := &api.ForemanHost{
+		InterfacesAttributes: []api.ForemanInterfacesAttribute{
+			{Primary: true, IP: "127.0.0.1"},
+		},
+	}
+	d := schema.TestResourceDataRaw(t, resourceForemanHost().Schema, map[string]interface{}{
+		"name": "host01",
+		"readiness_check": []interface{}{
+			map[string]interface{}{
+				"port":     port,
+				"timeout":  1,
+				"interval": 1,
+			},
+		},
+	})
+	check := buildForemanHostReadinessCheck(d, h)
+	if check == nil || check.Address != "127.0.0.1" || check.Type != readinessCheckTCP {
+		t.Fatalf("Unexpected readiness check [%+v]", check)
+	}
+	if waitErr := check.wait(context.Background()); waitErr != nil {
+		t.Errorf("Expected the host to be ready, got: %s", waitErr)
+	}
+
+	listener.Close()
+	check.Interval = 10 * time.Millisecond
+	check.Timeout = 50 * time.Millisecond
+	if waitErr := check.wait(context.Background()); waitErr == nil {
+		t.Errorf("Expected the closed port to fail the readiness check")
+	}
+
+	d = schema.TestResourceDataRaw(t, resourceForemanHost().Schema, map[string]interface{}{
+		"name": "host01",
+	})
+	if check := buildForemanHostReadinessCheck(d, h); check != nil {
+		t.Errorf("Expected no readiness check without a readiness_check block, got [%+v]", check)
+	}
+}
+
 // ----------------------------------------------------------------------------
 // Test Cases for the Unit Test Framework
 // ----------------------------------------------------------------------------
@@ -259,7 +715,7 @@ func ResourceForemanHostCorrectURLAndMethodTestCases(t *testing.T) []TestCaseCor
 		TestCaseCorrectURLAndMethod{
 			TestCase: TestCase{
 				funcName:     "resourceForemanHostCreate",
-				crudFunc:     resourceForemanHostCreate,
+				crudFunc:     withoutDiagnostics(resourceForemanHostCreate),
 				resourceData: MockForemanHostResourceData(s),
 			},
 			expectedURI:    HostsURI,
@@ -277,7 +733,7 @@ func ResourceForemanHostCorrectURLAndMethodTestCases(t *testing.T) []TestCaseCor
 		TestCaseCorrectURLAndMethod{
 			TestCase: TestCase{
 				funcName:     "resourceForemanHostUpdate",
-				crudFunc:     resourceForemanHostUpdate,
+				crudFunc:     withoutContext(resourceForemanHostUpdate),
 				resourceData: MockForemanHostResourceData(s),
 			},
 			expectedURI:    hostsURIById,
@@ -328,22 +784,31 @@ func ResourceForemanHostRequestDataTestCases(t *testing.T) []TestCaseRequestData
 	obj = *buildForemanHost(rd)
 	// NOTE(ALL): See note in Create and Update functions for build flag
 	//   override
-	obj.Build = true
-	reqData, _ := json.Marshal(obj)
+	obj.Build = obj.Method == "build"
+	reqData, _ := api.WrapJson("host", obj)
+
+	// NOTE(ALL): the creation is tracked by a random progress report ID,
+	//   make it predictable
+	progressReportId := "d0c1b62c-5e7f-4a1c-9c3e-4f5b6a7d8e9f"
+	newProgressReportId = func() string {
+		return progressReportId
+	}
+	obj.ProgressReportId = progressReportId
+	createReqData, _ := api.WrapJson("host", obj)
 
 	return []TestCaseRequestData{
 		TestCaseRequestData{
 			TestCase: TestCase{
 				funcName:     "resourceForemanHostCreate",
-				crudFunc:     resourceForemanHostCreate,
+				crudFunc:     withoutDiagnostics(resourceForemanHostCreate),
 				resourceData: MockForemanHostResourceData(s),
 			},
-			expectedData: reqData,
+			expectedData: createReqData,
 		},
 		TestCaseRequestData{
 			TestCase: TestCase{
 				funcName:     "resourceForemanHostUpdate",
-				crudFunc:     resourceForemanHostUpdate,
+				crudFunc:     withoutContext(resourceForemanHostUpdate),
 				resourceData: MockForemanHostResourceData(s),
 			},
 			expectedData: reqData,
@@ -362,7 +827,7 @@ func ResourceForemanHostStatusCodeTestCases(t *testing.T) []TestCase {
 	return []TestCase{
 		TestCase{
 			funcName:     "resourceForemanHostCreate",
-			crudFunc:     resourceForemanHostCreate,
+			crudFunc:     withoutDiagnostics(resourceForemanHostCreate),
 			resourceData: MockForemanHostResourceData(s),
 		},
 		TestCase{
@@ -372,7 +837,7 @@ func ResourceForemanHostStatusCodeTestCases(t *testing.T) []TestCase {
 		},
 		TestCase{
 			funcName:     "resourceForemanHostUpdate",
-			crudFunc:     resourceForemanHostUpdate,
+			crudFunc:     withoutContext(resourceForemanHostUpdate),
 			resourceData: MockForemanHostResourceData(s),
 		},
 		TestCase{
@@ -392,7 +857,7 @@ func ResourceForemanHostEmptyResponseTestCases(t *testing.T) []TestCase {
 	return []TestCase{
 		TestCase{
 			funcName:     "resourceForemanHostCreate",
-			crudFunc:     resourceForemanHostCreate,
+			crudFunc:     withoutDiagnostics(resourceForemanHostCreate),
 			resourceData: MockForemanHostResourceData(s),
 		},
 		TestCase{
@@ -402,7 +867,7 @@ func ResourceForemanHostEmptyResponseTestCases(t *testing.T) []TestCase {
 		},
 		TestCase{
 			funcName:     "resourceForemanHostUpdate",
-			crudFunc:     resourceForemanHostUpdate,
+			crudFunc:     withoutContext(resourceForemanHostUpdate),
 			resourceData: MockForemanHostResourceData(s),
 		},
 	}
@@ -421,7 +886,7 @@ func ResourceForemanHostMockResponseTestCases(t *testing.T) []TestCaseMockRespon
 		TestCaseMockResponse{
 			TestCase: TestCase{
 				funcName:     "resourceForemanHostCreate",
-				crudFunc:     resourceForemanHostCreate,
+				crudFunc:     withoutDiagnostics(resourceForemanHostCreate),
 				resourceData: MockForemanHostResourceData(s),
 			},
 			responseFile: HostsTestDataPath + "/create_response.json",
@@ -455,7 +920,7 @@ func ResourceForemanHostMockResponseTestCases(t *testing.T) []TestCaseMockRespon
 		TestCaseMockResponse{
 			TestCase: TestCase{
 				funcName:     "resourceForemanHostUpdate",
-				crudFunc:     resourceForemanHostUpdate,
+				crudFunc:     withoutContext(resourceForemanHostUpdate),
 				resourceData: MockForemanHostResourceData(s),
 			},
 			responseFile: HostsTestDataPath + "/update_response.json",
@@ -469,3 +934,985 @@ func ResourceForemanHostMockResponseTestCases(t *testing.T) []TestCaseMockRespon
 	}
 
 }
+
+// Ensures the partition table of the host is checked against the OS family of
+// the host's operating system while planning
+func TestResourceForemanHostCustomizeDiff_PartitionTable(t *testing.T) {
+	cred := api.ClientCredentials{}
+	conf := api.ClientConfig{ValidateReferences: true}
+
+	mux, server, client := NewForemanAPIAndClient(cred, conf)
+	defer server.Close()
+
+	mux.HandleFunc(api.FOREMAN_API_URL_PREFIX+"/ptables/3", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id": 3, "name": "Kickstart default", "os_family": "Redhat"}`)
+	})
+	mux.HandleFunc(api.FOREMAN_API_URL_PREFIX+"/operatingsystems/4", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id": 4, "name": "Debian", "family": "Debian"}`)
+	})
+	mux.HandleFunc(api.FOREMAN_API_URL_PREFIX+"/operatingsystems/5", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id": 5, "name": "CentOS", "family": "Redhat"}`)
+	})
+
+	r := resourceForemanHost()
+	for osId, expectErr := range map[int]bool{4: true, 5: false} {
+		config := terraform.NewResourceConfigRaw(map[string]interface{}{
+			"name":               "host01",
+			"ptable_id":          3,
+			"operatingsystem_id": osId,
+		})
+		_, diffErr := r.Diff(context.Background(), nil, config, client)
+		if (diffErr != nil) != expectErr {
+			t.Errorf(
+				"Expected an error for operating system [%d] to be [%t], got [%v]",
+				osId,
+				expectErr,
+				diffErr,
+			)
+		}
+	}
+}
+
+// Ensures user data parameters are kept apart from the other host parameters
+// and may not overlap with them
+func TestResourceForemanHost_UserDataParameters(t *testing.T) {
+	r := resourceForemanHost()
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"name":                 "host01",
+		"parameters":           map[string]interface{}{"role": "web"},
+		"user_data_parameters": map[string]interface{}{"ssh_key": "ssh-ed25519 AAAA"},
+	})
+
+	host := buildForemanHost(d)
+	if len(host.HostParameters) != 2 {
+		t.Fatalf("Expected 2 host parameters, got [%+v]", host.HostParameters)
+	}
+
+	setResourceDataFromForemanHost(d, host)
+	_, inParameters := d.Get("parameters").(map[string]interface{})["ssh_key"]
+	if d.Get("user_data_parameters.ssh_key") != "ssh-ed25519 AAAA" || inParameters {
+		t.Fatalf(
+			"Expected ssh_key to be read as user data parameter, got parameters [%v] and user data parameters [%v]",
+			d.Get("parameters"),
+			d.Get("user_data_parameters"),
+		)
+	}
+
+	config := terraform.NewResourceConfigRaw(map[string]interface{}{
+		"name":                 "host01",
+		"parameters":           map[string]interface{}{"ssh_key": "a"},
+		"user_data_parameters": map[string]interface{}{"ssh_key": "b"},
+	})
+	if _, diffErr := r.Diff(context.Background(), nil, config, nil); diffErr == nil {
+		t.Fatalf("Expected an error for the overlapping parameter")
+	}
+}
+
+// -----------------------------------------------------------------------------
+// vmware
+// -----------------------------------------------------------------------------
+
+// Ensures the vmware block is translated to the compute attribute names of
+// VMware compute resources and its NIC type applies to the interfaces which
+// do not set one
+func TestBuildForemanHost_VMware(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, resourceForemanHost().Schema, map[string]interface{}{
+		"name": "host01",
+		"vmware": []interface{}{
+			map[string]interface{}{
+				"cluster":     "Cluster1",
+				"folder":      "/Datacenters/DC1/vm/Linux",
+				"guest_id":    "rhel8_64Guest",
+				"cpu_hot_add": true,
+				"nic_type":    "VirtualVmxnet3",
+				"volume": []interface{}{
+					map[string]interface{}{
+						"size_gb":     20,
+						"storage_pod": "Pod1",
+					},
+				},
+			},
+		},
+		"interfaces_attributes": []interface{}{
+			map[string]interface{}{
+				"identifier": "eth0",
+				"type":       "interface",
+			},
+			map[string]interface{}{
+				"identifier":         "eth1",
+				"type":               "interface",
+				"compute_attributes": map[string]interface{}{"type": "VirtualE1000"},
+			},
+		},
+	})
+
+	host := buildForemanHost(d)
+
+	expected := map[string]interface{}{
+		"cluster":          "Cluster1",
+		"path":             "/Datacenters/DC1/vm/Linux",
+		"guest_id":         "rhel8_64Guest",
+		"cpuHotAddEnabled": true,
+		"volumes_attributes": map[string]interface{}{
+			"0": map[string]interface{}{
+				"size_gb":     20,
+				"thin":        true,
+				"storage_pod": "Pod1",
+			},
+		},
+	}
+	if !reflect.DeepEqual(expected, host.ComputeAttributes) {
+		t.Fatalf("Expected the compute attributes [%v], got [%v]", expected, host.ComputeAttributes)
+	}
+	for _, iface := range host.InterfacesAttributes {
+		expectedType := "VirtualVmxnet3"
+		if iface.Identifier == "eth1" {
+			expectedType = "VirtualE1000"
+		}
+		if iface.ComputeAttributes["type"] != expectedType {
+			t.Errorf(
+				"Expected interface [%s] to be of type [%s], got [%v]",
+				iface.Identifier,
+				expectedType,
+				iface.ComputeAttributes["type"],
+			)
+		}
+	}
+}
+
+// Ensures the vmware block is refused for other types of compute resources
+// and volumes are placed on exactly one of a datastore and a storage pod
+func TestResourceForemanHostCustomizeDiff_VMware(t *testing.T) {
+	cred := api.ClientCredentials{}
+	conf := api.ClientConfig{ValidateReferences: true}
+
+	mux, server, client := NewForemanAPIAndClient(cred, conf)
+	defer server.Close()
+
+	mux.HandleFunc(api.FOREMAN_API_URL_PREFIX+"/compute_resources/1", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id": 1, "name": "vcenter", "provider": "Vmware"}`)
+	})
+	mux.HandleFunc(api.FOREMAN_API_URL_PREFIX+"/compute_resources/2", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id": 2, "name": "kvm", "provider": "Libvirt"}`)
+	})
+
+	testCases := []struct {
+		computeResourceId int
+		volume            map[string]interface{}
+		expectErr         bool
+	}{
+		{1, map[string]interface{}{"size_gb": 20, "datastore": "ds1"}, false},
+		{2, map[string]interface{}{"size_gb": 20, "datastore": "ds1"}, true},
+		{1, map[string]interface{}{"size_gb": 20}, true},
+		{1, map[string]interface{}{"size_gb": 20, "datastore": "ds1", "storage_pod": "Pod1"}, true},
+	}
+
+	r := resourceForemanHost()
+	for _, tc := range testCases {
+		config := terraform.NewResourceConfigRaw(map[string]interface{}{
+			"name":                "host01",
+			"compute_resource_id": tc.computeResourceId,
+			"vmware": []interface{}{
+				map[string]interface{}{
+					"cluster": "Cluster1",
+					"volume":  []interface{}{tc.volume},
+				},
+			},
+		})
+		_, diffErr := r.Diff(context.Background(), nil, config, client)
+		if (diffErr != nil) != tc.expectErr {
+			t.Errorf(
+				"Expected an error for compute resource [%d] and volume [%v] to be [%t], got [%v]",
+				tc.computeResourceId,
+				tc.volume,
+				tc.expectErr,
+				diffErr,
+			)
+		}
+	}
+}
+
+// -----------------------------------------------------------------------------
+// libvirt
+// -----------------------------------------------------------------------------
+
+// Ensures the libvirt block is translated to the compute attributes of
+// libvirt compute resources and its network applies to the interfaces which
+// do not set a type
+func TestBuildForemanHost_Libvirt(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, resourceForemanHost().Schema, map[string]interface{}{
+		"name": "host01",
+		"libvirt": []interface{}{
+			map[string]interface{}{
+				"memory_mb":    2048,
+				"cpus":         2,
+				"boot_order":   []interface{}{"network", "hd"},
+				"network_type": "bridge",
+				"bridge":       "br0",
+				"nic_model":    "virtio",
+				"volume": []interface{}{
+					map[string]interface{}{
+						"pool_name":   "default",
+						"capacity_gb": 20,
+					},
+				},
+			},
+		},
+		"interfaces_attributes": []interface{}{
+			map[string]interface{}{
+				"identifier": "eth0",
+				"type":       "interface",
+			},
+			map[string]interface{}{
+				"identifier": "eth1",
+				"type":       "interface",
+				"compute_attributes": map[string]interface{}{
+					"type":    "network",
+					"network": "default",
+				},
+			},
+		},
+	})
+
+	host := buildForemanHost(d)
+
+	expected := map[string]interface{}{
+		"memory":     "2147483648",
+		"cpus":       2,
+		"boot_order": []interface{}{"network", "hd"},
+		"volumes_attributes": map[string]interface{}{
+			"0": map[string]interface{}{
+				"pool_name":   "default",
+				"capacity":    "20G",
+				"format_type": "qcow2",
+			},
+		},
+	}
+	if !reflect.DeepEqual(expected, host.ComputeAttributes) {
+		t.Fatalf("Expected the compute attributes [%v], got [%v]", expected, host.ComputeAttributes)
+	}
+	for _, iface := range host.InterfacesAttributes {
+		expectedAttrs := map[string]interface{}{"type": "bridge", "bridge": "br0", "model": "virtio"}
+		if iface.Identifier == "eth1" {
+			expectedAttrs = map[string]interface{}{"type": "network", "network": "default"}
+		}
+		if !reflect.DeepEqual(expectedAttrs, iface.ComputeAttributes) {
+			t.Errorf(
+				"Expected interface [%s] to have the compute attributes [%v], got [%v]",
+				iface.Identifier,
+				expectedAttrs,
+				iface.ComputeAttributes,
+			)
+		}
+	}
+}
+
+// Ensures the libvirt network type requires the network or bridge it names
+func TestResourceForemanHostCustomizeDiff_Libvirt(t *testing.T) {
+	r := resourceForemanHost()
+	for config, expectErr := range map[string]bool{
+		"bridge":  false,
+		"network": true,
+	} {
+		diffConfig := terraform.NewResourceConfigRaw(map[string]interface{}{
+			"name": "host01",
+			"libvirt": []interface{}{
+				map[string]interface{}{
+					"network_type": config,
+					"bridge":       "br0",
+				},
+			},
+		})
+		_, diffErr := r.Diff(context.Background(), nil, diffConfig, nil)
+		if (diffErr != nil) != expectErr {
+			t.Errorf(
+				"Expected an error for network type [%s] to be [%t], got [%v]",
+				config,
+				expectErr,
+				diffErr,
+			)
+		}
+	}
+}
+
+// -----------------------------------------------------------------------------
+// ovirt
+// -----------------------------------------------------------------------------
+
+// Ensures the objects of the ovirt block are resolved by ID or name against
+// the compute resource and the network applies to the interfaces which do
+// not set one
+func TestResolveForemanHostOvirtComputeAttributes(t *testing.T) {
+	mux, server, client := NewForemanAPIAndClient(api.ClientCredentials{}, api.ClientConfig{})
+	defer server.Close()
+
+	for path, response := range map[string]string{
+		"/compute_resources/3/available_clusters":                        `{"results": [{"id": "c-1", "name": "Default"}]}`,
+		"/compute_resources/3/available_images":                          `{"results": [{"id": "t-1", "name": "rhel8"}]}`,
+		"/compute_resources/3/available_storage_domains":                 `{"results": [{"id": "s-1", "name": "data"}]}`,
+		"/compute_resources/3/available_clusters/c-1/available_networks": `{"results": [{"id": "n-1", "name": "ovirtmgmt"}]}`,
+	} {
+		response := response
+		mux.HandleFunc(api.FOREMAN_API_URL_PREFIX+path, func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprint(w, response)
+		})
+	}
+
+	d := schema.TestResourceDataRaw(t, resourceForemanHost().Schema, map[string]interface{}{
+		"name":                "host01",
+		"compute_resource_id": 3,
+		"ovirt": []interface{}{
+			map[string]interface{}{
+				"cluster":        "Default",
+				"template":       "t-1",
+				"cores":          2,
+				"storage_domain": "data",
+				"network":        "ovirtmgmt",
+				"volume": []interface{}{
+					map[string]interface{}{
+						"size_gb":  20,
+						"bootable": true,
+					},
+				},
+			},
+		},
+		"interfaces_attributes": []interface{}{
+			map[string]interface{}{
+				"identifier": "eth0",
+				"type":       "interface",
+			},
+		},
+	})
+
+	host := buildForemanHost(d)
+	if resolveErr := resolveForemanHostOvirtComputeAttributes(d, client, host); resolveErr != nil {
+		t.Fatalf("Expected no error, got [%s]", resolveErr)
+	}
+
+	expected := map[string]interface{}{
+		"cluster":  "c-1",
+		"template": "t-1",
+		"cores":    "2",
+		"volumes_attributes": map[string]interface{}{
+			"0": map[string]interface{}{
+				"size_gb":        20,
+				"bootable":       true,
+				"preallocate":    "0",
+				"storage_domain": "s-1",
+			},
+		},
+	}
+	if !reflect.DeepEqual(expected, host.ComputeAttributes) {
+		t.Fatalf("Expected the compute attributes [%v], got [%v]", expected, host.ComputeAttributes)
+	}
+	if host.InterfacesAttributes[0].ComputeAttributes["network"] != "n-1" {
+		t.Fatalf("Expected the interface on network [n-1], got [%v]", host.InterfacesAttributes[0].ComputeAttributes)
+	}
+
+	d.Set("ovirt", []interface{}{map[string]interface{}{"cluster": "Missing"}})
+	resolveErr := resolveForemanHostOvirtComputeAttributes(d, client, host)
+	if resolveErr == nil || !strings.Contains(resolveErr.Error(), "available: [Default]") {
+		t.Fatalf("Expected an error listing the available clusters, got [%v]", resolveErr)
+	}
+}
+
+// -----------------------------------------------------------------------------
+// ec2, gce, azure
+// -----------------------------------------------------------------------------
+
+// Ensures the cloud blocks are translated to the compute attribute names of
+// their compute resource type
+func TestBuildForemanHost_Cloud(t *testing.T) {
+	testCases := []struct {
+		block    string
+		config   map[string]interface{}
+		expected map[string]interface{}
+	}{
+		{
+			block: "ec2",
+			config: map[string]interface{}{
+				"flavor":             "t3.medium",
+				"availability_zone":  "eu-central-1a",
+				"security_group_ids": []interface{}{"sg-1", "sg-2"},
+			},
+			expected: map[string]interface{}{
+				"flavor_id":          "t3.medium",
+				"availability_zone":  "eu-central-1a",
+				"security_group_ids": []interface{}{"sg-1", "sg-2"},
+				"managed_ip":         "public",
+			},
+		},
+		{
+			block: "gce",
+			config: map[string]interface{}{
+				"machine_type":          "e2-medium",
+				"associate_external_ip": true,
+			},
+			expected: map[string]interface{}{
+				"machine_type":          "e2-medium",
+				"associate_external_ip": true,
+			},
+		},
+		{
+			block: "azure",
+			config: map[string]interface{}{
+				"vm_size":        "Standard_B2s",
+				"resource_group": "rg-hosts",
+				"ssh_key":        "ssh-ed25519 AAAA",
+			},
+			expected: map[string]interface{}{
+				"vm_size":         "Standard_B2s",
+				"resource_group":  "rg-hosts",
+				"platform":        "Linux",
+				"ssh_key_data":    "ssh-ed25519 AAAA",
+				"premium_os_disk": false,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		d := schema.TestResourceDataRaw(t, resourceForemanHost().Schema, map[string]interface{}{
+			"name":   "host01",
+			tc.block: []interface{}{tc.config},
+		})
+		host := buildForemanHost(d)
+		if !reflect.DeepEqual(tc.expected, host.ComputeAttributes) {
+			t.Errorf(
+				"Expected the %s compute attributes [%v], got [%v]",
+				tc.block,
+				tc.expected,
+				host.ComputeAttributes,
+			)
+		}
+	}
+}
+
+// -----------------------------------------------------------------------------
+// openstack
+// -----------------------------------------------------------------------------
+
+// Ensures the flavor, networks and security groups of the openstack block are
+// resolved against the compute resource, and unknown names fail the plan
+func TestResourceForemanHost_Openstack(t *testing.T) {
+	cred := api.ClientCredentials{}
+	conf := api.ClientConfig{ValidateReferences: true}
+
+	mux, server, client := NewForemanAPIAndClient(cred, conf)
+	defer server.Close()
+
+	for path, response := range map[string]string{
+		"/compute_resources/3":                           `{"id": 3, "name": "openstack", "provider": "Openstack"}`,
+		"/compute_resources/3/available_flavors":         `{"results": [{"id": "2", "name": "m1.small"}]}`,
+		"/compute_resources/3/available_networks":        `{"results": [{"id": "n-1", "name": "private"}]}`,
+		"/compute_resources/3/available_security_groups": `{"results": [{"id": "sg-1", "name": "default"}]}`,
+	} {
+		response := response
+		mux.HandleFunc(api.FOREMAN_API_URL_PREFIX+path, func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprint(w, response)
+		})
+	}
+
+	openstack := map[string]interface{}{
+		"flavor":           "m1.small",
+		"networks":         []interface{}{"private"},
+		"security_groups":  []interface{}{"sg-1"},
+		"floating_ip_pool": "public",
+	}
+	d := schema.TestResourceDataRaw(t, resourceForemanHost().Schema, map[string]interface{}{
+		"name":                "host01",
+		"compute_resource_id": 3,
+		"openstack":           []interface{}{openstack},
+	})
+
+	host := buildForemanHost(d)
+	if resolveErr := resolveForemanHostOpenstackComputeAttributes(d, client, host); resolveErr != nil {
+		t.Fatalf("Expected no error, got [%s]", resolveErr)
+	}
+	expected := map[string]interface{}{
+		"flavor_ref":       "2",
+		"boot_from_volume": false,
+		"network":          "public",
+		"nics":             []interface{}{"n-1"},
+		"security_groups":  []interface{}{"default"},
+	}
+	if !reflect.DeepEqual(expected, host.ComputeAttributes) {
+		t.Fatalf("Expected the compute attributes [%v], got [%v]", expected, host.ComputeAttributes)
+	}
+
+	r := resourceForemanHost()
+	openstack["flavor"] = "m1.huge"
+	config := terraform.NewResourceConfigRaw(map[string]interface{}{
+		"name":                "host01",
+		"compute_resource_id": 3,
+		"openstack":           []interface{}{openstack},
+	})
+	_, diffErr := r.Diff(context.Background(), nil, config, client)
+	if diffErr == nil || !strings.Contains(diffErr.Error(), "available: [m1.small]") {
+		t.Fatalf("Expected an error listing the available flavors, got [%v]", diffErr)
+	}
+}
+
+// -----------------------------------------------------------------------------
+// Compute Attributes Updates
+// -----------------------------------------------------------------------------
+
+// Ensures changed compute attributes recreate the host unless they are
+// updated in place
+func TestResourceForemanHostCustomizeDiff_ComputeAttributesInPlace(t *testing.T) {
+	state := &terraform.InstanceState{
+		ID: "1",
+		Attributes: map[string]string{
+			"name":              "host01",
+			"method":            "build",
+			"bmc_success":       "true",
+			"vmware.#":          "1",
+			"vmware.0.cluster":  "Cluster1",
+			"vmware.0.volume.#": "0",
+		},
+	}
+
+	testCases := []struct {
+		updateInPlace []interface{}
+		expectNew     bool
+	}{
+		{[]interface{}{}, true},
+		{[]interface{}{"compute_attributes"}, false},
+	}
+
+	r := resourceForemanHost()
+	for _, tc := range testCases {
+		config := terraform.NewResourceConfigRaw(map[string]interface{}{
+			"name":            "host01",
+			"update_in_place": tc.updateInPlace,
+			"vmware": []interface{}{
+				map[string]interface{}{"cluster": "Cluster2"},
+			},
+		})
+		diff, diffErr := r.Diff(context.Background(), state, config, nil)
+		if diffErr != nil {
+			t.Fatalf("Expected no error, got [%s]", diffErr)
+		}
+		if diff.RequiresNew() != tc.expectNew {
+			t.Errorf(
+				"Expected the host to be recreated with update_in_place %v to be [%t], got [%t]",
+				tc.updateInPlace,
+				tc.expectNew,
+				diff.RequiresNew(),
+			)
+		}
+	}
+}
+
+// Ensures resizing a host in place sends its compute attributes and reboots
+// it with "reboot_on_resize", while other updates leave them out
+func TestResourceForemanHostUpdate_Resize(t *testing.T) {
+	cred := api.ClientCredentials{}
+	conf := api.ClientConfig{}
+
+	mux, server, client := NewForemanAPIAndClient(cred, conf)
+	defer server.Close()
+
+	var sent map[string]map[string]interface{}
+	mux.HandleFunc(HostsURI+"/1", func(w http.ResponseWriter, r *http.Request) {
+		sent = nil
+		json.NewDecoder(r.Body).Decode(&sent)
+		fmt.Fprint(w, `{"id": 1, "name": "host01"}`)
+	})
+	actions := []string{}
+	mux.HandleFunc(HostsURI+"/1/power", func(w http.ResponseWriter, r *http.Request) {
+		var power api.Power
+		json.NewDecoder(r.Body).Decode(&power)
+		actions = append(actions, power.PowerAction)
+		fmt.Fprint(w, `{"power": true}`)
+	})
+
+	r := resourceForemanHost()
+	state := &terraform.InstanceState{
+		ID: "1",
+		Attributes: map[string]string{
+			"name":              "host01",
+			"method":            "build",
+			"bmc_success":       "true",
+			"vmware.#":          "1",
+			"vmware.0.cluster":  "Cluster1",
+			"vmware.0.volume.#": "0",
+		},
+	}
+
+	// planData returns the resource data of the host updated to the supplied
+	// parameters and vmware block
+	planData := func(parameters map[string]interface{}, vmware map[string]interface{}) *schema.ResourceData {
+		config := terraform.NewResourceConfigRaw(map[string]interface{}{
+			"name":             "host01",
+			"parameters":       parameters,
+			"reboot_on_resize": true,
+			"update_in_place":  []interface{}{"compute_attributes"},
+			"vmware":           []interface{}{vmware},
+		})
+		diff, diffErr := r.Diff(context.Background(), state, config, nil)
+		if diffErr != nil {
+			t.Fatalf("Expected no error, got [%s]", diffErr)
+		}
+		d, dataErr := schema.InternalMap(r.Schema).Data(state, diff)
+		if dataErr != nil {
+			t.Fatalf("Expected no error, got [%s]", dataErr)
+		}
+		return d
+	}
+
+	d := planData(map[string]interface{}{}, map[string]interface{}{"cluster": "Cluster1", "guest_id": "rhel9_64Guest"})
+	if updateErr := resourceForemanHostUpdate(context.Background(), d, client); updateErr != nil {
+		t.Fatalf("Expected no error, got [%s]", updateErr)
+	}
+	if _, ok := sent["host"]["compute_attributes"]; !ok {
+		t.Errorf("Expected the compute attributes to be sent, got [%v]", sent)
+	}
+	if len(actions) != 1 || actions[0] != api.PowerReboot {
+		t.Errorf("Expected the host to be rebooted, got power actions %v", actions)
+	}
+
+	actions = []string{}
+	d = planData(map[string]interface{}{"role": "web"}, map[string]interface{}{"cluster": "Cluster1"})
+	if updateErr := resourceForemanHostUpdate(context.Background(), d, client); updateErr != nil {
+		t.Fatalf("Expected no error, got [%s]", updateErr)
+	}
+	if _, ok := sent["host"]["compute_attributes"]; ok {
+		t.Errorf("Expected the compute attributes to be left out, got [%v]", sent)
+	}
+	if len(actions) != 0 {
+		t.Errorf("Expected the host not to be rebooted, got power actions %v", actions)
+	}
+}
+
+// Ensures a changed root password is sent to Foreman, even when nothing else
+// about the host changed
+func TestResourceForemanHostUpdate_RootPassword(t *testing.T) {
+	cred := api.ClientCredentials{}
+	conf := api.ClientConfig{}
+
+	mux, server, client := NewForemanAPIAndClient(cred, conf)
+	defer server.Close()
+
+	var sent map[string]map[string]interface{}
+	mux.HandleFunc(HostsURI+"/1", func(w http.ResponseWriter, r *http.Request) {
+		sent = nil
+		json.NewDecoder(r.Body).Decode(&sent)
+		fmt.Fprint(w, `{"id": 1, "name": "host01"}`)
+	})
+
+	r := resourceForemanHost()
+	state := &terraform.InstanceState{
+		ID: "1",
+		Attributes: map[string]string{
+			"name":          "host01",
+			"method":        "build",
+			"bmc_success":   "true",
+			"root_password": hashSensitiveValue("old-password"),
+		},
+	}
+	config := terraform.NewResourceConfigRaw(map[string]interface{}{
+		"name":          "host01",
+		"root_password": "new-password",
+	})
+	diff, diffErr := r.Diff(context.Background(), state, config, nil)
+	if diffErr != nil {
+		t.Fatalf("Expected no error, got [%s]", diffErr)
+	}
+	d, dataErr := schema.InternalMap(r.Schema).Data(state, diff)
+	if dataErr != nil {
+		t.Fatalf("Expected no error, got [%s]", dataErr)
+	}
+	if updateErr := resourceForemanHostUpdate(context.Background(), d, client); updateErr != nil {
+		t.Fatalf("Expected no error, got [%s]", updateErr)
+	}
+	if sent["host"]["root_pass"] != "new-password" {
+		t.Fatalf("Expected the new root password to be sent, got [%v]", sent)
+	}
+}
+
+// Ensures a changed partition table override is sent to Foreman, even when
+// nothing else about the host changed
+func TestResourceForemanHostUpdate_PartitionTable(t *testing.T) {
+	cred := api.ClientCredentials{}
+	conf := api.ClientConfig{}
+
+	mux, server, client := NewForemanAPIAndClient(cred, conf)
+	defer server.Close()
+
+	var sent map[string]map[string]interface{}
+	mux.HandleFunc(HostsURI+"/1", func(w http.ResponseWriter, r *http.Request) {
+		sent = nil
+		json.NewDecoder(r.Body).Decode(&sent)
+		fmt.Fprint(w, `{"id": 1, "name": "host01", "ptable_id": 7}`)
+	})
+
+	r := resourceForemanHost()
+	state := &terraform.InstanceState{
+		ID: "1",
+		Attributes: map[string]string{
+			"name":        "host01",
+			"method":      "build",
+			"bmc_success": "true",
+			"ptable_id":   "3",
+		},
+	}
+	config := terraform.NewResourceConfigRaw(map[string]interface{}{
+		"name":      "host01",
+		"ptable_id": 7,
+	})
+	diff, diffErr := r.Diff(context.Background(), state, config, nil)
+	if diffErr != nil {
+		t.Fatalf("Expected no error, got [%s]", diffErr)
+	}
+	d, dataErr := schema.InternalMap(r.Schema).Data(state, diff)
+	if dataErr != nil {
+		t.Fatalf("Expected no error, got [%s]", dataErr)
+	}
+	if updateErr := resourceForemanHostUpdate(context.Background(), d, client); updateErr != nil {
+		t.Fatalf("Expected no error, got [%s]", updateErr)
+	}
+	if sent["host"]["ptable_id"] != "7" {
+		t.Fatalf("Expected the new partition table to be sent, got [%v]", sent)
+	}
+	if d.Get("ptable_id").(int) != 7 {
+		t.Fatalf("Expected the partition table to be set from the updated host, got [%d]", d.Get("ptable_id").(int))
+	}
+}
+
+// Ensures BMC operations which failed on create are retried the same way
+// create starts the host, booting hosts with provision_boot from PXE even
+// without enable_bmc
+func TestResourceForemanHostUpdate_RetryProvisionBoot(t *testing.T) {
+	cred := api.ClientCredentials{}
+	conf := api.ClientConfig{}
+
+	mux, server, client := NewForemanAPIAndClient(cred, conf)
+	defer server.Close()
+
+	actions := []string{}
+	mux.HandleFunc(HostsURI+"/1/boot", func(w http.ResponseWriter, r *http.Request) {
+		var boot api.BMCBoot
+		json.NewDecoder(r.Body).Decode(&boot)
+		actions = append(actions, "boot "+boot.Device)
+		fmt.Fprint(w, `{"boot": {"action": "pxe", "result": true}}`)
+	})
+	mux.HandleFunc(HostsURI+"/1/power", func(w http.ResponseWriter, r *http.Request) {
+		var power api.Power
+		json.NewDecoder(r.Body).Decode(&power)
+		actions = append(actions, power.PowerAction)
+		fmt.Fprint(w, `{"power": true}`)
+	})
+
+	r := resourceForemanHost()
+	state := &terraform.InstanceState{
+		ID: "1",
+		Attributes: map[string]string{
+			"name":           "host01",
+			"method":         "build",
+			"bmc_success":    "false",
+			"provision_boot": "true",
+		},
+	}
+	config := terraform.NewResourceConfigRaw(map[string]interface{}{
+		"name":           "host01",
+		"provision_boot": true,
+	})
+	diff, diffErr := r.Diff(context.Background(), state, config, nil)
+	if diffErr != nil {
+		t.Fatalf("Expected no error, got [%s]", diffErr)
+	}
+	d, dataErr := schema.InternalMap(r.Schema).Data(state, diff)
+	if dataErr != nil {
+		t.Fatalf("Expected no error, got [%s]", dataErr)
+	}
+	if updateErr := resourceForemanHostUpdate(context.Background(), d, client); updateErr != nil {
+		t.Fatalf("Expected no error, got [%s]", updateErr)
+	}
+	expected := []string{"boot " + api.BootPxe, api.PowerCycle}
+	if !reflect.DeepEqual(actions, expected) {
+		t.Fatalf("Expected the BMC operations %v, got %v", expected, actions)
+	}
+	if !d.Get("bmc_success").(bool) {
+		t.Fatalf("Expected bmc_success to be set")
+	}
+}
+
+// Ensures a host which could not be powered on after create is kept, with
+// the failure reported as a warning and retried on the next apply
+func TestResourceForemanHostCreate_PowerFailureWarns(t *testing.T) {
+	cred := api.ClientCredentials{}
+	conf := api.ClientConfig{}
+
+	mux, server, client := NewForemanAPIAndClient(cred, conf)
+	defer server.Close()
+
+	mux.HandleFunc(HostsURI, func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id": 1, "name": "host01"}`)
+	})
+	mux.HandleFunc(HostsURI+"/1/power", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		fmt.Fprint(w, `{"error": {"message": "BMC unreachable"}}`)
+	})
+
+	r := resourceForemanHost()
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"name":        "host01",
+		"retry_count": 1,
+	})
+	diags := resourceForemanHostCreate(context.Background(), d, client)
+	if diags.HasError() {
+		t.Fatalf("Expected no error, got [%v]", diags)
+	}
+	if len(diags) != 1 || diags[0].Severity != diag.Warning {
+		t.Fatalf("Expected a warning, got [%v]", diags)
+	}
+	if d.Id() != "1" || d.Get("bmc_success").(bool) {
+		t.Fatalf("Expected the host to be kept without bmc_success, got [%s] [%t]", d.Id(), d.Get("bmc_success").(bool))
+	}
+}
+
+// Ensures secrets are stored as a prefixed hash which is not a plain SHA-256
+// of the secret and does not depend on the state encryption key
+func TestHashSensitiveValue(t *testing.T) {
+	plain := sha256.Sum256([]byte("password"))
+
+	derived := hashSensitiveValue("password")
+	if !strings.HasPrefix(derived, sensitiveKDFPrefix) || strings.Contains(derived, hex.EncodeToString(plain[:])) {
+		t.Fatalf("Expected a derived hash, got [%s]", derived)
+	}
+	if derived != hashSensitiveValue("password") || !isSensitiveValueHash(derived) {
+		t.Fatalf("Expected the hash to be stable, got [%s]", derived)
+	}
+
+	setTestStateEncryptionKey(t, "correct horse battery staple")
+	if keyed := hashSensitiveValue("password"); keyed != derived {
+		t.Fatalf("Expected the hash not to depend on the state encryption key, got [%s]", keyed)
+	}
+
+	// a secret looking like a bare SHA-256 is still hashed
+	if isSensitiveValueHash(hex.EncodeToString(plain[:])) {
+		t.Fatalf("Expected a bare SHA-256 not to be recognized as a hash")
+	}
+	if hashed := hashSensitiveValue(hex.EncodeToString(plain[:])); !isSensitiveValueHash(hashed) {
+		t.Fatalf("Expected a hex secret to be hashed, got [%s]", hashed)
+	}
+}
+
+// Ensures the state upgrade hashes plain text secrets and leaves the hashes
+// of earlier versions alone
+func TestUpgradeSensitiveStateValue(t *testing.T) {
+	plain := sha256.Sum256([]byte("password"))
+	legacy := hex.EncodeToString(plain[:])
+
+	rawState := map[string]interface{}{
+		"plain":  "password",
+		"legacy": legacy,
+		"hashed": hashSensitiveValue("password"),
+	}
+	for key := range rawState {
+		upgradeSensitiveStateValue(rawState, key)
+	}
+	expected := map[string]interface{}{
+		"plain":  hashSensitiveValue("password"),
+		"legacy": legacy,
+		"hashed": hashSensitiveValue("password"),
+	}
+	if !reflect.DeepEqual(rawState, expected) {
+		t.Fatalf("Expected the upgraded state [%v], got [%v]", expected, rawState)
+	}
+}
+
+// Ensures the provider's default host parameters are sent with the host's
+// parameters, without showing up in the parameters read back
+func TestResourceForemanHost_DefaultParameters(t *testing.T) {
+	cred := api.ClientCredentials{}
+	conf := api.ClientConfig{
+		DefaultHostParameters: map[string]string{
+			"cost_center":        "4711",
+			"puppet_environment": "production",
+		},
+	}
+
+	h := &api.ForemanHost{
+		HostParameters: []api.ForemanKVParameter{
+			{Name: "role", Value: "web"},
+			{Name: "cost_center", Value: "42"},
+		},
+	}
+	applyForemanHostDefaultParameters(h, conf.DefaultHostParameters)
+	expected := []api.ForemanKVParameter{
+		{Name: "role", Value: "web"},
+		{Name: "cost_center", Value: "42"},
+		{Name: "puppet_environment", Value: "production"},
+	}
+	if !reflect.DeepEqual(h.HostParameters, expected) {
+		t.Fatalf("Expected the defaults not set by the host to be added, got [%+v]", h.HostParameters)
+	}
+
+	mux, server, client := NewForemanAPIAndClient(cred, conf)
+	defer server.Close()
+
+	mux.HandleFunc(HostsURI+"/1", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{
+			"id": 1,
+			"name": "host01",
+			"parameters": [
+				{"name": "role", "value": "web"},
+				{"name": "cost_center", "value": "4711"},
+				{"name": "puppet_environment", "value": "staging"}
+			]
+		}`)
+	})
+
+	r := resourceForemanHost()
+	d := r.Data(&terraform.InstanceState{
+		ID: "1",
+		Attributes: map[string]string{
+			"name":            "host01",
+			"parameters.%":    "1",
+			"parameters.role": "web",
+		},
+	})
+	if readErr := resourceForemanHostRead(d, client); readErr != nil {
+		t.Fatalf("Expected no error, got [%s]", readErr)
+	}
+	parameters := d.Get("parameters").(map[string]interface{})
+	if len(parameters) != 2 || parameters["role"] != "web" || parameters["puppet_environment"] != "staging" {
+		t.Fatalf("Expected the defaults to be left out unless they differ, got [%v]", parameters)
+	}
+}
+
+// Ensures a host with destroy_behavior "unmanage" is released from Foreman's
+// management instead of being deleted
+func TestResourceForemanHostDelete_Unmanage(t *testing.T) {
+	cred := api.ClientCredentials{}
+	conf := api.ClientConfig{}
+
+	mux, server, client := NewForemanAPIAndClient(cred, conf)
+	defer server.Close()
+
+	var sent map[string]map[string]interface{}
+	mux.HandleFunc(HostsURI+"/1", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut {
+			t.Fatalf("Expected the host not to be deleted, got a [%s] request", r.Method)
+		}
+		json.NewDecoder(r.Body).Decode(&sent)
+		fmt.Fprint(w, `{"id": 1, "name": "host01"}`)
+	})
+
+	r := resourceForemanHost()
+	d := r.Data(&terraform.InstanceState{
+		ID: "1",
+		Attributes: map[string]string{
+			"name":                "host01",
+			"destroy_behavior":    "unmanage",
+			"shutdown_on_destroy": "true",
+		},
+	})
+	if deleteErr := resourceForemanHostDelete(d, client); deleteErr != nil {
+		t.Fatalf("Expected no error, got [%s]", deleteErr)
+	}
+	if sent["host"]["managed"] != false || sent["host"]["build"] != false {
+		t.Fatalf("Expected the host to be unmanaged with its build cancelled, got [%v]", sent)
+	}
+}
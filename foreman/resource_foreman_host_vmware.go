@@ -0,0 +1,202 @@
+package foreman
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/HanseMerkur/terraform-provider-foreman/foreman/api"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// vmwareComputeResourceProvider is the provider of VMware compute resources
+const vmwareComputeResourceProvider = "Vmware"
+
+// -----------------------------------------------------------------------------
+// Schema
+// -----------------------------------------------------------------------------
+
+// vmwareSchema is the "vmware" block of a host.  It holds the attributes of
+// the virtual machine created on a VMware compute resource, which are
+// translated to the compute attribute names Foreman expects for VMware.
+// Changing them recreates the host, unless "compute_attributes" is listed in
+// update_in_place.
+func vmwareSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:          schema.TypeList,
+		Optional:      true,
+		MaxItems:      1,
+		ConflictsWith: otherForemanHostComputeBlocks("vmware"),
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"cluster": &schema.Schema{
+					Type:        schema.TypeString,
+					Required:    true,
+					Description: "Cluster the virtual machine is placed in.",
+				},
+				"resource_pool": &schema.Schema{
+					Type:        schema.TypeString,
+					Optional:    true,
+					Description: "Resource pool of the cluster the virtual machine is placed in.",
+				},
+				"folder": &schema.Schema{
+					Type:     schema.TypeString,
+					Optional: true,
+					Description: "Folder the virtual machine is placed in, ie: " +
+						"`\"/Datacenters/DC1/vm/Linux\"`.",
+				},
+				"guest_id": &schema.Schema{
+					Type:     schema.TypeString,
+					Optional: true,
+					Description: "Guest operating system identifier of the virtual " +
+						"machine, ie: `\"rhel8_64Guest\"`.",
+				},
+				"cpu_hot_add": &schema.Schema{
+					Type:        schema.TypeBool,
+					Optional:    true,
+					Default:     false,
+					Description: "Whether or not CPUs can be added to the running virtual machine.",
+				},
+				"nic_type": &schema.Schema{
+					Type:     schema.TypeString,
+					Optional: true,
+					// NOTE(ALL): false - do not ignore case when comparing values
+					ValidateFunc: validation.StringInSlice(interfaceComputeAttributes[vmwareComputeResourceProvider]["type"], false),
+					Description: "Type of the network adapters of the virtual machine, " +
+						"ie: `\"VirtualVmxnet3\"`. Applies to the interfaces which do " +
+						"not set a `type` in their `compute_attributes`.",
+				},
+				"volume": &schema.Schema{
+					Type:        schema.TypeList,
+					Optional:    true,
+					Description: "Disks of the virtual machine.",
+					Elem: &schema.Resource{
+						Schema: map[string]*schema.Schema{
+							"size_gb": &schema.Schema{
+								Type:         schema.TypeInt,
+								Required:     true,
+								ValidateFunc: validation.IntAtLeast(1),
+								Description:  "Size of the disk in GB.",
+							},
+							"datastore": &schema.Schema{
+								Type:     schema.TypeString,
+								Optional: true,
+								Description: "Datastore the disk is placed on. Exactly one " +
+									"of `datastore` and `storage_pod` must be set.",
+							},
+							"storage_pod": &schema.Schema{
+								Type:     schema.TypeString,
+								Optional: true,
+								Description: "Datastore cluster (storage pod) the disk is " +
+									"placed on. Exactly one of `datastore` and `storage_pod` " +
+									"must be set.",
+							},
+							"thin": &schema.Schema{
+								Type:        schema.TypeBool,
+								Optional:    true,
+								Default:     true,
+								Description: "Whether or not the disk is thin provisioned.",
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// -----------------------------------------------------------------------------
+// Conversion Helpers
+// -----------------------------------------------------------------------------
+
+// buildForemanHostVMwareComputeAttributes translates the "vmware" block of a
+// host to the compute attributes of the VMware compute resource.  Nil is
+// returned if the block is not set.
+func buildForemanHostVMwareComputeAttributes(d *schema.ResourceData) map[string]interface{} {
+	vmware, ok := foremanHostComputeBlock(d.Get("vmware"))
+	if !ok {
+		return nil
+	}
+
+	attrs := map[string]interface{}{
+		"cluster":          vmware["cluster"],
+		"cpuHotAddEnabled": vmware["cpu_hot_add"],
+	}
+	for attr, computeAttr := range map[string]string{
+		"resource_pool": "resource_pool",
+		"folder":        "path",
+		"guest_id":      "guest_id",
+	} {
+		if value, _ := vmware[attr].(string); value != "" {
+			attrs[computeAttr] = value
+		}
+	}
+
+	// NOTE(ALL): Foreman expects the volumes as a map keyed by their index,
+	//   like the nested attributes of its forms
+	volumes, _ := vmware["volume"].([]interface{})
+	if len(volumes) > 0 {
+		volumeAttrs := map[string]interface{}{}
+		for idx, volume := range volumes {
+			volumeMap, _ := volume.(map[string]interface{})
+			volumeAttr := map[string]interface{}{
+				"size_gb": volumeMap["size_gb"],
+				"thin":    volumeMap["thin"],
+			}
+			for _, attr := range []string{"datastore", "storage_pod"} {
+				if value, _ := volumeMap[attr].(string); value != "" {
+					volumeAttr[attr] = value
+				}
+			}
+			volumeAttrs[strconv.Itoa(idx)] = volumeAttr
+		}
+		attrs["volumes_attributes"] = volumeAttrs
+	}
+
+	return attrs
+}
+
+// applyForemanHostVMwareNICType sets the "nic_type" of the "vmware" block as
+// the adapter type of the interfaces which do not set one in their compute
+// attributes.
+func applyForemanHostVMwareNICType(d *schema.ResourceData, ifaces []api.ForemanInterfacesAttribute) {
+	vmware, ok := foremanHostComputeBlock(d.Get("vmware"))
+	if !ok {
+		return
+	}
+	nicType, _ := vmware["nic_type"].(string)
+	if nicType == "" {
+		return
+	}
+	applyForemanInterfaceComputeAttributeDefaults(ifaces, "type", map[string]interface{}{"type": nicType})
+}
+
+// -----------------------------------------------------------------------------
+// Plan-time Validation
+// -----------------------------------------------------------------------------
+
+// validateForemanHostVMware verifies each volume of the "vmware" block is
+// placed on exactly one of a datastore and a storage pod.
+func validateForemanHostVMware(d *schema.ResourceDiff) error {
+	if !d.NewValueKnown("vmware") {
+		return nil
+	}
+	vmware, ok := foremanHostComputeBlock(d.Get("vmware"))
+	if !ok {
+		return nil
+	}
+	volumes, _ := vmware["volume"].([]interface{})
+	for idx, volume := range volumes {
+		volumeMap, _ := volume.(map[string]interface{})
+		datastore, _ := volumeMap["datastore"].(string)
+		storagePod, _ := volumeMap["storage_pod"].(string)
+		if (datastore == "") == (storagePod == "") {
+			return fmt.Errorf(
+				"vmware volume [%d] must set exactly one of datastore and storage_pod",
+				idx,
+			)
+		}
+	}
+	return nil
+}
@@ -1,6 +1,7 @@
 package foreman
 
 import (
+	"context"
 	"fmt"
 	"strconv"
 
@@ -8,12 +9,12 @@ import (
 	"github.com/wayfair/terraform-provider-utils/autodoc"
 	"github.com/wayfair/terraform-provider-utils/log"
 
-	"github.com/hashicorp/terraform/helper/schema"
-	"github.com/hashicorp/terraform/helper/validation"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceForemanHostgroup() *schema.Resource {
-	return &schema.Resource{
+	r := &schema.Resource{
 
 		Create: resourceForemanHostgroupCreate,
 		Read:   resourceForemanHostgroupRead,
@@ -24,6 +25,8 @@ func resourceForemanHostgroup() *schema.Resource {
 			State: schema.ImportStatePassthrough,
 		},
 
+		SchemaVersion: 1,
+
 		Schema: map[string]*schema.Schema{
 
 			autodoc.MetaAttribute: &schema.Schema{
@@ -62,8 +65,10 @@ func resourceForemanHostgroup() *schema.Resource {
 				Type:         schema.TypeString,
 				Required:     true,
 				Sensitive:    true,
+				StateFunc:    hashSensitiveValue,
 				ValidateFunc: validation.StringLenBetween(8, 256),
-				Description:  "Default root password",
+				Description: "Default root password. Only a hash of the password " +
+					"is stored in the state.",
 			},
 
 			"pxe_loader": &schema.Schema{
@@ -100,6 +105,14 @@ func resourceForemanHostgroup() *schema.Resource {
 				Description: "A map of parameters that will be saved as hostgroup parameters " +
 					"in the group config.",
 			},
+			"hidden_parameters": &schema.Schema{
+				Type:      schema.TypeMap,
+				ForceNew:  false,
+				Optional:  true,
+				Sensitive: true,
+				Description: "A map of parameters that will be saved as hostgroup parameters " +
+					"with their values masked in the Foreman UI.",
+			},
 
 			// -- Foreign Key Relationships --
 
@@ -199,8 +212,59 @@ func resourceForemanHostgroup() *schema.Resource {
 				ValidateFunc: validation.IntAtLeast(0),
 				Description:  "ID of the subnet associated with the hostgroup.",
 			},
+
+			// -- Name-based Foreign Key Alternatives --
+
+			"domain_name": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Description: fmt.Sprintf(
+					"Name of the domain associated with this hostgroup. Resolved "+
+						"to `domain_id` by the provider and takes precedence over it. "+
+						"%s \"dev.dc1.company.com\"",
+					autodoc.MetaExample,
+				),
+			},
+
+			"operatingsystem_title": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Description: fmt.Sprintf(
+					"Title of the operating system associated with this hostgroup. "+
+						"Resolved to `operatingsystem_id` by the provider and takes "+
+						"precedence over it. "+
+						"%s \"CentOS 7.5\"",
+					autodoc.MetaExample,
+				),
+			},
+
+			"subnet_name": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Description: fmt.Sprintf(
+					"Name of the subnet associated with the hostgroup. Resolved "+
+						"to `subnet_id` by the provider and takes precedence over it. "+
+						"%s \"10.228.247.0 BO1\"",
+					autodoc.MetaExample,
+				),
+			},
+
+			"created_at": createdAtSchema(),
+			"updated_at": updatedAtSchema(),
 		},
 	}
+
+	// NOTE(ALL): the schema kept its shape in version 1, only the root
+	//   password is stored as a hash instead of plain text
+	r.StateUpgraders = []schema.StateUpgrader{
+		{
+			Version: 0,
+			Type:    r.CoreConfigSchema().ImpliedType(),
+			Upgrade: resourceForemanHostgroupStateUpgradeV0,
+		},
+	}
+
+	return r
 }
 
 // -----------------------------------------------------------------------------
@@ -290,16 +354,46 @@ func buildForemanHostgroup(d *schema.ResourceData) *api.ForemanHostgroup {
 			})
 		}
 	}
+	if attr, ok = d.GetOk("hidden_parameters"); ok {
+		hiddenTags := attr.(map[string]interface{})
+		for key, value := range hiddenTags {
+			hostgroup.HostGroupParameters = append(hostgroup.HostGroupParameters, api.ForemanKVParameter{
+				Name:        key,
+				Value:       value.(string),
+				HiddenValue: true,
+			})
+		}
+	}
 
 	return &hostgroup
 }
 
+// resolveForemanHostgroupForeignKeyNames resolves the name-based alternatives
+// of the hostgroup's foreign key attributes and sets the IDs on the supplied
+// ForemanHostgroup reference.
+func resolveForemanHostgroupForeignKeyNames(d *schema.ResourceData, client *api.Client, h *api.ForemanHostgroup) error {
+	log.Tracef("resource_foreman_hostgroup.go#resolveForemanHostgroupForeignKeyNames")
+
+	var resolveErr error
+	if h.DomainId, resolveErr = resolveForeignKeyName(d, client, "domain_name", h.DomainId, lookupDomainId); resolveErr != nil {
+		return resolveErr
+	}
+	if h.OperatingSystemId, resolveErr = resolveForeignKeyName(d, client, "operatingsystem_title", h.OperatingSystemId, lookupOperatingSystemId); resolveErr != nil {
+		return resolveErr
+	}
+	if h.SubnetId, resolveErr = resolveForeignKeyName(d, client, "subnet_name", h.SubnetId, lookupSubnetId); resolveErr != nil {
+		return resolveErr
+	}
+	return nil
+}
+
 // setResourceDataFromForemanHostgroup sets a ResourceData's attributes from
 // the attributes of the supplied ForemanHostgroup struct
 func setResourceDataFromForemanHostgroup(d *schema.ResourceData, fh *api.ForemanHostgroup) {
 	log.Tracef("resource_foreman_hostgroup.go#setResourceDataFromForemanHostgroup")
 
 	d.SetId(strconv.Itoa(fh.Id))
+	setResourceDataFromForemanObjectTimestamps(d, &fh.ForemanObject)
 	d.Set("title", fh.Title)
 	d.Set("name", fh.Name)
 	d.Set("pxe_loader", fh.PXELoader)
@@ -318,6 +412,20 @@ func setResourceDataFromForemanHostgroup(d *schema.ResourceData, fh *api.Foreman
 	d.Set("subnet_id", fh.SubnetId)
 }
 
+// -----------------------------------------------------------------------------
+// State Upgrades
+// -----------------------------------------------------------------------------
+
+// resourceForemanHostgroupStateUpgradeV0 upgrades the state of a hostgroup from schema
+// version 0.  The root password used to be stored in plain text, version 1
+// only stores its hash.
+func resourceForemanHostgroupStateUpgradeV0(ctx context.Context, rawState map[string]interface{}, meta interface{}) (map[string]interface{}, error) {
+	log.Tracef("resource_foreman_hostgroup.go#StateUpgradeV0")
+
+	upgradeSensitiveStateValue(rawState, "root_password")
+	return rawState, nil
+}
+
 // -----------------------------------------------------------------------------
 // Resource CRUD Operations
 // -----------------------------------------------------------------------------
@@ -328,6 +436,10 @@ func resourceForemanHostgroupCreate(d *schema.ResourceData, meta interface{}) er
 	client := meta.(*api.Client)
 	h := buildForemanHostgroup(d)
 
+	if resolveErr := resolveForemanHostgroupForeignKeyNames(d, client, h); resolveErr != nil {
+		return resolveErr
+	}
+
 	log.Debugf("ForemanHostgroup: [%+v]", h)
 
 	createdHostgroup, createErr := client.CreateHostgroup(h)
@@ -352,7 +464,7 @@ func resourceForemanHostgroupRead(d *schema.ResourceData, meta interface{}) erro
 
 	readHostgroup, readErr := client.ReadHostgroup(h.Id)
 	if readErr != nil {
-		return readErr
+		return handleReadError(d, readErr)
 	}
 
 	log.Debugf("Read ForemanHostgroup: [%+v]", readHostgroup)
@@ -373,6 +485,16 @@ func resourceForemanHostgroupUpdate(d *schema.ResourceData, meta interface{}) er
 	client := meta.(*api.Client)
 	h := buildForemanHostgroup(d)
 
+	if resolveErr := resolveForemanHostgroupForeignKeyNames(d, client, h); resolveErr != nil {
+		return resolveErr
+	}
+
+	// NOTE(ALL): An unchanged root password only exists as a hash in the
+	//   state - do not send it back to Foreman
+	if !d.HasChange("root_password") {
+		h.RootPassword = ""
+	}
+
 	log.Debugf("ForemanHostgroup: [%+v]", h)
 
 	updatedHostgroup, updateErr := client.UpdateHostgroup(h)
@@ -1,6 +1,7 @@
 package foreman
 
 import (
+	"context"
 	"encoding/json"
 	"math/rand"
 	"net/http"
@@ -11,8 +12,8 @@ import (
 	"github.com/HanseMerkur/terraform-provider-foreman/foreman/api"
 	tfrand "github.com/wayfair/terraform-provider-utils/rand"
 
-	"github.com/hashicorp/terraform/helper/schema"
-	"github.com/hashicorp/terraform/terraform"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
 
 // -----------------------------------------------------------------------------
@@ -28,13 +29,15 @@ func ForemanHostgroupToInstanceState(obj api.ForemanHostgroup) *terraform.Instan
 	state.ID = strconv.Itoa(obj.Id)
 	// Build the attribute map from ForemanHostgroup
 	attr := map[string]string{}
+	attr["created_at"] = obj.CreatedAt
+	attr["updated_at"] = obj.UpdatedAt
 	attr["name"] = obj.Name
 	attr["title"] = obj.Title
 	attr["architecture_id"] = strconv.Itoa(obj.ArchitectureId)
 	attr["compute_profile_id"] = strconv.Itoa(obj.ComputeProfileId)
 	attr["domain_id"] = strconv.Itoa(obj.DomainId)
 	attr["environment_id"] = strconv.Itoa(obj.EnvironmentId)
-	attr["medium_id"] = strconv.Itoa(obj.MediaId)
+	attr["medium_id"] = strconv.Itoa(obj.MediumId)
 	attr["operatingsystem_id"] = strconv.Itoa(obj.OperatingSystemId)
 	attr["parent_id"] = strconv.Itoa(obj.ParentId)
 	attr["ptable_id"] = strconv.Itoa(obj.PartitionTableId)
@@ -74,7 +77,7 @@ func RandForemanHostgroup() api.ForemanHostgroup {
 	obj.ComputeProfileId = rand.Intn(100)
 	obj.DomainId = rand.Intn(100)
 	obj.EnvironmentId = rand.Intn(100)
-	obj.MediaId = rand.Intn(100)
+	obj.MediumId = rand.Intn(100)
 	obj.OperatingSystemId = rand.Intn(100)
 	obj.ParentId = rand.Intn(100)
 	obj.PartitionTableId = rand.Intn(100)
@@ -186,6 +189,36 @@ func TestSetResourceDataFromForemanHostgroup_Value(t *testing.T) {
 
 }
 
+// -----------------------------------------------------------------------------
+// resourceForemanHostgroupStateUpgradeV0
+// -----------------------------------------------------------------------------
+
+// Ensures a plain text root password is hashed and a hashed one is kept
+func TestResourceForemanHostgroupStateUpgradeV0(t *testing.T) {
+
+	password := tfrand.String(12, tfrand.Lower+tfrand.Digit)
+	expected := hashSensitiveValue(password)
+
+	for _, stored := range []string{password, expected} {
+		rawState := map[string]interface{}{
+			"name":          "terraformtesthostgroup",
+			"root_password": stored,
+		}
+		upgraded, upgradeErr := resourceForemanHostgroupStateUpgradeV0(context.Background(), rawState, nil)
+		if upgradeErr != nil {
+			t.Fatalf("resourceForemanHostgroupStateUpgradeV0 returned an error: %s", upgradeErr)
+		}
+		if upgraded["root_password"] != expected {
+			t.Fatalf(
+				"resourceForemanHostgroupStateUpgradeV0 stored root_password [%v], expected [%s]",
+				upgraded["root_password"],
+				expected,
+			)
+		}
+	}
+
+}
+
 // ----------------------------------------------------------------------------
 // Test Cases for the Unit Test Framework
 // ----------------------------------------------------------------------------
@@ -269,7 +302,7 @@ func ResourceForemanHostgroupRequestDataTestCases(t *testing.T) []TestCaseReques
 
 	rd := MockForemanHostgroupResourceData(s)
 	obj = *buildForemanHostgroup(rd)
-	reqData, _ := json.Marshal(obj)
+	reqData, _ := api.WrapJson("hostgroup", obj)
 
 	return []TestCaseRequestData{
 		TestCaseRequestData{
@@ -8,7 +8,8 @@ import (
 	"github.com/wayfair/terraform-provider-utils/autodoc"
 	"github.com/wayfair/terraform-provider-utils/log"
 
-	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceForemanImage() *schema.Resource {
@@ -19,8 +20,10 @@ func resourceForemanImage() *schema.Resource {
 		Update: resourceForemanImageUpdate,
 		Delete: resourceForemanImageDelete,
 
+		// NOTE(ALL): Image objects are nested underneath their parent. The
+		//   import ID has the form "<compute_resource_id>/<id>"
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			State: importStateCompositeId("compute_resource_id"),
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -51,6 +54,7 @@ func resourceForemanImage() *schema.Resource {
 			"compute_resource_id": &schema.Schema{
 				Type:        schema.TypeInt,
 				Optional:    true,
+				ForceNew:    true,
 				Description: "",
 			},
 			"operating_system_id": &schema.Schema{
@@ -63,6 +67,31 @@ func resourceForemanImage() *schema.Resource {
 				Optional:    true,
 				Description: "",
 			},
+			"user_data": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+				Description: "Whether or not the image supports user data. Hosts " +
+					"cloned from the image get the rendered `user_data` template " +
+					"of their operating system passed to the compute resource, " +
+					"ie: for cloud-init.",
+			},
+			"user_data_template_id": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+				RequiredWith: []string{"operating_system_id"},
+				Description: "ID of the provisioning template of kind `user_data` " +
+					"to bootstrap hosts cloned from the image with. Foreman selects " +
+					"the template through the operating system of the host, so the " +
+					"template is associated with `operating_system_id` and becomes " +
+					"its default `user_data` template. Template parameters are " +
+					"injected through the parameters of the host, ie: " +
+					"`user_data_parameters` of `foreman_host`.",
+			},
+
+			"created_at": createdAtSchema(),
+			"updated_at": updatedAtSchema(),
 		},
 	}
 }
@@ -104,6 +133,7 @@ func buildForemanImage(d *schema.ResourceData) *api.ForemanImage {
 	if attr, ok = d.GetOk("compute_resource_id"); ok {
 		image.ComputeResourceID = attr.(int)
 	}
+	image.UserData = d.Get("user_data").(bool)
 
 	return &image
 }
@@ -114,12 +144,14 @@ func setResourceDataFromForemanImage(d *schema.ResourceData, fd *api.ForemanImag
 	log.Tracef("resource_foreman_image.go#setResourceDataFromForemanImage")
 
 	d.SetId(strconv.Itoa(fd.Id))
+	setResourceDataFromForemanObjectTimestamps(d, &fd.ForemanObject)
 	d.Set("name", fd.Name)
 	d.Set("username", fd.Username)
 	d.Set("uuid", fd.UUID)
 	d.Set("operating_system_id", fd.OperatingSystemID)
 	d.Set("architecture_id", fd.ArchitectureID)
 	d.Set("compute_resource_id", fd.ComputeResourceID)
+	d.Set("user_data", fd.UserData)
 }
 
 // -----------------------------------------------------------------------------
@@ -128,7 +160,22 @@ func setResourceDataFromForemanImage(d *schema.ResourceData, fd *api.ForemanImag
 
 func resourceForemanImageCreate(d *schema.ResourceData, meta interface{}) error {
 	log.Tracef("resource_foreman_image.go#Create")
-	return nil
+
+	client := meta.(*api.Client)
+	image := buildForemanImage(d)
+
+	log.Debugf("ForemanImage: [%+v]", image)
+
+	createdImage, createErr := client.CreateImage(image.ComputeResourceID, image)
+	if createErr != nil {
+		return createErr
+	}
+
+	log.Debugf("Created ForemanImage: [%+v]", createdImage)
+
+	setResourceDataFromForemanImage(d, createdImage)
+
+	return ensureForemanImageUserDataTemplate(d, client, createdImage)
 }
 
 func resourceForemanImageRead(d *schema.ResourceData, meta interface{}) error {
@@ -139,9 +186,9 @@ func resourceForemanImageRead(d *schema.ResourceData, meta interface{}) error {
 
 	log.Debugf("ForemanImage: [%+v]", image)
 
-	readImage, readErr := client.ReadImage(image)
+	readImage, readErr := client.ReadImage(image.ComputeResourceID, image.Id)
 	if readErr != nil {
-		return readErr
+		return handleReadError(d, readErr)
 	}
 
 	log.Debugf("Read ForemanImage: [%+v]", readImage)
@@ -153,14 +200,51 @@ func resourceForemanImageRead(d *schema.ResourceData, meta interface{}) error {
 
 func resourceForemanImageUpdate(d *schema.ResourceData, meta interface{}) error {
 	log.Tracef("resource_foreman_image.go#Update")
-	return nil
+
+	client := meta.(*api.Client)
+	image := buildForemanImage(d)
+
+	log.Debugf("ForemanImage: [%+v]", image)
+
+	updatedImage, updateErr := client.UpdateImage(image.ComputeResourceID, image)
+	if updateErr != nil {
+		return updateErr
+	}
+
+	log.Debugf("Updated ForemanImage: [%+v]", updatedImage)
+
+	setResourceDataFromForemanImage(d, updatedImage)
+
+	return ensureForemanImageUserDataTemplate(d, client, updatedImage)
 }
 
 func resourceForemanImageDelete(d *schema.ResourceData, meta interface{}) error {
 	log.Tracef("resource_foreman_image.go#Delete")
 
+	client := meta.(*api.Client)
+	image := buildForemanImage(d)
+
 	// NOTE(ALL): d.SetId("") is automatically called by terraform assuming delete
 	//   returns no errors
+	deleteErr := client.DeleteImage(image.ComputeResourceID, image.Id)
+	if deleteErr != nil && !api.IsNotFound(deleteErr) {
+		return deleteErr
+	}
 
 	return nil
 }
+
+// ensureForemanImageUserDataTemplate makes the configured user_data template
+// the user data template of the image's operating system.  The template is
+// only set if it is configured and changed, leaving the operating system's
+// default template alone otherwise.
+func ensureForemanImageUserDataTemplate(d *schema.ResourceData, client *api.Client, image *api.ForemanImage) error {
+	templateId, _ := d.Get("user_data_template_id").(int)
+	if templateId == 0 || !d.HasChanges("user_data_template_id", "operating_system_id") {
+		return nil
+	}
+	if image.OperatingSystemID == 0 {
+		image.OperatingSystemID, _ = d.Get("operating_system_id").(int)
+	}
+	return client.EnsureImageUserDataTemplate(image, templateId)
+}
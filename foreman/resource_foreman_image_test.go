@@ -11,8 +11,8 @@ import (
 	"github.com/HanseMerkur/terraform-provider-foreman/foreman/api"
 	tfrand "github.com/wayfair/terraform-provider-utils/rand"
 
-	"github.com/hashicorp/terraform/helper/schema"
-	"github.com/hashicorp/terraform/terraform"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
 
 // -----------------------------------------------------------------------------
@@ -29,6 +29,8 @@ func ForemanImageToInstanceState(obj api.ForemanImage) *terraform.InstanceState
 	// Build the attribute map from ForemanImage
 	state.Attributes = map[string]string{
 		"name":                obj.Name,
+		"created_at":          obj.CreatedAt,
+		"updated_at":          obj.UpdatedAt,
 		"username":            obj.Username,
 		"uuid":                obj.UUID,
 		"architecture_id":     strconv.Itoa(obj.ArchitectureID),
@@ -248,3 +250,54 @@ func ResourceForemanImageMockResponseTestCases(t *testing.T) []TestCaseMockRespo
 	}
 
 }
+
+// -----------------------------------------------------------------------------
+// resourceForemanImageCreate
+// -----------------------------------------------------------------------------
+
+// Ensures the image is created underneath its compute resource and its user
+// data template is made the default of the image's operating system
+func TestResourceForemanImageCreate_UserDataTemplate(t *testing.T) {
+	mux, server, client := NewForemanAPIAndClient(api.ClientCredentials{}, api.ClientConfig{})
+	defer server.Close()
+
+	mux.HandleFunc(ImagesURI+"/3/images", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"id": 11, "name": "centos", "compute_resource_id": 3, "operating_system_id": 2, "user_data": true}`))
+	})
+	mux.HandleFunc(api.FOREMAN_API_URL_PREFIX+"/provisioning_templates/7", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"id": 7, "template_kind_id": 9, "operatingsystems": [{"id": 2}]}`))
+	})
+	mux.HandleFunc(api.FOREMAN_API_URL_PREFIX+"/template_kinds/9", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"id": 9, "name": "user_data"}`))
+	})
+	defaulted := false
+	mux.HandleFunc(api.FOREMAN_API_URL_PREFIX+"/operatingsystems/2/os_default_templates", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			defaulted = true
+			w.Write([]byte(`{"id": 4, "provisioning_template_id": 7, "template_kind_id": 9}`))
+			return
+		}
+		w.Write([]byte(`{"results": []}`))
+	})
+
+	r := resourceForemanImage()
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"name":                  "centos",
+		"username":              "root",
+		"uuid":                  "centos-7",
+		"compute_resource_id":   3,
+		"operating_system_id":   2,
+		"user_data":             true,
+		"user_data_template_id": 7,
+	})
+
+	if createErr := r.Create(d, client); createErr != nil {
+		t.Fatalf("expected no error, got [%s]", createErr)
+	}
+	if d.Id() != "11" || !d.Get("user_data").(bool) {
+		t.Fatalf("expected the created image in the state, got ID [%s]", d.Id())
+	}
+	if !defaulted {
+		t.Fatalf("expected the user data template to become the operating system default")
+	}
+}
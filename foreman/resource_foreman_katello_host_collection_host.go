@@ -0,0 +1,157 @@
+package foreman
+
+import (
+	"fmt"
+
+	"github.com/HanseMerkur/terraform-provider-foreman/foreman/api"
+	"github.com/wayfair/terraform-provider-utils/autodoc"
+	"github.com/wayfair/terraform-provider-utils/log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceForemanKatelloHostCollectionHost() *schema.Resource {
+	return &schema.Resource{
+
+		Create: resourceForemanKatelloHostCollectionHostCreate,
+		Read:   resourceForemanKatelloHostCollectionHostRead,
+		Delete: resourceForemanKatelloHostCollectionHostDelete,
+
+		Importer: &schema.ResourceImporter{
+			State: resourceForemanKatelloHostCollectionHostImport,
+		},
+
+		Schema: map[string]*schema.Schema{
+
+			autodoc.MetaAttribute: &schema.Schema{
+				Type:     schema.TypeBool,
+				Computed: true,
+				Description: fmt.Sprintf(
+					"%s Membership of a host in a Katello host collection. The "+
+						"membership is managed on its own, so hosts and host "+
+						"collections managed by different configurations can be "+
+						"associated without either owning the other. Requires the "+
+						"`katello` provider feature. The import ID has the form "+
+						"\"<host collection id>/<host id>\".",
+					autodoc.MetaSummary,
+				),
+			},
+
+			"host_collection_id": &schema.Schema{
+				Type:         schema.TypeInt,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+				Description:  "ID of the host collection the host is a member of.",
+			},
+			"host_id": &schema.Schema{
+				Type:         schema.TypeInt,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+				Description:  "ID of the host added to the host collection.",
+			},
+		},
+	}
+}
+
+// -----------------------------------------------------------------------------
+// Conversion Helpers
+// -----------------------------------------------------------------------------
+
+// foremanKatelloHostCollectionHostId returns the ID of the membership of the
+// supplied host in the supplied host collection
+func foremanKatelloHostCollectionHostId(collectionId int, hostId int) string {
+	return fmt.Sprintf("%d/%d", collectionId, hostId)
+}
+
+// -----------------------------------------------------------------------------
+// Resource CRUD Operations
+// -----------------------------------------------------------------------------
+
+// resourceForemanKatelloHostCollectionHostImport imports the membership of a
+// host in a host collection.  The import ID has the form
+// "<host collection id>/<host id>".
+func resourceForemanKatelloHostCollectionHostImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	log.Tracef("resource_foreman_katello_host_collection_host.go#Import")
+
+	ids, splitErr := splitCompositeImportId(d.Id(), 2)
+	if splitErr != nil {
+		return nil, splitErr
+	}
+	d.Set("host_collection_id", ids[0])
+	d.Set("host_id", ids[1])
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func resourceForemanKatelloHostCollectionHostCreate(d *schema.ResourceData, meta interface{}) error {
+	log.Tracef("resource_foreman_katello_host_collection_host.go#Create")
+
+	client := meta.(*api.Client)
+	if !client.Config().KatelloEnabled {
+		return fmt.Errorf("Host collections require the [katello] provider feature")
+	}
+	collectionId := d.Get("host_collection_id").(int)
+	hostId := d.Get("host_id").(int)
+
+	log.Debugf("Adding host [%d] to host collection [%d]", hostId, collectionId)
+
+	if addErr := client.AddKatelloHostCollectionHosts(collectionId, []int{hostId}); addErr != nil {
+		return addErr
+	}
+
+	d.SetId(foremanKatelloHostCollectionHostId(collectionId, hostId))
+
+	return nil
+}
+
+func resourceForemanKatelloHostCollectionHostRead(d *schema.ResourceData, meta interface{}) error {
+	log.Tracef("resource_foreman_katello_host_collection_host.go#Read")
+
+	client := meta.(*api.Client)
+	collectionId := d.Get("host_collection_id").(int)
+	hostId := d.Get("host_id").(int)
+
+	readHostCollection, readErr := client.ReadKatelloHostCollection(collectionId)
+	if readErr != nil {
+		return handleReadError(d, readErr)
+	}
+
+	log.Debugf("Read ForemanKatelloHostCollection: [%+v]", readHostCollection)
+
+	for _, memberId := range readHostCollection.HostIds {
+		if memberId == hostId {
+			return nil
+		}
+	}
+	log.Infof(
+		"Host [%d] is no longer a member of host collection [%d], removing it from the state",
+		hostId,
+		collectionId,
+	)
+	d.SetId("")
+
+	return nil
+}
+
+func resourceForemanKatelloHostCollectionHostDelete(d *schema.ResourceData, meta interface{}) error {
+	log.Tracef("resource_foreman_katello_host_collection_host.go#Delete")
+
+	client := meta.(*api.Client)
+	collectionId := d.Get("host_collection_id").(int)
+	hostId := d.Get("host_id").(int)
+
+	log.Debugf("Removing host [%d] from host collection [%d]", hostId, collectionId)
+
+	removeErr := client.RemoveKatelloHostCollectionHosts(collectionId, []int{hostId})
+	if removeErr != nil && !api.IsNotFound(removeErr) {
+		return removeErr
+	}
+
+	// NOTE(ALL): d.SetId("") is automatically called by terraform assuming delete
+	//   returns no errors
+
+	return nil
+}
@@ -0,0 +1,74 @@
+package foreman
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/HanseMerkur/terraform-provider-foreman/foreman/api"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// -----------------------------------------------------------------------------
+// resourceForemanKatelloHostCollectionHost
+// -----------------------------------------------------------------------------
+
+// Ensures the host is added to and removed from the host collection, and the
+// membership is removed from the state once the host left the collection
+func TestResourceForemanKatelloHostCollectionHost(t *testing.T) {
+
+	mux, server, client := NewForemanAPIAndClient(api.ClientCredentials{}, api.ClientConfig{KatelloEnabled: true})
+	defer server.Close()
+
+	collectionURI := api.KATELLO_API_URL_PREFIX + "/host_collections/4"
+	hostIds := "[]"
+	requests := []string{}
+	mux.HandleFunc(collectionURI, func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"id": 4, "name": "web", "host_ids": %s}`, hostIds)
+	})
+	for _, action := range []string{"add_hosts", "remove_hosts"} {
+		mux.HandleFunc(collectionURI+"/"+action, func(w http.ResponseWriter, r *http.Request) {
+			body, _ := io.ReadAll(r.Body)
+			requests = append(requests, r.URL.Path+" "+string(body))
+			fmt.Fprint(w, `{"displayMessages": {"success": ["1 host(s) updated"], "error": []}}`)
+		})
+	}
+
+	r := resourceForemanKatelloHostCollectionHost()
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"host_collection_id": 4,
+		"host_id":            7,
+	})
+
+	if createErr := r.Create(d, client); createErr != nil {
+		t.Fatalf("expected no error, got [%s]", createErr)
+	}
+	if d.Id() != "4/7" {
+		t.Fatalf("expected the ID [4/7], got [%s]", d.Id())
+	}
+
+	hostIds = "[3, 7]"
+	if readErr := r.Read(d, client); readErr != nil || d.Id() != "4/7" {
+		t.Fatalf("expected the membership to be kept, got ID [%s] and error [%v]", d.Id(), readErr)
+	}
+
+	if deleteErr := r.Delete(d, client); deleteErr != nil {
+		t.Fatalf("expected no error, got [%s]", deleteErr)
+	}
+	expected := []string{
+		collectionURI + `/add_hosts {"host_ids":[7]}`,
+		collectionURI + `/remove_hosts {"host_ids":[7]}`,
+	}
+	if !reflect.DeepEqual(expected, requests) {
+		t.Fatalf("expected the requests [%v], got [%v]", expected, requests)
+	}
+
+	hostIds = "[3]"
+	if readErr := r.Read(d, client); readErr != nil || d.Id() != "" {
+		t.Fatalf("expected the membership to be removed, got ID [%s] and error [%v]", d.Id(), readErr)
+	}
+
+}
@@ -9,8 +9,8 @@ import (
 	"github.com/wayfair/terraform-provider-utils/conv"
 	"github.com/wayfair/terraform-provider-utils/log"
 
-	"github.com/hashicorp/terraform/helper/schema"
-	"github.com/hashicorp/terraform/helper/validation"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceForemanMedia() *schema.Resource {
@@ -98,6 +98,12 @@ func resourceForemanMedia() *schema.Resource {
 				},
 				Description: "IDs of the operating systems associated with this media.",
 			},
+
+			"location_ids":     locationIdsSchema("media"),
+			"organization_ids": organizationIdsSchema("media"),
+
+			"created_at": createdAtSchema(),
+			"updated_at": updatedAtSchema(),
 		},
 	}
 }
@@ -117,6 +123,7 @@ func buildForemanMedia(d *schema.ResourceData) *api.ForemanMedia {
 
 	obj := buildForemanObject(d)
 	media.ForemanObject = *obj
+	media.ForemanTaxonomies = buildForemanTaxonomies(d)
 
 	var attr interface{}
 	var ok bool
@@ -141,6 +148,8 @@ func setResourceDataFromForemanMedia(d *schema.ResourceData, fm *api.ForemanMedi
 	log.Tracef("resource_foreman_media.go#setResourceDataFromForemanMedia")
 
 	d.SetId(strconv.Itoa(fm.Id))
+	setResourceDataFromForemanObjectTimestamps(d, &fm.ForemanObject)
+	setResourceDataFromForemanTaxonomies(d, &fm.ForemanTaxonomies)
 	d.Set("name", fm.Name)
 	d.Set("path", fm.Path)
 	d.Set("os_family", fm.OSFamily)
@@ -181,7 +190,7 @@ func resourceForemanMediaRead(d *schema.ResourceData, meta interface{}) error {
 
 	readMedia, readErr := client.ReadMedia(m.Id)
 	if readErr != nil {
-		return readErr
+		return handleReadError(d, readErr)
 	}
 
 	log.Debugf("Read ForemanMedia: [%+v]", readMedia)
@@ -12,8 +12,8 @@ import (
 	"github.com/HanseMerkur/terraform-provider-foreman/foreman/api"
 	tfrand "github.com/wayfair/terraform-provider-utils/rand"
 
-	"github.com/hashicorp/terraform/helper/schema"
-	"github.com/hashicorp/terraform/terraform"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
 
 // -----------------------------------------------------------------------------
@@ -29,6 +29,8 @@ func ForemanMediaToInstanceState(obj api.ForemanMedia) *terraform.InstanceState
 	state.ID = strconv.Itoa(obj.Id)
 	// Build the attribute map from ForemanMedia
 	attr := map[string]string{}
+	attr["created_at"] = obj.CreatedAt
+	attr["updated_at"] = obj.UpdatedAt
 	attr["name"] = obj.Name
 	attr["path"] = obj.Path
 	attr["os_family"] = obj.OSFamily
@@ -279,7 +281,7 @@ func ResourceForemanMediaRequestDataTestCases(t *testing.T) []TestCaseRequestDat
 
 	rd := MockForemanMediaResourceData(s)
 	obj = *buildForemanMedia(rd)
-	reqData, _ := json.Marshal(obj)
+	reqData, _ := api.WrapJson("medium", obj)
 
 	return []TestCaseRequestData{
 		TestCaseRequestData{
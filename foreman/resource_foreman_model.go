@@ -8,7 +8,7 @@ import (
 	"github.com/wayfair/terraform-provider-utils/autodoc"
 	"github.com/wayfair/terraform-provider-utils/log"
 
-	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func resourceForemanModel() *schema.Resource {
@@ -61,6 +61,9 @@ func resourceForemanModel() *schema.Resource {
 				Optional:    true,
 				Description: "Name of the specific hardware model.",
 			},
+
+			"created_at": createdAtSchema(),
+			"updated_at": updatedAtSchema(),
 		},
 	}
 }
@@ -105,6 +108,7 @@ func setResourceDataFromForemanModel(d *schema.ResourceData, fm *api.ForemanMode
 	log.Tracef("resource_foreman_model.go#setResourceDataFromForemanModel")
 
 	d.SetId(strconv.Itoa(fm.Id))
+	setResourceDataFromForemanObjectTimestamps(d, &fm.ForemanObject)
 	d.Set("name", fm.Name)
 	d.Set("info", fm.Info)
 	d.Set("vendor_class", fm.VendorClass)
@@ -145,7 +149,7 @@ func resourceForemanModelRead(d *schema.ResourceData, meta interface{}) error {
 
 	readModel, readErr := client.ReadModel(m.Id)
 	if readErr != nil {
-		return readErr
+		return handleReadError(d, readErr)
 	}
 
 	log.Debugf("Read ForemanModel: [%+v]", readModel)
@@ -11,8 +11,8 @@ import (
 	"github.com/HanseMerkur/terraform-provider-foreman/foreman/api"
 	tfrand "github.com/wayfair/terraform-provider-utils/rand"
 
-	"github.com/hashicorp/terraform/helper/schema"
-	"github.com/hashicorp/terraform/terraform"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
 
 // -----------------------------------------------------------------------------
@@ -28,6 +28,8 @@ func ForemanModelToInstanceState(obj api.ForemanModel) *terraform.InstanceState
 	state.ID = strconv.Itoa(obj.Id)
 	// Build the attribute map from ForemanModel
 	attr := map[string]string{}
+	attr["created_at"] = obj.CreatedAt
+	attr["updated_at"] = obj.UpdatedAt
 	attr["name"] = obj.Name
 	attr["info"] = obj.Info
 	attr["vendor_class"] = obj.VendorClass
@@ -249,7 +251,7 @@ func ResourceForemanModelRequestDataTestCases(t *testing.T) []TestCaseRequestDat
 
 	rd := MockForemanModelResourceData(s)
 	obj = *buildForemanModel(rd)
-	reqData, _ := json.Marshal(obj)
+	reqData, _ := api.WrapJson("model", obj)
 
 	return []TestCaseRequestData{
 		TestCaseRequestData{
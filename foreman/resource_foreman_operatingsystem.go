@@ -9,10 +9,29 @@ import (
 	"github.com/wayfair/terraform-provider-utils/conv"
 	"github.com/wayfair/terraform-provider-utils/log"
 
-	"github.com/hashicorp/terraform/helper/schema"
-	"github.com/hashicorp/terraform/helper/validation"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
+// operatingSystemFamilies are the operating system families known to Foreman.
+// Shared between the operating system resource, its data source and the
+// partition table resource so all validate the family the same way.
+var operatingSystemFamilies = []string{
+	"AIX",
+	"Altlinux",
+	"Archlinux",
+	"Coreos",
+	"Debian",
+	"Freebsd",
+	"Gentoo",
+	"Junos",
+	"NXOS",
+	"Redhat",
+	"Solaris",
+	"Suse",
+	"Windows",
+}
+
 func resourceForemanOperatingSystem() *schema.Resource {
 	return &schema.Resource{
 
@@ -83,22 +102,11 @@ func resourceForemanOperatingSystem() *schema.Resource {
 			"family": &schema.Schema{
 				Type:     schema.TypeString,
 				Optional: true,
-				ValidateFunc: validation.StringInSlice([]string{
-					"AIX",
-					"Altlinux",
-					"Archlinux",
-					"Coreos",
-					"Debian",
-					"Freebsd",
-					"Gentoo",
-					"Junos",
-					"NXOS",
-					"Redhat",
-					"Solaris",
-					"Suse",
-					"Windows",
+				ValidateFunc: validation.StringInSlice(
+					operatingSystemFamilies,
 					// NOTE(ALL): false - do not ignore case when comparing values
-				}, false),
+					false,
+				),
 				Description: "Operating system family. Values include: " +
 					"`\"AIX\"`, `\"Altlinux\"`, `\"Archlinux\"`, `\"Coreos\"`, " +
 					"`\"Debian\"`, `\"Freebsd\"`, `\"Gentoo\"`, `\"Junos\"`, " +
@@ -121,10 +129,12 @@ func resourceForemanOperatingSystem() *schema.Resource {
 					"SHA256",
 					"SHA512",
 					"Base64",
+					"Base64-Windows",
 					// NOTE(ALL): false - do not ignore case when comparing values
 				}, false),
 				Description: "Root password hash function to use. Valid values " +
-					"include: `\"MD5\"`, `\"SHA256\"`, `\"SHA512\"`, `\"Base64\"`.",
+					"include: `\"MD5\"`, `\"SHA256\"`, `\"SHA512\"`, `\"Base64\"`, " +
+					"`\"Base64-Windows\"`.",
 			},
 			"provisioning_templates": &schema.Schema{
 				Type:     schema.TypeSet,
@@ -164,6 +174,13 @@ func resourceForemanOperatingSystem() *schema.Resource {
 				},
 				Description: "Identifiers of attached partition tables",
 			},
+
+			"parameters":        parametersSchema("operating system"),
+			"hidden_parameters": hiddenParametersSchema("operating system"),
+			"parameter_types":   parameterTypesSchema("operating system"),
+
+			"created_at": createdAtSchema(),
+			"updated_at": updatedAtSchema(),
 		},
 	}
 }
@@ -224,6 +241,7 @@ func buildForemanOperatingSystem(d *schema.ResourceData) *api.ForemanOperatingSy
 		attrSet := attr.(*schema.Set)
 		os.PartitiontableIds = conv.InterfaceSliceToIntSlice(attrSet.List())
 	}
+	os.OperatingSystemParameters = buildForemanKVParameters(d)
 
 	return &os
 }
@@ -234,6 +252,7 @@ func setResourceDataFromForemanOperatingSystem(d *schema.ResourceData, fo *api.F
 	log.Tracef("resource_foreman_operatingsystem.go#setResourceDataFromForemanOperatingSystem")
 
 	d.SetId(strconv.Itoa(fo.Id))
+	setResourceDataFromForemanObjectTimestamps(d, &fo.ForemanObject)
 	d.Set("name", fo.Name)
 	d.Set("major", fo.Major)
 	d.Set("minor", fo.Minor)
@@ -246,6 +265,7 @@ func setResourceDataFromForemanOperatingSystem(d *schema.ResourceData, fo *api.F
 	d.Set("media", fo.MediumIds)
 	d.Set("architectures", fo.ArchitectureIds)
 	d.Set("partitiontables", fo.PartitiontableIds)
+	setResourceDataFromForemanKVParameters(d, fo.OperatingSystemParameters)
 }
 
 // -----------------------------------------------------------------------------
@@ -265,9 +285,11 @@ func resourceForemanOperatingSystemCreate(d *schema.ResourceData, meta interface
 
 	log.Debugf("Created ForemanOperatingSystem: [%+v]", createdOs)
 
+	createdOs, assocErr := client.EnsureOperatingSystemAssociations(o, createdOs)
+
 	setResourceDataFromForemanOperatingSystem(d, createdOs)
 
-	return nil
+	return assocErr
 }
 
 func resourceForemanOperatingSystemRead(d *schema.ResourceData, meta interface{}) error {
@@ -280,7 +302,7 @@ func resourceForemanOperatingSystemRead(d *schema.ResourceData, meta interface{}
 
 	readOS, readErr := client.ReadOperatingSystem(o.Id)
 	if readErr != nil {
-		return readErr
+		return handleReadError(d, readErr)
 	}
 
 	log.Debugf("ForemanOperatingSystem: [%+v]", readOS)
@@ -305,9 +327,11 @@ func resourceForemanOperatingSystemUpdate(d *schema.ResourceData, meta interface
 
 	log.Debugf("Updated ForemanOperatingSystem: [%+v]", updatedOs)
 
+	updatedOs, assocErr := client.EnsureOperatingSystemAssociations(o, updatedOs)
+
 	setResourceDataFromForemanOperatingSystem(d, updatedOs)
 
-	return nil
+	return assocErr
 }
 
 func resourceForemanOperatingSystemDelete(d *schema.ResourceData, meta interface{}) error {
@@ -2,17 +2,19 @@ package foreman
 
 import (
 	"encoding/json"
+	"fmt"
 	"math/rand"
 	"net/http"
 	"reflect"
+	"sort"
 	"strconv"
 	"testing"
 
 	"github.com/HanseMerkur/terraform-provider-foreman/foreman/api"
 	tfrand "github.com/wayfair/terraform-provider-utils/rand"
 
-	"github.com/hashicorp/terraform/helper/schema"
-	"github.com/hashicorp/terraform/terraform"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
 
 // -----------------------------------------------------------------------------
@@ -28,6 +30,8 @@ func ForemanOperatingSystemToInstanceState(obj api.ForemanOperatingSystem) *terr
 	state.ID = strconv.Itoa(obj.Id)
 	// Build the attribute map from ForemanOperatingSystem
 	attr := map[string]string{}
+	attr["created_at"] = obj.CreatedAt
+	attr["updated_at"] = obj.UpdatedAt
 	attr["name"] = obj.Name
 	attr["major"] = obj.Major
 	attr["minor"] = obj.Minor
@@ -153,6 +157,75 @@ func TestSetResourceDataFromForemanOperatingSystem_Value(t *testing.T) {
 
 }
 
+// Ensures the parameters of the operating system are sent only when managed
+// and read back from the API
+func TestForemanOperatingSystemParameters(t *testing.T) {
+
+	d := schema.TestResourceDataRaw(t, resourceForemanOperatingSystem().Schema, map[string]interface{}{
+		"name":              "CentOS",
+		"major":             "7",
+		"parameters":        map[string]interface{}{"kernelopts": "console=ttyS0"},
+		"hidden_parameters": map[string]interface{}{"token": "secret"},
+	})
+
+	o := buildForemanOperatingSystem(d)
+	sort.Slice(o.OperatingSystemParameters, func(i, j int) bool {
+		return o.OperatingSystemParameters[i].Name < o.OperatingSystemParameters[j].Name
+	})
+	oJSON, _ := json.Marshal(o)
+	var oMap map[string]interface{}
+	json.Unmarshal(oJSON, &oMap)
+	paramsJSON, _ := json.Marshal(oMap["os_parameters_attributes"])
+	expectedJSON := `[` +
+		`{"hidden_value":false,"name":"kernelopts","value":"console=ttyS0"},` +
+		`{"hidden_value":true,"name":"token","value":"secret"}]`
+	if string(paramsJSON) != expectedJSON {
+		t.Fatalf("expected the parameters [%s], got [%s]", expectedJSON, paramsJSON)
+	}
+
+	// NOTE(ALL): Foreman masks the values of hidden parameters unless they
+	//   are explicitly requested
+	mux, server, client := NewForemanAPIAndClient(api.ClientCredentials{}, api.ClientConfig{})
+	defer server.Close()
+	mux.HandleFunc(OperatingSystemsURI+"/2", func(w http.ResponseWriter, r *http.Request) {
+		token := "*****"
+		if r.URL.Query().Get("show_hidden_parameters") == "true" {
+			token = "other"
+		}
+		fmt.Fprintf(w, `{"id": 2, "name": "CentOS", "parameters": [
+			{"name": "kernelopts", "value": "quiet"},
+			{"name": "token", "value": "%s", "hidden_value?": true}
+		]}`, token)
+	})
+	readOperatingSystem, readErr := client.ReadOperatingSystem(2)
+	if readErr != nil {
+		t.Fatalf("expected no error, got [%s]", readErr)
+	}
+	setResourceDataFromForemanOperatingSystem(d, readOperatingSystem)
+
+	expected := map[string]interface{}{
+		"parameters":        map[string]interface{}{"kernelopts": "quiet"},
+		"hidden_parameters": map[string]interface{}{"token": "other"},
+	}
+	for attr, value := range expected {
+		if actual := d.Get(attr); !reflect.DeepEqual(value, actual) {
+			t.Fatalf("expected [%s] to be [%v], got [%v]", attr, value, actual)
+		}
+	}
+
+	// unmanaged parameters are not sent
+	d = schema.TestResourceDataRaw(t, resourceForemanOperatingSystem().Schema, map[string]interface{}{
+		"name": "CentOS",
+	})
+	oJSON, _ = json.Marshal(buildForemanOperatingSystem(d))
+	oMap = nil
+	json.Unmarshal(oJSON, &oMap)
+	if _, ok := oMap["os_parameters_attributes"]; ok {
+		t.Fatalf("expected no parameters to be sent, got [%s]", oJSON)
+	}
+
+}
+
 // ----------------------------------------------------------------------------
 // Test Cases for the Unit Test Framework
 // ----------------------------------------------------------------------------
@@ -3,13 +3,14 @@ package foreman
 import (
 	"fmt"
 	"strconv"
+	"strings"
 
 	"github.com/HanseMerkur/terraform-provider-foreman/foreman/api"
 	"github.com/wayfair/terraform-provider-utils/autodoc"
 	"github.com/wayfair/terraform-provider-utils/log"
 
-	"github.com/hashicorp/terraform/helper/schema"
-	"github.com/hashicorp/terraform/helper/validation"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceForemanParameter() *schema.Resource {
@@ -21,7 +22,7 @@ func resourceForemanParameter() *schema.Resource {
 		Delete: resourceForemanParameterDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			State: resourceForemanParameterImport,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -73,9 +74,20 @@ func resourceForemanParameter() *schema.Resource {
 				Required: true,
 			},
 			"value": &schema.Schema{
-				Type:     schema.TypeString,
-				Required: true,
+				Type:      schema.TypeString,
+				Required:  true,
+				Sensitive: true,
+			},
+			"hidden_value": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+				Description: "Whether the value of the parameter is masked in the " +
+					"Foreman UI.",
 			},
+
+			"created_at": createdAtSchema(),
+			"updated_at": updatedAtSchema(),
 		},
 	}
 }
@@ -123,6 +135,7 @@ func buildForemanParameter(d *schema.ResourceData) *api.ForemanParameter {
 	if attr, ok = d.GetOk("value"); ok {
 		parameter.Parameter.Value = attr.(string)
 	}
+	parameter.Parameter.HiddenValue = d.Get("hidden_value").(bool)
 	return &parameter
 }
 
@@ -132,19 +145,57 @@ func setResourceDataFromForemanParameter(d *schema.ResourceData, fd *api.Foreman
 	log.Tracef("resource_foreman_parameter.go#setResourceDataFromForemanParameter")
 
 	d.SetId(strconv.Itoa(fd.Id))
+	setResourceDataFromForemanObjectTimestamps(d, &fd.ForemanObject)
 	d.Set("subject", fd.Subject)
 	d.Set("host_id", fd.HostID)
 	d.Set("hostgroup_id", fd.HostGroupID)
 	d.Set("operatingsystem_id", fd.OperatingSystemID)
+	d.Set("domain_id", fd.DomainID)
 	d.Set("subnet_id", fd.SubnetID)
 	d.Set("name", fd.Parameter.Name)
 	d.Set("value", fd.Parameter.Value)
+	d.Set("hidden_value", fd.Parameter.HiddenValue)
 }
 
 // -----------------------------------------------------------------------------
 // Resource CRUD Operations
 // -----------------------------------------------------------------------------
 
+// resourceForemanParameterImport imports a parameter nested underneath one of
+// its possible parents.  The import ID has the form
+// "<parent type>/<parent id>/<id>", where the parent type is one of "host",
+// "hostgroup", "domain", "operatingsystem" or "subnet".
+func resourceForemanParameterImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	log.Tracef("resource_foreman_parameter.go#Import")
+
+	parts := strings.SplitN(d.Id(), "/", 2)
+	if len(parts) != 2 {
+		return nil, fmt.Errorf(
+			"Invalid import ID [%s]. Expected \"<parent type>/<parent id>/<id>\"",
+			d.Id(),
+		)
+	}
+	parentAttr := parts[0] + "_id"
+	switch parts[0] {
+	case "host", "hostgroup", "domain", "operatingsystem", "subnet":
+	default:
+		return nil, fmt.Errorf(
+			"Invalid import ID [%s]. Unknown parent type [%s]",
+			d.Id(),
+			parts[0],
+		)
+	}
+
+	ids, splitErr := splitCompositeImportId(parts[1], 2)
+	if splitErr != nil {
+		return nil, splitErr
+	}
+	d.Set(parentAttr, ids[0])
+	d.SetId(strconv.Itoa(ids[1]))
+
+	return []*schema.ResourceData{d}, nil
+}
+
 func resourceForemanParameterCreate(d *schema.ResourceData, meta interface{}) error {
 	log.Tracef("resource_foreman_parameter.go#Create")
 
@@ -175,7 +226,7 @@ func resourceForemanParameterRead(d *schema.ResourceData, meta interface{}) erro
 
 	readParameter, readErr := client.ReadParameter(parameter, parameter.Id)
 	if readErr != nil {
-		return readErr
+		return handleReadError(d, readErr)
 	}
 
 	log.Debugf("Read ForemanParameter: [%+v]", readParameter)
@@ -9,8 +9,8 @@ import (
 	"github.com/wayfair/terraform-provider-utils/conv"
 	"github.com/wayfair/terraform-provider-utils/log"
 
-	"github.com/hashicorp/terraform/helper/schema"
-	"github.com/hashicorp/terraform/helper/validation"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceForemanPartitionTable() *schema.Resource {
@@ -86,22 +86,11 @@ func resourceForemanPartitionTable() *schema.Resource {
 			"os_family": &schema.Schema{
 				Type:     schema.TypeString,
 				Optional: true,
-				ValidateFunc: validation.StringInSlice([]string{
-					"AIX",
-					"Altlinux",
-					"Archlinux",
-					"Coreos",
-					"Debian",
-					"Freebsd",
-					"Gentoo",
-					"Junos",
-					"NXOS",
-					"Redhat",
-					"Solaris",
-					"Suse",
-					"Windows",
+				ValidateFunc: validation.StringInSlice(
+					operatingSystemFamilies,
 					// NOTE(ALL): false - do not ignore case when comparing values
-				}, false),
+					false,
+				),
 				Description: "Operating system family. Values include: " +
 					"`\"AIX\"`, `\"Altlinux\"`, `\"Archlinux\"`, `\"Coreos\"`, " +
 					"`\"Debian\"`, `\"Freebsd\"`, `\"Gentoo\"`, `\"Junos\"`, " +
@@ -139,6 +128,9 @@ func resourceForemanPartitionTable() *schema.Resource {
 				},
 				Description: "IDs of the hosts associated with this partition table.",
 			},
+
+			"created_at": createdAtSchema(),
+			"updated_at": updatedAtSchema(),
 		},
 	}
 }
@@ -204,6 +196,7 @@ func setResourceDataFromForemanPartitionTable(d *schema.ResourceData, ft *api.Fo
 	log.Tracef("resource_foreman_partitiontable.go#setResourceDataFromForemanPartitionTable")
 
 	d.SetId(strconv.Itoa(ft.Id))
+	setResourceDataFromForemanObjectTimestamps(d, &ft.ForemanObject)
 	d.Set("name", ft.Name)
 	d.Set("layout", ft.Layout)
 	d.Set("os_family", ft.OSFamily)
@@ -277,7 +270,7 @@ func resourceForemanPartitionTableRead(d *schema.ResourceData, meta interface{})
 
 	readTable, readErr := client.ReadPartitionTable(t.Id)
 	if readErr != nil {
-		return readErr
+		return handleReadError(d, readErr)
 	}
 
 	log.Debugf("Read ForemanPartitionTable: [%+v]", readTable)
@@ -12,8 +12,8 @@ import (
 	"github.com/HanseMerkur/terraform-provider-foreman/foreman/api"
 	tfrand "github.com/wayfair/terraform-provider-utils/rand"
 
-	"github.com/hashicorp/terraform/helper/schema"
-	"github.com/hashicorp/terraform/terraform"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
 
 // -----------------------------------------------------------------------------
@@ -29,6 +29,8 @@ func ForemanPartitionTableToInstanceState(obj api.ForemanPartitionTable) *terraf
 	state.ID = strconv.Itoa(obj.Id)
 	// Build the attribute map from ForemanPartitionTable
 	attr := map[string]string{}
+	attr["created_at"] = obj.CreatedAt
+	attr["updated_at"] = obj.UpdatedAt
 	attr["name"] = obj.Name
 	attr["layout"] = obj.Layout
 	attr["snippet"] = fmt.Sprintf("%t", obj.Snippet)
@@ -354,7 +356,7 @@ func ResourceForemanPartitionTableRequestDataTestCases(t *testing.T) []TestCaseR
 
 	rd := MockForemanPartitionTableResourceData(s)
 	obj = *buildForemanPartitionTable(rd)
-	reqData, _ := json.Marshal(obj)
+	reqData, _ := api.WrapJson("ptable", obj)
 
 	return []TestCaseRequestData{
 		TestCaseRequestData{
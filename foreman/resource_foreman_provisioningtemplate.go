@@ -1,6 +1,8 @@
 package foreman
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"strconv"
 
@@ -9,8 +11,9 @@ import (
 	"github.com/wayfair/terraform-provider-utils/conv"
 	"github.com/wayfair/terraform-provider-utils/log"
 
-	"github.com/hashicorp/terraform/helper/schema"
-	"github.com/hashicorp/terraform/helper/validation"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/pmezard/go-difflib/difflib"
 )
 
 func resourceForemanProvisioningTemplate() *schema.Resource {
@@ -21,6 +24,8 @@ func resourceForemanProvisioningTemplate() *schema.Resource {
 		Update: resourceForemanProvisioningTemplateUpdate,
 		Delete: resourceForemanProvisioningTemplateDelete,
 
+		CustomizeDiff: resourceForemanProvisioningTemplateCustomizeDiff,
+
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
 		},
@@ -116,6 +121,24 @@ func resourceForemanProvisioningTemplate() *schema.Resource {
 					"and environment ID combinations so they can be used in the " +
 					"provisioning template selection described above.",
 			},
+
+			"preview_host_id": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+				Description: "ID of a representative host the template is rendered " +
+					"for while planning a change of its content. A unified diff of " +
+					"the rendered output is logged with the plan, and the plan " +
+					"fails if the changed content cannot be rendered (ie: ERB " +
+					"errors). Requires an account which may use the template " +
+					"editor's preview.",
+			},
+
+			"location_ids":     locationIdsSchema("provisioning template"),
+			"organization_ids": organizationIdsSchema("provisioning template"),
+
+			"created_at": createdAtSchema(),
+			"updated_at": updatedAtSchema(),
 		},
 	}
 }
@@ -167,6 +190,7 @@ func buildForemanProvisioningTemplate(d *schema.ResourceData) *api.ForemanProvis
 
 	obj := buildForemanObject(d)
 	template.ForemanObject = *obj
+	template.ForemanTaxonomies = buildForemanTaxonomies(d)
 
 	var attr interface{}
 	var ok bool
@@ -272,6 +296,8 @@ func setResourceDataFromForemanProvisioningTemplate(d *schema.ResourceData, ft *
 	log.Tracef("resource_foreman_provisioningtemplate.go#setResourceDataFromForemanProvisioningTemplate")
 
 	d.SetId(strconv.Itoa(ft.Id))
+	setResourceDataFromForemanObjectTimestamps(d, &ft.ForemanObject)
+	setResourceDataFromForemanTaxonomies(d, &ft.ForemanTaxonomies)
 
 	d.Set("name", ft.Name)
 	d.Set("template", ft.Template)
@@ -352,7 +378,7 @@ func resourceForemanProvisioningTemplateRead(d *schema.ResourceData, meta interf
 
 	readTemplate, readErr := client.ReadProvisioningTemplate(t.Id)
 	if readErr != nil {
-		return readErr
+		return handleReadError(d, readErr)
 	}
 
 	log.Debugf("Read ForemanProvisioningTemplate: [%+v]", readTemplate)
@@ -469,3 +495,71 @@ func resourceForemanProvisioningTemplateDelete(d *schema.ResourceData, meta inte
 	//   returns no errors
 	return client.DeleteProvisioningTemplate(t.Id)
 }
+
+// -----------------------------------------------------------------------------
+// Plan-time Validation
+// -----------------------------------------------------------------------------
+
+// resourceForemanProvisioningTemplateCustomizeDiff renders the changed
+// content of the template for the host of "preview_host_id" and logs a
+// unified diff of the rendered output.  Render errors of the changed content
+// fail the plan.  Failing previews of the current content, or previews which
+// Foreman refuses for other reasons, are only logged.
+func resourceForemanProvisioningTemplateCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	log.Tracef("resource_foreman_provisioningtemplate.go#CustomizeDiff")
+
+	client, ok := meta.(*api.Client)
+	hostId, _ := d.Get("preview_host_id").(int)
+	if !ok || client == nil || hostId == 0 || d.Id() == "" ||
+		!d.HasChange("template") || !d.NewValueKnown("template") {
+		return nil
+	}
+	id, atoiErr := strconv.Atoi(d.Id())
+	if atoiErr != nil {
+		return nil
+	}
+	oldTemplate, newTemplate := d.GetChange("template")
+
+	renderedNew, previewErr := client.PreviewProvisioningTemplate(id, hostId, newTemplate.(string))
+	if previewErr != nil {
+		var renderErr *api.TemplateRenderError
+		if errors.As(previewErr, &renderErr) {
+			return renderErr
+		}
+		log.Warningf("Provisioning template [%d] could not be previewed: %s", id, previewErr)
+		return nil
+	}
+	renderedOld, previewErr := client.PreviewProvisioningTemplate(id, hostId, oldTemplate.(string))
+	if previewErr != nil {
+		log.Warningf(
+			"Current content of provisioning template [%d] could not be previewed: %s",
+			id,
+			previewErr,
+		)
+	}
+
+	log.Infof(
+		"Rendered changes of provisioning template [%d] for host [%d]:\n%s",
+		id,
+		hostId,
+		renderedTemplateDiff(renderedOld, renderedNew),
+	)
+
+	return nil
+}
+
+// renderedTemplateDiff returns the unified diff of the supplied rendered
+// template contents
+func renderedTemplateDiff(renderedOld string, renderedNew string) string {
+	diff, diffErr := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        difflib.SplitLines(renderedOld),
+		B:        difflib.SplitLines(renderedNew),
+		FromFile: "rendered (current)",
+		ToFile:   "rendered (planned)",
+		Context:  3,
+	})
+	if diffErr != nil {
+		return diffErr.Error()
+	}
+	return diff
+}
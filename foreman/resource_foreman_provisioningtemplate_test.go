@@ -1,7 +1,9 @@
 package foreman
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"math/rand"
 	"net/http"
@@ -12,8 +14,8 @@ import (
 	"github.com/HanseMerkur/terraform-provider-foreman/foreman/api"
 	tfrand "github.com/wayfair/terraform-provider-utils/rand"
 
-	"github.com/hashicorp/terraform/helper/schema"
-	"github.com/hashicorp/terraform/terraform"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
 
 // -----------------------------------------------------------------------------
@@ -29,6 +31,8 @@ func ForemanProvisioningTemplateToInstanceState(obj api.ForemanProvisioningTempl
 	state.ID = strconv.Itoa(obj.Id)
 	// Build the attribute map from ForemanProvisioningTemplate
 	attr := map[string]string{}
+	attr["created_at"] = obj.CreatedAt
+	attr["updated_at"] = obj.UpdatedAt
 	attr["name"] = obj.Name
 	attr["template"] = obj.Template
 	attr["snippet"] = fmt.Sprintf("%t", obj.Snippet)
@@ -246,6 +250,84 @@ func TestSetResourceDataFromForemanProvisioningTemplate_Value(t *testing.T) {
 
 }
 
+// -----------------------------------------------------------------------------
+// resourceForemanProvisioningTemplateCustomizeDiff
+// -----------------------------------------------------------------------------
+
+// Ensures changed template content is previewed for the preview host, and
+// render errors of the changed content fail the plan
+func TestResourceForemanProvisioningTemplateCustomizeDiff_Preview(t *testing.T) {
+
+	mux, server, client := NewForemanAPIAndClient(api.ClientCredentials{}, api.ClientConfig{})
+	defer server.Close()
+
+	previews := []string{}
+	mux.HandleFunc("/templates/provisioning_templates/3/preview", func(w http.ResponseWriter, r *http.Request) {
+		var preview struct {
+			Template      string `json:"template"`
+			PreviewHostId int    `json:"preview_host_id"`
+		}
+		json.NewDecoder(r.Body).Decode(&preview)
+		previews = append(previews, fmt.Sprintf("%d %s", preview.PreviewHostId, preview.Template))
+		if preview.Template == "<%= broken" {
+			w.WriteHeader(http.StatusNotAcceptable)
+			fmt.Fprint(w, "unterminated ERB tag")
+			return
+		}
+		fmt.Fprint(w, "rendered "+preview.Template)
+	})
+
+	r := resourceForemanProvisioningTemplate()
+	state := &terraform.InstanceState{
+		ID: "3",
+		Attributes: map[string]string{
+			"id":              "3",
+			"name":            "kickstart",
+			"template":        "old",
+			"preview_host_id": "5",
+		},
+	}
+	config := func(template string) *terraform.ResourceConfig {
+		return terraform.NewResourceConfigRaw(map[string]interface{}{
+			"name":            "kickstart",
+			"template":        template,
+			"preview_host_id": 5,
+		})
+	}
+
+	if _, diffErr := r.Diff(context.Background(), state, config("new"), client); diffErr != nil {
+		t.Fatalf("expected no error, got [%s]", diffErr)
+	}
+	expected := []string{"5 new", "5 old"}
+	if !reflect.DeepEqual(expected, previews) {
+		t.Fatalf("expected the previews [%v], got [%v]", expected, previews)
+	}
+
+	_, diffErr := r.Diff(context.Background(), state, config("<%= broken"), client)
+	var renderErr *api.TemplateRenderError
+	if !errors.As(diffErr, &renderErr) || renderErr.Message != "unterminated ERB tag" {
+		t.Fatalf("expected a render error, got [%v]", diffErr)
+	}
+
+	// unchanged content is not previewed
+	previews = []string{}
+	if _, diffErr := r.Diff(context.Background(), state, config("old"), client); diffErr != nil || len(previews) > 0 {
+		t.Fatalf("expected no previews, got [%v] (%v)", previews, diffErr)
+	}
+
+}
+
+// Ensures the diff of the rendered content is a unified diff
+func TestRenderedTemplateDiff(t *testing.T) {
+
+	diff := renderedTemplateDiff("a\nb", "a\nc")
+	expected := "--- rendered (current)\n+++ rendered (planned)\n@@ -1,2 +1,2 @@\n a\n-b\n+c\n"
+	if diff != expected {
+		t.Fatalf("expected the diff [%q], got [%q]", expected, diff)
+	}
+
+}
+
 // ----------------------------------------------------------------------------
 // Test Cases for the Unit Test Framework
 // ----------------------------------------------------------------------------
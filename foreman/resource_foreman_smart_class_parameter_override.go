@@ -0,0 +1,236 @@
+package foreman
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/HanseMerkur/terraform-provider-foreman/foreman/api"
+	"github.com/wayfair/terraform-provider-utils/autodoc"
+	"github.com/wayfair/terraform-provider-utils/log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/structure"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceForemanSmartClassParameterOverride() *schema.Resource {
+	return &schema.Resource{
+
+		Create: resourceForemanSmartClassParameterOverrideCreate,
+		Read:   resourceForemanSmartClassParameterOverrideRead,
+		Update: resourceForemanSmartClassParameterOverrideUpdate,
+		Delete: resourceForemanSmartClassParameterOverrideDelete,
+
+		Importer: &schema.ResourceImporter{
+			State: resourceForemanSmartClassParameterOverrideImport,
+		},
+
+		Schema: map[string]*schema.Schema{
+
+			autodoc.MetaAttribute: &schema.Schema{
+				Type:     schema.TypeBool,
+				Computed: true,
+				Description: fmt.Sprintf(
+					"%s Value of a smart class parameter (a parameter of a Puppet "+
+						"class) overridden for a single host. The override value "+
+						"matches the FQDN of the host (\"fqdn=<host>\"), so it is "+
+						"created and cleaned up along with the host. The import ID "+
+						"has the form \"<smart class parameter id>/<override value "+
+						"id>/<host id>\".",
+					autodoc.MetaSummary,
+				),
+			},
+
+			"smart_class_parameter_id": &schema.Schema{
+				Type:         schema.TypeInt,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+				Description:  "ID of the smart class parameter to override.",
+			},
+			"host_id": &schema.Schema{
+				Type:         schema.TypeInt,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+				Description:  "ID of the host the value is overridden for.",
+			},
+			"value": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				// NOTE(ALL): Foreman returns typed values in its own JSON
+				//   formatting
+				DiffSuppressFunc: structure.SuppressJsonDiff,
+				Description: "The value for the host, encoded as the type of the " +
+					"smart class parameter (ie: a JSON object for parameters of " +
+					"type \"hash\").",
+			},
+			"omit": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+				Description: "Whether or not the parameter is omitted from the " +
+					"Puppet class of the host, instead of overriding its value.",
+			},
+			"match": &schema.Schema{
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The matcher of the override value (ie: \"fqdn=host.example.com\").",
+			},
+		},
+	}
+}
+
+// -----------------------------------------------------------------------------
+// Conversion Helpers
+// -----------------------------------------------------------------------------
+
+// buildForemanOverrideValue constructs a ForemanOverrideValue reference from a
+// resource data reference.  The matcher is not set, see
+// foremanHostFQDNMatcher.
+func buildForemanOverrideValue(d *schema.ResourceData) *api.ForemanOverrideValue {
+	log.Tracef("resource_foreman_smart_class_parameter_override.go#buildForemanOverrideValue")
+
+	overrideValue := api.ForemanOverrideValue{}
+
+	overrideValue.Id, _ = strconv.Atoi(d.Id())
+	overrideValue.SmartClassParameterId = d.Get("smart_class_parameter_id").(int)
+	overrideValue.Match = d.Get("match").(string)
+	overrideValue.Value = d.Get("value").(string)
+	overrideValue.Omit = d.Get("omit").(bool)
+
+	return &overrideValue
+}
+
+// setResourceDataFromForemanOverrideValue sets a ResourceData's attributes
+// from the attributes of the supplied ForemanOverrideValue reference
+func setResourceDataFromForemanOverrideValue(d *schema.ResourceData, fo *api.ForemanOverrideValue) {
+	log.Tracef("resource_foreman_smart_class_parameter_override.go#setResourceDataFromForemanOverrideValue")
+
+	d.SetId(strconv.Itoa(fo.Id))
+	d.Set("smart_class_parameter_id", fo.SmartClassParameterId)
+	d.Set("match", fo.Match)
+	d.Set("value", fo.Value)
+	d.Set("omit", fo.Omit)
+}
+
+// foremanHostFQDNMatcher returns the override value matcher of the host
+// identified by the supplied ID
+func foremanHostFQDNMatcher(client *api.Client, hostId int) (string, error) {
+	readHost, readErr := client.ReadHost(hostId)
+	if readErr != nil {
+		return "", readErr
+	}
+	// NOTE(ALL): the domain is stripped from the name of read hosts
+	fqdn := readHost.Name
+	if readHost.DomainName != "" {
+		fqdn = fqdn + "." + readHost.DomainName
+	}
+	return "fqdn=" + fqdn, nil
+}
+
+// -----------------------------------------------------------------------------
+// Resource CRUD Operations
+// -----------------------------------------------------------------------------
+
+// resourceForemanSmartClassParameterOverrideImport imports an override value
+// of a host.  The import ID has the form
+// "<smart class parameter id>/<override value id>/<host id>".
+func resourceForemanSmartClassParameterOverrideImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	log.Tracef("resource_foreman_smart_class_parameter_override.go#Import")
+
+	ids, splitErr := splitCompositeImportId(d.Id(), 3)
+	if splitErr != nil {
+		return nil, splitErr
+	}
+	d.Set("smart_class_parameter_id", ids[0])
+	d.SetId(strconv.Itoa(ids[1]))
+	d.Set("host_id", ids[2])
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func resourceForemanSmartClassParameterOverrideCreate(d *schema.ResourceData, meta interface{}) error {
+	log.Tracef("resource_foreman_smart_class_parameter_override.go#Create")
+
+	client := meta.(*api.Client)
+	overrideValue := buildForemanOverrideValue(d)
+
+	match, matchErr := foremanHostFQDNMatcher(client, d.Get("host_id").(int))
+	if matchErr != nil {
+		return matchErr
+	}
+	overrideValue.Match = match
+
+	log.Debugf("ForemanOverrideValue: [%+v]", overrideValue)
+
+	createdOverrideValue, createErr := client.CreateOverrideValue(overrideValue)
+	if createErr != nil {
+		return createErr
+	}
+
+	log.Debugf("Created ForemanOverrideValue: [%+v]", createdOverrideValue)
+
+	setResourceDataFromForemanOverrideValue(d, createdOverrideValue)
+
+	return nil
+}
+
+func resourceForemanSmartClassParameterOverrideRead(d *schema.ResourceData, meta interface{}) error {
+	log.Tracef("resource_foreman_smart_class_parameter_override.go#Read")
+
+	client := meta.(*api.Client)
+	overrideValue := buildForemanOverrideValue(d)
+
+	log.Debugf("ForemanOverrideValue: [%+v]", overrideValue)
+
+	readOverrideValue, readErr := client.ReadOverrideValue(overrideValue.SmartClassParameterId, overrideValue.Id)
+	if readErr != nil {
+		return handleReadError(d, readErr)
+	}
+
+	log.Debugf("Read ForemanOverrideValue: [%+v]", readOverrideValue)
+
+	setResourceDataFromForemanOverrideValue(d, readOverrideValue)
+
+	return nil
+}
+
+func resourceForemanSmartClassParameterOverrideUpdate(d *schema.ResourceData, meta interface{}) error {
+	log.Tracef("resource_foreman_smart_class_parameter_override.go#Update")
+
+	client := meta.(*api.Client)
+	overrideValue := buildForemanOverrideValue(d)
+
+	log.Debugf("ForemanOverrideValue: [%+v]", overrideValue)
+
+	updatedOverrideValue, updateErr := client.UpdateOverrideValue(overrideValue)
+	if updateErr != nil {
+		return updateErr
+	}
+
+	log.Debugf("Updated ForemanOverrideValue: [%+v]", updatedOverrideValue)
+
+	setResourceDataFromForemanOverrideValue(d, updatedOverrideValue)
+
+	return nil
+}
+
+func resourceForemanSmartClassParameterOverrideDelete(d *schema.ResourceData, meta interface{}) error {
+	log.Tracef("resource_foreman_smart_class_parameter_override.go#Delete")
+
+	client := meta.(*api.Client)
+	overrideValue := buildForemanOverrideValue(d)
+
+	log.Debugf("ForemanOverrideValue: [%+v]", overrideValue)
+
+	// NOTE(ALL): Foreman deletes the override values matching the FQDN of a
+	//   host along with the host - the value is gone if the host was
+	//   destroyed first
+	deleteErr := client.DeleteOverrideValue(overrideValue.SmartClassParameterId, overrideValue.Id)
+	if deleteErr != nil && !api.IsNotFound(deleteErr) {
+		return deleteErr
+	}
+
+	return nil
+}
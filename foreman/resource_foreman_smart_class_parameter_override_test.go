@@ -0,0 +1,58 @@
+package foreman
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/HanseMerkur/terraform-provider-foreman/foreman/api"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// -----------------------------------------------------------------------------
+// resourceForemanSmartClassParameterOverride
+// -----------------------------------------------------------------------------
+
+// Ensures the override value is created with the FQDN matcher of the host and
+// typed values are read back as their JSON representation
+func TestResourceForemanSmartClassParameterOverride(t *testing.T) {
+
+	mux, server, client := NewForemanAPIAndClient(api.ClientCredentials{}, api.ClientConfig{})
+	defer server.Close()
+
+	mux.HandleFunc(api.FOREMAN_API_URL_PREFIX+"/hosts/7", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id": 7, "name": "web01.example.com", "domain_name": "example.com"}`)
+	})
+	var sent struct {
+		OverrideValue api.ForemanOverrideValue `json:"override_value"`
+	}
+	mux.HandleFunc(api.FOREMAN_API_URL_PREFIX+"/smart_class_parameters/3/override_values", func(w http.ResponseWriter, r *http.Request) {
+		json.NewDecoder(r.Body).Decode(&sent)
+		fmt.Fprint(w, `{"id": 11, "match": "fqdn=web01.example.com", "value": {"port": 8080}, "omit": false}`)
+	})
+
+	r := resourceForemanSmartClassParameterOverride()
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"smart_class_parameter_id": 3,
+		"host_id":                  7,
+		"value":                    `{"port": 8080}`,
+	})
+
+	if createErr := r.Create(d, client); createErr != nil {
+		t.Fatalf("expected no error, got [%s]", createErr)
+	}
+	if sent.OverrideValue.Match != "fqdn=web01.example.com" {
+		t.Fatalf("expected the matcher [fqdn=web01.example.com], got [%s]", sent.OverrideValue.Match)
+	}
+	if d.Id() != "11" || d.Get("value").(string) != `{"port": 8080}` {
+		t.Fatalf("expected the ID [11] and the JSON value, got [%s] [%s]", d.Id(), d.Get("value"))
+	}
+
+	suppress := r.Schema["value"].DiffSuppressFunc
+	if !suppress("value", `{"port":8080}`, `{ "port": 8080 }`, d) {
+		t.Fatalf("expected equal JSON values to be suppressed")
+	}
+
+}
@@ -1,6 +1,7 @@
 package foreman
 
 import (
+	"context"
 	"fmt"
 	"regexp"
 	"strconv"
@@ -9,8 +10,8 @@ import (
 	"github.com/wayfair/terraform-provider-utils/autodoc"
 	"github.com/wayfair/terraform-provider-utils/log"
 
-	"github.com/hashicorp/terraform/helper/schema"
-	"github.com/hashicorp/terraform/helper/validation"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 const (
@@ -40,6 +41,8 @@ func resourceForemanSmartProxy() *schema.Resource {
 			State: schema.ImportStatePassthrough,
 		},
 
+		CustomizeDiff: resourceForemanSmartProxyCustomizeDiff,
+
 		Schema: map[string]*schema.Schema{
 
 			autodoc.MetaAttribute: &schema.Schema{
@@ -79,6 +82,31 @@ func resourceForemanSmartProxy() *schema.Resource {
 					autodoc.MetaExample,
 				),
 			},
+
+			"refresh_trigger": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Description: "Changing the value makes Foreman detect the features " +
+					"of the smart proxy again, so features enabled on the proxy " +
+					"(ie: DHCP, Templates) are usable by the resources of the same " +
+					"apply. Any value can be used, ie: the version of the proxy's " +
+					"configuration.",
+			},
+			"features": &schema.Schema{
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Description: "Names of the features the smart proxy provides (ie: " +
+					"`\"DHCP\"`, `\"TFTP\"`), as detected by Foreman.",
+			},
+
+			"location_ids":     locationIdsSchema("smart proxy"),
+			"organization_ids": organizationIdsSchema("smart proxy"),
+
+			"created_at": createdAtSchema(),
+			"updated_at": updatedAtSchema(),
 		},
 	}
 }
@@ -98,6 +126,7 @@ func buildForemanSmartProxy(d *schema.ResourceData) *api.ForemanSmartProxy {
 
 	obj := buildForemanObject(d)
 	proxy.ForemanObject = *obj
+	proxy.ForemanTaxonomies = buildForemanTaxonomies(d)
 
 	proxy.URL = d.Get("url").(string)
 
@@ -110,8 +139,26 @@ func setResourceDataFromForemanSmartProxy(d *schema.ResourceData, fp *api.Forema
 	log.Tracef("resource_foreman_smartproxy.go#setResourceDataFromForemanSmartProxy")
 
 	d.SetId(strconv.Itoa(fp.Id))
+	setResourceDataFromForemanObjectTimestamps(d, &fp.ForemanObject)
+	setResourceDataFromForemanTaxonomies(d, &fp.ForemanTaxonomies)
 	d.Set("name", fp.Name)
 	d.Set("url", fp.URL)
+
+	features := []string{}
+	for _, feature := range fp.Features {
+		features = append(features, feature.Name)
+	}
+	d.Set("features", features)
+}
+
+// resourceForemanSmartProxyCustomizeDiff plans the features of the smart proxy
+// as unknown when its refresh is triggered, so resources depending on them
+// use the refreshed features
+func resourceForemanSmartProxyCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if d.Id() != "" && d.HasChange("refresh_trigger") {
+		return d.SetNewComputed("features")
+	}
+	return nil
 }
 
 // -----------------------------------------------------------------------------
@@ -148,7 +195,7 @@ func resourceForemanSmartProxyRead(d *schema.ResourceData, meta interface{}) err
 
 	readSmartProxy, readErr := client.ReadSmartProxy(s.Id)
 	if readErr != nil {
-		return readErr
+		return handleReadError(d, readErr)
 	}
 
 	log.Debugf("Read ForemanSmartProxy: [%+v]", readSmartProxy)
@@ -173,6 +220,14 @@ func resourceForemanSmartProxyUpdate(d *schema.ResourceData, meta interface{}) e
 
 	log.Debugf("ForemanSmartProxy: [%+v]", updatedSmartProxy)
 
+	if d.HasChange("refresh_trigger") {
+		refreshedSmartProxy, refreshErr := client.RefreshSmartProxy(s.Id)
+		if refreshErr != nil {
+			return refreshErr
+		}
+		updatedSmartProxy = refreshedSmartProxy
+	}
+
 	setResourceDataFromForemanSmartProxy(d, updatedSmartProxy)
 
 	return nil
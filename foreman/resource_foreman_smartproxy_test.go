@@ -2,6 +2,7 @@ package foreman
 
 import (
 	"encoding/json"
+	"fmt"
 	"math/rand"
 	"net/http"
 	"reflect"
@@ -11,8 +12,8 @@ import (
 	"github.com/HanseMerkur/terraform-provider-foreman/foreman/api"
 	tfrand "github.com/wayfair/terraform-provider-utils/rand"
 
-	"github.com/hashicorp/terraform/helper/schema"
-	"github.com/hashicorp/terraform/terraform"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
 
 // -----------------------------------------------------------------------------
@@ -28,6 +29,8 @@ func ForemanSmartProxyToInstanceState(obj api.ForemanSmartProxy) *terraform.Inst
 	state.ID = strconv.Itoa(obj.Id)
 	// Build the attribute map from ForemanSmartProxy
 	attr := map[string]string{}
+	attr["created_at"] = obj.CreatedAt
+	attr["updated_at"] = obj.UpdatedAt
 	attr["name"] = obj.Name
 	attr["url"] = obj.URL
 	state.Attributes = attr
@@ -162,6 +165,49 @@ func TestSetResourceDataFromForemanSmartProxy_Value(t *testing.T) {
 
 }
 
+// -----------------------------------------------------------------------------
+// resourceForemanSmartProxyUpdate
+// -----------------------------------------------------------------------------
+
+// Ensures a changed refresh_trigger refreshes the features of the smart proxy
+// and the refreshed features are set
+func TestResourceForemanSmartProxyUpdate_RefreshTrigger(t *testing.T) {
+
+	mux, server, client := NewForemanAPIAndClient(api.ClientCredentials{}, api.ClientConfig{})
+	defer server.Close()
+
+	refreshed := false
+	mux.HandleFunc(SmartProxiesURI+"/3", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id": 3, "name": "proxy", "url": "https://proxy:8443", "features": [{"id": 1, "name": "TFTP"}]}`)
+	})
+	mux.HandleFunc(SmartProxiesURI+"/3/refresh", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut {
+			t.Errorf("expected a [PUT] request, got [%s]", r.Method)
+		}
+		refreshed = true
+		fmt.Fprint(w, `{"id": 3, "name": "proxy", "url": "https://proxy:8443", "features": [{"id": 1, "name": "TFTP"}, {"id": 2, "name": "DHCP"}]}`)
+	})
+
+	d := schema.TestResourceDataRaw(t, resourceForemanSmartProxy().Schema, map[string]interface{}{
+		"name":            "proxy",
+		"url":             "https://proxy:8443",
+		"refresh_trigger": "1",
+	})
+	d.SetId("3")
+
+	if updateErr := resourceForemanSmartProxyUpdate(d, client); updateErr != nil {
+		t.Fatalf("expected no error, got [%s]", updateErr)
+	}
+	if !refreshed {
+		t.Fatalf("expected the features of the smart proxy to be refreshed")
+	}
+	expected := []interface{}{"TFTP", "DHCP"}
+	if actual := d.Get("features"); !reflect.DeepEqual(expected, actual) {
+		t.Fatalf("expected the features [%v], got [%v]", expected, actual)
+	}
+
+}
+
 // ----------------------------------------------------------------------------
 // Test Cases for the Unit Test Framework
 // ----------------------------------------------------------------------------
@@ -245,7 +291,7 @@ func ResourceForemanSmartProxyRequestDataTestCases(t *testing.T) []TestCaseReque
 
 	rd := MockForemanSmartProxyResourceData(s)
 	obj = *buildForemanSmartProxy(rd)
-	reqData, _ := json.Marshal(obj)
+	reqData, _ := api.WrapJson("smart_proxy", obj)
 
 	return []TestCaseRequestData{
 		TestCaseRequestData{
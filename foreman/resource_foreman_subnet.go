@@ -1,6 +1,7 @@
 package foreman
 
 import (
+	"context"
 	"fmt"
 	"strconv"
 
@@ -8,8 +9,8 @@ import (
 	"github.com/wayfair/terraform-provider-utils/autodoc"
 	"github.com/wayfair/terraform-provider-utils/log"
 
-	"github.com/hashicorp/terraform/helper/schema"
-	"github.com/hashicorp/terraform/helper/validation"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceForemanSubnet() *schema.Resource {
@@ -20,6 +21,8 @@ func resourceForemanSubnet() *schema.Resource {
 		Update: resourceForemanSubnetUpdate,
 		Delete: resourceForemanSubnetDelete,
 
+		CustomizeDiff: resourceForemanSubnetCustomizeDiff,
+
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
 		},
@@ -48,7 +51,7 @@ func resourceForemanSubnet() *schema.Resource {
 			"network": &schema.Schema{
 				Type:         schema.TypeString,
 				Required:     true,
-				ValidateFunc: validation.SingleIP(),
+				ValidateFunc: validation.IsIPAddress,
 				Description: fmt.Sprintf(
 					"Subnet network. "+
 						"%s \"10.228.247.0\"",
@@ -59,7 +62,7 @@ func resourceForemanSubnet() *schema.Resource {
 			"mask": &schema.Schema{
 				Type:         schema.TypeString,
 				Required:     true,
-				ValidateFunc: validation.SingleIP(),
+				ValidateFunc: validation.IsIPAddress,
 				Description: fmt.Sprintf(
 					"Netmask for this subnet. "+
 						"%s \"255.255.255.0\"",
@@ -70,7 +73,7 @@ func resourceForemanSubnet() *schema.Resource {
 			"gateway": &schema.Schema{
 				Type:         schema.TypeString,
 				Optional:     true,
-				ValidateFunc: validation.SingleIP(),
+				ValidateFunc: validation.IsIPAddress,
 				Description: "Gateway server to use when connecting/communicating to " +
 					"anything not on the same network.",
 			},
@@ -78,14 +81,14 @@ func resourceForemanSubnet() *schema.Resource {
 			"dns_primary": &schema.Schema{
 				Type:         schema.TypeString,
 				Optional:     true,
-				ValidateFunc: validation.SingleIP(),
+				ValidateFunc: validation.IsIPAddress,
 				Description:  "Primary DNS server for this subnet.",
 			},
 
 			"dns_secondary": &schema.Schema{
 				Type:         schema.TypeString,
 				Optional:     true,
-				ValidateFunc: validation.SingleIP(),
+				ValidateFunc: validation.IsIPAddress,
 				Description:  "Secondary DNS sever for this subnet.",
 			},
 
@@ -105,14 +108,14 @@ func resourceForemanSubnet() *schema.Resource {
 			"from": &schema.Schema{
 				Type:         schema.TypeString,
 				Optional:     true,
-				ValidateFunc: validation.SingleIP(),
+				ValidateFunc: validation.IsIPAddress,
 				Description:  "Start IP address for IP auto suggestion.",
 			},
 
 			"to": &schema.Schema{
 				Type:         schema.TypeString,
 				Optional:     true,
-				ValidateFunc: validation.SingleIP(),
+				ValidateFunc: validation.IsIPAddress,
 				Description:  "Ending IP address for IP auto suggestion.",
 			},
 
@@ -127,6 +130,27 @@ func resourceForemanSubnet() *schema.Resource {
 				Description: "Default boot mode for instances assigned to this subnet. " +
 					"Values include: `\"Static\"`, `\"DHCP\"`.",
 			},
+
+			"bmc_id": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+				Description: "ID of the smart proxy performing the BMC operations " +
+					"(ie: power, boot device) of the hosts with a BMC interface in " +
+					"this subnet. The smart proxy must provide the BMC feature. When " +
+					"unset, Foreman uses any smart proxy with the BMC feature.",
+			},
+
+			"parameters":        parametersSchema("subnet"),
+			"hidden_parameters": hiddenParametersSchema("subnet"),
+			"parameter_types":   parameterTypesSchema("subnet"),
+
+			"location_ids":     locationIdsSchema("subnet"),
+			"organization_ids": organizationIdsSchema("subnet"),
+
+			"created_at": createdAtSchema(),
+			"updated_at": updatedAtSchema(),
 		},
 	}
 }
@@ -146,6 +170,7 @@ func buildForemanSubnet(d *schema.ResourceData) *api.ForemanSubnet {
 
 	obj := buildForemanObject(d)
 	s.ForemanObject = *obj
+	s.ForemanTaxonomies = buildForemanTaxonomies(d)
 
 	s.Network = d.Get("network").(string)
 	s.Mask = d.Get("mask").(string)
@@ -174,6 +199,10 @@ func buildForemanSubnet(d *schema.ResourceData) *api.ForemanSubnet {
 	if attr, ok = d.GetOk("boot_mode"); ok {
 		s.BootMode = attr.(string)
 	}
+	if attr, ok = d.GetOk("bmc_id"); ok {
+		s.BMCId = attr.(int)
+	}
+	s.SubnetParameters = buildForemanKVParameters(d)
 
 	return &s
 }
@@ -184,6 +213,8 @@ func setResourceDataFromForemanSubnet(d *schema.ResourceData, fs *api.ForemanSub
 	log.Tracef("resource_foreman_subnet.go#setResourceDataFromForemanSubnet")
 
 	d.SetId(strconv.Itoa(fs.Id))
+	setResourceDataFromForemanObjectTimestamps(d, &fs.ForemanObject)
+	setResourceDataFromForemanTaxonomies(d, &fs.ForemanTaxonomies)
 	d.Set("name", fs.Name)
 	d.Set("network", fs.Network)
 	d.Set("mask", fs.Mask)
@@ -194,6 +225,40 @@ func setResourceDataFromForemanSubnet(d *schema.ResourceData, fs *api.ForemanSub
 	d.Set("from", fs.From)
 	d.Set("to", fs.To)
 	d.Set("boot_mode", fs.BootMode)
+	d.Set("bmc_id", fs.BMCId)
+	setResourceDataFromForemanKVParameters(d, fs.SubnetParameters)
+}
+
+// resourceForemanSubnetCustomizeDiff verifies at plan time that the smart
+// proxy selected for the BMC operations provides the BMC feature.  Foreman
+// only rejects the proxy once a host in the subnet performs a BMC operation.
+func resourceForemanSubnetCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if !d.HasChange("bmc_id") || !d.NewValueKnown("bmc_id") {
+		return nil
+	}
+	bmcId, _ := d.Get("bmc_id").(int)
+	if bmcId == 0 {
+		return nil
+	}
+	return validateForemanBMCProxy(meta.(*api.Client), bmcId)
+}
+
+// validateForemanBMCProxy returns an error if the smart proxy identified by
+// the supplied ID does not exist or does not provide the BMC feature
+func validateForemanBMCProxy(client *api.Client, id int) error {
+	proxy, readErr := client.ReadSmartProxy(id)
+	if readErr != nil {
+		return fmt.Errorf("bmc_id [%d] could not be verified: %s", id, readErr)
+	}
+	if !proxy.HasFeature(api.SmartProxyFeatureBMC) {
+		return fmt.Errorf(
+			"bmc_id [%d]: smart proxy [%s] does not provide the [%s] feature",
+			id,
+			proxy.Name,
+			api.SmartProxyFeatureBMC,
+		)
+	}
+	return nil
 }
 
 // -----------------------------------------------------------------------------
@@ -202,6 +267,21 @@ func setResourceDataFromForemanSubnet(d *schema.ResourceData, fs *api.ForemanSub
 
 func resourceForemanSubnetCreate(d *schema.ResourceData, meta interface{}) error {
 	log.Tracef("resource_foreman_subnet.go#Create")
+
+	client := meta.(*api.Client)
+	s := buildForemanSubnet(d)
+
+	log.Debugf("ForemanSubnet: [%+v]", s)
+
+	createdSubnet, createErr := client.CreateSubnet(s)
+	if createErr != nil {
+		return createErr
+	}
+
+	log.Debugf("Created ForemanSubnet: [%+v]", createdSubnet)
+
+	setResourceDataFromForemanSubnet(d, createdSubnet)
+
 	return nil
 }
 
@@ -215,7 +295,7 @@ func resourceForemanSubnetRead(d *schema.ResourceData, meta interface{}) error {
 
 	readSubnet, readErr := client.ReadSubnet(s.Id)
 	if readErr != nil {
-		return readErr
+		return handleReadError(d, readErr)
 	}
 
 	log.Debugf("Read ForemanSubnet: [%+v]", readSubnet)
@@ -227,14 +307,33 @@ func resourceForemanSubnetRead(d *schema.ResourceData, meta interface{}) error {
 
 func resourceForemanSubnetUpdate(d *schema.ResourceData, meta interface{}) error {
 	log.Tracef("resource_foreman_subnet.go#Update")
+
+	client := meta.(*api.Client)
+	s := buildForemanSubnet(d)
+
+	log.Debugf("ForemanSubnet: [%+v]", s)
+
+	updatedSubnet, updateErr := client.UpdateSubnet(s)
+	if updateErr != nil {
+		return updateErr
+	}
+
+	log.Debugf("Updated ForemanSubnet: [%+v]", updatedSubnet)
+
+	setResourceDataFromForemanSubnet(d, updatedSubnet)
+
 	return nil
 }
 
 func resourceForemanSubnetDelete(d *schema.ResourceData, meta interface{}) error {
 	log.Tracef("resource_foreman_subnet.go#Delete")
 
+	client := meta.(*api.Client)
+	s := buildForemanSubnet(d)
+
+	log.Debugf("ForemanSubnet: [%+v]", s)
+
 	// NOTE(ALL): d.SetId("") is automatically called by terraform assuming delete
 	//   returns no errors
-
-	return nil
+	return client.DeleteSubnet(s.Id)
 }
@@ -2,17 +2,19 @@ package foreman
 
 import (
 	"encoding/json"
+	"fmt"
 	"math/rand"
 	"net/http"
 	"reflect"
+	"sort"
 	"strconv"
 	"testing"
 
 	"github.com/HanseMerkur/terraform-provider-foreman/foreman/api"
 	tfrand "github.com/wayfair/terraform-provider-utils/rand"
 
-	"github.com/hashicorp/terraform/helper/schema"
-	"github.com/hashicorp/terraform/terraform"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
 
 // -----------------------------------------------------------------------------
@@ -28,6 +30,8 @@ func ForemanSubnetToInstanceState(obj api.ForemanSubnet) *terraform.InstanceStat
 	state.ID = strconv.Itoa(obj.Id)
 	// Build the attribute map from ForemanSubnet
 	attr := map[string]string{}
+	attr["created_at"] = obj.CreatedAt
+	attr["updated_at"] = obj.UpdatedAt
 	attr["name"] = obj.Name
 	attr["network"] = obj.Network
 	attr["mask"] = obj.Mask
@@ -38,6 +42,7 @@ func ForemanSubnetToInstanceState(obj api.ForemanSubnet) *terraform.InstanceStat
 	attr["from"] = obj.From
 	attr["to"] = obj.To
 	attr["boot_mode"] = obj.BootMode
+	attr["bmc_id"] = strconv.Itoa(obj.BMCId)
 	state.Attributes = attr
 	return &state
 }
@@ -74,6 +79,7 @@ func RandForemanSubnet() api.ForemanSubnet {
 	obj.From = tfrand.IPv4Str(tfrand.IPv4PrivateClassCStart, tfrand.IPv4PrivateClassCMask)
 	obj.To = tfrand.IPv4Str(tfrand.IPv4PrivateClassCStart, tfrand.IPv4PrivateClassCMask)
 	obj.BootMode = tfrand.String(5, tfrand.Lower)
+	obj.BMCId = rand.Intn(100) + 1
 
 	return obj
 }
@@ -157,6 +163,110 @@ func TestSetResourceDataFromForemanSubnet_Value(t *testing.T) {
 
 }
 
+// Ensures the parameters are sent with their types and the parameters read
+// back are set on the ResourceData
+func TestForemanSubnetParameters(t *testing.T) {
+
+	d := schema.TestResourceDataRaw(t, resourceForemanSubnet().Schema, map[string]interface{}{
+		"name":              "subnet",
+		"network":           "10.0.0.0",
+		"mask":              "255.255.255.0",
+		"parameters":        map[string]interface{}{"mtu": "9000", "vlan": "12"},
+		"hidden_parameters": map[string]interface{}{"token": "secret"},
+		"parameter_types":   map[string]interface{}{"mtu": "integer"},
+	})
+
+	s := buildForemanSubnet(d)
+	sort.Slice(s.SubnetParameters, func(i, j int) bool {
+		return s.SubnetParameters[i].Name < s.SubnetParameters[j].Name
+	})
+	sJSON, _ := json.Marshal(s)
+	var sMap map[string]interface{}
+	json.Unmarshal(sJSON, &sMap)
+	paramsJSON, _ := json.Marshal(sMap["subnet_parameters_attributes"])
+	expectedJSON := `[` +
+		`{"hidden_value":false,"name":"mtu","parameter_type":"integer","value":"9000"},` +
+		`{"hidden_value":true,"name":"token","value":"secret"},` +
+		`{"hidden_value":false,"name":"vlan","value":"12"}]`
+	if string(paramsJSON) != expectedJSON {
+		t.Fatalf("expected the parameters [%s], got [%s]", expectedJSON, paramsJSON)
+	}
+
+	// NOTE(ALL): Foreman masks the values of hidden parameters unless they
+	//   are explicitly requested
+	mux, server, client := NewForemanAPIAndClient(api.ClientCredentials{}, api.ClientConfig{})
+	defer server.Close()
+	mux.HandleFunc(SubnetsURI+"/2", func(w http.ResponseWriter, r *http.Request) {
+		token := "*****"
+		if r.URL.Query().Get("show_hidden_parameters") == "true" {
+			token = "other"
+		}
+		fmt.Fprintf(w, `{"id": 2, "name": "subnet", "parameters": [
+			{"name": "mtu", "value": 1500, "parameter_type": "integer"},
+			{"name": "token", "value": "%s", "hidden_value?": true, "parameter_type": "string"}
+		]}`, token)
+	})
+	readSubnet, readErr := client.ReadSubnet(2)
+	if readErr != nil {
+		t.Fatalf("expected no error, got [%s]", readErr)
+	}
+	setResourceDataFromForemanSubnet(d, readSubnet)
+
+	expected := map[string]interface{}{
+		"parameters":        map[string]interface{}{"mtu": "1500"},
+		"hidden_parameters": map[string]interface{}{"token": "other"},
+		"parameter_types":   map[string]interface{}{"mtu": "integer"},
+	}
+	for attr, value := range expected {
+		if actual := d.Get(attr); !reflect.DeepEqual(value, actual) {
+			t.Fatalf("expected [%s] to be [%v], got [%v]", attr, value, actual)
+		}
+	}
+
+	// unmanaged parameters are not sent
+	d = schema.TestResourceDataRaw(t, resourceForemanSubnet().Schema, map[string]interface{}{
+		"name": "subnet",
+	})
+	sJSON, _ = json.Marshal(buildForemanSubnet(d))
+	sMap = nil
+	json.Unmarshal(sJSON, &sMap)
+	if _, ok := sMap["subnet_parameters_attributes"]; ok {
+		t.Fatalf("expected no parameters to be sent, got [%s]", sJSON)
+	}
+
+}
+
+// -----------------------------------------------------------------------------
+// validateForemanBMCProxy
+// -----------------------------------------------------------------------------
+
+// Ensures only smart proxies providing the BMC feature can be selected for the
+// BMC operations of a subnet
+func TestValidateForemanBMCProxy(t *testing.T) {
+	cred := api.ClientCredentials{}
+	conf := api.ClientConfig{}
+
+	mux, server, client := NewForemanAPIAndClient(cred, conf)
+	defer server.Close()
+
+	mux.HandleFunc(api.FOREMAN_API_URL_PREFIX+"/smart_proxies/1", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"id": 1, "name": "bmc01", "features": [{"id": 2, "name": "DHCP"}, {"id": 6, "name": "BMC"}]}`))
+	})
+	mux.HandleFunc(api.FOREMAN_API_URL_PREFIX+"/smart_proxies/2", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"id": 2, "name": "dhcp01", "features": [{"id": 2, "name": "DHCP"}]}`))
+	})
+
+	if err := validateForemanBMCProxy(client, 1); err != nil {
+		t.Errorf("Expected smart proxy [1] to be valid, got [%s]", err)
+	}
+	if err := validateForemanBMCProxy(client, 2); err == nil {
+		t.Errorf("Expected smart proxy [2] without the BMC feature to be rejected")
+	}
+	if err := validateForemanBMCProxy(client, 3); err == nil {
+		t.Errorf("Expected the unknown smart proxy [3] to be rejected")
+	}
+}
+
 // ----------------------------------------------------------------------------
 // Test Cases for the Unit Test Framework
 // ----------------------------------------------------------------------------
@@ -170,6 +280,15 @@ func ResourceForemanSubnetCorrectURLAndMethodTestCases(t *testing.T) []TestCaseC
 	architecturesURIById := SubnetsURI + "/" + strconv.Itoa(obj.Id)
 
 	return []TestCaseCorrectURLAndMethod{
+		TestCaseCorrectURLAndMethod{
+			TestCase: TestCase{
+				funcName:     "resourceForemanSubnetCreate",
+				crudFunc:     resourceForemanSubnetCreate,
+				resourceData: MockForemanSubnetResourceData(s),
+			},
+			expectedURI:    SubnetsURI,
+			expectedMethod: http.MethodPost,
+		},
 		TestCaseCorrectURLAndMethod{
 			TestCase: TestCase{
 				funcName:     "resourceForemanSubnetRead",
@@ -179,6 +298,24 @@ func ResourceForemanSubnetCorrectURLAndMethodTestCases(t *testing.T) []TestCaseC
 			expectedURI:    architecturesURIById,
 			expectedMethod: http.MethodGet,
 		},
+		TestCaseCorrectURLAndMethod{
+			TestCase: TestCase{
+				funcName:     "resourceForemanSubnetUpdate",
+				crudFunc:     resourceForemanSubnetUpdate,
+				resourceData: MockForemanSubnetResourceData(s),
+			},
+			expectedURI:    architecturesURIById,
+			expectedMethod: http.MethodPut,
+		},
+		TestCaseCorrectURLAndMethod{
+			TestCase: TestCase{
+				funcName:     "resourceForemanSubnetDelete",
+				crudFunc:     resourceForemanSubnetDelete,
+				resourceData: MockForemanSubnetResourceData(s),
+			},
+			expectedURI:    architecturesURIById,
+			expectedMethod: http.MethodDelete,
+		},
 	}
 
 }
@@ -207,11 +344,26 @@ func ResourceForemanSubnetStatusCodeTestCases(t *testing.T) []TestCase {
 	s := ForemanSubnetToInstanceState(obj)
 
 	return []TestCase{
+		TestCase{
+			funcName:     "resourceForemanSubnetCreate",
+			crudFunc:     resourceForemanSubnetCreate,
+			resourceData: MockForemanSubnetResourceData(s),
+		},
 		TestCase{
 			funcName:     "resourceForemanSubnetRead",
 			crudFunc:     resourceForemanSubnetRead,
 			resourceData: MockForemanSubnetResourceData(s),
 		},
+		TestCase{
+			funcName:     "resourceForemanSubnetUpdate",
+			crudFunc:     resourceForemanSubnetUpdate,
+			resourceData: MockForemanSubnetResourceData(s),
+		},
+		TestCase{
+			funcName:     "resourceForemanSubnetDelete",
+			crudFunc:     resourceForemanSubnetDelete,
+			resourceData: MockForemanSubnetResourceData(s),
+		},
 	}
 }
 
@@ -0,0 +1,208 @@
+package foreman
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/HanseMerkur/terraform-provider-foreman/foreman/api"
+	"github.com/wayfair/terraform-provider-utils/autodoc"
+	"github.com/wayfair/terraform-provider-utils/log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// -----------------------------------------------------------------------------
+// Schema
+// -----------------------------------------------------------------------------
+
+// taskWaitSchema is the schema shared by the foreman_task_wait resource and
+// data source.  The arguments of the resource force a new wait.
+func taskWaitSchema(forceNew bool) map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"task_id": &schema.Schema{
+			Type:         schema.TypeString,
+			Required:     true,
+			ForceNew:     forceNew,
+			ValidateFunc: validation.StringIsNotWhiteSpace,
+			Description: "UUID of the Foreman or Katello task waited for (ie: " +
+				"the task of a repository synchronization, a content view publish " +
+				"or a job invocation).",
+		},
+		"timeout": &schema.Schema{
+			Type:         schema.TypeInt,
+			Optional:     true,
+			ForceNew:     forceNew,
+			Default:      3600,
+			ValidateFunc: validation.IntAtLeast(1),
+			Description: "Number of seconds the task has to finish. Defaults to " +
+				"`3600`.",
+		},
+		"interval": &schema.Schema{
+			Type:         schema.TypeInt,
+			Optional:     true,
+			ForceNew:     forceNew,
+			Default:      10,
+			ValidateFunc: validation.IntAtLeast(1),
+			Description:  "Number of seconds between polls of the task. Defaults to `10`.",
+		},
+		"allow_warning": &schema.Schema{
+			Type:     schema.TypeBool,
+			Optional: true,
+			ForceNew: forceNew,
+			Default:  false,
+			Description: "Whether a task which finished with result `\"warning\"` " +
+				"succeeds. Defaults to `false`.",
+		},
+
+		"label": &schema.Schema{
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Action executed by the task (ie: `\"Actions::Katello::Repository::Sync\"`).",
+		},
+		"state": &schema.Schema{
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "State the task ended in.",
+		},
+		"result": &schema.Schema{
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Result of the task.",
+		},
+		"ended_at": &schema.Schema{
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Time the task ended at.",
+		},
+		"output": &schema.Schema{
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Human readable output of the task.",
+		},
+	}
+}
+
+func resourceForemanTaskWait() *schema.Resource {
+	r := &schema.Resource{
+
+		CreateContext: withDiagnostics(resourceForemanTaskWaitCreate),
+		ReadContext:   withDiagnostics(resourceForemanTaskWaitRead),
+		DeleteContext: withDiagnostics(resourceForemanTaskWaitDelete),
+
+		Schema: taskWaitSchema(true),
+	}
+	r.Schema[autodoc.MetaAttribute] = &schema.Schema{
+		Type:     schema.TypeBool,
+		Computed: true,
+		Description: fmt.Sprintf(
+			"%s Waits until a Foreman or Katello task succeeds. Use it to order "+
+				"resources after asynchronous operations, such as repository "+
+				"synchronizations, content view publishes or job invocations. A "+
+				"task which fails or does not finish within the timeout fails the "+
+				"apply. The wait is done once, on create.",
+			autodoc.MetaSummary,
+		),
+	}
+	return r
+}
+
+// -----------------------------------------------------------------------------
+// Conversion Helpers
+// -----------------------------------------------------------------------------
+
+// buildForemanTaskWaitConfig constructs the TaskWaitConfig of the wait from
+// the ResourceData reference
+func buildForemanTaskWaitConfig(d *schema.ResourceData) api.TaskWaitConfig {
+	return api.TaskWaitConfig{
+		Interval:     time.Duration(d.Get("interval").(int)) * time.Second,
+		Timeout:      time.Duration(d.Get("timeout").(int)) * time.Second,
+		AllowWarning: d.Get("allow_warning").(bool),
+	}
+}
+
+// setResourceDataFromForemanTask sets the computed attributes of the wait
+// from the task it waited for
+func setResourceDataFromForemanTask(d *schema.ResourceData, task *api.ForemanTask) {
+	d.SetId(task.Id)
+	d.Set("task_id", task.Id)
+	d.Set("label", task.Label)
+	d.Set("state", task.State)
+	d.Set("result", task.Result)
+	d.Set("ended_at", task.EndedAt)
+	d.Set("output", task.Humanized.Output)
+}
+
+// logForemanTaskProgress reports the progress of a task waited for
+func logForemanTaskProgress(task *api.ForemanTask) {
+	log.Infof(
+		"Task [%s] (%s) is [%s], [%.0f%%] done",
+		task.Id,
+		task.Label,
+		task.State,
+		task.Progress*100,
+	)
+}
+
+// waitForForemanTask waits for the task of the ResourceData reference and
+// sets its computed attributes from the task
+func waitForForemanTask(ctx context.Context, d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*api.Client)
+
+	task, waitErr := client.WaitForTask(
+		ctx,
+		d.Get("task_id").(string),
+		buildForemanTaskWaitConfig(d),
+		logForemanTaskProgress,
+	)
+	if waitErr != nil {
+		return waitErr
+	}
+
+	setResourceDataFromForemanTask(d, task)
+
+	return nil
+}
+
+// -----------------------------------------------------------------------------
+// Resource CRUD Operations
+// -----------------------------------------------------------------------------
+
+func resourceForemanTaskWaitCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) error {
+	log.Tracef("resource_foreman_task_wait.go#Create")
+
+	return waitForForemanTask(ctx, d, meta)
+}
+
+func resourceForemanTaskWaitRead(ctx context.Context, d *schema.ResourceData, meta interface{}) error {
+	log.Tracef("resource_foreman_task_wait.go#Read")
+
+	client := meta.(*api.Client)
+
+	task, readErr := client.ReadTask(d.Id())
+	if readErr != nil {
+		// NOTE(ALL): finished tasks are cleaned up by Foreman after a while.
+		//   The task succeeded, removing the wait would only wait for a task
+		//   which no longer exists.
+		if api.IsNotFound(readErr) {
+			log.Debugf("Task [%s] was cleaned up, keeping the wait", d.Id())
+			return nil
+		}
+		return readErr
+	}
+
+	setResourceDataFromForemanTask(d, task)
+
+	return nil
+}
+
+func resourceForemanTaskWaitDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) error {
+	log.Tracef("resource_foreman_task_wait.go#Delete")
+
+	// NOTE(ALL): waiting for a task has no remote counterpart, the wait is
+	//   only removed from the state
+	d.SetId("")
+
+	return nil
+}
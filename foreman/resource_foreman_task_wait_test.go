@@ -0,0 +1,60 @@
+package foreman
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/HanseMerkur/terraform-provider-foreman/foreman/api"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// -----------------------------------------------------------------------------
+// resourceForemanTaskWait
+// -----------------------------------------------------------------------------
+
+// Ensures the wait succeeds with the task's attributes once the task
+// succeeded, and a failed task fails the create
+func TestResourceForemanTaskWaitCreate(t *testing.T) {
+
+	mux, server, client := NewForemanAPIAndClient(api.ClientCredentials{}, api.ClientConfig{})
+	defer server.Close()
+
+	mux.HandleFunc(api.FOREMAN_TASKS_API_URL_PREFIX+"/tasks/a1b2", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{
+			"id": "a1b2",
+			"label": "Actions::Katello::ContentView::Publish",
+			"state": "stopped",
+			"result": "success",
+			"ended_at": "2024-05-02 10:00:00 UTC",
+			"humanized": {"output": "Published"}
+		}`)
+	})
+	mux.HandleFunc(api.FOREMAN_TASKS_API_URL_PREFIX+"/tasks/c3d4", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id": "c3d4", "state": "stopped", "result": "error"}`)
+	})
+
+	r := resourceForemanTaskWait()
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"task_id": "a1b2",
+	})
+	if createErr := resourceForemanTaskWaitCreate(context.Background(), d, client); createErr != nil {
+		t.Fatalf("expected no error, got [%s]", createErr)
+	}
+	if d.Id() != "a1b2" || d.Get("result") != "success" || d.Get("output") != "Published" {
+		t.Fatalf("expected the attributes of task [a1b2], got [%v]", d.State())
+	}
+
+	d = schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"task_id": "c3d4",
+	})
+	if createErr := resourceForemanTaskWaitCreate(context.Background(), d, client); createErr == nil {
+		t.Fatalf("expected the failed task to fail the create")
+	}
+	if d.Id() != "" {
+		t.Fatalf("expected no wait to be recorded, got [%s]", d.Id())
+	}
+
+}
@@ -0,0 +1,164 @@
+package foreman
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/HanseMerkur/terraform-provider-foreman/foreman/api"
+	"github.com/wayfair/terraform-provider-utils/autodoc"
+	"github.com/wayfair/terraform-provider-utils/log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceForemanTemplateCombination() *schema.Resource {
+	return &schema.Resource{
+
+		Create: resourceForemanTemplateCombinationCreate,
+		Read:   resourceForemanTemplateCombinationRead,
+		Delete: resourceForemanTemplateCombinationDelete,
+
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+
+			autodoc.MetaAttribute: &schema.Schema{
+				Type:     schema.TypeBool,
+				Computed: true,
+				Description: fmt.Sprintf(
+					"%s Combination of a provisioning template with a hostgroup "+
+						"and/or an environment. Hosts of the hostgroup and "+
+						"environment are rendered with the provisioning template "+
+						"instead of the default template of their operating "+
+						"system. Do not manage the combinations of a provisioning "+
+						"template with this resource and the "+
+						"`template_combinations_attributes` of the "+
+						"`foreman_provisioningtemplate` resource at the same time.",
+					autodoc.MetaSummary,
+				),
+			},
+
+			"provisioningtemplate_id": &schema.Schema{
+				Type:         schema.TypeInt,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+				Description:  "ID of the provisioning template of the combination.",
+			},
+			"hostgroup_id": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+				AtLeastOneOf: []string{"hostgroup_id", "environment_id"},
+				Description: "ID of the hostgroup of the combination. Omit to " +
+					"combine the template with the environment only.",
+			},
+			"environment_id": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+				AtLeastOneOf: []string{"hostgroup_id", "environment_id"},
+				Description: "ID of the environment of the combination. Omit to " +
+					"combine the template with the hostgroup only.",
+			},
+		},
+	}
+}
+
+// -----------------------------------------------------------------------------
+// Conversion Helpers
+// -----------------------------------------------------------------------------
+
+// buildForemanTemplateCombination constructs a ForemanTemplateCombination
+// reference from a resource data reference.  The struct's members are
+// populated from the data populated in the resource data.  Missing members
+// will be left to the zero value for that member's type.
+func buildForemanTemplateCombination(d *schema.ResourceData) *api.ForemanTemplateCombination {
+	log.Tracef("resource_foreman_template_combination.go#buildForemanTemplateCombination")
+
+	combination := api.ForemanTemplateCombination{}
+
+	obj := buildForemanObject(d)
+	combination.ForemanObject = *obj
+
+	combination.ProvisioningTemplateId = d.Get("provisioningtemplate_id").(int)
+	combination.HostgroupId = d.Get("hostgroup_id").(int)
+	combination.EnvironmentId = d.Get("environment_id").(int)
+
+	return &combination
+}
+
+// setResourceDataFromForemanTemplateCombination sets a ResourceData's
+// attributes from the attributes of the supplied ForemanTemplateCombination
+// reference
+func setResourceDataFromForemanTemplateCombination(d *schema.ResourceData, ft *api.ForemanTemplateCombination) {
+	log.Tracef("resource_foreman_template_combination.go#setResourceDataFromForemanTemplateCombination")
+
+	d.SetId(strconv.Itoa(ft.Id))
+	d.Set("provisioningtemplate_id", ft.ProvisioningTemplateId)
+	d.Set("hostgroup_id", ft.HostgroupId)
+	d.Set("environment_id", ft.EnvironmentId)
+}
+
+// -----------------------------------------------------------------------------
+// Resource CRUD Operations
+// -----------------------------------------------------------------------------
+
+func resourceForemanTemplateCombinationCreate(d *schema.ResourceData, meta interface{}) error {
+	log.Tracef("resource_foreman_template_combination.go#Create")
+
+	client := meta.(*api.Client)
+	combination := buildForemanTemplateCombination(d)
+
+	log.Debugf("ForemanTemplateCombination: [%+v]", combination)
+
+	createdCombination, createErr := client.CreateTemplateCombination(combination)
+	if createErr != nil {
+		return createErr
+	}
+
+	log.Debugf("Created ForemanTemplateCombination: [%+v]", createdCombination)
+
+	setResourceDataFromForemanTemplateCombination(d, createdCombination)
+
+	return nil
+}
+
+func resourceForemanTemplateCombinationRead(d *schema.ResourceData, meta interface{}) error {
+	log.Tracef("resource_foreman_template_combination.go#Read")
+
+	client := meta.(*api.Client)
+	combination := buildForemanTemplateCombination(d)
+
+	log.Debugf("ForemanTemplateCombination: [%+v]", combination)
+
+	readCombination, readErr := client.ReadTemplateCombination(combination.Id)
+	if readErr != nil {
+		return handleReadError(d, readErr)
+	}
+
+	log.Debugf("Read ForemanTemplateCombination: [%+v]", readCombination)
+
+	setResourceDataFromForemanTemplateCombination(d, readCombination)
+
+	return nil
+}
+
+func resourceForemanTemplateCombinationDelete(d *schema.ResourceData, meta interface{}) error {
+	log.Tracef("resource_foreman_template_combination.go#Delete")
+
+	client := meta.(*api.Client)
+	combination := buildForemanTemplateCombination(d)
+
+	log.Debugf("ForemanTemplateCombination: [%+v]", combination)
+
+	// NOTE(ALL): d.SetId("") is automatically called by terraform assuming delete
+	//   returns no errors
+
+	return client.DeleteTemplateCombination(combination.Id)
+}
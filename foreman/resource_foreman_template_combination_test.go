@@ -0,0 +1,71 @@
+package foreman
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/HanseMerkur/terraform-provider-foreman/foreman/api"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// -----------------------------------------------------------------------------
+// resourceForemanTemplateCombination
+// -----------------------------------------------------------------------------
+
+// Ensures the combination is created underneath its provisioning template,
+// and read and deleted by its ID
+func TestResourceForemanTemplateCombination(t *testing.T) {
+
+	mux, server, client := NewForemanAPIAndClient(api.ClientCredentials{}, api.ClientConfig{})
+	defer server.Close()
+
+	requests := []string{}
+	var sent struct {
+		TemplateCombination api.ForemanTemplateCombination `json:"template_combination"`
+	}
+	mux.HandleFunc(api.FOREMAN_API_URL_PREFIX+"/provisioning_templates/3/template_combinations", func(w http.ResponseWriter, r *http.Request) {
+		json.NewDecoder(r.Body).Decode(&sent)
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		fmt.Fprint(w, `{"id": 8, "provisioning_template_id": 3, "hostgroup_id": 5, "environment_id": null}`)
+	})
+	mux.HandleFunc(api.FOREMAN_API_URL_PREFIX+"/template_combinations/8", func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		fmt.Fprint(w, `{"id": 8, "provisioning_template_id": 3, "hostgroup_id": 5, "environment_id": null}`)
+	})
+
+	r := resourceForemanTemplateCombination()
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"provisioningtemplate_id": 3,
+		"hostgroup_id":            5,
+	})
+
+	if createErr := r.Create(d, client); createErr != nil {
+		t.Fatalf("expected no error, got [%s]", createErr)
+	}
+	if d.Id() != "8" {
+		t.Fatalf("expected the ID [8], got [%s]", d.Id())
+	}
+	if readErr := r.Read(d, client); readErr != nil {
+		t.Fatalf("expected no error, got [%s]", readErr)
+	}
+	if deleteErr := r.Delete(d, client); deleteErr != nil {
+		t.Fatalf("expected no error, got [%s]", deleteErr)
+	}
+
+	expected := []string{
+		`POST ` + api.FOREMAN_API_URL_PREFIX + `/provisioning_templates/3/template_combinations`,
+		`GET ` + api.FOREMAN_API_URL_PREFIX + `/template_combinations/8`,
+		`DELETE ` + api.FOREMAN_API_URL_PREFIX + `/template_combinations/8`,
+	}
+	if !reflect.DeepEqual(expected, requests) {
+		t.Fatalf("expected the requests [%v], got [%v]", expected, requests)
+	}
+	if sent.TemplateCombination.HostgroupId != 5 || sent.TemplateCombination.EnvironmentId != 0 {
+		t.Fatalf("expected the hostgroup [5] without environment, got [%+v]", sent.TemplateCombination)
+	}
+
+}
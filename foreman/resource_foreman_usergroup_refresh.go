@@ -0,0 +1,128 @@
+package foreman
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/HanseMerkur/terraform-provider-foreman/foreman/api"
+	"github.com/wayfair/terraform-provider-utils/autodoc"
+	"github.com/wayfair/terraform-provider-utils/log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceForemanUsergroupRefresh() *schema.Resource {
+	return &schema.Resource{
+
+		Create: resourceForemanUsergroupRefreshCreate,
+		Read:   resourceForemanUsergroupRefreshRead,
+		Delete: resourceForemanUsergroupRefreshDelete,
+
+		Schema: map[string]*schema.Schema{
+
+			autodoc.MetaAttribute: &schema.Schema{
+				Type:     schema.TypeBool,
+				Computed: true,
+				Description: fmt.Sprintf(
+					"%s Synchronizes the members of a usergroup with the groups of "+
+						"the external authentication sources (ie: LDAP) mapped to it. "+
+						"Foreman otherwise only synchronizes the members when the "+
+						"users log in or on a schedule. The synchronization runs when "+
+						"the resource is created, and again whenever `triggers` "+
+						"change. Destroying the resource does not change the usergroup.",
+					autodoc.MetaSummary,
+				),
+			},
+
+			"usergroup_id": &schema.Schema{
+				Type:         schema.TypeInt,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+				Description:  "ID of the usergroup to synchronize.",
+			},
+			"triggers": &schema.Schema{
+				Type:     schema.TypeMap,
+				Optional: true,
+				ForceNew: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Description: "Arbitrary values which synchronize the usergroup again " +
+					"when they change, ie: the names of the mapped external groups.",
+			},
+			"external_usergroups": &schema.Schema{
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Description: "Names of the external groups the usergroup was " +
+					"synchronized with.",
+			},
+		},
+	}
+}
+
+// -----------------------------------------------------------------------------
+// Resource CRUD Operations
+// -----------------------------------------------------------------------------
+
+func resourceForemanUsergroupRefreshCreate(d *schema.ResourceData, meta interface{}) error {
+	log.Tracef("resource_foreman_usergroup_refresh.go#Create")
+
+	client := meta.(*api.Client)
+	usergroupId := d.Get("usergroup_id").(int)
+
+	readUsergroup, readErr := client.ReadUsergroup(usergroupId)
+	if readErr != nil {
+		return readErr
+	}
+
+	log.Debugf("Read ForemanUsergroup: [%+v]", readUsergroup)
+
+	externalNames := []string{}
+	for _, external := range readUsergroup.ExternalUsergroups {
+		log.Debugf("Refreshing external usergroup [%s] of usergroup [%d]", external.Name, usergroupId)
+		if refreshErr := client.RefreshExternalUsergroup(usergroupId, external.Id); refreshErr != nil {
+			return fmt.Errorf(
+				"External usergroup [%s] of usergroup [%d] could not be refreshed: %s",
+				external.Name,
+				usergroupId,
+				refreshErr,
+			)
+		}
+		externalNames = append(externalNames, external.Name)
+	}
+	if len(externalNames) == 0 {
+		log.Warningf("Usergroup [%d] has no external usergroups to refresh", usergroupId)
+	}
+
+	d.SetId(strconv.Itoa(usergroupId))
+	d.Set("external_usergroups", externalNames)
+
+	return nil
+}
+
+func resourceForemanUsergroupRefreshRead(d *schema.ResourceData, meta interface{}) error {
+	log.Tracef("resource_foreman_usergroup_refresh.go#Read")
+
+	client := meta.(*api.Client)
+	usergroupId := d.Get("usergroup_id").(int)
+
+	if _, readErr := client.ReadUsergroup(usergroupId); readErr != nil {
+		return handleReadError(d, readErr)
+	}
+
+	return nil
+}
+
+func resourceForemanUsergroupRefreshDelete(d *schema.ResourceData, meta interface{}) error {
+	log.Tracef("resource_foreman_usergroup_refresh.go#Delete")
+
+	// NOTE(ALL): a refresh cannot be undone, the resource is only removed from
+	//   the state
+
+	return nil
+}
@@ -0,0 +1,56 @@
+package foreman
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/HanseMerkur/terraform-provider-foreman/foreman/api"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// -----------------------------------------------------------------------------
+// resourceForemanUsergroupRefresh
+// -----------------------------------------------------------------------------
+
+// Ensures each external usergroup of the usergroup is refreshed on create
+func TestResourceForemanUsergroupRefresh(t *testing.T) {
+
+	mux, server, client := NewForemanAPIAndClient(api.ClientCredentials{}, api.ClientConfig{})
+	defer server.Close()
+
+	refreshed := []string{}
+	mux.HandleFunc(api.FOREMAN_API_URL_PREFIX+"/usergroups/6", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id": 6, "name": "ops", "external_usergroups": [
+			{"id": 1, "name": "cn=ops", "auth_source_id": 4},
+			{"id": 2, "name": "cn=oncall", "auth_source_id": 4}
+		]}`)
+	})
+	mux.HandleFunc(api.FOREMAN_API_URL_PREFIX+"/usergroups/6/external_usergroups/", func(w http.ResponseWriter, r *http.Request) {
+		refreshed = append(refreshed, r.Method+" "+r.URL.Path)
+		fmt.Fprint(w, `[]`)
+	})
+
+	r := resourceForemanUsergroupRefresh()
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"usergroup_id": 6,
+	})
+
+	if createErr := r.Create(d, client); createErr != nil {
+		t.Fatalf("expected no error, got [%s]", createErr)
+	}
+	expected := []string{
+		"PUT " + api.FOREMAN_API_URL_PREFIX + "/usergroups/6/external_usergroups/1/refresh",
+		"PUT " + api.FOREMAN_API_URL_PREFIX + "/usergroups/6/external_usergroups/2/refresh",
+	}
+	if !reflect.DeepEqual(expected, refreshed) {
+		t.Fatalf("expected the requests [%v], got [%v]", expected, refreshed)
+	}
+	externals := d.Get("external_usergroups").([]interface{})
+	if d.Id() != "6" || !reflect.DeepEqual(externals, []interface{}{"cn=ops", "cn=oncall"}) {
+		t.Fatalf("expected the ID [6] and both external usergroups, got [%s] %v", d.Id(), externals)
+	}
+
+}
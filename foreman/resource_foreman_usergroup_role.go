@@ -0,0 +1,152 @@
+package foreman
+
+import (
+	"fmt"
+
+	"github.com/HanseMerkur/terraform-provider-foreman/foreman/api"
+	"github.com/wayfair/terraform-provider-utils/autodoc"
+	"github.com/wayfair/terraform-provider-utils/log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceForemanUsergroupRole() *schema.Resource {
+	return &schema.Resource{
+
+		Create: resourceForemanUsergroupRoleCreate,
+		Read:   resourceForemanUsergroupRoleRead,
+		Delete: resourceForemanUsergroupRoleDelete,
+
+		Importer: &schema.ResourceImporter{
+			State: resourceForemanUsergroupRoleImport,
+		},
+
+		Schema: map[string]*schema.Schema{
+
+			autodoc.MetaAttribute: &schema.Schema{
+				Type:     schema.TypeBool,
+				Computed: true,
+				Description: fmt.Sprintf(
+					"%s Assignment of a role to a usergroup. The assignment is "+
+						"managed on its own, so roles can be assigned to usergroups "+
+						"managed elsewhere or created manually without owning the "+
+						"whole usergroup. Other roles of the usergroup are kept. "+
+						"The import ID has the form \"<usergroup id>/<role id>\".",
+					autodoc.MetaSummary,
+				),
+			},
+
+			"usergroup_id": &schema.Schema{
+				Type:         schema.TypeInt,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+				Description:  "ID of the usergroup the role is assigned to.",
+			},
+			"role_id": &schema.Schema{
+				Type:         schema.TypeInt,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+				Description:  "ID of the role assigned to the usergroup.",
+			},
+		},
+	}
+}
+
+// -----------------------------------------------------------------------------
+// Conversion Helpers
+// -----------------------------------------------------------------------------
+
+// foremanUsergroupRoleId returns the ID of the assignment of the supplied role
+// to the supplied usergroup
+func foremanUsergroupRoleId(usergroupId int, roleId int) string {
+	return fmt.Sprintf("%d/%d", usergroupId, roleId)
+}
+
+// -----------------------------------------------------------------------------
+// Resource CRUD Operations
+// -----------------------------------------------------------------------------
+
+// resourceForemanUsergroupRoleImport imports the assignment of a role to a
+// usergroup.  The import ID has the form "<usergroup id>/<role id>".
+func resourceForemanUsergroupRoleImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	log.Tracef("resource_foreman_usergroup_role.go#Import")
+
+	ids, splitErr := splitCompositeImportId(d.Id(), 2)
+	if splitErr != nil {
+		return nil, splitErr
+	}
+	d.Set("usergroup_id", ids[0])
+	d.Set("role_id", ids[1])
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func resourceForemanUsergroupRoleCreate(d *schema.ResourceData, meta interface{}) error {
+	log.Tracef("resource_foreman_usergroup_role.go#Create")
+
+	client := meta.(*api.Client)
+	usergroupId := d.Get("usergroup_id").(int)
+	roleId := d.Get("role_id").(int)
+
+	log.Debugf("Assigning role [%d] to usergroup [%d]", roleId, usergroupId)
+
+	if addErr := client.AddUsergroupRole(usergroupId, roleId); addErr != nil {
+		return addErr
+	}
+
+	d.SetId(foremanUsergroupRoleId(usergroupId, roleId))
+
+	return nil
+}
+
+func resourceForemanUsergroupRoleRead(d *schema.ResourceData, meta interface{}) error {
+	log.Tracef("resource_foreman_usergroup_role.go#Read")
+
+	client := meta.(*api.Client)
+	usergroupId := d.Get("usergroup_id").(int)
+	roleId := d.Get("role_id").(int)
+
+	readUsergroup, readErr := client.ReadUsergroup(usergroupId)
+	if readErr != nil {
+		return handleReadError(d, readErr)
+	}
+
+	log.Debugf("Read ForemanUsergroup: [%+v]", readUsergroup)
+
+	for _, assignedId := range readUsergroup.RoleIds {
+		if assignedId == roleId {
+			return nil
+		}
+	}
+	log.Infof(
+		"Role [%d] is no longer assigned to usergroup [%d], removing it from the state",
+		roleId,
+		usergroupId,
+	)
+	d.SetId("")
+
+	return nil
+}
+
+func resourceForemanUsergroupRoleDelete(d *schema.ResourceData, meta interface{}) error {
+	log.Tracef("resource_foreman_usergroup_role.go#Delete")
+
+	client := meta.(*api.Client)
+	usergroupId := d.Get("usergroup_id").(int)
+	roleId := d.Get("role_id").(int)
+
+	log.Debugf("Removing role [%d] from usergroup [%d]", roleId, usergroupId)
+
+	removeErr := client.RemoveUsergroupRole(usergroupId, roleId)
+	if removeErr != nil && !api.IsNotFound(removeErr) {
+		return removeErr
+	}
+
+	// NOTE(ALL): d.SetId("") is automatically called by terraform assuming delete
+	//   returns no errors
+
+	return nil
+}
@@ -0,0 +1,71 @@
+package foreman
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/HanseMerkur/terraform-provider-foreman/foreman/api"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// -----------------------------------------------------------------------------
+// resourceForemanUsergroupRole
+// -----------------------------------------------------------------------------
+
+// Ensures the role is assigned to and removed from the usergroup while the
+// other roles of the usergroup are kept, and the assignment is removed from
+// the state once the role was removed elsewhere
+func TestResourceForemanUsergroupRole(t *testing.T) {
+
+	mux, server, client := NewForemanAPIAndClient(api.ClientCredentials{}, api.ClientConfig{})
+	defer server.Close()
+
+	roles := `[{"id": 2, "name": "Viewer"}]`
+	updates := []string{}
+	mux.HandleFunc(api.FOREMAN_API_URL_PREFIX+"/usergroups/6", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPut {
+			body, _ := io.ReadAll(r.Body)
+			updates = append(updates, string(body))
+		}
+		fmt.Fprintf(w, `{"id": 6, "name": "ops", "roles": %s}`, roles)
+	})
+
+	r := resourceForemanUsergroupRole()
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"usergroup_id": 6,
+		"role_id":      9,
+	})
+
+	if createErr := r.Create(d, client); createErr != nil {
+		t.Fatalf("expected no error, got [%s]", createErr)
+	}
+	if d.Id() != "6/9" {
+		t.Fatalf("expected the ID [6/9], got [%s]", d.Id())
+	}
+
+	roles = `[{"id": 2, "name": "Viewer"}, {"id": 9, "name": "Manager"}]`
+	if readErr := r.Read(d, client); readErr != nil || d.Id() != "6/9" {
+		t.Fatalf("expected the assignment to be kept, got ID [%s] and error [%v]", d.Id(), readErr)
+	}
+
+	if deleteErr := r.Delete(d, client); deleteErr != nil {
+		t.Fatalf("expected no error, got [%s]", deleteErr)
+	}
+	expected := []string{
+		`{"usergroup":{"role_ids":[2,9]}}`,
+		`{"usergroup":{"role_ids":[2]}}`,
+	}
+	if !reflect.DeepEqual(expected, updates) {
+		t.Fatalf("expected the updates [%v], got [%v]", expected, updates)
+	}
+
+	roles = `[{"id": 2, "name": "Viewer"}]`
+	if readErr := r.Read(d, client); readErr != nil || d.Id() != "" {
+		t.Fatalf("expected the assignment to be removed, got ID [%s] and error [%v]", d.Id(), readErr)
+	}
+
+}
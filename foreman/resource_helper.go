@@ -1,11 +1,25 @@
 package foreman
 
 import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
 	"strconv"
+	"strings"
+	"sync"
 
 	"github.com/HanseMerkur/terraform-provider-foreman/foreman/api"
+	"github.com/wayfair/terraform-provider-utils/conv"
+	"github.com/wayfair/terraform-provider-utils/log"
 
-	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"golang.org/x/crypto/pbkdf2"
 )
 
 // buildForemanObject constructs a base ForemanObject reference from a
@@ -41,3 +55,705 @@ func buildForemanObject(d *schema.ResourceData) *api.ForemanObject {
 
 	return &obj
 }
+
+// importStateCompositeId returns a schema.StateFunc importing a resource that
+// is nested underneath a parent object in the API.  The import ID is expected
+// in the form "<parent id>/<id>".  The parent ID is stored in the supplied
+// attribute and the resource's ID is set to the trailing component, so the
+// resource's Read can build the nested endpoint.
+func importStateCompositeId(parentAttr string) schema.StateFunc {
+	return func(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+		ids, splitErr := splitCompositeImportId(d.Id(), 2)
+		if splitErr != nil {
+			return nil, splitErr
+		}
+		d.Set(parentAttr, ids[0])
+		d.SetId(strconv.Itoa(ids[1]))
+		return []*schema.ResourceData{d}, nil
+	}
+}
+
+// splitCompositeImportId splits an import ID of the form "<id>/<id>/..." into
+// exactly the supplied number of numeric Foreman IDs.
+func splitCompositeImportId(id string, num int) ([]int, error) {
+	parts := strings.Split(id, "/")
+	if len(parts) != num {
+		return nil, fmt.Errorf(
+			"Invalid import ID [%s]. Expected [%d] IDs separated by '/'",
+			id,
+			num,
+		)
+	}
+	ids := make([]int, num)
+	for idx, part := range parts {
+		var atoiErr error
+		if ids[idx], atoiErr = strconv.Atoi(part); atoiErr != nil {
+			return nil, fmt.Errorf(
+				"Invalid import ID [%s]. [%s] is not a numeric ID",
+				id,
+				part,
+			)
+		}
+	}
+	return ids, nil
+}
+
+// -----------------------------------------------------------------------------
+// Foreign Key Name Resolution
+// -----------------------------------------------------------------------------
+
+// lookupSingleId returns the ID of the only result of the supplied query
+// response.  The name of the attribute and the value searched for are used
+// to build the error message if the query did not return exactly 1 result.
+func lookupSingleId(queryResponse api.QueryResponse, queryErr error, attr string, value string) (int, error) {
+	if queryErr != nil {
+		return 0, queryErr
+	}
+	if queryResponse.Subtotal == 0 || len(queryResponse.Results) == 0 {
+		return 0, fmt.Errorf("Lookup of %s [%s] returned no results", attr, value)
+	} else if queryResponse.Subtotal > 1 {
+		return 0, fmt.Errorf("Lookup of %s [%s] returned more than 1 result", attr, value)
+	}
+	switch result := queryResponse.Results[0].(type) {
+	case api.ForemanDomain:
+		return result.Id, nil
+	case api.ForemanHostgroup:
+		return result.Id, nil
+	case api.ForemanOperatingSystem:
+		return result.Id, nil
+	case api.ForemanSubnet:
+		return result.Id, nil
+	}
+	return 0, fmt.Errorf(
+		"Lookup of %s [%s] returned an unexpected type [%T]",
+		attr,
+		value,
+		queryResponse.Results[0],
+	)
+}
+
+// lookupDomainId resolves the name of a domain to its ID
+func lookupDomainId(client *api.Client, name string) (int, error) {
+	q := api.ForemanDomain{}
+	q.Name = name
+	queryResponse, queryErr := client.QueryDomain(&q)
+	return lookupSingleId(queryResponse, queryErr, "domain_name", name)
+}
+
+// lookupHostgroupId resolves the title of a hostgroup to its ID
+func lookupHostgroupId(client *api.Client, title string) (int, error) {
+	q := api.ForemanHostgroup{Title: title}
+	queryResponse, queryErr := client.QueryHostgroup(&q)
+	return lookupSingleId(queryResponse, queryErr, "hostgroup_title", title)
+}
+
+// lookupOperatingSystemId resolves the title of an operating system to its ID
+func lookupOperatingSystemId(client *api.Client, title string) (int, error) {
+	q := api.ForemanOperatingSystem{Title: title}
+	queryResponse, queryErr := client.QueryOperatingSystem(&q)
+	return lookupSingleId(queryResponse, queryErr, "operatingsystem_title", title)
+}
+
+// lookupSubnetId resolves the name of a subnet to its ID
+func lookupSubnetId(client *api.Client, name string) (int, error) {
+	q := api.ForemanSubnet{}
+	q.Name = name
+	queryResponse, queryErr := client.QuerySubnet(&q)
+	return lookupSingleId(queryResponse, queryErr, "subnet_name", name)
+}
+
+// resolveForeignKeyName resolves the name-based alternative of a foreign key
+// attribute.  If the name attribute is set, it is looked up and the ID is
+// returned.  Otherwise the supplied current ID is returned unmodified.
+//
+// NOTE(ALL): lookups are memoized - many resources usually reference the
+//   same handful of objects
+func resolveForeignKeyName(d *schema.ResourceData, client *api.Client, nameAttr string, currentId int, lookup func(*api.Client, string) (int, error)) (int, error) {
+	attr, ok := d.GetOk(nameAttr)
+	if !ok {
+		return currentId, nil
+	}
+	return lookup(client.Memoized(), attr.(string))
+}
+
+// -----------------------------------------------------------------------------
+// Secrets
+// -----------------------------------------------------------------------------
+
+// sensitiveKDFPrefix marks the hashes stored by hashSensitiveValue
+const sensitiveKDFPrefix = "pbkdf2-sha256:"
+
+// sensitiveKDFSalt and sensitiveKDFIterations configure the derivation of
+// the hashes.  The hash must not change between runs, so the salt is fixed;
+// the iterations make guessing the secret from the state expensive.
+var (
+	sensitiveKDFSalt       = []byte("terraform-provider-foreman/sensitive-value")
+	sensitiveKDFIterations = 100000
+)
+
+// sensitiveValueHashes memoizes the hashes derived by hashSensitiveValue, the
+// StateFunc is called for every plan of every resource.  The secrets are only
+// kept as their SHA-256.
+var sensitiveValueHashes sync.Map
+
+// hashSensitiveValue is a StateFunc storing a hash of a secret in the state
+// instead of the configured value.  Foreman does not return secrets in plain
+// text, so the hash of the configured value is compared to the state to
+// detect changes.  The hash is derived with PBKDF2 and does not depend on the
+// provider's configuration.
+func hashSensitiveValue(v interface{}) string {
+	value, ok := v.(string)
+	if !ok || value == "" {
+		return ""
+	}
+
+	digest := sha256.Sum256([]byte(value))
+	if hash, ok := sensitiveValueHashes.Load(digest); ok {
+		return hash.(string)
+	}
+	sum := pbkdf2.Key([]byte(value), sensitiveKDFSalt, sensitiveKDFIterations, sha256.Size, sha256.New)
+	hash := sensitiveKDFPrefix + hex.EncodeToString(sum)
+	sensitiveValueHashes.Store(digest, hash)
+	return hash
+}
+
+// isSensitiveValueHash returns whether the supplied state value is a hash
+// stored by hashSensitiveValue
+func isSensitiveValueHash(value string) bool {
+	if !strings.HasPrefix(value, sensitiveKDFPrefix) {
+		return false
+	}
+	decoded, decodeErr := hex.DecodeString(strings.TrimPrefix(value, sensitiveKDFPrefix))
+	return decodeErr == nil && len(decoded) == sha256.Size
+}
+
+// upgradeSensitiveStateValue replaces the plain text secret stored under key
+// in a raw state with its hash, as stored by hashSensitiveValue.  Values which
+// already are a hash are left untouched.
+//
+// NOTE(ALL): earlier versions of the provider stored a plain SHA-256 of the
+//   secret.  It is only recognized here, it cannot be converted and shows up
+//   as a change of the secret once.
+func upgradeSensitiveStateValue(rawState map[string]interface{}, key string) {
+	value, ok := rawState[key].(string)
+	if !ok || value == "" || isSensitiveValueHash(value) {
+		return
+	}
+	if decoded, decodeErr := hex.DecodeString(value); decodeErr == nil && len(decoded) == sha256.Size {
+		return
+	}
+	rawState[key] = hashSensitiveValue(value)
+}
+
+// stateEncryptionPrefix marks the values encrypted by encryptSensitiveValue.
+// It is followed by the salt the key was derived with and the sealed value.
+const stateEncryptionPrefix = "foreman-encrypted:v2:"
+
+// legacyStateEncryptionPrefix marks the values encrypted by earlier versions
+// of the provider with a key derived without a salt.  They are only
+// decrypted, changing them stores them with the current format.
+const legacyStateEncryptionPrefix = "foreman-encrypted:v1:"
+
+// stateEncryptionSaltSize and stateEncryptionKDFIterations configure the
+// derivation of the keys from the passphrase
+var (
+	stateEncryptionSaltSize      = 16
+	stateEncryptionKDFIterations = 100000
+)
+
+// stateEncryptionKeys are the keys derived from the passphrase with a salt
+type stateEncryptionKeys struct {
+	aead     cipher.AEAD
+	nonceKey []byte
+}
+
+// stateEncryption holds the keys derived from the provider's
+// state_encryption_key.  StateFuncs have no access to the provider's client,
+// so all configurations of the provider share the keys.  Every run of the
+// provider encrypts with a new salt, the keys of the salts found in the state
+// are derived once and kept by salt.
+var stateEncryption struct {
+	sync.RWMutex
+	configured bool
+	passphrase string
+	salt       []byte
+	keys       map[string]*stateEncryptionKeys
+}
+
+// deriveStateEncryptionKey derives the key used for the supplied purpose
+// from the master key
+func deriveStateEncryptionKey(master []byte, purpose string) []byte {
+	mac := hmac.New(sha256.New, master)
+	mac.Write([]byte(purpose))
+	return mac.Sum(nil)
+}
+
+// newStateEncryptionKeys derives the keys from the master key
+func newStateEncryptionKeys(master []byte) (*stateEncryptionKeys, error) {
+	block, blockErr := aes.NewCipher(deriveStateEncryptionKey(master, "encryption"))
+	if blockErr != nil {
+		return nil, blockErr
+	}
+	aead, aeadErr := cipher.NewGCM(block)
+	if aeadErr != nil {
+		return nil, aeadErr
+	}
+	return &stateEncryptionKeys{
+		aead:     aead,
+		nonceKey: deriveStateEncryptionKey(master, "nonce"),
+	}, nil
+}
+
+// stateEncryptionKeysForSalt returns the keys derived from the passphrase
+// with the supplied salt.  A nil salt returns the keys of the legacy values.
+// The caller must hold the lock of stateEncryption.
+func stateEncryptionKeysForSalt(salt []byte) (*stateEncryptionKeys, error) {
+	cacheKey := "legacy"
+	if salt != nil {
+		cacheKey = "salt:" + string(salt)
+	}
+	if keys, ok := stateEncryption.keys[cacheKey]; ok {
+		return keys, nil
+	}
+	master := []byte(stateEncryption.passphrase)
+	if salt != nil {
+		master = pbkdf2.Key(master, salt, stateEncryptionKDFIterations, sha256.Size, sha256.New)
+	}
+	keys, keysErr := newStateEncryptionKeys(master)
+	if keysErr != nil {
+		return nil, keysErr
+	}
+	stateEncryption.keys[cacheKey] = keys
+	return keys, nil
+}
+
+// configureStateEncryption sets up the encryption of sensitive values in the
+// state with the supplied passphrase.  An empty passphrase disables the
+// encryption.  Configurations of the provider using different passphrases
+// are refused.
+func configureStateEncryption(passphrase string) error {
+	stateEncryption.Lock()
+	defer stateEncryption.Unlock()
+
+	if stateEncryption.configured {
+		if stateEncryption.passphrase != passphrase {
+			return fmt.Errorf("All configurations of the provider must use the same state_encryption_key")
+		}
+		return nil
+	}
+	stateEncryption.configured = true
+	stateEncryption.passphrase = passphrase
+	stateEncryption.salt = nil
+	stateEncryption.keys = map[string]*stateEncryptionKeys{}
+	if passphrase == "" {
+		return nil
+	}
+
+	salt := make([]byte, stateEncryptionSaltSize)
+	if _, randErr := rand.Read(salt); randErr != nil {
+		return randErr
+	}
+	if _, keysErr := stateEncryptionKeysForSalt(salt); keysErr != nil {
+		return keysErr
+	}
+	stateEncryption.salt = salt
+	return nil
+}
+
+// isEncryptedSensitiveValue returns whether the supplied value was encrypted
+// by encryptSensitiveValue
+func isEncryptedSensitiveValue(value string) bool {
+	return strings.HasPrefix(value, stateEncryptionPrefix) ||
+		strings.HasPrefix(value, legacyStateEncryptionPrefix)
+}
+
+// encryptSensitiveValue is a StateFunc storing a secret encrypted with the
+// provider's state_encryption_key in the state.  Without a key the secret is
+// stored unmodified.  The nonce is derived from the secret, so the same
+// secret always encrypts to the same value during a run.  Values encrypted
+// by other runs differ in the salt, suppressSensitiveValueDiff compares the
+// decrypted secrets instead.
+func encryptSensitiveValue(v interface{}) string {
+	value, ok := v.(string)
+	if !ok || value == "" || isEncryptedSensitiveValue(value) {
+		return value
+	}
+
+	stateEncryption.RLock()
+	defer stateEncryption.RUnlock()
+	if stateEncryption.salt == nil {
+		return value
+	}
+
+	keys := stateEncryption.keys["salt:"+string(stateEncryption.salt)]
+	mac := hmac.New(sha256.New, keys.nonceKey)
+	mac.Write([]byte(value))
+	nonce := mac.Sum(nil)[:keys.aead.NonceSize()]
+	sealed := keys.aead.Seal(nonce, nonce, []byte(value), nil)
+	return stateEncryptionPrefix +
+		base64.StdEncoding.EncodeToString(stateEncryption.salt) + ":" +
+		base64.StdEncoding.EncodeToString(sealed)
+}
+
+// decryptSensitiveValue returns the secret encrypted in the state by
+// encryptSensitiveValue.  Values which are not encrypted are returned
+// unmodified.
+func decryptSensitiveValue(value string) (string, error) {
+	if !isEncryptedSensitiveValue(value) {
+		return value, nil
+	}
+
+	stateEncryption.Lock()
+	defer stateEncryption.Unlock()
+	if stateEncryption.salt == nil {
+		return "", fmt.Errorf("A sensitive value is encrypted in the state, configure the provider's state_encryption_key to decrypt it")
+	}
+
+	var salt []byte
+	var encoded string
+	var decodeErr error
+	if strings.HasPrefix(value, legacyStateEncryptionPrefix) {
+		encoded = strings.TrimPrefix(value, legacyStateEncryptionPrefix)
+	} else {
+		parts := strings.SplitN(strings.TrimPrefix(value, stateEncryptionPrefix), ":", 2)
+		salt, decodeErr = base64.StdEncoding.DecodeString(parts[0])
+		if len(parts) != 2 || decodeErr != nil || len(salt) == 0 {
+			return "", fmt.Errorf("A sensitive value in the state is not a valid encrypted value")
+		}
+		encoded = parts[1]
+	}
+	keys, keysErr := stateEncryptionKeysForSalt(salt)
+	if keysErr != nil {
+		return "", keysErr
+	}
+
+	sealed, decodeErr := base64.StdEncoding.DecodeString(encoded)
+	nonceSize := keys.aead.NonceSize()
+	if decodeErr != nil || len(sealed) < nonceSize {
+		return "", fmt.Errorf("A sensitive value in the state is not a valid encrypted value")
+	}
+	plain, openErr := keys.aead.Open(nil, sealed[:nonceSize], sealed[nonceSize:], nil)
+	if openErr != nil {
+		return "", fmt.Errorf("A sensitive value in the state could not be decrypted, it was encrypted with another state_encryption_key")
+	}
+	return string(plain), nil
+}
+
+// suppressSensitiveValueDiff is a DiffSuppressFunc for the values stored by
+// encryptSensitiveValue.  The same secret encrypts to another value in every
+// run of the provider, so the decrypted secrets are compared.
+func suppressSensitiveValueDiff(k, old, new string, d *schema.ResourceData) bool {
+	if old == new {
+		return true
+	}
+	if !isEncryptedSensitiveValue(old) || !isEncryptedSensitiveValue(new) {
+		return false
+	}
+	oldValue, oldErr := decryptSensitiveValue(old)
+	newValue, newErr := decryptSensitiveValue(new)
+	return oldErr == nil && newErr == nil && oldValue == newValue
+}
+
+// -----------------------------------------------------------------------------
+// Read Helpers
+// -----------------------------------------------------------------------------
+
+// handleReadError handles an error encountered while reading a resource.  If
+// the resource was deleted from Foreman out of band, it is removed from the
+// state so it gets recreated instead of failing the whole refresh.  Any other
+// error is returned unmodified.
+func handleReadError(d *schema.ResourceData, readErr error) error {
+	if api.IsNotFound(readErr) {
+		log.Infof("Resource [%s] no longer exists in Foreman, removing it from the state", d.Id())
+		d.SetId("")
+		return nil
+	}
+	return readErr
+}
+
+// -----------------------------------------------------------------------------
+// Timestamps
+// -----------------------------------------------------------------------------
+
+// createdAtSchema returns the schema of the computed created_at attribute
+// shared by every Foreman object.
+func createdAtSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeString,
+		Computed: true,
+		Description: "Timestamp of when the object was created, as reported " +
+			"by Foreman.",
+	}
+}
+
+// updatedAtSchema returns the schema of the computed updated_at attribute
+// shared by every Foreman object.
+func updatedAtSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeString,
+		Computed: true,
+		Description: "Timestamp of when the object was last updated, as " +
+			"reported by Foreman.",
+	}
+}
+
+// setResourceDataFromForemanObjectTimestamps sets a ResourceData's
+// "created_at" and "updated_at" attributes from the supplied ForemanObject.
+func setResourceDataFromForemanObjectTimestamps(d *schema.ResourceData, fo *api.ForemanObject) {
+	d.Set("created_at", fo.CreatedAt)
+	d.Set("updated_at", fo.UpdatedAt)
+}
+
+// -----------------------------------------------------------------------------
+// Taxonomies
+// -----------------------------------------------------------------------------
+
+// locationIdsSchema returns the schema of the location_ids attribute of the
+// objects associated with locations.  The object is described by the supplied
+// noun (ie: "domain").
+func locationIdsSchema(noun string) *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeSet,
+		Optional: true,
+		Computed: true,
+		Elem: &schema.Schema{
+			Type: schema.TypeInt,
+		},
+		Description: "IDs of the locations the " + noun + " is associated " +
+			"with. When unset, Foreman associates it with the locations of " +
+			"the provider's user.",
+	}
+}
+
+// organizationIdsSchema returns the schema of the organization_ids attribute
+// of the objects associated with organizations.  The object is described by
+// the supplied noun (ie: "domain").
+func organizationIdsSchema(noun string) *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeSet,
+		Optional: true,
+		Computed: true,
+		Elem: &schema.Schema{
+			Type: schema.TypeInt,
+		},
+		Description: "IDs of the organizations the " + noun + " is associated " +
+			"with. When unset, Foreman associates it with the organizations " +
+			"of the provider's user.",
+	}
+}
+
+// buildForemanTaxonomies constructs the ForemanTaxonomies of an object from
+// the "location_ids" and "organization_ids" attributes of a ResourceData
+// reference.
+func buildForemanTaxonomies(d *schema.ResourceData) api.ForemanTaxonomies {
+	ft := api.ForemanTaxonomies{}
+	if attr, ok := d.GetOk("location_ids"); ok {
+		ft.LocationIds = conv.InterfaceSliceToIntSlice(attr.(*schema.Set).List())
+	}
+	if attr, ok := d.GetOk("organization_ids"); ok {
+		ft.OrganizationIds = conv.InterfaceSliceToIntSlice(attr.(*schema.Set).List())
+	}
+	return ft
+}
+
+// setResourceDataFromForemanTaxonomies sets a ResourceData's "location_ids"
+// and "organization_ids" attributes from the supplied ForemanTaxonomies.
+func setResourceDataFromForemanTaxonomies(d *schema.ResourceData, ft *api.ForemanTaxonomies) {
+	d.Set("location_ids", ft.LocationIds)
+	d.Set("organization_ids", ft.OrganizationIds)
+}
+
+// -----------------------------------------------------------------------------
+// Parameters
+// -----------------------------------------------------------------------------
+
+// parametersSchema returns the schema of the parameters attribute of the
+// objects with inline parameters.  The object is described by the supplied
+// noun (ie: "subnet").
+func parametersSchema(noun string) *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeMap,
+		Optional: true,
+		Elem: &schema.Schema{
+			Type: schema.TypeString,
+		},
+		Description: "A map of parameters that will be saved as " + noun +
+			" parameters, consumed by the templates of the hosts.",
+	}
+}
+
+// hiddenParametersSchema returns the schema of the hidden_parameters
+// attribute of the objects with inline parameters.  The object is described
+// by the supplied noun (ie: "subnet").
+func hiddenParametersSchema(noun string) *schema.Schema {
+	return &schema.Schema{
+		Type:      schema.TypeMap,
+		Optional:  true,
+		Sensitive: true,
+		Elem: &schema.Schema{
+			Type: schema.TypeString,
+		},
+		Description: "A map of parameters that will be saved as " + noun +
+			" parameters with their values masked in the Foreman UI.",
+	}
+}
+
+// parameterTypesSchema returns the schema of the parameter_types attribute of
+// the objects with inline parameters.  The object is described by the
+// supplied noun (ie: "subnet").
+func parameterTypesSchema(noun string) *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeMap,
+		Optional: true,
+		Elem: &schema.Schema{
+			Type: schema.TypeString,
+		},
+		ValidateFunc: func(i interface{}, k string) (warnings []string, errors []error) {
+			for name, value := range i.(map[string]interface{}) {
+				if _, errs := validation.StringInSlice(api.ParameterTypes, false)(value, k+"."+name); len(errs) > 0 {
+					errors = append(errors, errs...)
+				}
+			}
+			return warnings, errors
+		},
+		Description: "Types of the " + noun + " parameters by name, one of " +
+			"`\"string\"`, `\"boolean\"`, `\"integer\"`, `\"real\"`, " +
+			"`\"array\"`, `\"hash\"`, `\"yaml\"` or `\"json\"`. Parameters " +
+			"without a type are strings.",
+	}
+}
+
+// buildForemanKVParameters constructs the inline parameters of an object from
+// the "parameters", "hidden_parameters" and "parameter_types" attributes of a
+// ResourceData reference.  nil is returned if the parameters are not managed,
+// which leaves the parameters of the object unchanged.
+func buildForemanKVParameters(d *schema.ResourceData) []api.ForemanKVParameter {
+	parameters := d.Get("parameters").(map[string]interface{})
+	hiddenParameters := d.Get("hidden_parameters").(map[string]interface{})
+	parameterTypes := d.Get("parameter_types").(map[string]interface{})
+	if len(parameters) == 0 && len(hiddenParameters) == 0 &&
+		!d.HasChange("parameters") && !d.HasChange("hidden_parameters") {
+		return nil
+	}
+
+	params := []api.ForemanKVParameter{}
+	for name, value := range parameters {
+		params = append(params, api.ForemanKVParameter{
+			Name:  name,
+			Value: value.(string),
+		})
+	}
+	for name, value := range hiddenParameters {
+		params = append(params, api.ForemanKVParameter{
+			Name:        name,
+			Value:       value.(string),
+			HiddenValue: true,
+		})
+	}
+	for idx, param := range params {
+		if paramType, ok := parameterTypes[param.Name].(string); ok {
+			params[idx].ParameterType = paramType
+		}
+	}
+	return params
+}
+
+// setResourceDataFromForemanKVParameters sets a ResourceData's "parameters",
+// "hidden_parameters" and "parameter_types" attributes from the supplied
+// inline parameters.  Only types other than the default "string" are set.
+func setResourceDataFromForemanKVParameters(d *schema.ResourceData, params []api.ForemanKVParameter) {
+	parameters := map[string]string{}
+	hiddenParameters := map[string]string{}
+	parameterTypes := map[string]string{}
+	for _, param := range params {
+		if param.HiddenValue {
+			hiddenParameters[param.Name] = param.Value
+		} else {
+			parameters[param.Name] = param.Value
+		}
+		if param.ParameterType != "" && param.ParameterType != "string" {
+			parameterTypes[param.Name] = param.ParameterType
+		}
+	}
+	d.Set("parameters", parameters)
+	d.Set("hidden_parameters", hiddenParameters)
+	d.Set("parameter_types", parameterTypes)
+}
+
+// -----------------------------------------------------------------------------
+// Data Source Matching
+// -----------------------------------------------------------------------------
+
+// exactMatchSchema returns the schema of the exact_match attribute shared by
+// the data sources looking up a single object.
+func exactMatchSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeBool,
+		Optional: true,
+		Default:  false,
+		Description: "Whether the searched value must match exactly, including " +
+			"case. By default results are compared case-insensitively.",
+	}
+}
+
+// filterQueryResults drops the search results whose key, as returned by keyOf,
+// does not equal the searched value.  Foreman searches are case-insensitive
+// and may match substrings, so "prod" could otherwise resolve "preprod".
+// Keys are compared case-insensitively unless exact_match is set.  The
+// subtotal of the query response is updated to the remaining results.  An
+// empty value leaves the results untouched.
+func filterQueryResults(d *schema.ResourceData, queryResponse *api.QueryResponse, value string, keyOf func(interface{}) string) {
+	if value == "" {
+		return
+	}
+
+	// NOTE(ALL): use the comma-ok form, the attribute only exists in the
+	//   data source schema
+	exactMatch, _ := d.Get("exact_match").(bool)
+
+	results := []interface{}{}
+	for _, result := range queryResponse.Results {
+		key := keyOf(result)
+		if key == value || (!exactMatch && strings.EqualFold(key, value)) {
+			results = append(results, result)
+		}
+	}
+	log.Debugf("Filtered [%d] of [%d] results matching [%s]", len(results), len(queryResponse.Results), value)
+
+	queryResponse.Results = results
+	queryResponse.Subtotal = len(results)
+}
+
+// -----------------------------------------------------------------------------
+// Data Source Schemas
+// -----------------------------------------------------------------------------
+
+// dataSourceSchemaFromResourceSchema copies the schema map of a resource for
+// use in a data source, so the attributes of the two stay in sync.  Every
+// attribute of the copy is computed.  The type, description, sensitive flag
+// and set hash are preserved, nested resources are copied recursively.
+func dataSourceSchemaFromResourceSchema(rs map[string]*schema.Schema) map[string]*schema.Schema {
+	schemaMap := make(map[string]*schema.Schema, len(rs))
+
+	for key, val := range rs {
+		s := &schema.Schema{
+			Type:        val.Type,
+			Description: val.Description,
+			Sensitive:   val.Sensitive,
+			Computed:    true,
+			Set:         val.Set,
+		}
+
+		if elem, ok := val.Elem.(*schema.Resource); ok {
+			s.Elem = &schema.Resource{
+				Schema: dataSourceSchemaFromResourceSchema(elem.Schema),
+			}
+		} else {
+			s.Elem = val.Elem
+		}
+
+		schemaMap[key] = s
+	}
+
+	return schemaMap
+}
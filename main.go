@@ -3,7 +3,7 @@ package main
 import (
 	"github.com/HanseMerkur/terraform-provider-foreman/foreman"
 
-	"github.com/hashicorp/terraform/plugin"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/plugin"
 )
 
 func main() {
@@ -13,4 +13,6 @@ func main() {
 	}
 	// Serves the foreman plugin in the defined configurations.
 	plugin.Serve(&opts)
+	// Serve returns once Terraform is done with the plugin
+	foreman.LogAPICallSummary()
 }